@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/jsonmessage"
 	controlapi "github.com/moby/buildkit/api/services/control"
@@ -37,7 +38,7 @@ type dockerImageBuilder struct {
 type DockerBuilder interface {
 	BuildImage(ctx context.Context, ps *PipelineState, refs container.RefSet, db model.DockerBuild, filter model.PathMatcher) (container.TaggedRefs, error)
 	DumpImageDeployRef(ctx context.Context, ref string) (reference.NamedTagged, error)
-	PushImage(ctx context.Context, name reference.NamedTagged) error
+	PushImage(ctx context.Context, name reference.NamedTagged) (digest.Digest, error)
 	TagRefs(ctx context.Context, refs container.RefSet, dig digest.Digest) (container.TaggedRefs, error)
 	ImageExists(ctx context.Context, ref reference.NamedTagged) (bool, error)
 }
@@ -112,16 +113,17 @@ func (d *dockerImageBuilder) TagRefs(ctx context.Context, refs container.RefSet,
 }
 
 // Push the specified ref up to the docker registry specified in the name.
+// Returns the digest reported by the registry, if any.
 //
 // TODO(nick) In the future, I would like us to be smarter about checking if the kubernetes cluster
 // we're running in has access to the given registry. And if it doesn't, we should either emit an
 // error, or push to a registry that kubernetes does have access to (e.g., a local registry).
-func (d *dockerImageBuilder) PushImage(ctx context.Context, ref reference.NamedTagged) error {
+func (d *dockerImageBuilder) PushImage(ctx context.Context, ref reference.NamedTagged) (digest.Digest, error) {
 	l := logger.Get(ctx)
 
 	imagePushResponse, err := d.dCli.ImagePush(ctx, ref)
 	if err != nil {
-		return errors.Wrap(err, "PushImage#ImagePush")
+		return "", errors.Wrap(err, "PushImage#ImagePush")
 	}
 
 	defer func() {
@@ -131,12 +133,21 @@ func (d *dockerImageBuilder) PushImage(ctx context.Context, ref reference.NamedT
 		}
 	}()
 
-	_, err = readDockerOutput(ctx, imagePushResponse)
+	output, err := readDockerOutput(ctx, imagePushResponse)
 	if err != nil {
-		return errors.Wrapf(err, "pushing image %q", ref.Name())
+		return "", errors.Wrapf(err, "pushing image %q", ref.Name())
 	}
 
-	return nil
+	// The registry digest is only informational (e.g., for signing the image),
+	// so an unexpected aux message shouldn't fail the push.
+	if output.aux != nil {
+		var result types.PushResult
+		if err := json.Unmarshal(*output.aux, &result); err == nil {
+			return digest.Digest(result.Digest), nil
+		}
+	}
+
+	return "", nil
 }
 
 func (d *dockerImageBuilder) ImageExists(ctx context.Context, ref reference.NamedTagged) (bool, error) {
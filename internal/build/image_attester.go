@@ -0,0 +1,79 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// Runs the post-push steps of an image target (signing, SBOM generation).
+type ImageAttester interface {
+	Attest(ctx context.Context, ps *PipelineState, a model.ImageAttestation, ref reference.NamedTagged, dig digest.Digest) error
+}
+
+type ExecImageAttester struct{}
+
+var _ ImageAttester = &ExecImageAttester{}
+
+func NewExecImageAttester() *ExecImageAttester {
+	return &ExecImageAttester{}
+}
+
+func (a *ExecImageAttester) Attest(ctx context.Context, ps *PipelineState, att model.ImageAttestation, ref reference.NamedTagged, dig digest.Digest) error {
+	env := AttestationEnv(ref, dig)
+
+	if !att.SignCmd.Empty() {
+		ps.StartBuildStep(ctx, "Signing image")
+		err := a.run(ps.AttachLogger(ctx), att.SignCmd, env)
+		if err != nil {
+			return errors.Wrap(err, "Image signing failed")
+		}
+	}
+
+	if !att.SBOMCmd.Empty() {
+		ps.StartBuildStep(ctx, "Generating SBOM")
+		err := a.run(ps.AttachLogger(ctx), att.SBOMCmd, env)
+		if err != nil {
+			return errors.Wrap(err, "SBOM generation failed")
+		}
+	}
+	return nil
+}
+
+func (a *ExecImageAttester) run(ctx context.Context, c model.Cmd, env []string) error {
+	l := logger.Get(ctx)
+	l.Infof("Running cmd %q", c)
+
+	cmd := exec.CommandContext(ctx, c.Argv[0], c.Argv[1:]...)
+	cmd.Dir = c.Dir
+	cmd.Env = append(append(os.Environ(), c.Env...), env...)
+
+	w := l.Writer(logger.InfoLvl)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	return cmd.Run()
+}
+
+// The environment variables that describe a pushed image to attestation commands.
+//
+// If the registry didn't report a digest, TILT_IMAGE_DIGEST is empty
+// and TILT_IMAGE_DIGEST_REF falls back to the tagged ref.
+func AttestationEnv(ref reference.NamedTagged, dig digest.Digest) []string {
+	digestRef := ref.String()
+	if dig != "" {
+		digestRef = fmt.Sprintf("%s@%s", ref.Name(), dig)
+	}
+	return []string{
+		fmt.Sprintf("TILT_IMAGE_REF=%s", ref.String()),
+		fmt.Sprintf("TILT_IMAGE_DIGEST=%s", dig),
+		fmt.Sprintf("TILT_IMAGE_DIGEST_REF=%s", digestRef),
+	}
+}
@@ -19,6 +19,7 @@ func Options(archive io.Reader, db model.DockerBuild) docker.BuildOptions {
 		Network:     db.Network,
 		ExtraTags:   db.ExtraTags,
 		SecretSpecs: db.SecretSpecs,
+		Secrets:     manifestSecretsToDockerSecrets(db.Secrets),
 		CacheFrom:   db.CacheFrom,
 		PullParent:  db.PullParent,
 	}
@@ -37,3 +38,14 @@ func manifestBuildArgsToDockerBuildArgs(args model.DockerBuildArgs) map[string]*
 
 	return r
 }
+
+func manifestSecretsToDockerSecrets(secrets map[string]string) map[string][]byte {
+	if len(secrets) == 0 {
+		return nil
+	}
+	r := make(map[string][]byte, len(secrets))
+	for id, v := range secrets {
+		r[id] = []byte(v)
+	}
+	return r
+}
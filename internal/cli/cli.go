@@ -67,6 +67,7 @@ up-to-date in real-time. Think 'docker build && kubectl apply' or 'docker-compos
 	rootCmd.AddCommand(analytics.NewCommand())
 	rootCmd.AddCommand(newDumpCmd(rootCmd))
 	rootCmd.AddCommand(newTriggerCmd())
+	rootCmd.AddCommand(newDiffCmd())
 	rootCmd.AddCommand(newAlphaCmd())
 
 	globalFlags := rootCmd.PersistentFlags()
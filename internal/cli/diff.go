@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func newDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [RESOURCE_NAME]",
+		Short: "Show how the live Kubernetes objects differ from what Tilt last deployed",
+		Long: `Show how the live Kubernetes objects differ from what Tilt last deployed.
+
+For each resource, Tilt does a dry-run apply of the objects from its last deploy,
+using the configured apply mode, and diffs the result against the live objects
+in the cluster. This shows any changes that would be made if Tilt re-applied
+the resource, including changes that someone else made in the cluster.
+
+If a resource name is given, only shows the changes for that resource.
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  diff,
+	}
+	addConnectServerFlags(cmd)
+	return cmd
+}
+
+func diff(cmd *cobra.Command, args []string) {
+	path := "diff"
+	if len(args) == 1 {
+		path = fmt.Sprintf("diff?resource=%s", url.QueryEscape(args[0]))
+	}
+
+	body := apiGet(path)
+	defer func() {
+		_ = body.Close()
+	}()
+
+	_, err := io.Copy(os.Stdout, body)
+	if err != nil {
+		cmdFail(fmt.Errorf("diff: %v", err))
+	}
+}
@@ -2,11 +2,15 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/tilt-dev/tilt/internal/analytics"
@@ -19,9 +23,21 @@ import (
 type downCmd struct {
 	fileName         string
 	deleteNamespaces bool
+	keepNamespaces   bool
+	deleteVolumes    bool
+	labels           []string
+	dryRun           bool
+	wait             bool
+	waitTimeout      time.Duration
 	downDepsProvider func(ctx context.Context, tiltAnalytics *analytics.TiltAnalytics, subcommand model.TiltSubcommand) (DownDeps, error)
 }
 
+// How often to check whether deleted objects are gone.
+const downWaitInterval = 500 * time.Millisecond
+
+var namespaceGVK = schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"}
+var pvcGVK = schema.GroupVersionKind{Group: "", Version: "v1", Kind: "PersistentVolumeClaim"}
+
 func newDownCmd() *downCmd {
 	return &downCmd{downDepsProvider: wireDownDeps}
 }
@@ -38,9 +54,17 @@ Deletes resources specified in the Tiltfile
 
 Specify additional flags and arguments to control which resources are deleted.
 
+By default, Tiltfile args are interpreted as the list of resources to delete,
+e.g. tilt down frontend backend. As with 'tilt up', the resources they depend on
+are included. Resources are deleted in reverse dependency order.
+
 Namespaces are not deleted by default. Use --delete-namespaces to change that.
 
+PersistentVolumeClaims are not deleted by default, so that your data survives a
+'tilt down'. Use --delete-volumes to change that.
+
 Kubernetes resources with the annotation 'tilt.dev/down-policy: keep' are not deleted.
+Kubernetes resources with the annotation 'tilt.dev/down-policy: delete' are always deleted.
 
 For more complex cases, the Tiltfile has APIs to add additional flags and arguments to the Tilt CLI.
 These arguments can be scripted to define custom subsets of resources to delete.
@@ -51,13 +75,22 @@ See https://docs.tilt.dev/tiltfile_config.html for examples.
 	addTiltfileFlag(cmd, &c.fileName)
 	addKubeContextFlag(cmd)
 	cmd.Flags().BoolVar(&c.deleteNamespaces, "delete-namespaces", false, "delete namespaces defined in the Tiltfile (by default, don't)")
+	cmd.Flags().BoolVar(&c.keepNamespaces, "keep-namespaces", false, "don't delete namespaces defined in the Tiltfile (the default)")
+	cmd.Flags().BoolVar(&c.deleteVolumes, "delete-volumes", false, "delete PersistentVolumeClaims defined in the Tiltfile (by default, don't)")
+	cmd.Flags().StringArrayVarP(&c.labels, "label", "l", nil, "only delete Kubernetes objects that match this label selector (e.g., app=frontend). May be repeated")
+	cmd.Flags().BoolVar(&c.dryRun, "dry-run", false, "print what would be deleted, without deleting anything")
+	cmd.Flags().BoolVar(&c.wait, "wait", false, "wait until the deleted Kubernetes objects are gone")
+	cmd.Flags().DurationVar(&c.waitTimeout, "wait-timeout", 2*time.Minute, "how long to wait with --wait")
 
 	return cmd
 }
 
 func (c *downCmd) run(ctx context.Context, args []string) error {
 	a := analytics.Get(ctx)
-	a.Incr("cmd.down", map[string]string{})
+	a.Incr("cmd.down", map[string]string{
+		"dryRun": fmt.Sprintf("%t", c.dryRun),
+		"wait":   fmt.Sprintf("%t", c.wait),
+	})
 	defer a.Flush(time.Second)
 
 	downDeps, err := c.downDepsProvider(ctx, a, "down")
@@ -68,67 +101,314 @@ func (c *downCmd) run(ctx context.Context, args []string) error {
 }
 
 func (c *downCmd) down(ctx context.Context, downDeps DownDeps, args []string) error {
-	tlr := downDeps.tfl.Load(ctx, c.fileName, model.NewUserConfigState(args))
-	err := tlr.Error
+	if c.deleteNamespaces && c.keepNamespaces {
+		return fmt.Errorf("--delete-namespaces and --keep-namespaces can't be used together")
+	}
+
+	selectors, err := c.labelSelectors()
 	if err != nil {
 		return err
 	}
 
-	entities, err := engine.ParseYAMLFromManifests(tlr.Manifests...)
+	tlr := downDeps.tfl.Load(ctx, c.fileName, model.NewUserConfigState(args))
+	err = tlr.Error
 	if err != nil {
-		return errors.Wrap(err, "Parsing manifest YAML")
+		return err
+	}
+
+	var deletions []contextEntities
+	for _, group := range manifestsByContext(tlr.Manifests) {
+		entities, err := c.entitiesToDelete(ctx, group.manifests, selectors)
+		if err != nil {
+			return err
+		}
+		if len(entities) > 0 {
+			deletions = append(deletions, contextEntities{kubeContext: group.kubeContext, entities: entities})
+		}
+	}
+
+	var dcConfigPaths []string
+	for _, m := range tlr.Manifests {
+		if m.IsDC() {
+			dcConfigPaths = m.DockerComposeTarget().ConfigPaths
+			break
+		}
+	}
+
+	// Docker Compose services don't have labels we can filter on,
+	// so don't tear down the whole project when the user asked for a subset.
+	if len(dcConfigPaths) > 0 && len(selectors) > 0 {
+		logger.Get(ctx).Infof("Not running `docker-compose down`: --label only applies to Kubernetes objects.")
+		dcConfigPaths = nil
+	}
+
+	if c.dryRun {
+		c.printDryRun(ctx, deletions, dcConfigPaths)
+		return nil
+	}
+
+	for _, d := range deletions {
+		err = downDeps.clients.For(d.kubeContext).Client.Delete(ctx, d.entities)
+		if err != nil {
+			return errors.Wrap(err, "Deleting k8s entities")
+		}
+	}
+
+	if len(dcConfigPaths) > 0 {
+		dcc := downDeps.dcClient
+		err = dcc.Down(ctx, dcConfigPaths, logger.Get(ctx).Writer(logger.InfoLvl), logger.Get(ctx).Writer(logger.InfoLvl))
+		if err != nil {
+			return errors.Wrap(err, "Running `docker-compose down`")
+		}
+	}
+
+	if c.wait {
+		for _, d := range deletions {
+			err = c.waitForDeletion(ctx, downDeps.clients.For(d.kubeContext).Client, d.entities)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	entities = k8s.ReverseSortedEntities(entities)
+	return nil
+}
+
+// Returns the Kubernetes objects of the given manifests that we should delete, in order.
+func (c *downCmd) entitiesToDelete(ctx context.Context, manifests []model.Manifest, selectors []labels.Selector) ([]k8s.K8sEntity, error) {
+	entities, err := deletionOrder(manifests)
+	if err != nil {
+		return nil, errors.Wrap(err, "Parsing manifest YAML")
+	}
 
 	entities, _, err = k8s.Filter(entities, func(e k8s.K8sEntity) (b bool, err error) {
 		downPolicy, exists := e.Annotations()["tilt.dev/down-policy"]
 		return !exists || downPolicy != "keep", nil
 	})
 	if err != nil {
-		return errors.Wrap(err, "Filtering entities by down policy")
+		return nil, errors.Wrap(err, "Filtering entities by down policy")
+	}
+
+	if len(selectors) > 0 {
+		entities, _, err = k8s.Filter(entities, func(e k8s.K8sEntity) (b bool, err error) {
+			for _, selector := range selectors {
+				if !selector.Matches(labels.Set(e.Labels())) {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "Filtering entities by label")
+		}
 	}
 
 	if !c.deleteNamespaces {
 		var namespaces []k8s.K8sEntity
 		entities, namespaces, err = k8s.Filter(entities, func(e k8s.K8sEntity) (b bool, err error) {
-			return e.GVK() != schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"}, nil
+			return e.GVK() != namespaceGVK, nil
 		})
 		if err != nil {
-			return errors.Wrap(err, "filtering out namespaces")
+			return nil, errors.Wrap(err, "filtering out namespaces")
 		}
 		if len(namespaces) > 0 {
-			var nsNames []string
-			for _, ns := range namespaces {
-				nsNames = append(nsNames, ns.Name())
-			}
-			logger.Get(ctx).Infof("Not deleting namespaces: %s", strings.Join(nsNames, ", "))
+			logger.Get(ctx).Infof("Not deleting namespaces: %s", entityNames(namespaces))
 			logger.Get(ctx).Infof("Run with --delete-namespaces to delete namespaces as well.")
 		}
 	}
 
-	if len(entities) > 0 {
-		err = downDeps.kClient.Delete(ctx, entities)
+	if !c.deleteVolumes {
+		var volumes []k8s.K8sEntity
+		entities, volumes, err = k8s.Filter(entities, func(e k8s.K8sEntity) (b bool, err error) {
+			// An explicit down policy overrides the default.
+			return e.GVK() != pvcGVK || e.Annotations()["tilt.dev/down-policy"] == "delete", nil
+		})
 		if err != nil {
-			return errors.Wrap(err, "Deleting k8s entities")
+			return nil, errors.Wrap(err, "filtering out volumes")
+		}
+		if len(volumes) > 0 {
+			logger.Get(ctx).Infof("Not deleting persistent volume claims: %s", entityNames(volumes))
+			logger.Get(ctx).Infof("Run with --delete-volumes to delete persistent volume claims as well.")
 		}
 	}
 
-	var dcConfigPaths []string
-	for _, m := range tlr.Manifests {
-		if m.IsDC() {
-			dcConfigPaths = m.DockerComposeTarget().ConfigPaths
-			break
+	return entities, nil
+}
+
+func (c *downCmd) labelSelectors() ([]labels.Selector, error) {
+	var result []labels.Selector
+	for _, l := range c.labels {
+		selector, err := labels.Parse(l)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Parsing --label %q", l)
 		}
+		result = append(result, selector)
+	}
+	return result, nil
+}
+
+func (c *downCmd) printDryRun(ctx context.Context, deletions []contextEntities, dcConfigPaths []string) {
+	l := logger.Get(ctx)
+	if len(deletions) == 0 && len(dcConfigPaths) == 0 {
+		l.Infof("Nothing to delete.")
+		return
 	}
 
+	for _, d := range deletions {
+		for _, e := range d.entities {
+			if d.kubeContext == "" {
+				l.Infof("Would delete %s", entityDescription(e))
+			} else {
+				l.Infof("Would delete %s from context %s", entityDescription(e), d.kubeContext)
+			}
+		}
+	}
 	if len(dcConfigPaths) > 0 {
-		dcc := downDeps.dcClient
-		err = dcc.Down(ctx, dcConfigPaths, logger.Get(ctx).Writer(logger.InfoLvl), logger.Get(ctx).Writer(logger.InfoLvl))
+		l.Infof("Would run `docker-compose down` for: %s", strings.Join(dcConfigPaths, ", "))
+	}
+}
+
+// Blocks until the Kubernetes API no longer knows about any of the given entities.
+//
+// Objects with finalizers (like PersistentVolumeClaims and Namespaces)
+// can stick around for a while after the delete call returns.
+func (c *downCmd) waitForDeletion(ctx context.Context, kCli k8s.Client, entities []k8s.K8sEntity) error {
+	ctx, cancel := context.WithTimeout(ctx, c.waitTimeout)
+	defer cancel()
+
+	remaining := entities
+	for {
+		var stillExists []k8s.K8sEntity
+		for _, e := range remaining {
+			_, err := kCli.GetMetaByReference(ctx, e.ToObjectReference())
+			if err != nil && apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil && ctx.Err() == nil {
+				logger.Get(ctx).Debugf("Checking whether %s is gone: %v", entityDescription(e), err)
+			}
+			stillExists = append(stillExists, e)
+		}
+
+		remaining = stillExists
+		if len(remaining) == 0 {
+			return nil
+		}
+
+		logger.Get(ctx).Debugf("Waiting for %d objects to be deleted", len(remaining))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Timed out waiting for objects to be deleted: %s", entityNames(remaining))
+		case <-time.After(downWaitInterval):
+		}
+	}
+}
+
+type contextManifests struct {
+	kubeContext k8s.KubeContext
+	manifests   []model.Manifest
+}
+
+type contextEntities struct {
+	kubeContext k8s.KubeContext
+	entities    []k8s.K8sEntity
+}
+
+// Groups manifests by the kubeconfig context they deploy to.
+// The context that Tilt started with comes first.
+func manifestsByContext(manifests []model.Manifest) []contextManifests {
+	byContext := make(map[k8s.KubeContext][]model.Manifest)
+	var contexts []k8s.KubeContext
+	for _, m := range manifests {
+		kubeContext := k8s.KubeContext(m.K8sTarget().KubeContext)
+		if _, ok := byContext[kubeContext]; !ok {
+			contexts = append(contexts, kubeContext)
+		}
+		byContext[kubeContext] = append(byContext[kubeContext], m)
+	}
+
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i] < contexts[j] })
+
+	result := make([]contextManifests, 0, len(contexts))
+	for _, kubeContext := range contexts {
+		result = append(result, contextManifests{kubeContext: kubeContext, manifests: byContext[kubeContext]})
+	}
+	return result
+}
+
+// Returns the entities of the given manifests, in the order we should delete them.
+//
+// Manifests are deleted in reverse dependency order, so that each resource goes away
+// before the resources it depends on (via resource_deps). Within a group of manifests
+// at the same depth, objects are deleted in the reverse of the order that we apply them.
+func deletionOrder(manifests []model.Manifest) ([]k8s.K8sEntity, error) {
+	byName := make(map[model.ManifestName]model.Manifest, len(manifests))
+	for _, m := range manifests {
+		byName[m.Name] = m
+	}
+
+	depths := make(map[model.ManifestName]int, len(manifests))
+	var depth func(mn model.ManifestName, visiting map[model.ManifestName]bool) int
+	depth = func(mn model.ManifestName, visiting map[model.ManifestName]bool) int {
+		if d, ok := depths[mn]; ok {
+			return d
+		}
+		// The Tiltfile loader rejects cycles, but don't loop forever if we get one.
+		if visiting[mn] {
+			return 0
+		}
+		visiting[mn] = true
+
+		d := 0
+		for _, dep := range byName[mn].ResourceDependencies {
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			if dd := depth(dep, visiting) + 1; dd > d {
+				d = dd
+			}
+		}
+		depths[mn] = d
+		return d
+	}
+
+	maxDepth := 0
+	for _, m := range manifests {
+		if d := depth(m.Name, make(map[model.ManifestName]bool)); d > maxDepth {
+			maxDepth = d
+		}
+	}
+
+	var result []k8s.K8sEntity
+	for d := maxDepth; d >= 0; d-- {
+		var group []model.Manifest
+		for _, m := range manifests {
+			if depths[m.Name] == d {
+				group = append(group, m)
+			}
+		}
+
+		entities, err := engine.ParseYAMLFromManifests(group...)
 		if err != nil {
-			return errors.Wrap(err, "Running `docker-compose down`")
+			return nil, err
 		}
+		result = append(result, k8s.ReverseSortedEntities(entities)...)
 	}
+	return result, nil
+}
 
-	return nil
+func entityDescription(e k8s.K8sEntity) string {
+	ns := e.Namespace()
+	if ns == "" {
+		return fmt.Sprintf("%s/%s", e.GVK().Kind, e.Name())
+	}
+	return fmt.Sprintf("%s/%s (namespace: %s)", e.GVK().Kind, e.Name(), ns)
+}
+
+func entityNames(entities []k8s.K8sEntity) string {
+	var names []string
+	for _, e := range entities {
+		names = append(names, e.Name())
+	}
+	return strings.Join(names, ", ")
 }
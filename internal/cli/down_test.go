@@ -1,9 +1,11 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +17,7 @@ import (
 	"github.com/tilt-dev/tilt/internal/k8s/testyaml"
 	"github.com/tilt-dev/tilt/internal/testutils"
 	"github.com/tilt-dev/tilt/internal/tiltfile"
+	"github.com/tilt-dev/tilt/pkg/logger"
 	"github.com/tilt-dev/tilt/pkg/model"
 )
 
@@ -87,6 +90,142 @@ func TestDownDeletesInReverseOrder(t *testing.T) {
 	require.Regexp(t, "(?s)name: sancho.*name: foo", f.kCli.DeletedYaml) // namespace comes after deployment
 }
 
+func TestDownDeletesDependentsFirst(t *testing.T) {
+	f := newDownFixture(t)
+	defer f.TearDown()
+
+	db := newK8sPVCManifest("db", "delete")
+	fe := newK8sManifest()[0]
+	fe.ResourceDependencies = []model.ManifestName{"db"}
+
+	f.tfl.Result = tiltfile.TiltfileLoadResult{Manifests: []model.Manifest{db, fe}}
+	err := f.cmd.down(f.ctx, f.deps, nil)
+	require.NoError(t, err)
+	require.Regexp(t, "(?s)name: sancho.*name: db", f.kCli.DeletedYaml) // dependent comes before its dependency
+}
+
+func TestDownPreservesVolumesByDefault(t *testing.T) {
+	f := newDownFixture(t)
+	defer f.TearDown()
+
+	manifests := append([]model.Manifest{}, newK8sManifest()...)
+	manifests = append(manifests, newK8sPVCManifest("data", ""))
+
+	f.tfl.Result = tiltfile.TiltfileLoadResult{Manifests: manifests}
+	err := f.cmd.down(f.ctx, f.deps, nil)
+	require.NoError(t, err)
+	require.Contains(t, f.kCli.DeletedYaml, "sancho")
+	require.NotContains(t, f.kCli.DeletedYaml, "name: data")
+}
+
+func TestDownDeletesVolumesIfSpecified(t *testing.T) {
+	f := newDownFixture(t)
+	defer f.TearDown()
+
+	f.tfl.Result = tiltfile.TiltfileLoadResult{Manifests: []model.Manifest{newK8sPVCManifest("data", "")}}
+	f.cmd.deleteVolumes = true
+	err := f.cmd.down(f.ctx, f.deps, nil)
+	require.NoError(t, err)
+	require.Contains(t, f.kCli.DeletedYaml, "name: data")
+}
+
+func TestDownKeepAndDeleteNamespacesConflict(t *testing.T) {
+	f := newDownFixture(t)
+	defer f.TearDown()
+
+	f.tfl.Result = tiltfile.TiltfileLoadResult{Manifests: newK8sManifest()}
+	f.cmd.deleteNamespaces = true
+	f.cmd.keepNamespaces = true
+	err := f.cmd.down(f.ctx, f.deps, nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "can't be used together")
+	}
+	require.Equal(t, "", f.kCli.DeletedYaml)
+}
+
+func TestDownLabel(t *testing.T) {
+	f := newDownFixture(t)
+	defer f.TearDown()
+
+	manifests := append([]model.Manifest{}, newK8sManifest()...)
+	manifests = append(manifests, model.Manifest{Name: "doggos"}.WithDeployTarget(k8s.MustTarget("doggos", testyaml.DoggosDeploymentYaml)))
+
+	f.tfl.Result = tiltfile.TiltfileLoadResult{Manifests: manifests}
+	f.cmd.labels = []string{"app=doggos"}
+	err := f.cmd.down(f.ctx, f.deps, nil)
+	require.NoError(t, err)
+	require.Contains(t, f.kCli.DeletedYaml, "name: doggos")
+	require.NotContains(t, f.kCli.DeletedYaml, "sancho")
+}
+
+func TestDownLabelInvalid(t *testing.T) {
+	f := newDownFixture(t)
+	defer f.TearDown()
+
+	f.tfl.Result = tiltfile.TiltfileLoadResult{Manifests: newK8sManifest()}
+	f.cmd.labels = []string{"app in ("}
+	err := f.cmd.down(f.ctx, f.deps, nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Parsing --label")
+	}
+}
+
+func TestDownDryRun(t *testing.T) {
+	f := newDownFixture(t)
+	defer f.TearDown()
+
+	manifests := append([]model.Manifest{}, newK8sManifest()...)
+	manifests = append(manifests, newDCManifest()...)
+
+	f.tfl.Result = tiltfile.TiltfileLoadResult{Manifests: manifests}
+	f.cmd.dryRun = true
+	err := f.cmd.down(f.ctx, f.deps, nil)
+	require.NoError(t, err)
+	require.Equal(t, "", f.kCli.DeletedYaml)
+	require.Contains(t, f.out.String(), "Would delete Deployment/sancho")
+	require.Contains(t, f.out.String(), "Would run `docker-compose down` for: dc.yaml")
+}
+
+func TestDownWait(t *testing.T) {
+	f := newDownFixture(t)
+	defer f.TearDown()
+
+	manifests := newK8sManifest()
+	entities, err := k8s.ParseYAMLFromString(testyaml.SanchoYAML)
+	require.NoError(t, err)
+	f.kCli.InjectEntityByName(entities...)
+
+	f.tfl.Result = tiltfile.TiltfileLoadResult{Manifests: manifests}
+	f.cmd.wait = true
+	f.cmd.waitTimeout = time.Second
+	err = f.cmd.down(f.ctx, f.deps, nil)
+	require.NoError(t, err)
+	require.Contains(t, f.kCli.DeletedYaml, "sancho")
+}
+
+func TestDownOtherContext(t *testing.T) {
+	f := newDownFixture(t)
+	defer f.TearDown()
+
+	m := newK8sManifest()[0]
+	kTarget := m.K8sTarget()
+	kTarget.KubeContext = "data"
+	manifests := []model.Manifest{
+		m.WithDeployTarget(kTarget),
+		newK8sPVCManifest("foo", "delete"),
+	}
+
+	f.tfl.Result = tiltfile.TiltfileLoadResult{Manifests: manifests}
+	err := f.cmd.down(f.ctx, f.deps, nil)
+	require.NoError(t, err)
+
+	dataCli := f.deps.clients.For("data").Client.(*k8s.FakeK8sClient)
+	assert.Contains(t, dataCli.DeletedYaml, "sancho")
+	assert.NotContains(t, dataCli.DeletedYaml, "foo")
+	assert.Contains(t, f.kCli.DeletedYaml, "foo")
+	assert.NotContains(t, f.kCli.DeletedYaml, "sancho")
+}
+
 func TestDownK8sFails(t *testing.T) {
 	f := newDownFixture(t)
 	defer f.TearDown()
@@ -166,6 +305,7 @@ status: {}`, name, downPolicy)
 type downFixture struct {
 	t      *testing.T
 	ctx    context.Context
+	out    *bytes.Buffer
 	cancel func()
 	cmd    *downCmd
 	deps   DownDeps
@@ -176,17 +316,20 @@ type downFixture struct {
 
 func newDownFixture(t *testing.T) downFixture {
 	ctx, _, _ := testutils.CtxAndAnalyticsForTest()
+	out := bytes.NewBuffer(nil)
+	ctx = logger.WithLogger(ctx, logger.NewLogger(logger.InfoLvl, out))
 	ctx, cancel := context.WithCancel(ctx)
 	tfl := tiltfile.NewFakeTiltfileLoader()
 	dcc := dockercompose.NewFakeDockerComposeClient(t, ctx)
 	kCli := k8s.NewFakeK8sClient()
-	downDeps := DownDeps{tfl, dcc, kCli}
+	downDeps := DownDeps{tfl, dcc, k8s.NewFakeContextClients(ctx, kCli)}
 	cmd := &downCmd{downDepsProvider: func(ctx context.Context, tiltAnalytics *analytics.TiltAnalytics, subcommand model.TiltSubcommand) (deps DownDeps, err error) {
 		return downDeps, nil
 	}}
 	return downFixture{
 		t:      t,
 		ctx:    ctx,
+		out:    out,
 		cancel: cancel,
 		cmd:    cmd,
 		deps:   downDeps,
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"io"
 	"log"
 	"time"
 
@@ -14,7 +15,10 @@ import (
 )
 
 type logsCmd struct {
-	follow bool // if true, follow logs (otherwise print current logs and exit)
+	follow  bool // if true, follow logs (otherwise print current logs and exit)
+	history bool // if true, print logs that Tilt no longer keeps in memory
+	filter  server.LogFilterParams
+	json    bool // if true, print each log as a line of JSON
 }
 
 func (c *logsCmd) name() model.TiltSubcommand { return "logs" }
@@ -28,12 +32,33 @@ func (c *logsCmd) register() *cobra.Command {
 
 By default, looks for a running Tilt instance on localhost:10350
 (this is configurable with the --port and --host flags).
+
+The --grep, --level, --since, --span-type, --field, and --since-last-build
+flags filter logs on the Tilt server, so only matching logs are downloaded.
+
+With --json, each log is printed as a JSON object on its own line, with
+the fields: time, manifest_name, span_id, level, source (one of build, pod,
+container, local, tiltfile, tilt), text, and fields (if the log has any).
 `,
+		Example: `  tilt logs --grep='connection refused'
+  tilt logs frontend --level=warn --since=10m
+  tilt logs --span-type=build -f
+  tilt logs db --field stream=stderr
+  tilt logs frontend --since-last-build
+  tilt logs --json -f | jq -r 'select(.level == "error") | .text'`,
 	}
 
 	cmd.Flags().BoolVarP(&c.follow, "follow", "f", false, "If true, stream the requested logs; otherwise, print the requested logs at the current moment in time, then exit.")
+	cmd.Flags().BoolVar(&c.history, "history", false, "If true, print the full log history, including logs from the previous Tilt session.")
+	cmd.Flags().StringVar(&c.filter.Grep, "grep", "", "Only print log lines that match this regular expression.")
+	cmd.Flags().StringVar(&c.filter.Level, "level", "", "Only print logs at least this severe: one of debug, verbose, info, warn, error.")
+	cmd.Flags().StringVar(&c.filter.Since, "since", "", "Only print logs newer than a relative duration, like 10m, or an RFC3339 timestamp.")
+	cmd.Flags().StringVar(&c.filter.SpanType, "span-type", "", "Only print build logs or runtime logs: one of build, runtime.")
+	cmd.Flags().StringArrayVar(&c.filter.Fields, "field", nil, "Only print logs with this field, formatted as key=value, like stream=stderr. May be repeated.")
+	cmd.Flags().BoolVar(&c.filter.SinceLastBuild, "since-last-build", false, "Only print each resource's logs since its last build started, or since its logs were last cleared, whichever is later.")
+
+	cmd.Flags().BoolVar(&c.json, "json", false, "If true, print each log as a line of JSON, for other programs to read.")
 
-	// TODO: log level flags
 	addConnectServerFlags(cmd)
 	return cmd
 }
@@ -53,5 +78,10 @@ func (c *logsCmd) run(ctx context.Context, args []string) error {
 		return err
 	}
 
-	return server.StreamLogs(ctx, c.follow, logDeps.url, args, logDeps.printer)
+	var jsonOut io.Writer
+	if c.json {
+		jsonOut = logDeps.stdout
+	}
+
+	return server.StreamLogs(ctx, c.follow, c.history, logDeps.url, args, c.filter, logDeps.printer, jsonOut)
 }
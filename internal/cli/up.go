@@ -2,15 +2,18 @@ package cli
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"log"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
+	"github.com/tilt-dev/wmclient/pkg/dirs"
 	"k8s.io/klog/v2"
 
 	"github.com/tilt-dev/tilt/internal/analytics"
@@ -22,6 +25,7 @@ import (
 	"github.com/tilt-dev/tilt/pkg/assets"
 	"github.com/tilt-dev/tilt/pkg/logger"
 	"github.com/tilt-dev/tilt/pkg/model"
+	"github.com/tilt-dev/tilt/pkg/model/logstore"
 	"github.com/tilt-dev/tilt/web"
 )
 
@@ -165,8 +169,16 @@ func (c *upCmd) run(ctx context.Context, args []string) error {
 
 	engineMode := store.EngineModeUp
 
+	logArchive, err := openLogArchive(c.fileName)
+	if err != nil {
+		logger.Get(ctx).Debugf("Log history disabled: %v", err)
+	} else {
+		defer closeLogArchive(ctx, cmdUpDeps.Store)
+	}
+
 	err = upper.Start(ctx, args, cmdUpDeps.TiltBuild, engineMode,
-		c.fileName, termMode, a.UserOpt(), cmdUpDeps.Token, string(cmdUpDeps.CloudAddress))
+		c.fileName, termMode, a.UserOpt(), cmdUpDeps.Token, string(cmdUpDeps.CloudAddress),
+		logArchive)
 	if err != context.Canceled {
 		return err
 	} else {
@@ -254,3 +266,32 @@ func provideAssetServer(mode model.WebMode, version model.WebVersion) (assets.Se
 	}
 	return nil, model.UnrecognizedWebModeError(string(mode))
 }
+
+// Logs that don't fit in memory are archived in the Tilt data dir, one
+// archive per Tiltfile, so that restarting Tilt shows the project's history.
+func openLogArchive(fileName string) (*logstore.Archive, error) {
+	absPath, err := filepath.Abs(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := dirs.GetTiltDevDir()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256([]byte(absPath))
+	return logstore.OpenArchive(filepath.Join(dir, "logs", fmt.Sprintf("%x", hash[:8])))
+}
+
+// Writes the logs that are still in memory to the archive, so that the next
+// session can show them.
+func closeLogArchive(ctx context.Context, st *store.Store) {
+	state := st.RLockState()
+	defer st.RUnlockState()
+
+	err := state.LogStore.CloseArchive()
+	if err != nil {
+		logger.Get(ctx).Debugf("Error saving log history: %v", err)
+	}
+}
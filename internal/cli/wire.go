@@ -34,9 +34,12 @@ import (
 	"github.com/tilt-dev/tilt/internal/engine/dockerprune"
 	"github.com/tilt-dev/tilt/internal/engine/exit"
 	"github.com/tilt-dev/tilt/internal/engine/fswatch"
+	"github.com/tilt-dev/tilt/internal/engine/k8sgc"
 	"github.com/tilt-dev/tilt/internal/engine/k8srollout"
 	"github.com/tilt-dev/tilt/internal/engine/k8swatch"
 	"github.com/tilt-dev/tilt/internal/engine/local"
+	"github.com/tilt-dev/tilt/internal/engine/logexport"
+	"github.com/tilt-dev/tilt/internal/engine/loglimit"
 	"github.com/tilt-dev/tilt/internal/engine/metrics"
 	"github.com/tilt-dev/tilt/internal/engine/portforward"
 	"github.com/tilt-dev/tilt/internal/engine/runtimelog"
@@ -70,6 +73,7 @@ var K8sWireSet = wire.NewSet(
 	k8s.ProvideServerVersion,
 	k8s.ProvideK8sClient,
 	k8s.ProvideOwnerFetcher,
+	k8s.ProvideContextClients,
 	ProvideKubeContextOverride)
 
 var BaseWireSet = wire.NewSet(
@@ -94,9 +98,13 @@ var BaseWireSet = wire.NewSet(
 	local.NewServerController,
 	k8swatch.NewPodWatcher,
 	k8swatch.NewServiceWatcher,
+	k8swatch.NewObjectReadinessWatcher,
+	k8swatch.NewRolloutWatcher,
 	k8swatch.NewEventWatchManager,
 	configs.NewConfigsController,
 	telemetry.NewController,
+	logexport.NewController,
+	loglimit.NewController,
 	dcwatch.NewEventWatcher,
 	runtimelog.NewDockerComposeLogManager,
 	cloud.WireSet,
@@ -115,6 +123,7 @@ var BaseWireSet = wire.NewSet(
 	wire.Bind(new(store.RStore), new(*store.Store)),
 
 	dockerprune.NewDockerPruner,
+	k8sgc.NewGarbageCollector,
 
 	provideTiltInfo,
 	engine.NewUpper,
@@ -277,17 +286,17 @@ func wireDownDeps(ctx context.Context, tiltAnalytics *analytics.TiltAnalytics, s
 type DownDeps struct {
 	tfl      tiltfile.TiltfileLoader
 	dcClient dockercompose.DockerComposeClient
-	kClient  k8s.Client
+	clients  *k8s.ContextClients
 }
 
 func ProvideDownDeps(
 	tfl tiltfile.TiltfileLoader,
 	dcClient dockercompose.DockerComposeClient,
-	kClient k8s.Client) DownDeps {
+	clients *k8s.ContextClients) DownDeps {
 	return DownDeps{
 		tfl:      tfl,
 		dcClient: dcClient,
-		kClient:  kClient,
+		clients:  clients,
 	}
 }
 
@@ -299,12 +308,14 @@ func wireLogsDeps(ctx context.Context, tiltAnalytics *analytics.TiltAnalytics, s
 type LogsDeps struct {
 	url     model.WebURL
 	printer *hud.IncrementalPrinter
+	stdout  hud.Stdout
 }
 
-func ProvideLogsDeps(u model.WebURL, p *hud.IncrementalPrinter) LogsDeps {
+func ProvideLogsDeps(u model.WebURL, p *hud.IncrementalPrinter, stdout hud.Stdout) LogsDeps {
 	return LogsDeps{
 		url:     u,
 		printer: p,
+		stdout:  stdout,
 	}
 }
 
@@ -38,9 +38,12 @@ import (
 	"github.com/tilt-dev/tilt/internal/engine/dockerprune"
 	"github.com/tilt-dev/tilt/internal/engine/exit"
 	"github.com/tilt-dev/tilt/internal/engine/fswatch"
+	"github.com/tilt-dev/tilt/internal/engine/k8sgc"
 	"github.com/tilt-dev/tilt/internal/engine/k8srollout"
 	"github.com/tilt-dev/tilt/internal/engine/k8swatch"
 	"github.com/tilt-dev/tilt/internal/engine/local"
+	"github.com/tilt-dev/tilt/internal/engine/logexport"
+	"github.com/tilt-dev/tilt/internal/engine/loglimit"
 	"github.com/tilt-dev/tilt/internal/engine/metrics"
 	"github.com/tilt-dev/tilt/internal/engine/portforward"
 	"github.com/tilt-dev/tilt/internal/engine/runtimelog"
@@ -84,17 +87,18 @@ func wireTiltfileResult(ctx context.Context, analytics2 *analytics.TiltAnalytics
 	}
 	minikubeClient := k8s.ProvideMinikubeClient(kubeContext)
 	client := k8s.ProvideK8sClient(ctx, env, restConfigOrError, clientsetOrError, portForwardClient, namespace, minikubeClient, clientConfig)
-	extension := k8scontext.NewExtension(kubeContext, env)
+	extension := k8scontext.NewExtension(kubeContext, env, apiConfig)
 	tiltBuild := provideTiltInfo()
 	versionExtension := version.NewExtension(tiltBuild)
 	configExtension := config.NewExtension(subcommand)
 	runtime := k8s.ProvideContainerRuntime(ctx, client)
 	clusterEnv := docker.ProvideClusterEnv(ctx, env, runtime, minikubeClient)
 	localEnv := docker.ProvideLocalEnv(ctx, clusterEnv)
-	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv)
+	localClient := docker.ProvideLocalCli(ctx, localEnv)
+	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv, localClient)
 	webHost := provideWebHost()
 	defaults := _wireDefaultsValue
-	tiltfileLoader := tiltfile.ProvideTiltfileLoader(analytics2, client, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
+	tiltfileLoader := tiltfile.ProvideTiltfileLoader(analytics2, client, namespace, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
 	cliCmdTiltfileResultDeps := newTiltfileResultDeps(tiltfileLoader)
 	return cliCmdTiltfileResultDeps, nil
 }
@@ -130,14 +134,14 @@ func wireDockerPrune(ctx context.Context, analytics2 *analytics.TiltAnalytics, s
 		return dpDeps{}, err
 	}
 	switchCli := docker.ProvideSwitchCli(clusterClient, localClient)
-	extension := k8scontext.NewExtension(kubeContext, env)
+	extension := k8scontext.NewExtension(kubeContext, env, apiConfig)
 	tiltBuild := provideTiltInfo()
 	versionExtension := version.NewExtension(tiltBuild)
 	configExtension := config.NewExtension(subcommand)
-	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv)
+	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv, localClient)
 	webHost := provideWebHost()
 	defaults := _wireDefaultsValue
-	tiltfileLoader := tiltfile.ProvideTiltfileLoader(analytics2, client, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
+	tiltfileLoader := tiltfile.ProvideTiltfileLoader(analytics2, client, namespace, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
 	cliDpDeps := newDPDeps(switchCli, tiltfileLoader)
 	return cliDpDeps, nil
 }
@@ -172,7 +176,26 @@ func wireCmdUp(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdTags
 	httpClient := cloud.ProvideHttpClient()
 	address := cloudurl.ProvideAddress()
 	snapshotUploader := cloud.NewSnapshotUploader(httpClient, address)
-	headsUpServer, err := server.ProvideHeadsUpServer(ctx, storeStore, assetsServer, analytics3, modeController, snapshotUploader)
+	k8sKubeContextOverride := ProvideKubeContextOverride()
+	clientConfig := k8s.ProvideClientConfig(k8sKubeContextOverride)
+	apiConfig, err := k8s.ProvideKubeConfig(clientConfig, k8sKubeContextOverride)
+	if err != nil {
+		return CmdUpDeps{}, err
+	}
+	env := k8s.ProvideEnv(ctx, apiConfig)
+	restConfigOrError := k8s.ProvideRESTConfig(clientConfig)
+	clientsetOrError := k8s.ProvideClientset(restConfigOrError)
+	portForwardClient := k8s.ProvidePortForwardClient(restConfigOrError, clientsetOrError)
+	namespace := k8s.ProvideConfigNamespace(clientConfig)
+	kubeContext, err := k8s.ProvideKubeContext(apiConfig)
+	if err != nil {
+		return CmdUpDeps{}, err
+	}
+	minikubeClient := k8s.ProvideMinikubeClient(kubeContext)
+	client := k8s.ProvideK8sClient(ctx, env, restConfigOrError, clientsetOrError, portForwardClient, namespace, minikubeClient, clientConfig)
+	ownerFetcher := k8s.ProvideOwnerFetcher(ctx, client)
+	contextClients := k8s.ProvideContextClients(ctx, kubeContext, client, namespace, ownerFetcher)
+	headsUpServer, err := server.ProvideHeadsUpServer(ctx, storeStore, assetsServer, analytics3, modeController, snapshotUploader, contextClients)
 	if err != nil {
 		return CmdUpDeps{}, err
 	}
@@ -194,24 +217,7 @@ func wireCmdUp(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdTags
 	execer := cmd.ProvideExecer()
 	proberManager := cmd.ProvideProberManager()
 	cmdController := cmd.NewController(ctx, execer, proberManager, deferredClient, storeStore)
-	k8sKubeContextOverride := ProvideKubeContextOverride()
-	clientConfig := k8s.ProvideClientConfig(k8sKubeContextOverride)
-	apiConfig, err := k8s.ProvideKubeConfig(clientConfig, k8sKubeContextOverride)
-	if err != nil {
-		return CmdUpDeps{}, err
-	}
-	env := k8s.ProvideEnv(ctx, apiConfig)
-	restConfigOrError := k8s.ProvideRESTConfig(clientConfig)
-	clientsetOrError := k8s.ProvideClientset(restConfigOrError)
-	portForwardClient := k8s.ProvidePortForwardClient(restConfigOrError, clientsetOrError)
-	namespace := k8s.ProvideConfigNamespace(clientConfig)
-	kubeContext, err := k8s.ProvideKubeContext(apiConfig)
-	if err != nil {
-		return CmdUpDeps{}, err
-	}
-	minikubeClient := k8s.ProvideMinikubeClient(kubeContext)
-	client := k8s.ProvideK8sClient(ctx, env, restConfigOrError, clientsetOrError, portForwardClient, namespace, minikubeClient, clientConfig)
-	podLogStreamController := runtimelog.NewPodLogStreamController(ctx, deferredClient, storeStore, client)
+	podLogStreamController := runtimelog.NewPodLogStreamController(ctx, deferredClient, storeStore, contextClients)
 	v := controllers.ProvideControllers(controller, cmdController, podLogStreamController)
 	controllerBuilder := controllers.NewControllerBuilder(tiltServerControllerManager, v)
 	v2 := provideClock()
@@ -223,11 +229,12 @@ func wireCmdUp(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdTags
 	openInput := _wireOpenInputValue
 	openURL := _wireOpenURLValue
 	terminalPrompt := prompt.NewTerminalPrompt(analytics3, openInput, openURL, stdout, webHost, webURL)
-	ownerFetcher := k8s.ProvideOwnerFetcher(ctx, client)
-	podWatcher := k8swatch.NewPodWatcher(client, ownerFetcher, namespace)
-	serviceWatcher := k8swatch.NewServiceWatcher(client, ownerFetcher, namespace)
+	podWatcher := k8swatch.NewPodWatcher(contextClients)
+	serviceWatcher := k8swatch.NewServiceWatcher(contextClients)
+	objectReadinessWatcher := k8swatch.NewObjectReadinessWatcher(contextClients)
+	rolloutWatcher := k8swatch.NewRolloutWatcher(contextClients)
 	podLogManager := runtimelog.NewPodLogManager(deferredClient)
-	portforwardController := portforward.NewController(client)
+	portforwardController := portforward.NewController(contextClients)
 	manifestSubscriber := fswatch.NewManifestSubscriber(deferredClient)
 	runtime := k8s.ProvideContainerRuntime(ctx, client)
 	clusterEnv := docker.ProvideClusterEnv(ctx, env, runtime, minikubeClient)
@@ -239,7 +246,7 @@ func wireCmdUp(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdTags
 	}
 	switchCli := docker.ProvideSwitchCli(clusterClient, localClient)
 	dockerUpdater := containerupdate.NewDockerUpdater(switchCli)
-	execUpdater := containerupdate.NewExecUpdater(client)
+	execUpdater := containerupdate.NewExecUpdater(contextClients)
 	buildcontrolUpdateModeFlag := provideUpdateModeFlag()
 	updateMode, err := buildcontrol.ProvideUpdateMode(buildcontrolUpdateModeFlag, env, runtime)
 	if err != nil {
@@ -253,8 +260,9 @@ func wireCmdUp(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdTags
 	execCustomBuilder := build.NewExecCustomBuilder(switchCli, clock)
 	clusterName := k8s.ProvideClusterName(ctx, apiConfig)
 	kindLoader := engine.NewKINDLoader(env, clusterName)
-	imageBuildAndDeployer := engine.NewImageBuildAndDeployer(dockerBuilder, execCustomBuilder, client, env, analytics3, updateMode, clock, runtime, kindLoader)
-	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv)
+	execImageAttester := build.NewExecImageAttester()
+	imageBuildAndDeployer := engine.NewImageBuildAndDeployer(dockerBuilder, execCustomBuilder, client, contextClients, env, analytics3, updateMode, clock, runtime, kindLoader, execImageAttester)
+	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv, localClient)
 	imageBuilder := engine.NewImageBuilder(dockerBuilder, execCustomBuilder, updateMode)
 	dockerComposeBuildAndDeployer := engine.NewDockerComposeBuildAndDeployer(dockerComposeClient, switchCli, imageBuilder, clock)
 	localTargetBuildAndDeployer := engine.NewLocalTargetBuildAndDeployer(clock)
@@ -266,17 +274,17 @@ func wireCmdUp(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdTags
 	}
 	compositeBuildAndDeployer := engine.NewCompositeBuildAndDeployer(buildOrder, traceTracer)
 	buildController := engine.NewBuildController(compositeBuildAndDeployer)
-	extension := k8scontext.NewExtension(kubeContext, env)
+	extension := k8scontext.NewExtension(kubeContext, env, apiConfig)
 	versionExtension := version.NewExtension(tiltBuild)
 	configExtension := config.NewExtension(subcommand)
 	defaults := _wireDefaultsValue
-	tiltfileLoader := tiltfile.ProvideTiltfileLoader(analytics3, client, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
+	tiltfileLoader := tiltfile.ProvideTiltfileLoader(analytics3, client, namespace, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
 	configsController := configs.NewConfigsController(tiltfileLoader, switchCli)
 	eventWatcher := dcwatch.NewEventWatcher(dockerComposeClient, localClient)
 	dockerComposeLogManager := runtimelog.NewDockerComposeLogManager(dockerComposeClient)
 	analyticsReporter := analytics2.ProvideAnalyticsReporter(analytics3, storeStore, client, env)
 	analyticsUpdater := analytics2.NewAnalyticsUpdater(analytics3, cmdTags)
-	eventWatchManager := k8swatch.NewEventWatchManager(client, ownerFetcher, namespace)
+	eventWatchManager := k8swatch.NewEventWatchManager(contextClients)
 	clockworkClock := clockwork.NewRealClock()
 	cloudStatusManager := cloud.NewStatusManager(httpClient, clockworkClock)
 	dockerPruner := dockerprune.NewDockerPruner(switchCli)
@@ -287,8 +295,11 @@ func wireCmdUp(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdTags
 	deferredExporter := ProvideDeferredExporter()
 	gitRemote := git.ProvideGitRemote()
 	metricsController := metrics.NewController(deferredExporter, tiltBuild, gitRemote)
-	v3 := engine.ProvideSubscribers(headsUpServerController, tiltServerControllerManager, controllerBuilder, headsUpDisplay, terminalStream, terminalPrompt, podWatcher, serviceWatcher, podLogManager, portforwardController, manifestSubscriber, buildController, configsController, eventWatcher, dockerComposeLogManager, analyticsReporter, analyticsUpdater, eventWatchManager, cloudStatusManager, dockerPruner, telemetryController, serverController, podMonitor, exitController, metricsController, modeController)
-	upper, err := engine.NewUpper(ctx, storeStore, v3)
+	garbageCollector := k8sgc.NewGarbageCollector(contextClients)
+	logexportController := logexport.NewController(clock, tiltBuild)
+	loglimitController := loglimit.NewController()
+	v3 := engine.ProvideSubscribers(headsUpServerController, tiltServerControllerManager, controllerBuilder, headsUpDisplay, terminalStream, terminalPrompt, podWatcher, serviceWatcher, objectReadinessWatcher, rolloutWatcher, podLogManager, portforwardController, manifestSubscriber, buildController, configsController, eventWatcher, dockerComposeLogManager, analyticsReporter, analyticsUpdater, eventWatchManager, cloudStatusManager, dockerPruner, telemetryController, serverController, podMonitor, exitController, metricsController, modeController, garbageCollector, logexportController, loglimitController)
+	upper, err := engine.NewUpper(ctx, storeStore, v3, kubeContext)
 	if err != nil {
 		return CmdUpDeps{}, err
 	}
@@ -344,7 +355,26 @@ func wireCmdCI(ctx context.Context, analytics3 *analytics.TiltAnalytics, subcomm
 	httpClient := cloud.ProvideHttpClient()
 	address := cloudurl.ProvideAddress()
 	snapshotUploader := cloud.NewSnapshotUploader(httpClient, address)
-	headsUpServer, err := server.ProvideHeadsUpServer(ctx, storeStore, assetsServer, analytics3, modeController, snapshotUploader)
+	k8sKubeContextOverride := ProvideKubeContextOverride()
+	clientConfig := k8s.ProvideClientConfig(k8sKubeContextOverride)
+	apiConfig, err := k8s.ProvideKubeConfig(clientConfig, k8sKubeContextOverride)
+	if err != nil {
+		return CmdCIDeps{}, err
+	}
+	env := k8s.ProvideEnv(ctx, apiConfig)
+	restConfigOrError := k8s.ProvideRESTConfig(clientConfig)
+	clientsetOrError := k8s.ProvideClientset(restConfigOrError)
+	portForwardClient := k8s.ProvidePortForwardClient(restConfigOrError, clientsetOrError)
+	namespace := k8s.ProvideConfigNamespace(clientConfig)
+	kubeContext, err := k8s.ProvideKubeContext(apiConfig)
+	if err != nil {
+		return CmdCIDeps{}, err
+	}
+	minikubeClient := k8s.ProvideMinikubeClient(kubeContext)
+	client := k8s.ProvideK8sClient(ctx, env, restConfigOrError, clientsetOrError, portForwardClient, namespace, minikubeClient, clientConfig)
+	ownerFetcher := k8s.ProvideOwnerFetcher(ctx, client)
+	contextClients := k8s.ProvideContextClients(ctx, kubeContext, client, namespace, ownerFetcher)
+	headsUpServer, err := server.ProvideHeadsUpServer(ctx, storeStore, assetsServer, analytics3, modeController, snapshotUploader, contextClients)
 	if err != nil {
 		return CmdCIDeps{}, err
 	}
@@ -366,24 +396,7 @@ func wireCmdCI(ctx context.Context, analytics3 *analytics.TiltAnalytics, subcomm
 	execer := cmd.ProvideExecer()
 	proberManager := cmd.ProvideProberManager()
 	cmdController := cmd.NewController(ctx, execer, proberManager, deferredClient, storeStore)
-	k8sKubeContextOverride := ProvideKubeContextOverride()
-	clientConfig := k8s.ProvideClientConfig(k8sKubeContextOverride)
-	apiConfig, err := k8s.ProvideKubeConfig(clientConfig, k8sKubeContextOverride)
-	if err != nil {
-		return CmdCIDeps{}, err
-	}
-	env := k8s.ProvideEnv(ctx, apiConfig)
-	restConfigOrError := k8s.ProvideRESTConfig(clientConfig)
-	clientsetOrError := k8s.ProvideClientset(restConfigOrError)
-	portForwardClient := k8s.ProvidePortForwardClient(restConfigOrError, clientsetOrError)
-	namespace := k8s.ProvideConfigNamespace(clientConfig)
-	kubeContext, err := k8s.ProvideKubeContext(apiConfig)
-	if err != nil {
-		return CmdCIDeps{}, err
-	}
-	minikubeClient := k8s.ProvideMinikubeClient(kubeContext)
-	client := k8s.ProvideK8sClient(ctx, env, restConfigOrError, clientsetOrError, portForwardClient, namespace, minikubeClient, clientConfig)
-	podLogStreamController := runtimelog.NewPodLogStreamController(ctx, deferredClient, storeStore, client)
+	podLogStreamController := runtimelog.NewPodLogStreamController(ctx, deferredClient, storeStore, contextClients)
 	v := controllers.ProvideControllers(controller, cmdController, podLogStreamController)
 	controllerBuilder := controllers.NewControllerBuilder(tiltServerControllerManager, v)
 	v2 := provideClock()
@@ -395,11 +408,12 @@ func wireCmdCI(ctx context.Context, analytics3 *analytics.TiltAnalytics, subcomm
 	openInput := _wireOpenInputValue
 	openURL := _wireOpenURLValue
 	terminalPrompt := prompt.NewTerminalPrompt(analytics3, openInput, openURL, stdout, webHost, webURL)
-	ownerFetcher := k8s.ProvideOwnerFetcher(ctx, client)
-	podWatcher := k8swatch.NewPodWatcher(client, ownerFetcher, namespace)
-	serviceWatcher := k8swatch.NewServiceWatcher(client, ownerFetcher, namespace)
+	podWatcher := k8swatch.NewPodWatcher(contextClients)
+	serviceWatcher := k8swatch.NewServiceWatcher(contextClients)
+	objectReadinessWatcher := k8swatch.NewObjectReadinessWatcher(contextClients)
+	rolloutWatcher := k8swatch.NewRolloutWatcher(contextClients)
 	podLogManager := runtimelog.NewPodLogManager(deferredClient)
-	portforwardController := portforward.NewController(client)
+	portforwardController := portforward.NewController(contextClients)
 	manifestSubscriber := fswatch.NewManifestSubscriber(deferredClient)
 	runtime := k8s.ProvideContainerRuntime(ctx, client)
 	clusterEnv := docker.ProvideClusterEnv(ctx, env, runtime, minikubeClient)
@@ -411,7 +425,7 @@ func wireCmdCI(ctx context.Context, analytics3 *analytics.TiltAnalytics, subcomm
 	}
 	switchCli := docker.ProvideSwitchCli(clusterClient, localClient)
 	dockerUpdater := containerupdate.NewDockerUpdater(switchCli)
-	execUpdater := containerupdate.NewExecUpdater(client)
+	execUpdater := containerupdate.NewExecUpdater(contextClients)
 	buildcontrolUpdateModeFlag := provideUpdateModeFlag()
 	updateMode, err := buildcontrol.ProvideUpdateMode(buildcontrolUpdateModeFlag, env, runtime)
 	if err != nil {
@@ -425,8 +439,9 @@ func wireCmdCI(ctx context.Context, analytics3 *analytics.TiltAnalytics, subcomm
 	execCustomBuilder := build.NewExecCustomBuilder(switchCli, clock)
 	clusterName := k8s.ProvideClusterName(ctx, apiConfig)
 	kindLoader := engine.NewKINDLoader(env, clusterName)
-	imageBuildAndDeployer := engine.NewImageBuildAndDeployer(dockerBuilder, execCustomBuilder, client, env, analytics3, updateMode, clock, runtime, kindLoader)
-	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv)
+	execImageAttester := build.NewExecImageAttester()
+	imageBuildAndDeployer := engine.NewImageBuildAndDeployer(dockerBuilder, execCustomBuilder, client, contextClients, env, analytics3, updateMode, clock, runtime, kindLoader, execImageAttester)
+	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv, localClient)
 	imageBuilder := engine.NewImageBuilder(dockerBuilder, execCustomBuilder, updateMode)
 	dockerComposeBuildAndDeployer := engine.NewDockerComposeBuildAndDeployer(dockerComposeClient, switchCli, imageBuilder, clock)
 	localTargetBuildAndDeployer := engine.NewLocalTargetBuildAndDeployer(clock)
@@ -438,18 +453,18 @@ func wireCmdCI(ctx context.Context, analytics3 *analytics.TiltAnalytics, subcomm
 	}
 	compositeBuildAndDeployer := engine.NewCompositeBuildAndDeployer(buildOrder, traceTracer)
 	buildController := engine.NewBuildController(compositeBuildAndDeployer)
-	extension := k8scontext.NewExtension(kubeContext, env)
+	extension := k8scontext.NewExtension(kubeContext, env, apiConfig)
 	versionExtension := version.NewExtension(tiltBuild)
 	configExtension := config.NewExtension(subcommand)
 	defaults := _wireDefaultsValue
-	tiltfileLoader := tiltfile.ProvideTiltfileLoader(analytics3, client, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
+	tiltfileLoader := tiltfile.ProvideTiltfileLoader(analytics3, client, namespace, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
 	configsController := configs.NewConfigsController(tiltfileLoader, switchCli)
 	eventWatcher := dcwatch.NewEventWatcher(dockerComposeClient, localClient)
 	dockerComposeLogManager := runtimelog.NewDockerComposeLogManager(dockerComposeClient)
 	analyticsReporter := analytics2.ProvideAnalyticsReporter(analytics3, storeStore, client, env)
 	cmdTags := _wireCmdTagsValue
 	analyticsUpdater := analytics2.NewAnalyticsUpdater(analytics3, cmdTags)
-	eventWatchManager := k8swatch.NewEventWatchManager(client, ownerFetcher, namespace)
+	eventWatchManager := k8swatch.NewEventWatchManager(contextClients)
 	clockworkClock := clockwork.NewRealClock()
 	cloudStatusManager := cloud.NewStatusManager(httpClient, clockworkClock)
 	dockerPruner := dockerprune.NewDockerPruner(switchCli)
@@ -460,8 +475,11 @@ func wireCmdCI(ctx context.Context, analytics3 *analytics.TiltAnalytics, subcomm
 	deferredExporter := ProvideDeferredExporter()
 	gitRemote := git.ProvideGitRemote()
 	metricsController := metrics.NewController(deferredExporter, tiltBuild, gitRemote)
-	v3 := engine.ProvideSubscribers(headsUpServerController, tiltServerControllerManager, controllerBuilder, headsUpDisplay, terminalStream, terminalPrompt, podWatcher, serviceWatcher, podLogManager, portforwardController, manifestSubscriber, buildController, configsController, eventWatcher, dockerComposeLogManager, analyticsReporter, analyticsUpdater, eventWatchManager, cloudStatusManager, dockerPruner, telemetryController, serverController, podMonitor, exitController, metricsController, modeController)
-	upper, err := engine.NewUpper(ctx, storeStore, v3)
+	garbageCollector := k8sgc.NewGarbageCollector(contextClients)
+	logexportController := logexport.NewController(clock, tiltBuild)
+	loglimitController := loglimit.NewController()
+	v3 := engine.ProvideSubscribers(headsUpServerController, tiltServerControllerManager, controllerBuilder, headsUpDisplay, terminalStream, terminalPrompt, podWatcher, serviceWatcher, objectReadinessWatcher, rolloutWatcher, podLogManager, portforwardController, manifestSubscriber, buildController, configsController, eventWatcher, dockerComposeLogManager, analyticsReporter, analyticsUpdater, eventWatchManager, cloudStatusManager, dockerPruner, telemetryController, serverController, podMonitor, exitController, metricsController, modeController, garbageCollector, logexportController, loglimitController)
+	upper, err := engine.NewUpper(ctx, storeStore, v3, kubeContext)
 	if err != nil {
 		return CmdCIDeps{}, err
 	}
@@ -513,7 +531,26 @@ func wireCmdUpdog(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdT
 	httpClient := cloud.ProvideHttpClient()
 	address := cloudurl.ProvideAddress()
 	snapshotUploader := cloud.NewSnapshotUploader(httpClient, address)
-	headsUpServer, err := server.ProvideHeadsUpServer(ctx, storeStore, assetsServer, analytics3, modeController, snapshotUploader)
+	k8sKubeContextOverride := ProvideKubeContextOverride()
+	clientConfig := k8s.ProvideClientConfig(k8sKubeContextOverride)
+	apiConfig, err := k8s.ProvideKubeConfig(clientConfig, k8sKubeContextOverride)
+	if err != nil {
+		return CmdUpdogDeps{}, err
+	}
+	env := k8s.ProvideEnv(ctx, apiConfig)
+	restConfigOrError := k8s.ProvideRESTConfig(clientConfig)
+	clientsetOrError := k8s.ProvideClientset(restConfigOrError)
+	portForwardClient := k8s.ProvidePortForwardClient(restConfigOrError, clientsetOrError)
+	namespace := k8s.ProvideConfigNamespace(clientConfig)
+	kubeContext, err := k8s.ProvideKubeContext(apiConfig)
+	if err != nil {
+		return CmdUpdogDeps{}, err
+	}
+	minikubeClient := k8s.ProvideMinikubeClient(kubeContext)
+	k8sClient := k8s.ProvideK8sClient(ctx, env, restConfigOrError, clientsetOrError, portForwardClient, namespace, minikubeClient, clientConfig)
+	ownerFetcher := k8s.ProvideOwnerFetcher(ctx, k8sClient)
+	contextClients := k8s.ProvideContextClients(ctx, kubeContext, k8sClient, namespace, ownerFetcher)
+	headsUpServer, err := server.ProvideHeadsUpServer(ctx, storeStore, assetsServer, analytics3, modeController, snapshotUploader, contextClients)
 	if err != nil {
 		return CmdUpdogDeps{}, err
 	}
@@ -535,24 +572,7 @@ func wireCmdUpdog(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdT
 	execer := cmd.ProvideExecer()
 	proberManager := cmd.ProvideProberManager()
 	cmdController := cmd.NewController(ctx, execer, proberManager, deferredClient, storeStore)
-	k8sKubeContextOverride := ProvideKubeContextOverride()
-	clientConfig := k8s.ProvideClientConfig(k8sKubeContextOverride)
-	apiConfig, err := k8s.ProvideKubeConfig(clientConfig, k8sKubeContextOverride)
-	if err != nil {
-		return CmdUpdogDeps{}, err
-	}
-	env := k8s.ProvideEnv(ctx, apiConfig)
-	restConfigOrError := k8s.ProvideRESTConfig(clientConfig)
-	clientsetOrError := k8s.ProvideClientset(restConfigOrError)
-	portForwardClient := k8s.ProvidePortForwardClient(restConfigOrError, clientsetOrError)
-	namespace := k8s.ProvideConfigNamespace(clientConfig)
-	kubeContext, err := k8s.ProvideKubeContext(apiConfig)
-	if err != nil {
-		return CmdUpdogDeps{}, err
-	}
-	minikubeClient := k8s.ProvideMinikubeClient(kubeContext)
-	k8sClient := k8s.ProvideK8sClient(ctx, env, restConfigOrError, clientsetOrError, portForwardClient, namespace, minikubeClient, clientConfig)
-	podLogStreamController := runtimelog.NewPodLogStreamController(ctx, deferredClient, storeStore, k8sClient)
+	podLogStreamController := runtimelog.NewPodLogStreamController(ctx, deferredClient, storeStore, contextClients)
 	v := controllers.ProvideControllers(controller, cmdController, podLogStreamController)
 	controllerBuilder := controllers.NewControllerBuilder(tiltServerControllerManager, v)
 	stdout := hud.ProvideStdout()
@@ -560,7 +580,7 @@ func wireCmdUpdog(ctx context.Context, analytics3 *analytics.TiltAnalytics, cmdT
 	terminalStream := hud.NewTerminalStream(incrementalPrinter, storeStore)
 	cliUpdogSubscriber := provideUpdogSubscriber(objects, deferredClient)
 	v2 := provideUpdogCmdSubscribers(headsUpServerController, tiltServerControllerManager, controllerBuilder, terminalStream, cliUpdogSubscriber)
-	upper, err := engine.NewUpper(ctx, storeStore, v2)
+	upper, err := engine.NewUpper(ctx, storeStore, v2, kubeContext)
 	if err != nil {
 		return CmdUpdogDeps{}, err
 	}
@@ -758,18 +778,21 @@ func wireDownDeps(ctx context.Context, tiltAnalytics *analytics.TiltAnalytics, s
 	}
 	minikubeClient := k8s.ProvideMinikubeClient(kubeContext)
 	k8sClient := k8s.ProvideK8sClient(ctx, env, restConfigOrError, clientsetOrError, portForwardClient, namespace, minikubeClient, clientConfig)
-	extension := k8scontext.NewExtension(kubeContext, env)
+	extension := k8scontext.NewExtension(kubeContext, env, apiConfig)
 	tiltBuild := provideTiltInfo()
 	versionExtension := version.NewExtension(tiltBuild)
 	configExtension := config.NewExtension(subcommand)
 	runtime := k8s.ProvideContainerRuntime(ctx, k8sClient)
 	clusterEnv := docker.ProvideClusterEnv(ctx, env, runtime, minikubeClient)
 	localEnv := docker.ProvideLocalEnv(ctx, clusterEnv)
-	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv)
+	localClient := docker.ProvideLocalCli(ctx, localEnv)
+	dockerComposeClient := dockercompose.NewDockerComposeClient(localEnv, localClient)
 	webHost := provideWebHost()
 	defaults := _wireDefaultsValue
-	tiltfileLoader := tiltfile.ProvideTiltfileLoader(tiltAnalytics, k8sClient, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
-	downDeps := ProvideDownDeps(tiltfileLoader, dockerComposeClient, k8sClient)
+	tiltfileLoader := tiltfile.ProvideTiltfileLoader(tiltAnalytics, k8sClient, namespace, extension, versionExtension, configExtension, dockerComposeClient, webHost, defaults, env)
+	ownerFetcher := k8s.ProvideOwnerFetcher(ctx, k8sClient)
+	contextClients := k8s.ProvideContextClients(ctx, kubeContext, k8sClient, namespace, ownerFetcher)
+	downDeps := ProvideDownDeps(tiltfileLoader, dockerComposeClient, contextClients)
 	return downDeps, nil
 }
 
@@ -782,7 +805,7 @@ func wireLogsDeps(ctx context.Context, tiltAnalytics *analytics.TiltAnalytics, s
 	}
 	stdout := hud.ProvideStdout()
 	incrementalPrinter := hud.NewIncrementalPrinter(stdout)
-	logsDeps := ProvideLogsDeps(webURL, incrementalPrinter)
+	logsDeps := ProvideLogsDeps(webURL, incrementalPrinter, stdout)
 	return logsDeps, nil
 }
 
@@ -838,7 +861,7 @@ func wireAnalytics(l logger.Logger, cmdName model.TiltSubcommand) (*analytics.Ti
 var K8sWireSet = wire.NewSet(k8s.ProvideEnv, k8s.ProvideClusterName, k8s.ProvideKubeContext, k8s.ProvideKubeConfig, k8s.ProvideClientConfig, k8s.ProvideClientset, k8s.ProvideRESTConfig, k8s.ProvidePortForwardClient, k8s.ProvideConfigNamespace, k8s.ProvideContainerRuntime, k8s.ProvideServerVersion, k8s.ProvideK8sClient, k8s.ProvideOwnerFetcher, ProvideKubeContextOverride)
 
 var BaseWireSet = wire.NewSet(
-	K8sWireSet, tiltfile.WireSet, git.ProvideGitRemote, docker.SwitchWireSet, ProvideDeferredExporter, metrics.WireSet, user.WireSet, dockercompose.NewDockerComposeClient, clockwork.NewRealClock, engine.DeployerWireSet, runtimelog.NewPodLogManager, runtimelog.NewPodLogStreamController, portforward.NewController, engine.NewBuildController, cmd.WireSet, local.NewServerController, k8swatch.NewPodWatcher, k8swatch.NewServiceWatcher, k8swatch.NewEventWatchManager, configs.NewConfigsController, telemetry.NewController, dcwatch.NewEventWatcher, runtimelog.NewDockerComposeLogManager, cloud.WireSet, cloudurl.ProvideAddress, k8srollout.NewPodMonitor, telemetry.NewStartTracker, exit.NewController, build.ProvideClock, provideClock, hud.WireSet, prompt.WireSet, provideLogActions, store.NewStore, wire.Bind(new(store.RStore), new(*store.Store)), dockerprune.NewDockerPruner, provideTiltInfo, engine.NewUpper, analytics2.NewAnalyticsUpdater, analytics2.ProvideAnalyticsReporter, provideUpdateModeFlag, fswatch.NewManifestSubscriber, fsevent.ProvideWatcherMaker, fsevent.ProvideTimerMaker, controllers.WireSet, provideWebVersion,
+	K8sWireSet, tiltfile.WireSet, git.ProvideGitRemote, docker.SwitchWireSet, ProvideDeferredExporter, metrics.WireSet, user.WireSet, dockercompose.NewDockerComposeClient, clockwork.NewRealClock, engine.DeployerWireSet, runtimelog.NewPodLogManager, runtimelog.NewPodLogStreamController, portforward.NewController, engine.NewBuildController, cmd.WireSet, local.NewServerController, k8swatch.NewPodWatcher, k8swatch.NewServiceWatcher, k8swatch.NewObjectReadinessWatcher, k8swatch.NewRolloutWatcher, k8swatch.NewEventWatchManager, configs.NewConfigsController, telemetry.NewController, logexport.NewController, loglimit.NewController, dcwatch.NewEventWatcher, runtimelog.NewDockerComposeLogManager, cloud.WireSet, cloudurl.ProvideAddress, k8srollout.NewPodMonitor, telemetry.NewStartTracker, exit.NewController, build.ProvideClock, provideClock, hud.WireSet, prompt.WireSet, provideLogActions, store.NewStore, wire.Bind(new(store.RStore), new(*store.Store)), dockerprune.NewDockerPruner, k8sgc.NewGarbageCollector, provideTiltInfo, engine.NewUpper, analytics2.NewAnalyticsUpdater, analytics2.ProvideAnalyticsReporter, provideUpdateModeFlag, fswatch.NewManifestSubscriber, fsevent.ProvideWatcherMaker, fsevent.ProvideTimerMaker, controllers.WireSet, provideWebVersion,
 	provideWebMode,
 	provideWebURL,
 	provideWebPort,
@@ -877,29 +900,31 @@ type CmdUpdogDeps struct {
 type DownDeps struct {
 	tfl      tiltfile.TiltfileLoader
 	dcClient dockercompose.DockerComposeClient
-	kClient  k8s.Client
+	clients  *k8s.ContextClients
 }
 
 func ProvideDownDeps(
 	tfl tiltfile.TiltfileLoader,
 	dcClient dockercompose.DockerComposeClient,
-	kClient k8s.Client) DownDeps {
+	clients *k8s.ContextClients) DownDeps {
 	return DownDeps{
 		tfl:      tfl,
 		dcClient: dcClient,
-		kClient:  kClient,
+		clients:  clients,
 	}
 }
 
 type LogsDeps struct {
 	url     model.WebURL
 	printer *hud.IncrementalPrinter
+	stdout  hud.Stdout
 }
 
-func ProvideLogsDeps(u model.WebURL, p *hud.IncrementalPrinter) LogsDeps {
+func ProvideLogsDeps(u model.WebURL, p *hud.IncrementalPrinter, stdout hud.Stdout) LogsDeps {
 	return LogsDeps{
 		url:     u,
 		printer: p,
+		stdout:  stdout,
 	}
 }
 
@@ -149,3 +149,40 @@ func (r Registry) ReplaceRegistryForLocalRef(rs RefSelector) (reference.Named, e
 func (r Registry) ReplaceRegistryForClusterRef(rs RefSelector) (reference.Named, error) {
 	return r.replaceRegistry(r.HostFromCluster(), rs)
 }
+
+// RegistryRule overrides the default registry for images whose configuration
+// ref matches Selector. Registry.Host is where we push the image, and
+// Registry.HostFromCluster() is where the cluster pulls it from (e.g., a
+// pull-through mirror).
+type RegistryRule struct {
+	Selector RefSelector
+	Registry Registry
+}
+
+func NewRegistryRule(image string, reg Registry) (RegistryRule, error) {
+	ref, err := ParseNamed(image)
+	if err != nil {
+		return RegistryRule{}, errors.Wrapf(err, "parsing registry rule image %q", image)
+	}
+	if err := reg.Validate(); err != nil {
+		return RegistryRule{}, errors.Wrapf(err, "validating registry rule for image %q", image)
+	}
+	return RegistryRule{Selector: NewRefSelector(ref), Registry: reg}, nil
+}
+
+// RegistryRules are evaluated in order; the first rule that matches an image wins.
+type RegistryRules []RegistryRule
+
+// RegistryFor returns the registry for the given configuration ref,
+// or the fallback if no rule matches.
+func (rules RegistryRules) RegistryFor(rs RefSelector, fallback Registry) Registry {
+	if rs.Empty() {
+		return fallback
+	}
+	for _, rule := range rules {
+		if rule.Selector.Matches(rs.ref) {
+			return rule.Registry
+		}
+	}
+	return fallback
+}
@@ -112,6 +112,43 @@ func TestNewRegistryEmptyOK(t *testing.T) {
 	require.NoError(t, err)
 }
 
+var registryRuleCases = []struct {
+	image           string
+	expectedLocal   string
+	expectedCluster string
+}{
+	{"gcr.io/foo/svc", "svc.io/gcr.io_foo_svc", "svc.io/gcr.io_foo_svc"},
+	{"gcr.io/foo/test:v1", "tools.io/gcr.io_foo_test", "mirror.io/gcr.io_foo_test"},
+	{"gcr.io/foo/test:v2", "default.io/gcr.io_foo_test", "default.io/gcr.io_foo_test"},
+	{"gcr.io/foo/other", "default.io/gcr.io_foo_other", "default.io/gcr.io_foo_other"},
+}
+
+func TestRegistryRules(t *testing.T) {
+	rules := RegistryRules{
+		{Selector: MustParseSelector("gcr.io/foo/svc"), Registry: MustNewRegistry("svc.io")},
+		{Selector: MustParseTaggedSelector("gcr.io/foo/test:v1"), Registry: MustNewRegistryWithHostFromCluster("tools.io", "mirror.io")},
+		{Selector: MustParseSelector("gcr.io/foo/svc"), Registry: MustNewRegistry("shadowed.io")},
+	}
+	fallback := MustNewRegistry("default.io")
+
+	for i, tc := range registryRuleCases {
+		t.Run(fmt.Sprintf("Test case #%d", i), func(t *testing.T) {
+			reg := rules.RegistryFor(NewRefSelector(MustParseNamed(tc.image)), fallback)
+			assertReplaceRegistryForLocal(t, reg, tc.image, tc.expectedLocal)
+			assertReplaceRegistryForCluster(t, reg, tc.image, tc.expectedCluster)
+		})
+	}
+}
+
+func TestNewRegistryRuleError(t *testing.T) {
+	_, err := NewRegistryRule("gcr.io/foo", Registry{Host: "invalid"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "repository name must be canonical")
+
+	_, err = NewRegistryRule("Not/A/Valid/Image", MustNewRegistry("gcr.io"))
+	require.Error(t, err)
+}
+
 func assertReplaceRegistryForLocal(t *testing.T, reg Registry, orig string, expected string) {
 	rs := NewRefSelector(MustParseNamed(orig))
 	actual, err := reg.ReplaceRegistryForLocalRef(rs)
@@ -15,13 +15,13 @@ import (
 )
 
 type ExecUpdater struct {
-	kCli k8s.Client
+	clients *k8s.ContextClients
 }
 
 var _ ContainerUpdater = &ExecUpdater{}
 
-func NewExecUpdater(kCli k8s.Client) *ExecUpdater {
-	return &ExecUpdater{kCli: kCli}
+func NewExecUpdater(clients *k8s.ContextClients) *ExecUpdater {
+	return &ExecUpdater{clients: clients}
 }
 
 func (cu *ExecUpdater) UpdateContainer(ctx context.Context, cInfo store.ContainerInfo,
@@ -34,12 +34,13 @@ func (cu *ExecUpdater) UpdateContainer(ctx context.Context, cInfo store.Containe
 
 	l := logger.Get(ctx)
 	w := logger.Get(ctx).Writer(logger.InfoLvl)
+	kCli := cu.clients.For(cInfo.KubeContext).Client
 
 	// delete files (if any)
 	if len(filesToDelete) > 0 {
 		buf := bytes.NewBuffer(nil)
 		rmWriter := io.MultiWriter(w, buf)
-		err := cu.kCli.Exec(ctx,
+		err := kCli.Exec(ctx,
 			cInfo.PodID, cInfo.ContainerName, cInfo.Namespace,
 			append([]string{"rm", "-rf"}, filesToDelete...), nil, rmWriter, rmWriter)
 		if err != nil {
@@ -50,7 +51,7 @@ func (cu *ExecUpdater) UpdateContainer(ctx context.Context, cInfo store.Containe
 	// copy files to container
 	buf := bytes.NewBuffer(nil)
 	tarWriter := io.MultiWriter(w, buf)
-	err := cu.kCli.Exec(ctx, cInfo.PodID, cInfo.ContainerName, cInfo.Namespace,
+	err := kCli.Exec(ctx, cInfo.PodID, cInfo.ContainerName, cInfo.Namespace,
 		[]string{"tar", "-C", "/", "-x", "-f", "-"}, archiveToCopy, tarWriter, tarWriter)
 	if err != nil {
 		return fmt.Errorf("copying changed files: %v", handleK8sExecError(buf, err))
@@ -59,7 +60,7 @@ func (cu *ExecUpdater) UpdateContainer(ctx context.Context, cInfo store.Containe
 	// run commands
 	for i, c := range cmds {
 		l.Infof("[CMD %d/%d] %s", i+1, len(cmds), strings.Join(c.Argv, " "))
-		err := cu.kCli.Exec(ctx, cInfo.PodID, cInfo.ContainerName, cInfo.Namespace,
+		err := kCli.Exec(ctx, cInfo.PodID, cInfo.ContainerName, cInfo.Namespace,
 			c.Argv, nil, w, w)
 		if err != nil {
 			return build.WrapCodeExitError(err, cInfo.ContainerID, c)
@@ -131,25 +131,41 @@ func TestUpdateContainerPermissionDenied(t *testing.T) {
 	assert.Equal(t, 1, len(f.kCli.ExecCalls))
 }
 
+func TestUpdateContainerOtherContext(t *testing.T) {
+	f := newExecFixture(t)
+
+	cInfo := TestContainerInfo
+	cInfo.KubeContext = "data"
+	err := f.ecu.UpdateContainer(f.ctx, cInfo, newReader("hello world"), nil, cmds, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataCli := f.clients.For("data").Client.(*k8s.FakeK8sClient)
+	assert.Len(t, dataCli.ExecCalls, 3)
+	assert.Len(t, f.kCli.ExecCalls, 0)
+}
+
 type execUpdaterFixture struct {
-	t    testing.TB
-	ctx  context.Context
-	kCli *k8s.FakeK8sClient
-	ecu  *ExecUpdater
+	t       testing.TB
+	ctx     context.Context
+	kCli    *k8s.FakeK8sClient
+	clients *k8s.ContextClients
+	ecu     *ExecUpdater
 }
 
 func newExecFixture(t testing.TB) *execUpdaterFixture {
 	fakeCli := k8s.NewFakeK8sClient()
-	cu := &ExecUpdater{
-		kCli: fakeCli,
-	}
 	ctx, _, _ := testutils.CtxAndAnalyticsForTest()
+	clients := k8s.NewFakeContextClients(ctx, fakeCli)
+	cu := NewExecUpdater(clients)
 
 	return &execUpdaterFixture{
-		t:    t,
-		ctx:  ctx,
-		kCli: fakeCli,
-		ecu:  cu,
+		t:       t,
+		ctx:     ctx,
+		kCli:    fakeCli,
+		clients: clients,
+		ecu:     cu,
 	}
 }
 
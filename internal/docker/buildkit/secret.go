@@ -23,15 +23,24 @@ https://github.com/docker/cli/blob/28ac2f82c690ac8f37c2980f2787572815439a50/cli/
 package buildkit
 
 import (
+	"context"
 	"encoding/csv"
 	"strings"
 
 	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/secrets"
 	"github.com/moby/buildkit/session/secrets/secretsprovider"
 	"github.com/pkg/errors"
 )
 
 func ParseSecretSpecs(sl []string) (session.Attachable, error) {
+	return ParseSecrets(sl, nil)
+}
+
+// ParseSecrets creates a secret provider that serves both secret specs
+// (files and env vars, like `docker build --secret`) and in-memory values
+// keyed by secret ID. In-memory values take precedence.
+func ParseSecrets(sl []string, values map[string][]byte) (session.Attachable, error) {
 	fs := make([]secretsprovider.Source, 0, len(sl))
 	for _, v := range sl {
 		s, err := parseSecret(v)
@@ -44,7 +53,20 @@ func ParseSecretSpecs(sl []string) (session.Attachable, error) {
 	if err != nil {
 		return nil, err
 	}
-	return secretsprovider.NewSecretProvider(store), nil
+	return secretsprovider.NewSecretProvider(valueStore{values: values, fallback: store}), nil
+}
+
+type valueStore struct {
+	values   map[string][]byte
+	fallback secrets.SecretStore
+}
+
+func (s valueStore) GetSecret(ctx context.Context, id string) ([]byte, error) {
+	v, ok := s.values[id]
+	if ok {
+		return v, nil
+	}
+	return s.fallback.GetSecret(ctx, id)
 }
 
 func parseSecret(value string) (*secretsprovider.Source, error) {
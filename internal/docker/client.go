@@ -18,6 +18,7 @@ import (
 	cliflags "github.com/docker/cli/cli/flags"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/registry"
@@ -81,6 +82,7 @@ type Client interface {
 
 	ContainerInspect(ctx context.Context, contianerID string) (types.ContainerJSON, error)
 	ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error)
+	ContainerLogs(ctx context.Context, containerID string, options types.ContainerLogsOptions) (io.ReadCloser, error)
 	ContainerRestartNoWait(ctx context.Context, containerID string) error
 	CopyToContainerRoot(ctx context.Context, container string, content io.Reader) error
 
@@ -98,6 +100,8 @@ type Client interface {
 	NewVersionError(APIrequired, feature string) error
 	BuildCachePrune(ctx context.Context, opts types.BuildCachePruneOptions) (*types.BuildCachePruneReport, error)
 	ContainersPrune(ctx context.Context, pruneFilters filters.Args) (types.ContainersPruneReport, error)
+
+	Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error)
 }
 
 type ExitError struct {
@@ -280,7 +284,7 @@ type dockerCreds struct {
 	sessionID   string
 }
 
-func (c *Cli) startBuildkitSession(ctx context.Context, key string, sshSpecs []string, secretSpecs []string) (*session.Session, error) {
+func (c *Cli) startBuildkitSession(ctx context.Context, key string, sshSpecs []string, secretSpecs []string, secrets map[string][]byte) (*session.Session, error) {
 	session, err := session.NewSession(ctx, "tilt", key)
 	if err != nil {
 		return nil, err
@@ -290,8 +294,8 @@ func (c *Cli) startBuildkitSession(ctx context.Context, key string, sshSpecs []s
 	provider := authprovider.NewDockerAuthProvider(logger.Get(ctx).Writer(logger.InfoLvl))
 	session.Allow(provider)
 
-	if len(secretSpecs) > 0 {
-		ss, err := buildkit.ParseSecretSpecs(secretSpecs)
+	if len(secretSpecs) > 0 || len(secrets) > 0 {
+		ss, err := buildkit.ParseSecrets(secretSpecs, secrets)
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not parse secret: %v", secretSpecs)
 		}
@@ -338,7 +342,7 @@ func (c *Cli) initCreds(ctx context.Context) dockerCreds {
 	creds := dockerCreds{}
 
 	if c.builderVersion == types.BuilderBuildKit {
-		session, err := c.startBuildkitSession(ctx, sessionSharedKey, nil, nil)
+		session, err := c.startBuildkitSession(ctx, sessionSharedKey, nil, nil, nil)
 		if err != nil {
 			logger.Get(ctx).Warnf("Docker BuildKit session failed to init: %v", err)
 		} else if session != nil {
@@ -458,11 +462,11 @@ func (c *Cli) ImageBuild(ctx context.Context, buildContext io.Reader, options Bu
 	var oneTimeSession *session.Session
 	sessionID := c.creds.sessionID
 
-	mustUseBuildkit := len(options.SSHSpecs) > 0 || len(options.SecretSpecs) > 0
+	mustUseBuildkit := len(options.SSHSpecs) > 0 || len(options.SecretSpecs) > 0 || len(options.Secrets) > 0
 	isUsingBuildkit := c.builderVersion == types.BuilderBuildKit
 	if isUsingBuildkit {
 		var err error
-		oneTimeSession, err = c.startBuildkitSession(ctx, identity.NewID(), options.SSHSpecs, options.SecretSpecs)
+		oneTimeSession, err = c.startBuildkitSession(ctx, identity.NewID(), options.SSHSpecs, options.SecretSpecs, options.Secrets)
 		if err != nil {
 			return types.ImageBuildResponse{}, errors.Wrapf(err, "ImageBuild")
 		}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 
 	"github.com/tilt-dev/tilt/internal/container"
@@ -44,6 +45,9 @@ func (c explodingClient) ContainerInspect(ctx context.Context, containerID strin
 func (c explodingClient) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
 	return nil, c.err
 }
+func (c explodingClient) ContainerLogs(ctx context.Context, containerID string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	return nil, c.err
+}
 func (c explodingClient) ContainerRestartNoWait(ctx context.Context, containerID string) error {
 	return c.err
 }
@@ -80,5 +84,10 @@ func (c explodingClient) BuildCachePrune(ctx context.Context, opts types.BuildCa
 func (c explodingClient) ContainersPrune(ctx context.Context, pruneFilters filters.Args) (types.ContainersPruneReport, error) {
 	return types.ContainersPruneReport{}, c.err
 }
+func (c explodingClient) Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+	errCh := make(chan error, 1)
+	errCh <- c.err
+	return make(chan events.Message), errCh
+}
 
 var _ Client = &explodingClient{}
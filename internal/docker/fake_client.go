@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/docker/go-units"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 
 	"github.com/tilt-dev/tilt/internal/container"
@@ -102,6 +104,12 @@ type FakeClient struct {
 
 	ContainerListOutput map[string][]types.Container
 
+	// Logs returned by ContainerLogs, by container ID.
+	ContainerLogsOutput map[string]string
+
+	// Messages sent to the channel returned by Events.
+	EventsOutput chan events.Message
+
 	CopyCount     int
 	CopyContainer string
 	CopyContent   io.Reader
@@ -136,6 +144,8 @@ func NewFakeClient() *FakeClient {
 		PushOutput:          ExamplePushOutput1,
 		BuildOutput:         ExampleBuildOutput1,
 		ContainerListOutput: make(map[string][]types.Container),
+		ContainerLogsOutput: make(map[string]string),
+		EventsOutput:        make(chan events.Message, 10),
 		RestartsByContainer: make(map[string]int),
 		Images:              make(map[string]types.ImageInspect),
 	}
@@ -196,6 +206,14 @@ func (c *FakeClient) ContainerList(ctx context.Context, options types.ContainerL
 	return res, nil
 }
 
+func (c *FakeClient) ContainerLogs(ctx context.Context, containerID string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	output, ok := c.ContainerLogsOutput[containerID]
+	if !ok {
+		return nil, fmt.Errorf("no logs for container %s", containerID)
+	}
+	return ioutil.NopCloser(strings.NewReader(output)), nil
+}
+
 func (c *FakeClient) ContainerRestartNoWait(ctx context.Context, containerID string) error {
 	c.RestartsByContainer[containerID]++
 	return nil
@@ -339,6 +357,10 @@ func (c *FakeClient) ContainersPrune(ctx context.Context, pruneFilters filters.A
 	return report, nil
 }
 
+func (c *FakeClient) Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+	return c.EventsOutput, make(chan error)
+}
+
 var _ Client = &FakeClient{}
 
 type fakeDockerResponse struct {
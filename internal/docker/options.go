@@ -10,6 +10,7 @@ type BuildOptions struct {
 	Target      string
 	SSHSpecs    []string
 	SecretSpecs []string
+	Secrets     map[string][]byte
 	Network     string
 	CacheFrom   []string
 	PullParent  bool
@@ -7,6 +7,7 @@ import (
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 
 	"github.com/tilt-dev/tilt/internal/container"
@@ -63,6 +64,9 @@ func (c *switchCli) ContainerInspect(ctx context.Context, containerID string) (t
 func (c *switchCli) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
 	return c.client().ContainerList(ctx, options)
 }
+func (c *switchCli) ContainerLogs(ctx context.Context, containerID string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	return c.client().ContainerLogs(ctx, containerID, options)
+}
 func (c *switchCli) ContainerRestartNoWait(ctx context.Context, containerID string) error {
 	return c.client().ContainerRestartNoWait(ctx, containerID)
 }
@@ -99,5 +103,8 @@ func (c *switchCli) BuildCachePrune(ctx context.Context, opts types.BuildCachePr
 func (c *switchCli) ContainersPrune(ctx context.Context, pruneFilters filters.Args) (types.ContainersPruneReport, error) {
 	return c.client().ContainersPrune(ctx, pruneFilters)
 }
+func (c *switchCli) Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+	return c.client().Events(ctx, options)
+}
 
 var _ Client = &switchCli{}
@@ -1,16 +1,19 @@
 package dockercompose
 
 import (
-	"bufio"
-	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/pkg/errors"
 
 	"github.com/tilt-dev/tilt/internal/container"
@@ -22,24 +25,35 @@ import (
 type DockerComposeClient interface {
 	Up(ctx context.Context, configPaths []string, serviceName model.TargetName, shouldBuild bool, stdout, stderr io.Writer) error
 	Down(ctx context.Context, configPaths []string, stdout, stderr io.Writer) error
-	StreamLogs(ctx context.Context, configPaths []string, serviceName model.TargetName) (io.ReadCloser, error)
+
+	// Streams the logs of the service's container, starting at `since` (or at
+	// the beginning, if `since` is zero), until the container stops.
+	//
+	// Each line is prefixed with the time that Docker recorded it (in
+	// RFC3339Nano format), so that callers can pick up where they left off.
+	StreamLogs(ctx context.Context, configPaths []string, serviceName model.TargetName, since time.Time, stdout, stderr io.Writer) error
+
+	// Streams the JSON of each container Event in the project.
 	StreamEvents(ctx context.Context, configPaths []string) (<-chan string, error)
+
 	Config(ctx context.Context, configPaths []string) (string, error)
 	Services(ctx context.Context, configPaths []string) (string, error)
 	ContainerID(ctx context.Context, configPaths []string, serviceName model.TargetName) (container.ID, error)
 }
 
 type cmdDCClient struct {
-	env docker.Env
-	mu  *sync.Mutex
+	env    docker.Env
+	docker docker.LocalClient
+	mu     *sync.Mutex
 }
 
 // TODO(dmiller): we might want to make this take a path to the docker-compose config so we don't
 // have to keep passing it in.
-func NewDockerComposeClient(env docker.LocalEnv) DockerComposeClient {
+func NewDockerComposeClient(env docker.LocalEnv, dCli docker.LocalClient) DockerComposeClient {
 	return &cmdDCClient{
-		env: docker.Env(env),
-		mu:  &sync.Mutex{},
+		env:    docker.Env(env),
+		docker: dCli,
+		mu:     &sync.Mutex{},
 	}
 }
 
@@ -120,71 +134,127 @@ func (c *cmdDCClient) Down(ctx context.Context, configPaths []string, stdout, st
 	return nil
 }
 
-func (c *cmdDCClient) StreamLogs(ctx context.Context, configPaths []string, serviceName model.TargetName) (io.ReadCloser, error) {
-	// TODO(maia): --since time
-	// (may need to implement with `docker log <cID>` instead since `d-c log` doesn't support `--since`
-	var args []string
-	for _, config := range configPaths {
-		args = append(args, "-f", config)
+func (c *cmdDCClient) StreamLogs(ctx context.Context, configPaths []string, serviceName model.TargetName, since time.Time, stdout, stderr io.Writer) error {
+	cID, err := c.serviceContainerID(ctx, configPaths, serviceName)
+	if err != nil {
+		return err
 	}
-	args = append(args, "logs", "--no-color", "-f", serviceName.String())
-	cmd := c.dcCommand(ctx, args)
-	stdout, err := cmd.StdoutPipe()
+
+	cJSON, err := c.docker.ContainerInspect(ctx, cID)
 	if err != nil {
-		return nil, errors.Wrap(err, "making stdout pipe for `docker-compose logs`")
+		return errors.Wrapf(err, "inspecting container for service %s", serviceName)
 	}
 
-	errBuf := bytes.Buffer{}
-	cmd.Stderr = &errBuf
+	options := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Timestamps: true,
+	}
+	if !since.IsZero() {
+		options.Since = since.Format(time.RFC3339Nano)
+	}
 
-	err = cmd.Start()
+	reader, err := c.docker.ContainerLogs(ctx, cID, options)
 	if err != nil {
-		return nil, errors.Wrapf(err, "`docker-compose %s`",
-			strings.Join(args, " "))
+		return errors.Wrapf(err, "streaming logs for service %s", serviceName)
 	}
-
-	go func() {
-		err = cmd.Wait()
-		if err != nil {
-			logger.Get(ctx).Debugf("cmd `docker-compose %s` exited with error: \"%v\" (stderr: %s)",
-				strings.Join(args, " "), err, errBuf.String())
-		}
+	defer func() {
+		_ = reader.Close()
 	}()
-	return stdout, nil
-}
 
-func (c *cmdDCClient) StreamEvents(ctx context.Context, configPaths []string) (<-chan string, error) {
-	ch := make(chan string)
-
-	var args []string
-	for _, config := range configPaths {
-		args = append(args, "-f", config)
+	// Containers with a TTY have a single output stream. Everything else is
+	// multiplexed, so that we can tell stdout and stderr apart.
+	if cJSON.Config != nil && cJSON.Config.Tty {
+		_, err = io.Copy(stdout, reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, reader)
 	}
-	args = append(args, "events", "--json")
-	cmd := c.dcCommand(ctx, args)
-	stdout, err := cmd.StdoutPipe()
+	return err
+}
+
+// Finds the most recent container for the service, even if it's stopped,
+// so that we can still show the logs of a container that exited.
+func (c *cmdDCClient) serviceContainerID(ctx context.Context, configPaths []string, serviceName model.TargetName) (string, error) {
+	project, err := c.projectName(ctx, configPaths)
 	if err != nil {
-		return ch, errors.Wrap(err, "making stdout pipe for `docker-compose events`")
+		return "", errors.Wrap(err, "reading docker-compose project name")
 	}
 
-	err = cmd.Start()
+	containers, err := c.docker.ContainerList(ctx, types.ContainerListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", fmt.Sprintf("%s=%s", ProjectLabel, project)),
+			filters.Arg("label", fmt.Sprintf("%s=%s", ServiceLabel, serviceName)),
+		),
+	})
 	if err != nil {
-		return ch, errors.Wrapf(err, "`docker-compose %s`",
-			strings.Join(args, " "))
+		return "", errors.Wrapf(err, "listing containers for service %s", serviceName)
 	}
-	go func() {
-		scanner := bufio.NewScanner(stdout)
-		for scanner.Scan() {
-			ch <- scanner.Text()
+	if len(containers) == 0 {
+		return "", fmt.Errorf("no container found for service %s", serviceName)
+	}
+
+	latest := containers[0]
+	for _, ctr := range containers[1:] {
+		if ctr.Created > latest.Created {
+			latest = ctr
 		}
+	}
+	return latest.ID, nil
+}
 
-		if err := scanner.Err(); err != nil {
-			logger.Get(ctx).Infof("[DOCKER-COMPOSE WATCHER] scanning `events` output: %v", err)
+// The name that docker-compose puts on the project label of each container.
+//
+// We ask docker-compose, rather than guess, because the labels are the only
+// way to find the project's containers and events.
+func (c *cmdDCClient) projectName(ctx context.Context, configPaths []string) (string, error) {
+	config, err := c.Config(ctx, configPaths)
+	if err == nil {
+		if name := projectNameFromConfig(config); name != "" {
+			return name, nil
 		}
+	}
+	return ProjectName(configPaths)
+}
 
-		err = cmd.Wait()
-		if err != nil {
-			logger.Get(ctx).Infof("[DOCKER-COMPOSE WATCHER] exited with error: %v", err)
+func (c *cmdDCClient) StreamEvents(ctx context.Context, configPaths []string) (<-chan string, error) {
+	ch := make(chan string)
+
+	project, err := c.projectName(ctx, configPaths)
+	if err != nil {
+		return ch, errors.Wrap(err, "reading docker-compose project name")
+	}
+
+	msgs, errs := c.docker.Events(ctx, types.EventsOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", "container"),
+			filters.Arg("label", fmt.Sprintf("%s=%s", ProjectLabel, project)),
+		),
+	})
+	go func() {
+		for {
+			select {
+			case msg := <-msgs:
+				j, err := json.Marshal(eventFromMessage(msg))
+				if err != nil {
+					logger.Get(ctx).Debugf("[DOCKER-COMPOSE WATCHER] marshaling event: %v", err)
+					continue
+				}
+
+				select {
+				case ch <- string(j):
+				case <-ctx.Done():
+					return
+				}
+			case err := <-errs:
+				if err != nil && ctx.Err() == nil {
+					logger.Get(ctx).Infof("[DOCKER-COMPOSE WATCHER] exited with error: %v", err)
+				}
+				return
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 
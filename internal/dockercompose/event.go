@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
 )
 
 type Event struct {
@@ -20,6 +24,28 @@ type Attributes struct {
 	Image string `json:"image"`
 }
 
+// Converts an event from the Docker API into the format that
+// `docker-compose events --json` uses.
+func eventFromMessage(msg events.Message) Event {
+	// Exec events include the command, e.g., "exec_start: sh -c ls"
+	action := msg.Action
+	if i := strings.Index(action, ":"); i != -1 {
+		action = action[:i]
+	}
+
+	return Event{
+		Time:    time.Unix(0, msg.TimeNano).Format(time.RFC3339Nano),
+		Type:    stringToType[msg.Type],
+		Action:  stringToAction[action],
+		ID:      msg.Actor.ID,
+		Service: msg.Actor.Attributes[ServiceLabel],
+		Attributes: Attributes{
+			Name:  msg.Actor.Attributes["name"],
+			Image: msg.Actor.Attributes["image"],
+		},
+	}
+}
+
 func EventFromJsonStr(j string) (Event, error) {
 	var evt Event
 
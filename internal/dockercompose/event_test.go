@@ -0,0 +1,30 @@
+package dockercompose
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFromMessage(t *testing.T) {
+	msg := events.Message{
+		Type:   "container",
+		Action: "exec_start: sh -c ls",
+		Actor: events.Actor{
+			ID: "abc123",
+			Attributes: map[string]string{
+				ServiceLabel: "snack",
+				"name":       "app_snack_1",
+				"image":      "snack:latest",
+			},
+		},
+	}
+
+	evt := eventFromMessage(msg)
+	assert.Equal(t, TypeContainer, evt.Type)
+	assert.Equal(t, ActionExecStart, evt.Action)
+	assert.Equal(t, "abc123", evt.ID)
+	assert.Equal(t, "snack", evt.Service)
+	assert.Equal(t, Attributes{Name: "app_snack_1", Image: "snack:latest"}, evt.Attributes)
+}
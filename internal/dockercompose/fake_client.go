@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/tilt-dev/tilt/internal/container"
 	"github.com/tilt-dev/tilt/pkg/model"
@@ -56,26 +57,19 @@ func (c *FakeDCClient) Down(ctx context.Context, configPaths []string, stdout, s
 	return nil
 }
 
-func (c *FakeDCClient) StreamLogs(ctx context.Context, configPaths []string, serviceName model.TargetName) (io.ReadCloser, error) {
+func (c *FakeDCClient) StreamLogs(ctx context.Context, configPaths []string, serviceName model.TargetName, since time.Time, stdout, stderr io.Writer) error {
 	output := c.RunLogOutput[serviceName]
-	reader, writer := io.Pipe()
-	go func() {
-		done := false
-		for !done {
-			select {
-			case <-ctx.Done():
-				done = true
-			case s, ok := <-output:
-				if !ok {
-					done = true
-				} else {
-					_, _ = writer.Write([]byte(s))
-				}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case s, ok := <-output:
+			if !ok {
+				return nil
 			}
+			_, _ = stdout.Write([]byte(s))
 		}
-		_ = writer.Close()
-	}()
-	return reader, nil
+	}
 }
 
 func (c *FakeDCClient) StreamEvents(ctx context.Context, configPaths []string) (<-chan string, error) {
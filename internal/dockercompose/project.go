@@ -0,0 +1,97 @@
+package dockercompose
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Labels that docker-compose attaches to the containers it creates.
+const (
+	ProjectLabel = "com.docker.compose.project"
+	ServiceLabel = "com.docker.compose.service"
+)
+
+const projectNameEnvVar = "COMPOSE_PROJECT_NAME"
+
+var invalidProjectNameChars = regexp.MustCompile("[^-_a-z0-9]")
+
+// Reads the project name from the output of `docker-compose config`.
+//
+// Newer versions of docker-compose print the name that they resolved for
+// the project, including from the top-level `name:` key. Older versions
+// don't print it, so this returns "".
+func projectNameFromConfig(config string) string {
+	var aux struct {
+		Name string `yaml:"name"`
+	}
+	err := yaml.Unmarshal([]byte(config), &aux)
+	if err != nil {
+		return ""
+	}
+	return aux.Name
+}
+
+// The name that older versions of docker-compose use for the project, and
+// put on the project label of each container.
+//
+// Follows the same rules as docker-compose v1, which doesn't support the
+// top-level `name:` key: COMPOSE_PROJECT_NAME from the environment or from
+// the .env file in the project directory, falling back to the name of the
+// directory of the first config file.
+func ProjectName(configPaths []string) (string, error) {
+	if name := os.Getenv(projectNameEnvVar); name != "" {
+		return normalizeProjectName(name), nil
+	}
+
+	dir := "."
+	if len(configPaths) > 0 {
+		dir = filepath.Dir(configPaths[0])
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if name := projectNameFromDotEnv(filepath.Join(dir, ".env")); name != "" {
+		return normalizeProjectName(name), nil
+	}
+
+	name := normalizeProjectName(filepath.Base(dir))
+	if name == "" {
+		return "default", nil
+	}
+	return name, nil
+}
+
+func normalizeProjectName(name string) string {
+	return invalidProjectNameChars.ReplaceAllString(strings.ToLower(name), "")
+}
+
+// Reads COMPOSE_PROJECT_NAME from a .env file, if it's there.
+func projectNameFromDotEnv(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) == 2 && strings.TrimSpace(parts[0]) == projectNameEnvVar {
+			return strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+		}
+	}
+	return ""
+}
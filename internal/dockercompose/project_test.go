@@ -0,0 +1,58 @@
+package dockercompose
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/internal/testutils/tempdir"
+)
+
+func TestProjectNameFromDir(t *testing.T) {
+	f := tempdir.NewTempDirFixture(t)
+	defer f.TearDown()
+
+	configPath := f.WriteFile("My_App.v2/docker-compose.yml", "")
+	name, err := ProjectName([]string{configPath})
+	require.NoError(t, err)
+	assert.Equal(t, "my_appv2", name)
+}
+
+func TestProjectNameFromDotEnv(t *testing.T) {
+	f := tempdir.NewTempDirFixture(t)
+	defer f.TearDown()
+
+	configPath := f.WriteFile("app/docker-compose.yml", "")
+	f.WriteFile("app/.env", "# comment\nCOMPOSE_PROJECT_NAME=\"snack\"\n")
+	name, err := ProjectName([]string{configPath})
+	require.NoError(t, err)
+	assert.Equal(t, "snack", name)
+}
+
+func TestProjectNameFromEnv(t *testing.T) {
+	f := tempdir.NewTempDirFixture(t)
+	defer f.TearDown()
+
+	oldName, hadName := os.LookupEnv(projectNameEnvVar)
+	defer func() {
+		if hadName {
+			_ = os.Setenv(projectNameEnvVar, oldName)
+		} else {
+			_ = os.Unsetenv(projectNameEnvVar)
+		}
+	}()
+	require.NoError(t, os.Setenv(projectNameEnvVar, "Sancho"))
+
+	configPath := f.WriteFile("app/docker-compose.yml", "")
+	name, err := ProjectName([]string{configPath})
+	require.NoError(t, err)
+	assert.Equal(t, "sancho", name)
+}
+
+func TestProjectNameFromConfig(t *testing.T) {
+	assert.Equal(t, "snack", projectNameFromConfig("name: snack\nservices:\n  redis:\n    image: redis\n"))
+	assert.Equal(t, "", projectNameFromConfig("services:\n  redis:\n    image: redis\n"))
+	assert.Equal(t, "", projectNameFromConfig("not: [valid"))
+}
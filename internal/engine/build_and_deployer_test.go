@@ -782,12 +782,13 @@ func newBDFixtureWithUpdateMode(t *testing.T, env k8s.Env, runtime container.Run
 			},
 		},
 	}
-	k8s := k8s.NewFakeK8sClient()
-	k8s.Runtime = runtime
+	kClient := k8s.NewFakeK8sClient()
+	kClient.Runtime = runtime
+	clients := k8s.NewFakeContextClients(ctx, kClient)
 	mode := buildcontrol.UpdateModeFlag(um)
 	dcc := dockercompose.NewFakeDockerComposeClient(t, ctx)
 	kl := &fakeKINDLoader{}
-	bd, err := provideBuildAndDeployer(ctx, docker, k8s, dir, env, mode, dcc, fakeClock{now: time.Unix(1551202573, 0)}, kl, ta)
+	bd, err := provideBuildAndDeployer(ctx, docker, kClient, clients, dir, env, mode, dcc, fakeClock{now: time.Unix(1551202573, 0)}, kl, ta)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -799,7 +800,7 @@ func newBDFixtureWithUpdateMode(t *testing.T, env k8s.Env, runtime container.Run
 		ctx:            ctx,
 		cancel:         cancel,
 		docker:         docker,
-		k8s:            k8s,
+		k8s:            kClient,
 		bd:             bd,
 		st:             st,
 		dcCli:          dcc,
@@ -139,9 +139,14 @@ func (q *TargetQueue) isBuilding(id model.TargetID) bool {
 }
 
 func (q *TargetQueue) CountBuilds() int {
+	return q.CountBuildsWhere(func(model.TargetSpec) bool { return true })
+}
+
+// Counts the targets that need to be built and satisfy the given predicate.
+func (q *TargetQueue) CountBuildsWhere(pred func(target model.TargetSpec) bool) int {
 	result := 0
 	for _, target := range q.sortedTargets {
-		if q.isBuilding(target.ID()) {
+		if q.isBuilding(target.ID()) && pred(target) {
 			result++
 		}
 	}
@@ -31,6 +31,7 @@ type ConfigsReloadedAction struct {
 	TeamID               string
 	TelemetrySettings    model.TelemetrySettings
 	MetricsSettings      model.MetricsSettings
+	LogExportSettings    model.LogExportSettings
 	Secrets              model.SecretSet
 	DockerPruneSettings  model.DockerPruneSettings
 	AnalyticsTiltfileOpt analytics.Opt
@@ -172,6 +172,7 @@ func (cc *ConfigsController) loadTiltfile(ctx context.Context, st store.RStore,
 		TeamID:                tlr.TeamID,
 		TelemetrySettings:     tlr.TelemetrySettings,
 		MetricsSettings:       tlr.MetricsSettings,
+		LogExportSettings:     tlr.LogExportSettings,
 		Secrets:               tlr.Secrets,
 		AnalyticsTiltfileOpt:  tlr.AnalyticsOpt,
 		DockerPruneSettings:   tlr.DockerPruneSettings,
@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -62,33 +64,39 @@ type ImageBuildAndDeployer struct {
 	db        build.DockerBuilder
 	ib        *imageBuilder
 	k8sClient k8s.Client
+	clients   *k8s.ContextClients
 	env       k8s.Env
 	runtime   container.Runtime
 	analytics *analytics.TiltAnalytics
 	clock     build.Clock
 	kl        KINDLoader
+	attester  build.ImageAttester
 }
 
 func NewImageBuildAndDeployer(
 	db build.DockerBuilder,
 	customBuilder build.CustomBuilder,
 	k8sClient k8s.Client,
+	clients *k8s.ContextClients,
 	env k8s.Env,
 	analytics *analytics.TiltAnalytics,
 	updMode buildcontrol.UpdateMode,
 	c build.Clock,
 	runtime container.Runtime,
 	kl KINDLoader,
+	attester build.ImageAttester,
 ) *ImageBuildAndDeployer {
 	return &ImageBuildAndDeployer{
 		db:        db,
 		ib:        NewImageBuilder(db, customBuilder, updMode),
 		k8sClient: k8sClient,
+		clients:   clients,
 		env:       env,
 		analytics: analytics,
 		clock:     c,
 		runtime:   runtime,
 		kl:        kl,
+		attester:  attester,
 	}
 }
 
@@ -115,6 +123,12 @@ func (ibd *ImageBuildAndDeployer) BuildAndDeploy(ctx context.Context, st store.R
 	// each image target has two stages: one for build, and one for push
 	numStages := q.CountBuilds()*2 + 1
 
+	// image targets with post-push steps (signing, SBOMs) get a third stage
+	numStages += q.CountBuildsWhere(func(target model.TargetSpec) bool {
+		iTarget, ok := target.(model.ImageTarget)
+		return ok && !iTarget.Attestation.Empty()
+	})
+
 	reused := q.ReusedResults()
 	hasReusedStep := len(reused) > 0
 	if hasReusedStep {
@@ -164,11 +178,18 @@ func (ibd *ImageBuildAndDeployer) BuildAndDeploy(ctx context.Context, st store.R
 			return nil, err
 		}
 
-		err = ibd.push(ctx, refs.LocalRef, ps, iTarget, kTarget)
+		dig, pushed, err := ibd.push(ctx, refs.LocalRef, ps, iTarget, kTarget)
 		if err != nil {
 			return nil, err
 		}
 
+		if !iTarget.Attestation.Empty() {
+			err = ibd.attest(ctx, refs.LocalRef, dig, pushed, ps, iTarget)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		return store.NewImageBuildResult(iTarget.ID(), refs.LocalRef, refs.ClusterRef), nil
 	})
 
@@ -190,7 +211,9 @@ func (ibd *ImageBuildAndDeployer) BuildAndDeploy(ctx context.Context, st store.R
 	return newResults, nil
 }
 
-func (ibd *ImageBuildAndDeployer) push(ctx context.Context, ref reference.NamedTagged, ps *build.PipelineState, iTarget model.ImageTarget, kTarget model.K8sTarget) error {
+// Returns the digest reported by the registry (if any), and whether
+// the image was pushed to a registry at all.
+func (ibd *ImageBuildAndDeployer) push(ctx context.Context, ref reference.NamedTagged, ps *build.PipelineState, iTarget model.ImageTarget, kTarget model.K8sTarget) (digest.Digest, bool, error) {
 	ps.StartPipelineStep(ctx, "Pushing %s", container.FamiliarString(ref))
 	defer ps.EndPipelineStep(ctx)
 
@@ -203,25 +226,41 @@ func (ibd *ImageBuildAndDeployer) push(ctx context.Context, ref reference.NamedT
 	// in any k8s resources! (e.g., it's consumed by another image).
 	if ibd.canAlwaysSkipPush() || !isImageDeployedToK8s(iTarget, kTarget) || cbSkip {
 		ps.Printf(ctx, "Skipping push")
-		return nil
+		return "", false, nil
 	}
 
-	var err error
 	if ibd.shouldUseKINDLoad(ctx, iTarget) {
 		ps.Printf(ctx, "Loading image to KIND")
 		err := ibd.kl.LoadToKIND(ps.AttachLogger(ctx), ref)
 		if err != nil {
-			return fmt.Errorf("Error loading image to KIND: %v", err)
-		}
-	} else {
-		ps.Printf(ctx, "Pushing with Docker client")
-		err = ibd.db.PushImage(ps.AttachLogger(ctx), ref)
-		if err != nil {
-			return err
+			return "", false, fmt.Errorf("Error loading image to KIND: %v", err)
 		}
+		return "", false, nil
 	}
 
-	return nil
+	ps.Printf(ctx, "Pushing with Docker client")
+	dig, err := ibd.db.PushImage(ps.AttachLogger(ctx), ref)
+	if err != nil {
+		return "", false, err
+	}
+
+	return dig, true, nil
+}
+
+// Runs the post-push steps (e.g., signing) of an image target.
+//
+// Attestations only make sense for images in a registry, so if
+// the image was never pushed, there's nothing to attest.
+func (ibd *ImageBuildAndDeployer) attest(ctx context.Context, ref reference.NamedTagged, dig digest.Digest, pushed bool, ps *build.PipelineState, iTarget model.ImageTarget) error {
+	ps.StartPipelineStep(ctx, "Attesting %s", container.FamiliarString(ref))
+	defer ps.EndPipelineStep(ctx)
+
+	if !pushed {
+		ps.Printf(ctx, "Skipping attestation: image was not pushed to a registry")
+		return nil
+	}
+
+	return ibd.attester.Attest(ctx, ps, iTarget.Attestation, ref, dig)
 }
 
 func (ibd *ImageBuildAndDeployer) shouldUseKINDLoad(ctx context.Context, iTarg model.ImageTarget) bool {
@@ -261,16 +300,38 @@ func (ibd *ImageBuildAndDeployer) deploy(ctx context.Context, st store.RStore, p
 	ctx = ibd.indentLogger(ctx)
 	l := logger.Get(ctx)
 
-	l.Infof("Applying via kubectl:")
+	state := st.RLockState()
+	us := state.UpdateSettings
+	st.RUnlockState()
+
+	if us.K8sApplyMode().IsServerSide() {
+		l.Infof("Applying via server-side apply:")
+	} else {
+		l.Infof("Applying via kubectl:")
+	}
 	for _, displayName := range kTarget.DisplayNames {
 		l.Infof("→ %s", displayName)
 	}
 
-	state := st.RLockState()
-	us := state.UpdateSettings
-	st.RUnlockState()
+	kCli := ibd.clients.For(k8s.KubeContext(kTarget.KubeContext)).Client
+	clearClusterScopedNamespaces(ctx, kCli, newK8sEntities)
+	err = ibd.createMissingNamespaces(ctx, kCli, kTarget, newK8sEntities, us)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := ""
+	if us.K8sDeployDiff() {
+		diff = ibd.diff(ctx, kCli, newK8sEntities, us)
+	}
 
-	deployed, err := ibd.k8sClient.Upsert(ctx, newK8sEntities, us.K8sUpsertTimeout())
+	// Serialize before the upsert, which may modify the entities.
+	appliedYAML, err := k8s.SerializeSpecYAML(newK8sEntities)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed, err := kCli.Upsert(ctx, newK8sEntities, us.K8sUpsertTimeout(), us.K8sApplyMode())
 	if err != nil {
 		return nil, err
 	}
@@ -291,7 +352,113 @@ func (ibd *ImageBuildAndDeployer) deploy(ctx context.Context, st store.RStore, p
 		podTemplateSpecHashes = append(podTemplateSpecHashes, hs...)
 	}
 
-	return store.NewK8sDeployResult(kTarget.ID(), uids, podTemplateSpecHashes, deployed), nil
+	result := store.NewK8sDeployResult(kTarget.ID(), uids, podTemplateSpecHashes, deployed)
+	result.Diff = diff
+	result.AppliedYAML = appliedYAML
+	return result, nil
+}
+
+// Logs a dry-run diff of the entities against the cluster, and returns it.
+//
+// The diff is informational, so it shouldn't block the deploy. It makes a
+// few API calls per object, so we bound it by the same timeout as the upsert.
+func (ibd *ImageBuildAndDeployer) diff(ctx context.Context, kCli k8s.Client, entities []k8s.K8sEntity, us model.UpdateSettings) string {
+	ctx, cancel := context.WithTimeout(ctx, us.K8sUpsertTimeout())
+	defer cancel()
+
+	l := logger.Get(ctx)
+	diff, err := kCli.Diff(ctx, entities, us.K8sApplyMode())
+	if err != nil {
+		l.Debugf("Unable to compute diff: %v", err)
+		return ""
+	}
+	if diff != "" {
+		l.Infof("Changes:")
+		l.Write(logger.InfoLvl, []byte(diff))
+	}
+	return diff
+}
+
+// Creates the namespaces that the target deploys to, if they don't exist yet.
+//
+// These namespaces aren't part of the deploy result, so they're never
+// garbage-collected or deleted by `tilt down`.
+func (ibd *ImageBuildAndDeployer) createMissingNamespaces(ctx context.Context, kCli k8s.Client, kTarget model.K8sTarget, entities []k8s.K8sEntity, us model.UpdateSettings) error {
+	if kTarget.NamespaceYAML == "" {
+		return nil
+	}
+
+	namespaces, err := k8s.ParseYAMLFromString(kTarget.NamespaceYAML)
+	if err != nil {
+		return errors.Wrap(err, "parsing namespaces")
+	}
+
+	// The Tiltfile doesn't know which custom resources are cluster-scoped,
+	// so only create the namespaces that the entities still reference.
+	referenced := make(map[k8s.Namespace]bool)
+	for _, ns := range k8s.ReferencedNamespaces(entities) {
+		referenced[ns] = true
+	}
+
+	var missing []k8s.K8sEntity
+	for _, ns := range namespaces {
+		if !referenced[k8s.Namespace(ns.Name())] {
+			continue
+		}
+
+		_, err := kCli.GetMetaByReference(ctx, ns.ToObjectReference())
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "looking up namespace %s", ns.Name())
+		}
+		missing = append(missing, ns)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	l := logger.Get(ctx)
+	for _, ns := range missing {
+		l.Infof("Creating namespace %s", ns.Name())
+	}
+	_, err = kCli.Upsert(ctx, missing, us.K8sUpsertTimeout(), us.K8sApplyMode())
+	if err != nil {
+		return errors.Wrap(err, "creating namespaces")
+	}
+	return nil
+}
+
+// The Tiltfile assumes that custom resources are namespaced, and may have
+// put cluster-scoped ones in a namespace. Ask the cluster, and take them
+// back out, in-place.
+//
+// Kinds that the cluster doesn't know yet (e.g., because their CRD is
+// deployed in the same batch) are left alone.
+func clearClusterScopedNamespaces(ctx context.Context, kCli k8s.Client, entities []k8s.K8sEntity) {
+	scopes := make(map[schema.GroupVersionKind]bool)
+	for _, e := range entities {
+		if e.IsClusterScoped() || e.NamespaceOrDefault("") == "" {
+			continue
+		}
+
+		gvk := e.GVK()
+		clusterScoped, ok := scopes[gvk]
+		if !ok {
+			var err error
+			clusterScoped, err = kCli.IsClusterScoped(ctx, gvk)
+			if err != nil {
+				logger.Get(ctx).Debugf("Looking up scope of %s: %v", gvk.Kind, err)
+			}
+			scopes[gvk] = clusterScoped
+		}
+
+		if clusterScoped {
+			e.SetNamespace("")
+		}
+	}
 }
 
 func (ibd *ImageBuildAndDeployer) indentLogger(ctx context.Context) context.Context {
@@ -312,7 +479,7 @@ func (ibd *ImageBuildAndDeployer) delete(ctx context.Context, k8sTarget model.K8
 	}
 
 	entities, _, err = k8s.Filter(entities, func(e k8s.K8sEntity) (b bool, err error) {
-		return e.GVK() != schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"}, nil
+		return e.GVK() != k8s.NamespaceGVK, nil
 	})
 	if err != nil {
 		return err
@@ -320,7 +487,7 @@ func (ibd *ImageBuildAndDeployer) delete(ctx context.Context, k8sTarget model.K8
 
 	entities = k8s.ReverseSortedEntities(entities)
 
-	return ibd.k8sClient.Delete(ctx, entities)
+	return ibd.clients.For(k8s.KubeContext(k8sTarget.KubeContext)).Client.Delete(ctx, entities)
 }
 
 func (ibd *ImageBuildAndDeployer) createEntitiesToDeploy(ctx context.Context,
@@ -338,10 +505,14 @@ func (ibd *ImageBuildAndDeployer) createEntitiesToDeploy(ctx context.Context,
 	locators := k8s.ToImageLocators(k8sTarget.ImageLocators)
 	depIDs := k8sTarget.DependencyIDs()
 	injectedDepIDs := map[model.TargetID]bool{}
+	labels := []model.LabelPair{k8s.TiltManagedByLabel()}
+
+	// Objects without the apply-set label are never garbage-collected.
+	if k8sTarget.ApplySet != "" && !k8sTarget.KeepOnRemoval {
+		labels = append(labels, k8s.ApplySetLabelPair(k8sTarget.ApplySet))
+	}
 	for _, e := range entities {
-		e, err = k8s.InjectLabels(e, []model.LabelPair{
-			k8s.TiltManagedByLabel(),
-		})
+		e, err = k8s.InjectLabels(e, labels)
 		if err != nil {
 			return nil, errors.Wrap(err, "deploy")
 		}
@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"sort"
 	"strings"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/tilt-dev/wmclient/pkg/dirs"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/tilt-dev/tilt/internal/container"
 	"github.com/tilt-dev/tilt/internal/docker"
@@ -53,6 +55,125 @@ func TestDeployTwinImages(t *testing.T) {
 		"Expected image to update twice in YAML: %s", f.k8s.Yaml)
 }
 
+func TestDeployInjectsApplySetLabel(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	manifest := NewSanchoDockerBuildManifest(f)
+	kTarget := manifest.K8sTarget()
+	kTarget.ApplySet = "0123456789abcdef"
+	manifest = manifest.WithDeployTarget(kTarget)
+
+	result, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), store.BuildStateSet{})
+	require.NoError(t, err)
+
+	assert.Contains(t, f.k8s.Yaml, "tilt.dev/apply-set: 0123456789abcdef")
+
+	deployResult := result[kTarget.ID()].(store.K8sBuildResult)
+	require.Len(t, deployResult.DeployedRefs, 1)
+	assert.Equal(t, "Deployment", deployResult.DeployedRefs[0].Kind)
+	assert.Equal(t, "sancho", deployResult.DeployedRefs[0].Name)
+}
+
+func TestDeployKeepOnRemovalSkipsApplySetLabel(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	manifest := NewSanchoDockerBuildManifest(f)
+	kTarget := manifest.K8sTarget()
+	kTarget.ApplySet = "0123456789abcdef"
+	kTarget.KeepOnRemoval = true
+	manifest = manifest.WithDeployTarget(kTarget)
+
+	_, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), store.BuildStateSet{})
+	require.NoError(t, err)
+
+	assert.NotContains(t, f.k8s.Yaml, "tilt.dev/apply-set")
+}
+
+func TestDeployToOtherContext(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	manifest := NewSanchoDockerBuildManifest(f)
+	kTarget := manifest.K8sTarget()
+	kTarget.KubeContext = "data"
+	manifest = manifest.WithDeployTarget(kTarget)
+
+	_, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), store.BuildStateSet{})
+	require.NoError(t, err)
+
+	dataCli := f.clients.For("data").Client.(*k8s.FakeK8sClient)
+	assert.Contains(t, dataCli.Yaml, "name: sancho")
+	assert.Equal(t, "", f.k8s.Yaml)
+}
+
+func TestDeployCreatesMissingNamespaces(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	existingYAML := strings.Replace(testyaml.MyNamespaceYAML, "mynamespace", "existing", 1)
+	existing, err := k8s.ParseYAMLFromString(existingYAML)
+	require.NoError(t, err)
+	f.k8s.InjectEntityByName(existing...)
+
+	// The Deployment is in mynamespace, and the ConfigMap is in existing.
+	sanchoYAML := strings.Replace(testyaml.SanchoYAML, "  name: sancho\n", "  name: sancho\n  namespace: mynamespace\n", 1)
+	manifest := NewSanchoDockerBuildManifestWithYaml(f, sanchoYAML+"---\n"+configMapInNamespace("existing"))
+	kTarget := manifest.K8sTarget()
+	kTarget.NamespaceYAML = testyaml.MyNamespaceYAML + "---\n" + existingYAML
+	manifest = manifest.WithDeployTarget(kTarget)
+
+	_, err = f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), store.BuildStateSet{})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(f.k8s.UpsertedYAMLs))
+	assert.Contains(t, f.k8s.UpsertedYAMLs[0], "name: mynamespace")
+	assert.NotContains(t, f.k8s.UpsertedYAMLs[0], "name: existing")
+	assert.Contains(t, f.k8s.UpsertedYAMLs[1], "name: sancho")
+}
+
+func TestDeployClearsNamespaceOfClusterScopedCustomResource(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	f.k8s.CustomKinds = map[schema.GroupKind]bool{
+		{Group: "cert-manager.io", Kind: "ClusterIssuer"}: true,
+	}
+
+	issuerYAML := `apiVersion: cert-manager.io/v1
+kind: ClusterIssuer
+metadata:
+  name: letsencrypt
+  namespace: mynamespace
+spec:
+  selfSigned: {}
+`
+	manifest := NewSanchoDockerBuildManifestWithYaml(f, testyaml.SanchoYAML+"---\n"+issuerYAML)
+	kTarget := manifest.K8sTarget()
+	kTarget.NamespaceYAML = testyaml.MyNamespaceYAML
+	manifest = manifest.WithDeployTarget(kTarget)
+
+	_, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), store.BuildStateSet{})
+	require.NoError(t, err)
+
+	// mynamespace isn't used by anything, so we shouldn't create it.
+	require.Equal(t, 1, len(f.k8s.UpsertedYAMLs))
+	assert.Contains(t, f.k8s.UpsertedYAMLs[0], "name: letsencrypt")
+	assert.NotContains(t, f.k8s.UpsertedYAMLs[0], "namespace: mynamespace")
+}
+
+func configMapInNamespace(namespace string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: %s
+data:
+  key: value
+`, namespace)
+}
+
 func TestForceUpdate(t *testing.T) {
 	f := newIBDFixture(t, k8s.EnvGKE)
 	defer f.TearDown()
@@ -447,6 +568,76 @@ func TestK8sUpsertTimeout(t *testing.T) {
 	assert.Equal(t, f.k8s.UpsertTimeout, timeout)
 }
 
+func TestK8sApplyMode(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	state := f.st.LockMutableStateForTesting()
+	state.UpdateSettings = state.UpdateSettings.WithK8sApplyMode(model.K8sApplyModeServerSide)
+	f.st.UnlockMutableState()
+
+	manifest := NewSanchoDockerBuildManifest(f)
+
+	_, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, model.K8sApplyModeServerSide, f.k8s.UpsertMode)
+}
+
+func TestDeployDiff(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	f.enableDeployDiff()
+
+	diff := "--- Deployment/sancho (live)\n+++ Deployment/sancho (tilt)\n@@ -1,1 +1,1 @@\n-  replicas: 1\n+  replicas: 2\n"
+	f.k8s.DiffResult = diff
+
+	manifest := NewSanchoDockerBuildManifest(f)
+	start := time.Now()
+	result, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, f.out.String(), "Changes:")
+	assert.Contains(t, f.out.String(), "+  replicas: 2")
+	assert.Equal(t, diff, result.K8sDiff())
+
+	// The diff is bounded by the upsert timeout.
+	require.False(t, f.k8s.DiffDeadline.IsZero())
+	assert.True(t, f.k8s.DiffDeadline.Before(start.Add(model.DefaultK8sUpsertTimeout+time.Minute)))
+}
+
+func TestDeployDiffOffByDefault(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	f.k8s.DiffResult = "+  replicas: 2\n"
+
+	manifest := NewSanchoDockerBuildManifest(f)
+	result, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, f.k8s.DiffCalls)
+	assert.NotContains(t, f.out.String(), "Changes:")
+	assert.Equal(t, "", result.K8sDiff())
+}
+
+func TestDeployDiffErrorDoesNotBlockDeploy(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	f.enableDeployDiff()
+	f.k8s.DiffError = fmt.Errorf("dry-run not supported")
+
+	manifest := NewSanchoDockerBuildManifest(f)
+	result, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, f.out.String(), "Unable to compute diff: dry-run not supported")
+	assert.Equal(t, "", result.K8sDiff())
+	assert.Contains(t, f.k8s.Yaml, "name: sancho")
+}
+
 func TestKINDLoad(t *testing.T) {
 	f := newIBDFixture(t, k8s.EnvKIND6)
 	defer f.TearDown()
@@ -486,6 +677,69 @@ func TestDockerPushIfKINDAndClusterRef(t *testing.T) {
 	assert.NotContains(t, yaml, iTarg.Refs.LocalRef().String(), "LocalRef was NOT injected into applied YAML")
 }
 
+func TestAttestationAfterPush(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	manifest := NewSanchoDockerBuildManifest(f)
+	iTarg := manifest.ImageTargetAt(0).WithAttestation(model.ImageAttestation{
+		SignCmd: model.ToHostCmdInDir("echo $TILT_IMAGE_DIGEST_REF > signed.txt", f.Path()),
+		SBOMCmd: model.ToHostCmdInDir("echo $TILT_IMAGE_DIGEST > sbom.txt", f.Path()),
+	})
+	manifest = manifest.WithImageTarget(iTarg)
+
+	_, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), store.BuildStateSet{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, f.docker.PushCount)
+	signed, err := ioutil.ReadFile(f.JoinPath("signed.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%s@%s\n", iTarg.Refs.LocalRef().String(), docker.ExamplePushSHA1), string(signed))
+
+	sbom, err := ioutil.ReadFile(f.JoinPath("sbom.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, docker.ExamplePushSHA1+"\n", string(sbom))
+	assert.Contains(t, f.out.String(), "STEP 3/4 — Attesting")
+	assert.Contains(t, f.k8s.Yaml, "sancho")
+}
+
+func TestAttestationFailureFailsBuild(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvGKE)
+	defer f.TearDown()
+
+	manifest := NewSanchoDockerBuildManifest(f)
+	iTarg := manifest.ImageTargetAt(0).WithAttestation(model.ImageAttestation{
+		SignCmd: model.ToHostCmd("exit 1"),
+	})
+	manifest = manifest.WithImageTarget(iTarg)
+
+	_, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), store.BuildStateSet{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Image signing failed")
+	}
+
+	assert.Equal(t, 1, f.docker.PushCount)
+	assert.Equal(t, "", f.k8s.Yaml, "should not deploy an unsigned image")
+}
+
+func TestAttestationSkippedIfNotPushed(t *testing.T) {
+	f := newIBDFixture(t, k8s.EnvKIND6)
+	defer f.TearDown()
+
+	manifest := NewSanchoDockerBuildManifest(f)
+	iTarg := manifest.ImageTargetAt(0).WithAttestation(model.ImageAttestation{
+		SignCmd: model.ToHostCmd("exit 1"),
+	})
+	manifest = manifest.WithImageTarget(iTarg)
+
+	_, err := f.ibd.BuildAndDeploy(f.ctx, f.st, buildTargets(manifest), store.BuildStateSet{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, f.kl.loadCount)
+	assert.Equal(t, 0, f.docker.PushCount)
+	assert.Contains(t, f.out.String(), "Skipping attestation")
+}
+
 func TestCustomBuildDisablePush(t *testing.T) {
 	f := newIBDFixture(t, k8s.EnvKIND6)
 	defer f.TearDown()
@@ -982,13 +1236,14 @@ func resultKeys(result store.BuildResultSet) []string {
 
 type ibdFixture struct {
 	*tempdir.TempDirFixture
-	out    *bufsync.ThreadSafeBuffer
-	ctx    context.Context
-	docker *docker.FakeClient
-	k8s    *k8s.FakeK8sClient
-	ibd    *ImageBuildAndDeployer
-	st     *store.TestingStore
-	kl     *fakeKINDLoader
+	out     *bufsync.ThreadSafeBuffer
+	ctx     context.Context
+	docker  *docker.FakeClient
+	k8s     *k8s.FakeK8sClient
+	clients *k8s.ContextClients
+	ibd     *ImageBuildAndDeployer
+	st      *store.TestingStore
+	kl      *fakeKINDLoader
 }
 
 func newIBDFixture(t *testing.T, env k8s.Env) *ibdFixture {
@@ -1008,7 +1263,8 @@ func newIBDFixture(t *testing.T, env k8s.Env) *ibdFixture {
 	kClient := k8s.NewFakeK8sClient()
 	kl := &fakeKINDLoader{}
 	clock := fakeClock{time.Date(2019, 1, 1, 1, 1, 1, 1, time.UTC)}
-	ibd, err := provideImageBuildAndDeployer(ctx, docker, kClient, env, dir, clock, kl, ta)
+	clients := k8s.NewFakeContextClients(ctx, kClient)
+	ibd, err := provideImageBuildAndDeployer(ctx, docker, kClient, clients, env, dir, clock, kl, ta)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1018,6 +1274,7 @@ func newIBDFixture(t *testing.T, env k8s.Env) *ibdFixture {
 		ctx:            ctx,
 		docker:         docker,
 		k8s:            kClient,
+		clients:        clients,
 		ibd:            ibd,
 		st:             store.NewTestingStore(),
 		kl:             kl,
@@ -1029,6 +1286,12 @@ func (f *ibdFixture) TearDown() {
 	f.TempDirFixture.TearDown()
 }
 
+func (f *ibdFixture) enableDeployDiff() {
+	state := f.st.LockMutableStateForTesting()
+	state.UpdateSettings = state.UpdateSettings.WithK8sDeployDiff(true)
+	f.st.UnlockMutableState()
+}
+
 func (f *ibdFixture) resultsToNextState(results store.BuildResultSet) store.BuildStateSet {
 	stateSet := store.BuildStateSet{}
 	for id, result := range results {
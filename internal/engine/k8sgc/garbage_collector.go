@@ -0,0 +1,306 @@
+package k8sgc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// Deletes Kubernetes objects that Tilt deployed, but that are no longer
+// declared in the Tiltfile.
+//
+// Every object Tilt deploys is labeled with the apply set of its Tiltfile.
+// After each successful Tiltfile load, we compare the objects we've deployed
+// against the objects the Tiltfile declares, and delete the difference.
+//
+// We only delete objects that still carry our apply-set label, so that
+// we never touch objects that someone else has taken over.
+//
+// Objects deployed by an earlier Tilt session aren't in memory, so the first
+// time we see an apply set in a cluster, we also list the objects that carry
+// its label and delete the ones that the Tiltfile no longer declares.
+//
+// Objects from resources with keep_on_removal=True don't get the label.
+type GarbageCollector struct {
+	clients *k8s.ContextClients
+
+	applied           map[model.ManifestName]appliedObjects
+	swept             map[sweep]bool
+	lastTiltfileBuild time.Time
+}
+
+// An apply set in one cluster.
+type sweep struct {
+	kubeContext k8s.KubeContext
+	applySet    string
+}
+
+type appliedObjects struct {
+	applySet      string
+	keepOnRemoval bool
+	kubeContext   k8s.KubeContext
+	refs          []v1.ObjectReference
+}
+
+var _ store.Subscriber = &GarbageCollector{}
+
+func NewGarbageCollector(clients *k8s.ContextClients) *GarbageCollector {
+	return &GarbageCollector{
+		clients: clients,
+		applied: make(map[model.ManifestName]appliedObjects),
+		swept:   make(map[sweep]bool),
+	}
+}
+
+func (gc *GarbageCollector) OnChange(ctx context.Context, st store.RStore, _ store.ChangeSummary) {
+	state := st.RLockState()
+	gc.recordApplied(state)
+
+	tiltfileBuild := state.TiltfileState.LastBuild()
+	isNewLoad := !tiltfileBuild.Empty() &&
+		!tiltfileBuild.FinishTime.IsZero() &&
+		tiltfileBuild.StartTime.After(gc.lastTiltfileBuild)
+	if !isNewLoad {
+		st.RUnlockState()
+		return
+	}
+	gc.lastTiltfileBuild = tiltfileBuild.StartTime
+
+	// If the Tiltfile failed to load, we don't know what it declares,
+	// so it's not safe to delete anything.
+	if tiltfileBuild.Error != nil {
+		st.RUnlockState()
+		return
+	}
+
+	declared := gc.declared(state)
+	garbage := gc.collect(state, declared)
+	sweeps := gc.sweepsNeeded(state, declared)
+	st.RUnlockState()
+
+	for _, sw := range sweeps {
+		garbage = append(garbage, gc.sweep(ctx, st, tiltfileBuild.SpanID, sw, declared)...)
+	}
+
+	for _, obj := range garbage {
+		gc.delete(ctx, st, tiltfileBuild.SpanID, obj)
+	}
+}
+
+// Remember the objects that each manifest has deployed.
+func (gc *GarbageCollector) recordApplied(state store.EngineState) {
+	for _, mt := range state.Targets() {
+		if !mt.Manifest.IsK8s() {
+			continue
+		}
+
+		kTarget := mt.Manifest.K8sTarget()
+		current := gc.applied[mt.Manifest.Name]
+		current.applySet = kTarget.ApplySet
+		current.keepOnRemoval = kTarget.KeepOnRemoval
+
+		// Build statuses are reset when the Tiltfile reloads, so a missing
+		// result doesn't mean that the objects have gone away.
+		result, ok := mt.State.BuildStatus(kTarget.ID()).LastResult.(store.K8sBuildResult)
+		if ok && result.DeployedRefs != nil {
+			current.refs = result.DeployedRefs
+			current.kubeContext = gc.clients.Resolve(k8s.KubeContext(kTarget.KubeContext))
+		}
+		gc.applied[mt.Manifest.Name] = current
+	}
+}
+
+type garbageObject struct {
+	// Empty for objects from an earlier session.
+	manifest    model.ManifestName
+	applySet    string
+	kubeContext k8s.KubeContext
+	ref         v1.ObjectReference
+}
+
+// The objects that the Tiltfile declares, by cluster.
+func (gc *GarbageCollector) declared(state store.EngineState) map[k8s.KubeContext][]v1.ObjectReference {
+	declared := make(map[k8s.KubeContext][]v1.ObjectReference)
+	for _, m := range state.Manifests() {
+		if m.IsK8s() {
+			kTarget := m.K8sTarget()
+			kubeContext := gc.clients.Resolve(k8s.KubeContext(kTarget.KubeContext))
+			declared[kubeContext] = append(declared[kubeContext], kTarget.ObjectRefs...)
+		}
+	}
+	return declared
+}
+
+// Find objects that we've deployed, but that the Tiltfile no longer declares.
+func (gc *GarbageCollector) collect(state store.EngineState, declared map[k8s.KubeContext][]v1.ObjectReference) []garbageObject {
+	var garbage []garbageObject
+	for mn, applied := range gc.applied {
+		_, stillExists := state.Manifest(mn)
+
+		var kept []v1.ObjectReference
+		for _, ref := range applied.refs {
+			if isDeclared(declared[applied.kubeContext], ref) {
+				kept = append(kept, ref)
+				continue
+			}
+
+			// Namespaces hold other objects, possibly ones that Tilt doesn't
+			// manage, so we never delete them automatically.
+			if applied.keepOnRemoval || applied.applySet == "" || ref.Kind == "Namespace" {
+				continue
+			}
+			garbage = append(garbage, garbageObject{
+				manifest:    mn,
+				applySet:    applied.applySet,
+				kubeContext: applied.kubeContext,
+				ref:         ref,
+			})
+		}
+
+		if !stillExists {
+			delete(gc.applied, mn)
+			continue
+		}
+		applied.refs = kept
+		gc.applied[mn] = applied
+	}
+	return garbage
+}
+
+// Find the apply sets that we haven't looked for in each cluster yet.
+func (gc *GarbageCollector) sweepsNeeded(state store.EngineState, declared map[k8s.KubeContext][]v1.ObjectReference) []sweep {
+	applySets := make(map[string]bool)
+	if state.TiltfilePath != "" {
+		applySets[k8s.ApplySetID(state.TiltfilePath)] = true
+	}
+	for _, m := range state.Manifests() {
+		if m.IsK8s() && m.K8sTarget().ApplySet != "" {
+			applySets[m.K8sTarget().ApplySet] = true
+		}
+	}
+
+	kubeContexts := map[k8s.KubeContext]bool{gc.clients.DefaultContext(): true}
+	for kubeContext := range declared {
+		kubeContexts[kubeContext] = true
+	}
+
+	var result []sweep
+	for kubeContext := range kubeContexts {
+		for applySet := range applySets {
+			sw := sweep{kubeContext: kubeContext, applySet: applySet}
+			if !gc.swept[sw] {
+				result = append(result, sw)
+			}
+		}
+	}
+	return result
+}
+
+// Find objects in the cluster that carry the apply-set label, but that
+// neither the Tiltfile nor this session know about.
+func (gc *GarbageCollector) sweep(ctx context.Context, st store.RStore, spanID model.LogSpanID, sw sweep, declared map[k8s.KubeContext][]v1.ObjectReference) []garbageObject {
+	kCli := gc.clients.For(sw.kubeContext).Client
+	refs, err := kCli.ListRefsWithLabel(ctx, k8s.ApplySetLabelPair(sw.applySet))
+	if err != nil {
+		// Try again on the next Tiltfile load.
+		gc.log(st, spanID, logger.WarnLvl, "Unable to list objects from earlier sessions: %v\n", err)
+		return nil
+	}
+	gc.swept[sw] = true
+
+	var garbage []garbageObject
+	for _, ref := range refs {
+		if ref.Kind == "Namespace" || isDeclared(declared[sw.kubeContext], ref) || gc.isApplied(sw.kubeContext, ref) {
+			continue
+		}
+		garbage = append(garbage, garbageObject{
+			applySet:    sw.applySet,
+			kubeContext: sw.kubeContext,
+			ref:         ref,
+		})
+	}
+	return garbage
+}
+
+// Whether this session has deployed the object, and is already tracking it.
+func (gc *GarbageCollector) isApplied(kubeContext k8s.KubeContext, ref v1.ObjectReference) bool {
+	for _, applied := range gc.applied {
+		if applied.kubeContext == kubeContext && isDeclared(applied.refs, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDeclared(declared []v1.ObjectReference, ref v1.ObjectReference) bool {
+	for _, d := range declared {
+		if d.Kind != ref.Kind || d.Name != ref.Name || groupOf(d) != groupOf(ref) {
+			continue
+		}
+
+		// If the Tiltfile doesn't specify a namespace, the object
+		// could be deployed to any namespace.
+		if d.Namespace == "" || ref.Namespace == "" || d.Namespace == ref.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func groupOf(ref v1.ObjectReference) string {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return ref.APIVersion
+	}
+	return gv.Group
+}
+
+func (gc *GarbageCollector) delete(ctx context.Context, st store.RStore, spanID model.LogSpanID, obj garbageObject) {
+	ref := obj.ref
+	kCli := gc.clients.For(obj.kubeContext).Client
+	meta, err := kCli.GetMetaByReference(ctx, ref)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			gc.log(st, spanID, logger.WarnLvl, "Unable to garbage-collect %s %s: %v\n", ref.Kind, ref.Name, err)
+		}
+		return
+	}
+
+	// Someone else has taken ownership of this object.
+	if meta.GetLabels()[k8s.ApplySetLabel] != obj.applySet {
+		return
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(ref.APIVersion)
+	u.SetKind(ref.Kind)
+	u.SetName(ref.Name)
+	u.SetNamespace(ref.Namespace)
+
+	err = kCli.Delete(ctx, []k8s.K8sEntity{k8s.NewK8sEntity(u)})
+	if err != nil {
+		gc.log(st, spanID, logger.WarnLvl, "Unable to garbage-collect %s %s: %v\n", ref.Kind, ref.Name, err)
+		return
+	}
+
+	if obj.manifest == "" {
+		gc.log(st, spanID, logger.InfoLvl, "Deleted %s %s (no longer in the Tiltfile)\n", ref.Kind, ref.Name)
+		return
+	}
+	gc.log(st, spanID, logger.InfoLvl, "Deleted %s %s (removed from resource %s)\n", ref.Kind, ref.Name, obj.manifest)
+}
+
+func (gc *GarbageCollector) log(st store.RStore, spanID model.LogSpanID, level logger.Level, format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	st.Dispatch(store.NewLogAction(model.TiltfileManifestName, spanID, level, nil, []byte(msg)))
+}
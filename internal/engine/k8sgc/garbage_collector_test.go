@@ -0,0 +1,286 @@
+package k8sgc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+const applySet = "0123456789abcdef"
+
+func TestDeletesRemovedManifest(t *testing.T) {
+	f := newFixture(t)
+
+	f.deploy("foo", "foo-deployment")
+	f.deploy("bar", "bar-deployment")
+	f.loadTiltfile(nil)
+
+	f.removeManifest("bar")
+	f.loadTiltfile(nil)
+
+	assert.Contains(t, f.kCli.DeletedYaml, "name: bar-deployment")
+	assert.NotContains(t, f.kCli.DeletedYaml, "foo-deployment")
+	assert.Contains(t, f.logs(), "Deleted Deployment bar-deployment (removed from resource bar)")
+}
+
+func TestDeletesObjectRemovedFromManifest(t *testing.T) {
+	f := newFixture(t)
+
+	f.deploy("foo", "foo-deployment", "foo-config")
+	f.loadTiltfile(nil)
+
+	f.redeclare("foo", "foo-deployment")
+	f.loadTiltfile(nil)
+
+	assert.Contains(t, f.kCli.DeletedYaml, "name: foo-config")
+	assert.NotContains(t, f.kCli.DeletedYaml, "foo-deployment")
+}
+
+func TestDeletesObjectFromEarlierSession(t *testing.T) {
+	f := newFixture(t)
+
+	// Left behind by a Tilt session that deployed bar.
+	f.injectObject("bar-deployment", applySet)
+	f.injectObject("other-deployment", "some-other-set")
+
+	f.deploy("foo", "foo-deployment")
+	f.loadTiltfile(nil)
+
+	assert.Contains(t, f.kCli.DeletedYaml, "name: bar-deployment")
+	assert.NotContains(t, f.kCli.DeletedYaml, "foo-deployment")
+	assert.NotContains(t, f.kCli.DeletedYaml, "other-deployment")
+	assert.Contains(t, f.logs(), "Deleted Deployment bar-deployment (no longer in the Tiltfile)")
+}
+
+func TestObjectMovedToAnotherManifest(t *testing.T) {
+	f := newFixture(t)
+
+	f.deploy("foo", "foo-deployment")
+	f.loadTiltfile(nil)
+
+	f.removeManifest("foo")
+	f.declare("foo2", "foo-deployment")
+	f.loadTiltfile(nil)
+
+	assert.Equal(t, "", f.kCli.DeletedYaml)
+}
+
+func TestObjectMovedToAnotherContext(t *testing.T) {
+	f := newFixture(t)
+
+	f.deploy("foo", "foo-deployment")
+	f.loadTiltfile(nil)
+
+	f.moveToContext("foo", "data")
+	f.loadTiltfile(nil)
+
+	assert.Contains(t, f.kCli.DeletedYaml, "name: foo-deployment")
+	dataCli := f.clients.For("data").Client.(*k8s.FakeK8sClient)
+	assert.Equal(t, "", dataCli.DeletedYaml)
+}
+
+func TestKeepOnRemoval(t *testing.T) {
+	f := newFixture(t)
+
+	f.deploy("bar", "bar-deployment")
+	f.setKeepOnRemoval("bar")
+	f.loadTiltfile(nil)
+
+	f.removeManifest("bar")
+	f.loadTiltfile(nil)
+
+	assert.Equal(t, "", f.kCli.DeletedYaml)
+}
+
+func TestSkipsObjectsWithoutApplySetLabel(t *testing.T) {
+	f := newFixture(t)
+
+	f.deploy("bar", "bar-deployment")
+	f.loadTiltfile(nil)
+
+	// Someone else has taken over the object.
+	f.injectObject("bar-deployment", "some-other-set")
+
+	f.removeManifest("bar")
+	f.loadTiltfile(nil)
+
+	assert.Equal(t, "", f.kCli.DeletedYaml)
+}
+
+func TestNoDeleteOnTiltfileError(t *testing.T) {
+	f := newFixture(t)
+
+	f.deploy("bar", "bar-deployment")
+	f.loadTiltfile(nil)
+
+	f.removeManifest("bar")
+	f.loadTiltfile(fmt.Errorf("syntax error"))
+
+	assert.Equal(t, "", f.kCli.DeletedYaml)
+}
+
+type fixture struct {
+	t       *testing.T
+	ctx     context.Context
+	out     *bytes.Buffer
+	st      *store.TestingStore
+	kCli    *k8s.FakeK8sClient
+	clients *k8s.ContextClients
+	gc      *GarbageCollector
+	loads   int
+}
+
+func newFixture(t *testing.T) *fixture {
+	out := bytes.NewBuffer(nil)
+	ctx := logger.WithLogger(context.Background(), logger.NewLogger(logger.InfoLvl, out))
+	kCli := k8s.NewFakeK8sClient()
+	clients := k8s.NewFakeContextClients(ctx, kCli)
+	return &fixture{
+		t:       t,
+		ctx:     ctx,
+		out:     out,
+		st:      store.NewTestingStore(),
+		kCli:    kCli,
+		clients: clients,
+		gc:      NewGarbageCollector(clients),
+	}
+}
+
+func (f *fixture) deploymentYAML(name, set string) string {
+	return fmt.Sprintf(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: %s
+  labels:
+    %s: %s
+`, name, k8s.ApplySetLabel, set)
+}
+
+func (f *fixture) configMapYAML(name string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  labels:
+    %s: %s
+`, name, k8s.ApplySetLabel, applySet)
+}
+
+func (f *fixture) entities(names ...string) []k8s.K8sEntity {
+	var yamls []string
+	for _, name := range names {
+		if strings.HasSuffix(name, "-config") {
+			yamls = append(yamls, f.configMapYAML(name))
+		} else {
+			yamls = append(yamls, f.deploymentYAML(name, applySet))
+		}
+	}
+	entities, err := k8s.ParseYAMLFromString(strings.Join(yamls, "---\n"))
+	require.NoError(f.t, err)
+	return entities
+}
+
+func (f *fixture) injectObject(name, set string) {
+	entities, err := k8s.ParseYAMLFromString(f.deploymentYAML(name, set))
+	require.NoError(f.t, err)
+	f.kCli.InjectEntityByName(entities...)
+}
+
+func (f *fixture) target(mn model.ManifestName, names ...string) model.K8sTarget {
+	kTarget, err := k8s.NewTarget(model.TargetName(mn), f.entities(names...), nil, nil, nil, nil, model.PodReadinessIgnore, nil, nil)
+	require.NoError(f.t, err)
+	kTarget.ApplySet = applySet
+	return kTarget
+}
+
+// Declare a manifest without deploying it.
+func (f *fixture) declare(mn model.ManifestName, names ...string) {
+	m := model.Manifest{Name: mn}.WithDeployTarget(f.target(mn, names...))
+	state := f.st.LockMutableStateForTesting()
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+	f.st.UnlockMutableState()
+}
+
+// Change the objects a manifest declares, keeping its build state.
+func (f *fixture) redeclare(mn model.ManifestName, names ...string) {
+	state := f.st.LockMutableStateForTesting()
+	mt := state.ManifestTargets[mn]
+	mt.Manifest = mt.Manifest.WithDeployTarget(f.target(mn, names...))
+	f.st.UnlockMutableState()
+}
+
+func (f *fixture) deploy(mn model.ManifestName, names ...string) {
+	f.declare(mn, names...)
+	f.kCli.InjectEntityByName(f.entities(names...)...)
+
+	state := f.st.LockMutableStateForTesting()
+	mt := state.ManifestTargets[mn]
+	kTarget := mt.Manifest.K8sTarget()
+	mt.State.MutableBuildStatus(kTarget.ID()).LastResult =
+		store.NewK8sDeployResult(kTarget.ID(), nil, nil, f.entities(names...))
+	f.st.UnlockMutableState()
+
+	f.gc.OnChange(f.ctx, f.st, store.ChangeSummary{})
+}
+
+func (f *fixture) setKeepOnRemoval(mn model.ManifestName) {
+	state := f.st.LockMutableStateForTesting()
+	mt := state.ManifestTargets[mn]
+	kTarget := mt.Manifest.K8sTarget()
+	kTarget.KeepOnRemoval = true
+	mt.Manifest = mt.Manifest.WithDeployTarget(kTarget)
+	f.st.UnlockMutableState()
+}
+
+// Deploy a manifest to a different context, as if the Tiltfile had changed.
+func (f *fixture) moveToContext(mn model.ManifestName, kubeContext string) {
+	state := f.st.LockMutableStateForTesting()
+	mt := state.ManifestTargets[mn]
+	kTarget := mt.Manifest.K8sTarget()
+	kTarget.KubeContext = kubeContext
+	mt.Manifest = mt.Manifest.WithDeployTarget(kTarget)
+	mt.State.MutableBuildStatus(kTarget.ID()).LastResult = nil
+	f.st.UnlockMutableState()
+}
+
+func (f *fixture) removeManifest(mn model.ManifestName) {
+	state := f.st.LockMutableStateForTesting()
+	state.RemoveManifestTarget(mn)
+	f.st.UnlockMutableState()
+}
+
+func (f *fixture) loadTiltfile(err error) {
+	f.loads++
+	start := time.Now()
+	state := f.st.LockMutableStateForTesting()
+	state.TiltfileState.AddCompletedBuild(model.BuildRecord{
+		StartTime:  start,
+		FinishTime: start,
+		Error:      err,
+		SpanID:     model.LogSpanID(fmt.Sprintf("tiltfile:update:%d", f.loads)),
+	})
+	f.st.UnlockMutableState()
+
+	f.gc.OnChange(f.ctx, f.st, store.ChangeSummary{})
+}
+
+func (f *fixture) logs() string {
+	var sb strings.Builder
+	for _, a := range f.st.Actions() {
+		if la, ok := a.(store.LogAction); ok {
+			sb.Write(la.Message())
+		}
+	}
+	return sb.String()
+}
@@ -71,3 +71,41 @@ func NewServiceChangeAction(service *v1.Service, mn model.ManifestName, url *url
 		URL:          url,
 	}
 }
+
+type ObjectReadinessAction struct {
+	ManifestName model.ManifestName
+	Check        model.K8sObjectReadiness
+	Ready        bool
+
+	// Why the object isn't ready, if it isn't.
+	Reason string
+}
+
+func (ObjectReadinessAction) Action() {}
+
+func NewObjectReadinessAction(mn model.ManifestName, check model.K8sObjectReadiness, ready bool, reason string) ObjectReadinessAction {
+	return ObjectReadinessAction{
+		ManifestName: mn,
+		Check:        check,
+		Ready:        ready,
+		Reason:       reason,
+	}
+}
+
+type RolloutStatusAction struct {
+	ManifestName model.ManifestName
+	Ref          v1.ObjectReference
+
+	// Why the rollout failed, or empty if it hasn't.
+	Error string
+}
+
+func (RolloutStatusAction) Action() {}
+
+func NewRolloutStatusAction(mn model.ManifestName, ref v1.ObjectReference, err string) RolloutStatusAction {
+	return RolloutStatusAction{
+		ManifestName: mn,
+		Ref:          ref,
+		Error:        err,
+	}
+}
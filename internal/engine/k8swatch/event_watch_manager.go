@@ -24,8 +24,7 @@ import (
 // TODO(nick): We should also add garbage collection and/or handle Delete events
 // from the kubernetes informer properly.
 type EventWatchManager struct {
-	kClient      k8s.Client
-	ownerFetcher k8s.OwnerFetcher
+	clients *k8s.ContextClients
 
 	mu                sync.RWMutex
 	watcherKnownState watcherKnownState
@@ -38,21 +37,39 @@ type EventWatchManager struct {
 
 	// An index of all the known events, by UID
 	knownEvents map[types.UID]*v1.Event
+
+	// An index of the UIDs of all the objects we've applied, by kind, namespace, and name.
+	//
+	// Some controllers emit events that don't have the UID of the involved object,
+	// so we need to match them up by name.
+	knownDeployedRefs map[deployedRefKey]types.UID
+}
+
+type deployedRefKey struct {
+	kubeContext k8s.KubeContext
+	kind        string
+	namespace   string
+	name        string
 }
 
-func NewEventWatchManager(kClient k8s.Client, ownerFetcher k8s.OwnerFetcher, cfgNS k8s.Namespace) *EventWatchManager {
+func newDeployedRefKey(kubeContext k8s.KubeContext, ref v1.ObjectReference) deployedRefKey {
+	return deployedRefKey{kubeContext: kubeContext, kind: ref.Kind, namespace: ref.Namespace, name: ref.Name}
+}
+
+func NewEventWatchManager(clients *k8s.ContextClients) *EventWatchManager {
 	return &EventWatchManager{
-		kClient:                  kClient,
-		ownerFetcher:             ownerFetcher,
-		watcherKnownState:        newWatcherKnownState(cfgNS),
+		clients:                  clients,
+		watcherKnownState:        newWatcherKnownState(clients),
 		knownDescendentEventUIDs: make(map[types.UID]store.UIDSet),
 		knownEvents:              make(map[types.UID]*v1.Event),
+		knownDeployedRefs:        make(map[deployedRefKey]types.UID),
 	}
 }
 
 type eventWatchTaskList struct {
 	watcherTaskList
 	tiltStartTime time.Time
+	deployedRefs  map[deployedRefKey]types.UID
 }
 
 func (m *EventWatchManager) diff(st store.RStore) eventWatchTaskList {
@@ -62,10 +79,30 @@ func (m *EventWatchManager) diff(st store.RStore) eventWatchTaskList {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	deployedRefs := make(map[deployedRefKey]types.UID)
+	for _, mt := range state.Targets() {
+		if !mt.Manifest.IsK8s() {
+			continue
+		}
+
+		kTarget := mt.Manifest.K8sTarget()
+		result, ok := mt.State.BuildStatus(kTarget.ID()).LastResult.(store.K8sBuildResult)
+		if !ok {
+			continue
+		}
+		kubeContext := m.clients.Resolve(k8s.KubeContext(kTarget.KubeContext))
+		for _, ref := range result.DeployedRefs {
+			if ref.UID != "" {
+				deployedRefs[newDeployedRefKey(kubeContext, ref)] = ref.UID
+			}
+		}
+	}
+
 	watcherTaskList := m.watcherKnownState.createTaskList(state)
 	return eventWatchTaskList{
 		watcherTaskList: watcherTaskList,
 		tiltStartTime:   state.TiltStartTime,
+		deployedRefs:    deployedRefs,
 	}
 }
 
@@ -75,6 +112,8 @@ func (m *EventWatchManager) OnChange(ctx context.Context, st store.RStore, _ sto
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.knownDeployedRefs = taskList.deployedRefs
+
 	for _, teardown := range taskList.teardownNamespaces {
 		watcher, ok := m.watcherKnownState.namespaceWatches[teardown]
 		if ok {
@@ -92,18 +131,20 @@ func (m *EventWatchManager) OnChange(ctx context.Context, st store.RStore, _ sto
 	}
 }
 
-func (m *EventWatchManager) setupWatch(ctx context.Context, st store.RStore, ns k8s.Namespace, tiltStartTime time.Time) {
-	ch, err := m.kClient.WatchEvents(ctx, ns)
+func (m *EventWatchManager) setupWatch(ctx context.Context, st store.RStore, cns clusterNamespace, tiltStartTime time.Time) {
+	cc := m.clients.For(cns.kubeContext)
+	ch, err := cc.Client.WatchEvents(ctx, cns.namespace)
 	if err != nil {
-		err = errors.Wrapf(err, "Error watching events. Are you connected to kubernetes?\nTry running `kubectl get events -n %q`", ns)
+		err = errors.Wrapf(err, "Error watching events. Are you connected to kubernetes?\nTry running `kubectl get events %s`",
+			m.watcherKnownState.kubectlFlags(cns))
 		st.Dispatch(store.NewErrorAction(err))
 		return
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
-	m.watcherKnownState.namespaceWatches[ns] = namespaceWatch{cancel: cancel}
+	m.watcherKnownState.namespaceWatches[cns] = namespaceWatch{cancel: cancel}
 
-	go m.dispatchEventsLoop(ctx, ch, st, tiltStartTime)
+	go m.dispatchEventsLoop(ctx, cc, ch, st, tiltStartTime)
 }
 
 // When new UIDs are deployed, go through all our known events and dispatch
@@ -160,11 +201,32 @@ func (m *EventWatchManager) triageEventUpdate(event *v1.Event, objTree k8s.Objec
 	return ""
 }
 
-func (m *EventWatchManager) dispatchEventChange(ctx context.Context, event *v1.Event, st store.RStore) {
-	objTree, err := m.ownerFetcher.OwnerTreeOfRef(ctx, event.InvolvedObject)
+// If the event doesn't have the UID of its involved object, try to fill it in
+// from the objects we've applied.
+func (m *EventWatchManager) resolveInvolvedObject(kubeContext k8s.KubeContext, ref v1.ObjectReference) v1.ObjectReference {
+	if ref.UID != "" {
+		return ref
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ref.UID = m.knownDeployedRefs[newDeployedRefKey(kubeContext, ref)]
+	return ref
+}
+
+func (m *EventWatchManager) dispatchEventChange(ctx context.Context, cc k8s.ContextClient, event *v1.Event, st store.RStore) {
+	involved := m.resolveInvolvedObject(cc.Context, event.InvolvedObject)
+	objTree, err := cc.OwnerFetcher.OwnerTreeOfRef(ctx, involved)
 	if err != nil {
-		logger.Get(ctx).Infof("Error handling event update (%q): %v", event.Name, err)
-		return
+		if involved.UID == "" {
+			logger.Get(ctx).Infof("Error handling event update (%q): %v", event.Name, err)
+			return
+		}
+
+		// We couldn't look up the owners of the involved object (e.g., it's already
+		// been deleted, or it's a kind we can't read), so match against the object itself.
+		logger.Get(ctx).Debugf("Error fetching owners of event object (%q): %v", event.Name, err)
+		objTree = k8s.ObjectRefTree{Ref: involved}
 	}
 
 	mn := m.triageEventUpdate(event, objTree)
@@ -175,7 +237,7 @@ func (m *EventWatchManager) dispatchEventChange(ctx context.Context, event *v1.E
 	st.Dispatch(store.NewK8sEventAction(event, mn))
 }
 
-func (m *EventWatchManager) dispatchEventsLoop(ctx context.Context, ch <-chan *v1.Event, st store.RStore, tiltStartTime time.Time) {
+func (m *EventWatchManager) dispatchEventsLoop(ctx context.Context, cc k8s.ContextClient, ch <-chan *v1.Event, st store.RStore, tiltStartTime time.Time) {
 	for {
 		select {
 		case event, ok := <-ch:
@@ -199,7 +261,7 @@ func (m *EventWatchManager) dispatchEventsLoop(ctx context.Context, ch <-chan *v
 				continue
 			}
 
-			go m.dispatchEventChange(ctx, event, st)
+			go m.dispatchEventChange(ctx, cc, event, st)
 
 		case <-ctx.Done():
 			return
@@ -191,6 +191,45 @@ func TestEventWatchManager_eventBeforeUID(t *testing.T) {
 	f.assertActions(expected)
 }
 
+// Events on objects that don't own pods (e.g., Services and PersistentVolumeClaims)
+// should be matched against the object itself.
+func TestEventWatchManager_dispatchesEventOnDeployedObject(t *testing.T) {
+	f := newEWMFixture(t)
+	defer f.TearDown()
+
+	mn := model.ManifestName("someK8sManifest")
+	manifest := f.addManifest(mn)
+	pvc := f.deployedRef(manifest, "PersistentVolumeClaim", "data", "pvc-uid")
+
+	evt := f.makeEvent(k8s.NewK8sEntity(&v1.PersistentVolumeClaim{}))
+	evt.InvolvedObject = pvc
+	evt.Reason = "ProvisioningFailed"
+
+	f.ewm.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	f.kClient.EmitEvent(f.ctx, evt)
+	expected := store.K8sEventAction{Event: evt, ManifestName: mn}
+	f.assertActions(expected)
+}
+
+// Some controllers don't include the UID of the involved object.
+func TestEventWatchManager_dispatchesEventWithoutUID(t *testing.T) {
+	f := newEWMFixture(t)
+	defer f.TearDown()
+
+	mn := model.ManifestName("someK8sManifest")
+	manifest := f.addManifest(mn)
+	svc := f.deployedRef(manifest, "Service", "sancho", "svc-uid")
+
+	evt := f.makeEvent(k8s.NewK8sEntity(&v1.Service{}))
+	evt.InvolvedObject = v1.ObjectReference{Kind: svc.Kind, Namespace: svc.Namespace, Name: svc.Name}
+	evt.Reason = "SyncLoadBalancerFailed"
+
+	f.ewm.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	f.kClient.EmitEvent(f.ctx, evt)
+	expected := store.K8sEventAction{Event: evt, ManifestName: mn}
+	f.assertActions(expected)
+}
+
 func TestEventWatchManager_ignoresPreStartEvents(t *testing.T) {
 	f := newEWMFixture(t)
 	defer f.TearDown()
@@ -249,15 +288,13 @@ func newEWMFixture(t *testing.T) *ewmFixture {
 	ctx, _, _ := testutils.CtxAndAnalyticsForTest()
 	ctx, cancel := context.WithCancel(ctx)
 
-	of := k8s.ProvideOwnerFetcher(ctx, kClient)
-
 	clock := clockwork.NewFakeClock()
 	st := store.NewTestingStore()
 
 	ret := &ewmFixture{
 		TempDirFixture: tempdir.NewTempDirFixture(t),
 		kClient:        kClient,
-		ewm:            NewEventWatchManager(kClient, of, k8s.DefaultNamespace),
+		ewm:            NewEventWatchManager(k8s.NewFakeContextClients(ctx, kClient)),
 		ctx:            ctx,
 		cancel:         cancel,
 		t:              t,
@@ -303,6 +340,32 @@ func (f *ewmFixture) addDeployedUID(m model.Manifest, uid types.UID) {
 	runtimeState.DeployedUIDSet[uid] = true
 }
 
+// Record an object as deployed by the manifest, without injecting it into the client.
+func (f *ewmFixture) deployedRef(m model.Manifest, kind, name string, uid types.UID) v1.ObjectReference {
+	defer f.ewm.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+
+	state := f.store.LockMutableStateForTesting()
+	defer f.store.UnlockMutableState()
+	mt, ok := state.ManifestTargets[m.Name]
+	if !ok {
+		f.t.Fatalf("Unknown manifest: %s", m.Name)
+	}
+
+	ref := v1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       kind,
+		Namespace:  k8s.DefaultNamespace.String(),
+		Name:       name,
+		UID:        uid,
+	}
+	kTarget := m.K8sTarget()
+	result := store.NewK8sDeployResult(kTarget.ID(), []types.UID{uid}, nil, nil)
+	result.DeployedRefs = []v1.ObjectReference{ref}
+	mt.State.MutableBuildStatus(kTarget.ID()).LastResult = result
+	mt.State.K8sRuntimeState().DeployedUIDSet[uid] = true
+	return ref
+}
+
 func (f *ewmFixture) assertNoActions() {
 	f.assertActions()
 }
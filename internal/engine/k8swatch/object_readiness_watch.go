@@ -0,0 +1,234 @@
+package k8swatch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// Watches objects that have readiness checks (e.g., custom resources declared
+// with k8s_kind(ready_condition=...)), and reports whether they're ready.
+type ObjectReadinessWatcher struct {
+	clients *k8s.ContextClients
+
+	mu      sync.Mutex
+	watches map[objectWatchKey]context.CancelFunc
+	checks  []readinessCheck
+
+	// The most recent version of each object we've seen.
+	objects map[objectID]*unstructured.Unstructured
+}
+
+type objectWatchKey struct {
+	kubeContext k8s.KubeContext
+	gvk         schema.GroupVersionKind
+	ns          k8s.Namespace
+}
+
+type objectID struct {
+	kubeContext k8s.KubeContext
+	gk          schema.GroupKind
+	ns          string
+	name        string
+}
+
+type readinessCheck struct {
+	manifest    model.ManifestName
+	kubeContext k8s.KubeContext
+	check       model.K8sObjectReadiness
+}
+
+func NewObjectReadinessWatcher(clients *k8s.ContextClients) *ObjectReadinessWatcher {
+	return &ObjectReadinessWatcher{
+		clients: clients,
+		watches: make(map[objectWatchKey]context.CancelFunc),
+		objects: make(map[objectID]*unstructured.Unstructured),
+	}
+}
+
+func (w *ObjectReadinessWatcher) OnChange(ctx context.Context, st store.RStore, _ store.ChangeSummary) {
+	var checks []readinessCheck
+	readyInState := make(map[readinessCheck]bool)
+	state := st.RLockState()
+	for _, mt := range state.Targets() {
+		if !mt.Manifest.IsK8s() {
+			continue
+		}
+		runtime := mt.State.K8sRuntimeState()
+		kTarget := mt.Manifest.K8sTarget()
+		kubeContext := w.clients.Resolve(k8s.KubeContext(kTarget.KubeContext))
+		for _, check := range kTarget.ObjectReadiness {
+			rc := readinessCheck{manifest: mt.Manifest.Name, kubeContext: kubeContext, check: check}
+			checks = append(checks, rc)
+			readyInState[rc] = runtime.ReadyObjects[check.Key()]
+		}
+	}
+	st.RUnlockState()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.checks = checks
+
+	needed := make(map[objectWatchKey]bool)
+	for _, rc := range checks {
+		needed[w.watchKey(rc)] = true
+	}
+
+	for key, cancel := range w.watches {
+		if !needed[key] {
+			cancel()
+			delete(w.watches, key)
+		}
+	}
+
+	for key := range needed {
+		if _, ok := w.watches[key]; ok {
+			continue
+		}
+		w.setupWatch(ctx, st, key)
+	}
+
+	// Re-check the objects we already know about, in case the readiness
+	// checks changed or the manifest state was reset.
+	for _, rc := range checks {
+		obj, ok := w.objects[w.checkObjectID(rc)]
+		if !ok {
+			continue
+		}
+		action := w.evaluate(rc, obj)
+		if action.Ready != readyInState[rc] {
+			st.Dispatch(action)
+		}
+	}
+}
+
+func (w *ObjectReadinessWatcher) watchKey(rc readinessCheck) objectWatchKey {
+	ns := k8s.Namespace(rc.check.Ref.Namespace)
+	if ns == "" {
+		ns = w.clients.For(rc.kubeContext).ConfigNamespace
+	}
+	if ns == "" {
+		ns = k8s.DefaultNamespace
+	}
+	return objectWatchKey{kubeContext: rc.kubeContext, gvk: k8s.ReferenceGVK(rc.check.Ref), ns: ns}
+}
+
+// The ID of the object that a check refers to. If the Tiltfile doesn't specify
+// a namespace, the object is either in the default namespace or cluster-scoped.
+func (w *ObjectReadinessWatcher) checkObjectID(rc readinessCheck) objectID {
+	gk := k8s.ReferenceGVK(rc.check.Ref).GroupKind()
+	id := objectID{kubeContext: rc.kubeContext, gk: gk, ns: rc.check.Ref.Namespace, name: rc.check.Ref.Name}
+	if id.ns != "" {
+		return id
+	}
+
+	id.ns = string(w.watchKey(rc).ns)
+	if _, ok := w.objects[id]; ok {
+		return id
+	}
+	return objectID{kubeContext: rc.kubeContext, gk: gk, name: rc.check.Ref.Name}
+}
+
+func (w *ObjectReadinessWatcher) setupWatch(ctx context.Context, st store.RStore, key objectWatchKey) {
+	ctx, cancel := context.WithCancel(ctx)
+	ch, err := w.clients.For(key.kubeContext).Client.WatchObjects(ctx, key.gvk, key.ns)
+	if err != nil {
+		// The type might not exist yet (e.g., if Tilt hasn't applied the CRD),
+		// so try again on the next change.
+		cancel()
+		logger.Get(ctx).Debugf("Error watching %s: %v", key.gvk.Kind, err)
+		return
+	}
+
+	w.watches[key] = cancel
+	go w.dispatchObjectChangesLoop(ctx, key, ch, st)
+}
+
+func (w *ObjectReadinessWatcher) dispatchObjectChangesLoop(ctx context.Context, key objectWatchKey, ch <-chan k8s.ObjectUpdate, st store.RStore) {
+	for {
+		select {
+		case update, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			var actions []ObjectReadinessAction
+			if obj, ok := update.AsUnstructured(); ok {
+				actions = w.triageObjectUpdate(key.kubeContext, obj)
+			} else if ns, name, ok := update.AsDeletedKey(); ok {
+				actions = w.triageObjectDelete(objectID{
+					kubeContext: key.kubeContext,
+					gk:          key.gvk.GroupKind(),
+					ns:          string(ns),
+					name:        name,
+				})
+			}
+
+			for _, action := range actions {
+				st.Dispatch(action)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Record the object update, and evaluate all the checks that refer to it.
+func (w *ObjectReadinessWatcher) triageObjectUpdate(kubeContext k8s.KubeContext, obj *unstructured.Unstructured) []ObjectReadinessAction {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := objectID{
+		kubeContext: kubeContext,
+		gk:          obj.GroupVersionKind().GroupKind(),
+		ns:          obj.GetNamespace(),
+		name:        obj.GetName(),
+	}
+	w.objects[id] = obj
+
+	var result []ObjectReadinessAction
+	for _, rc := range w.checks {
+		if w.checkObjectID(rc) != id {
+			continue
+		}
+		result = append(result, w.evaluate(rc, obj))
+	}
+	return result
+}
+
+// Forget the deleted object, and mark all the checks that refer to it as not ready.
+func (w *ObjectReadinessWatcher) triageObjectDelete(id objectID) []ObjectReadinessAction {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.objects[id]; !ok {
+		return nil
+	}
+
+	var result []ObjectReadinessAction
+	for _, rc := range w.checks {
+		if w.checkObjectID(rc) != id {
+			continue
+		}
+		result = append(result, NewObjectReadinessAction(rc.manifest, rc.check, false, "object deleted"))
+	}
+	delete(w.objects, id)
+	return result
+}
+
+func (w *ObjectReadinessWatcher) evaluate(rc readinessCheck, obj *unstructured.Unstructured) ObjectReadinessAction {
+	ready, reason, err := k8s.ObjectReady(obj, rc.check)
+	if err != nil {
+		reason = fmt.Sprintf("error checking readiness: %v", err)
+	}
+	return NewObjectReadinessAction(rc.manifest, rc.check, ready, reason)
+}
@@ -0,0 +1,200 @@
+package k8swatch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/internal/testutils"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+func TestObjectReadinessWatch(t *testing.T) {
+	f := newORWFixture(t)
+	defer f.TearDown()
+
+	f.addManifest("cert", "my-cert")
+	f.orw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	f.waitForWatch()
+
+	f.kClient.EmitObject(f.certificate("my-cert", "False"))
+	action := f.assertNextAction()
+	assert.Equal(t, model.ManifestName("cert"), action.ManifestName)
+	assert.False(t, action.Ready)
+	assert.Equal(t, "condition Ready is False", action.Reason)
+
+	f.kClient.EmitObject(f.certificate("my-cert", "True"))
+	action = f.assertNextAction()
+	assert.True(t, action.Ready)
+}
+
+func TestObjectReadinessWatchIgnoresOtherObjects(t *testing.T) {
+	f := newORWFixture(t)
+	defer f.TearDown()
+
+	f.addManifest("cert", "my-cert")
+	f.orw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	f.waitForWatch()
+
+	f.kClient.EmitObject(f.certificate("other-cert", "True"))
+	f.kClient.EmitObject(f.certificate("my-cert", "True"))
+	action := f.assertNextAction()
+	assert.Equal(t, "my-cert", action.Check.Ref.Name)
+	assert.Len(t, f.readinessActions(), 1)
+}
+
+// If the manifest state gets reset (e.g., the resource was removed and re-added),
+// we re-dispatch the readiness of objects we've already seen.
+func TestObjectReadinessWatchReconcilesState(t *testing.T) {
+	f := newORWFixture(t)
+	defer f.TearDown()
+
+	f.addManifest("cert", "my-cert")
+	f.orw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	f.waitForWatch()
+
+	f.kClient.EmitObject(f.certificate("my-cert", "True"))
+	f.assertNextAction()
+
+	f.orw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	assert.Len(t, f.readinessActions(), 2)
+}
+
+func TestObjectReadinessWatchDelete(t *testing.T) {
+	f := newORWFixture(t)
+	defer f.TearDown()
+
+	f.addManifest("cert", "my-cert")
+	f.orw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	f.waitForWatch()
+
+	cert := f.certificate("my-cert", "True")
+	f.kClient.EmitObject(cert)
+	action := f.assertNextAction()
+	assert.True(t, action.Ready)
+
+	f.kClient.EmitObjectDelete(cert)
+	action = f.assertNextAction()
+	assert.False(t, action.Ready)
+	assert.Equal(t, "object deleted", action.Reason)
+
+	// We no longer know about the object, so there's nothing to reconcile.
+	f.orw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	assert.Len(t, f.readinessActions(), 2)
+}
+
+func TestObjectReadinessWatchTeardown(t *testing.T) {
+	f := newORWFixture(t)
+	defer f.TearDown()
+
+	f.addManifest("cert", "my-cert")
+	f.orw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	f.waitForWatch()
+
+	state := f.store.LockMutableStateForTesting()
+	state.RemoveManifestTarget("cert")
+	f.store.UnlockMutableState()
+
+	f.orw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	require.Eventually(t, func() bool {
+		return f.kClient.ObjectWatchCount() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+type orwFixture struct {
+	t       *testing.T
+	kClient *k8s.FakeK8sClient
+	orw     *ObjectReadinessWatcher
+	ctx     context.Context
+	cancel  func()
+	store   *store.TestingStore
+	seen    int
+}
+
+func newORWFixture(t *testing.T) *orwFixture {
+	kClient := k8s.NewFakeK8sClient()
+	ctx, _, _ := testutils.CtxAndAnalyticsForTest()
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &orwFixture{
+		t:       t,
+		kClient: kClient,
+		orw:     NewObjectReadinessWatcher(k8s.NewFakeContextClients(ctx, kClient)),
+		ctx:     ctx,
+		cancel:  cancel,
+		store:   store.NewTestingStore(),
+	}
+}
+
+func (f *orwFixture) TearDown() {
+	f.kClient.TearDown()
+	f.cancel()
+	f.store.AssertNoErrorActions(f.t)
+}
+
+func (f *orwFixture) addManifest(mn model.ManifestName, certName string) {
+	state := f.store.LockMutableStateForTesting()
+	defer f.store.UnlockMutableState()
+
+	kTarget := model.K8sTarget{
+		Name: model.TargetName(mn),
+		YAML: "fake-yaml",
+		ObjectReadiness: []model.K8sObjectReadiness{{
+			Ref:       v1.ObjectReference{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: certName},
+			Condition: "Ready",
+		}},
+	}
+	m := model.Manifest{Name: mn}.WithDeployTarget(kTarget)
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+}
+
+func (f *orwFixture) certificate(name, status string) *unstructured.Unstructured {
+	entities, err := k8s.ParseYAMLFromString(fmt.Sprintf(`apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: %s
+  namespace: default
+status:
+  conditions:
+  - type: Ready
+    status: "%s"
+`, name, status))
+	require.NoError(f.t, err)
+	return entities[0].Obj.(*unstructured.Unstructured)
+}
+
+func (f *orwFixture) waitForWatch() {
+	require.Eventually(f.t, func() bool {
+		return f.kClient.ObjectWatchCount() > 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (f *orwFixture) readinessActions() []ObjectReadinessAction {
+	var result []ObjectReadinessAction
+	for _, a := range f.store.Actions() {
+		if ora, ok := a.(ObjectReadinessAction); ok {
+			result = append(result, ora)
+		}
+	}
+	return result
+}
+
+func (f *orwFixture) assertNextAction() ObjectReadinessAction {
+	var actions []ObjectReadinessAction
+	require.Eventually(f.t, func() bool {
+		actions = f.readinessActions()
+		return len(actions) > f.seen
+	}, time.Second, 10*time.Millisecond)
+
+	action := actions[f.seen]
+	f.seen++
+	return action
+}
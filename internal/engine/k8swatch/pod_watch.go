@@ -20,8 +20,7 @@ import (
 )
 
 type PodWatcher struct {
-	kCli         k8s.Client
-	ownerFetcher k8s.OwnerFetcher
+	clients *k8s.ContextClients
 
 	mu                sync.RWMutex
 	extraSelectors    []ExtraSelector
@@ -37,13 +36,12 @@ type PodWatcher struct {
 	knownPods map[types.UID]*v1.Pod
 }
 
-func NewPodWatcher(kCli k8s.Client, ownerFetcher k8s.OwnerFetcher, cfgNS k8s.Namespace) *PodWatcher {
+func NewPodWatcher(clients *k8s.ContextClients) *PodWatcher {
 	return &PodWatcher{
-		kCli:                   kCli,
-		ownerFetcher:           ownerFetcher,
+		clients:                clients,
 		knownDescendentPodUIDs: make(map[types.UID]store.UIDSet),
 		knownPods:              make(map[types.UID]*v1.Pod),
-		watcherKnownState:      newWatcherKnownState(cfgNS),
+		watcherKnownState:      newWatcherKnownState(clients),
 	}
 }
 
@@ -109,18 +107,20 @@ func (w *PodWatcher) OnChange(ctx context.Context, st store.RStore, _ store.Chan
 	}
 }
 
-func (w *PodWatcher) setupWatch(ctx context.Context, st store.RStore, ns k8s.Namespace) {
-	ch, err := w.kCli.WatchPods(ctx, ns)
+func (w *PodWatcher) setupWatch(ctx context.Context, st store.RStore, cns clusterNamespace) {
+	cc := w.clients.For(cns.kubeContext)
+	ch, err := cc.Client.WatchPods(ctx, cns.namespace)
 	if err != nil {
-		err = errors.Wrapf(err, "Error watching pods. Are you connected to kubernetes?\nTry running `kubectl get pods -n %q`", ns)
+		err = errors.Wrapf(err, "Error watching pods. Are you connected to kubernetes?\nTry running `kubectl get pods %s`",
+			w.watcherKnownState.kubectlFlags(cns))
 		st.Dispatch(store.NewErrorAction(err))
 		return
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
-	w.watcherKnownState.namespaceWatches[ns] = namespaceWatch{cancel: cancel}
+	w.watcherKnownState.namespaceWatches[cns] = namespaceWatch{cancel: cancel}
 
-	go w.dispatchPodChangesLoop(ctx, ch, st)
+	go w.dispatchPodChangesLoop(ctx, cc.OwnerFetcher, ch, st)
 }
 
 // When new UIDs are deployed, go through all our known pods and dispatch
@@ -206,8 +206,8 @@ func (w *PodWatcher) triagePodTree(pod *v1.Pod, objTree k8s.ObjectRefTree) (mode
 	return "", ""
 }
 
-func (w *PodWatcher) dispatchPodChange(ctx context.Context, pod *v1.Pod, st store.RStore) {
-	objTree, err := w.ownerFetcher.OwnerTreeOf(ctx, k8s.NewK8sEntity(pod))
+func (w *PodWatcher) dispatchPodChange(ctx context.Context, ownerFetcher k8s.OwnerFetcher, pod *v1.Pod, st store.RStore) {
+	objTree, err := ownerFetcher.OwnerTreeOf(ctx, k8s.NewK8sEntity(pod))
 	if err != nil {
 		logger.Get(ctx).Infof("Handling pod update (%q): %v", pod.Name, err)
 		return
@@ -226,7 +226,7 @@ func (w *PodWatcher) dispatchPodChange(ctx context.Context, pod *v1.Pod, st stor
 	w.mu.Unlock()
 }
 
-func (w *PodWatcher) dispatchPodChangesLoop(ctx context.Context, ch <-chan k8s.ObjectUpdate, st store.RStore) {
+func (w *PodWatcher) dispatchPodChangesLoop(ctx context.Context, ownerFetcher k8s.OwnerFetcher, ch <-chan k8s.ObjectUpdate, st store.RStore) {
 	for {
 		select {
 		case obj, ok := <-ch:
@@ -238,7 +238,7 @@ func (w *PodWatcher) dispatchPodChangesLoop(ctx context.Context, ch <-chan k8s.O
 			if ok {
 				w.upsertPod(pod)
 
-				go w.dispatchPodChange(ctx, pod, st)
+				go w.dispatchPodChange(ctx, ownerFetcher, pod, st)
 				continue
 			}
 
@@ -45,6 +45,37 @@ func TestPodWatch(t *testing.T) {
 	f.assertObservedPods(p)
 }
 
+func TestPodWatchOtherContext(t *testing.T) {
+	f := newPWFixture(t)
+	defer f.TearDown()
+
+	manifest := f.addManifestWithSelectors("server")
+	kTarget := manifest.K8sTarget()
+	kTarget.KubeContext = "data"
+	manifest = manifest.WithDeployTarget(kTarget)
+	state := f.store.LockMutableStateForTesting()
+	state.ManifestTargets["server"].Manifest = manifest
+	f.store.UnlockMutableState()
+
+	f.pw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+
+	dataClient := f.clients.For("data").Client.(*k8s.FakeK8sClient)
+	defer dataClient.TearDown()
+
+	pb := podbuilder.New(t, manifest)
+	p := pb.Build()
+
+	f.addDeployedUID(manifest, pb.DeploymentUID())
+	dataClient.InjectEntityByName(pb.ObjectTreeEntities()...)
+
+	// Pods in the current context's cluster aren't watched.
+	f.kClient.EmitPod(labels.Everything(), p)
+	f.assertObservedPods()
+
+	dataClient.EmitPod(labels.Everything(), p)
+	f.assertObservedPods(p)
+}
+
 func TestPodWatchChangeEventBeforeUID(t *testing.T) {
 	f := newPWFixture(t)
 	defer f.TearDown()
@@ -309,6 +340,7 @@ type pwFixture struct {
 	*tempdir.TempDirFixture
 	t             *testing.T
 	kClient       *k8s.FakeK8sClient
+	clients       *k8s.ContextClients
 	pw            *PodWatcher
 	ctx           context.Context
 	cancel        func()
@@ -336,11 +368,12 @@ func newPWFixture(t *testing.T) *pwFixture {
 	ctx, _, _ := testutils.CtxAndAnalyticsForTest()
 	ctx, cancel := context.WithCancel(ctx)
 
-	of := k8s.ProvideOwnerFetcher(ctx, kClient)
-	pw := NewPodWatcher(kClient, of, k8s.DefaultNamespace)
+	clients := k8s.NewFakeContextClients(ctx, kClient)
+	pw := NewPodWatcher(clients)
 	ret := &pwFixture{
 		TempDirFixture: tempdir.NewTempDirFixture(t),
 		kClient:        kClient,
+		clients:        clients,
 		pw:             pw,
 		ctx:            ctx,
 		cancel:         cancel,
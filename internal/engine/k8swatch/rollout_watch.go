@@ -0,0 +1,191 @@
+package k8swatch
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// The workloads whose rollout conditions we report.
+var rolloutGVKs = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+}
+
+// Watches the workloads that we deploy (e.g., Deployments and StatefulSets),
+// and reports rollouts that have failed (e.g., ProgressDeadlineExceeded).
+type RolloutWatcher struct {
+	clients *k8s.ContextClients
+
+	mu                sync.RWMutex
+	watcherKnownState watcherKnownState
+	knownObjects      map[types.UID]*unstructured.Unstructured
+
+	// The most recent rollout error we've reported for each object.
+	knownErrors map[types.UID]string
+}
+
+func NewRolloutWatcher(clients *k8s.ContextClients) *RolloutWatcher {
+	return &RolloutWatcher{
+		clients:           clients,
+		watcherKnownState: newWatcherKnownState(clients),
+		knownObjects:      make(map[types.UID]*unstructured.Unstructured),
+		knownErrors:       make(map[types.UID]string),
+	}
+}
+
+func (w *RolloutWatcher) diff(st store.RStore) watcherTaskList {
+	state := st.RLockState()
+	defer st.RUnlockState()
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.watcherKnownState.createTaskList(state)
+}
+
+func (w *RolloutWatcher) OnChange(ctx context.Context, st store.RStore, _ store.ChangeSummary) {
+	taskList := w.diff(st)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, teardown := range taskList.teardownNamespaces {
+		watcher, ok := w.watcherKnownState.namespaceWatches[teardown]
+		if ok {
+			watcher.cancel()
+		}
+		delete(w.watcherKnownState.namespaceWatches, teardown)
+	}
+
+	for _, setup := range taskList.setupNamespaces {
+		w.setupWatch(ctx, st, setup)
+	}
+
+	if len(taskList.newUIDs) > 0 {
+		w.setupNewUIDs(ctx, st, taskList.newUIDs)
+	}
+}
+
+func (w *RolloutWatcher) setupWatch(ctx context.Context, st store.RStore, cns clusterNamespace) {
+	kCli := w.clients.For(cns.kubeContext).Client
+	ctx, cancel := context.WithCancel(ctx)
+	for _, gvk := range rolloutGVKs {
+		ch, err := kCli.WatchObjects(ctx, gvk, cns.namespace)
+		if err != nil {
+			cancel()
+			err = errors.Wrapf(err, "Error watching %ss. Are you connected to kubernetes?\nTry running `kubectl get %ss %s`",
+				gvk.Kind, gvk.Kind, w.watcherKnownState.kubectlFlags(cns))
+			st.Dispatch(store.NewErrorAction(err))
+			return
+		}
+
+		go w.dispatchObjectChangesLoop(ctx, gvk, ch, st)
+	}
+
+	w.watcherKnownState.namespaceWatches[cns] = namespaceWatch{cancel: cancel}
+}
+
+// When new UIDs are deployed, go through all our known objects and dispatch
+// new actions. This handles the case where we get the object update
+// before the deploy id shows up in the manifest.
+func (w *RolloutWatcher) setupNewUIDs(ctx context.Context, st store.RStore, newUIDs map[types.UID]model.ManifestName) {
+	for uid, mn := range newUIDs {
+		w.watcherKnownState.knownDeployedUIDs[uid] = mn
+
+		// The manifest state may have been reset, so report the error again.
+		delete(w.knownErrors, uid)
+
+		obj, ok := w.knownObjects[uid]
+		if !ok {
+			continue
+		}
+
+		action, ok := w.rolloutStatusChange(obj, mn)
+		if ok {
+			st.Dispatch(action)
+		}
+	}
+}
+
+// Record the object update, and check whether it's a workload we deployed.
+func (w *RolloutWatcher) triageObjectUpdate(obj *unstructured.Unstructured) (RolloutStatusAction, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	uid := obj.GetUID()
+	w.knownObjects[uid] = obj
+
+	mn, ok := w.watcherKnownState.knownDeployedUIDs[uid]
+	if !ok {
+		return RolloutStatusAction{}, false
+	}
+
+	return w.rolloutStatusChange(obj, mn)
+}
+
+// Forget the deleted object, so that we don't hold onto it forever.
+func (w *RolloutWatcher) triageObjectDelete(gvk schema.GroupVersionKind, ns k8s.Namespace, name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for uid, obj := range w.knownObjects {
+		if obj.GroupVersionKind().GroupKind() == gvk.GroupKind() &&
+			obj.GetNamespace() == string(ns) && obj.GetName() == name {
+			delete(w.knownObjects, uid)
+			delete(w.knownErrors, uid)
+		}
+	}
+}
+
+// Returns an action if the rollout error of the object has changed
+// since we last reported it.
+//
+// Assumes we're holding the lock.
+func (w *RolloutWatcher) rolloutStatusChange(obj *unstructured.Unstructured, mn model.ManifestName) (RolloutStatusAction, bool) {
+	uid := obj.GetUID()
+	msg := k8s.RolloutError(obj)
+	old, ok := w.knownErrors[uid]
+	if msg == old && (ok || msg == "") {
+		return RolloutStatusAction{}, false
+	}
+
+	w.knownErrors[uid] = msg
+	return NewRolloutStatusAction(mn, k8s.NewK8sEntity(obj).ToObjectReference(), msg), true
+}
+
+func (w *RolloutWatcher) dispatchObjectChangesLoop(ctx context.Context, gvk schema.GroupVersionKind, ch <-chan k8s.ObjectUpdate, st store.RStore) {
+	for {
+		select {
+		case update, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			if ns, name, ok := update.AsDeletedKey(); ok {
+				w.triageObjectDelete(gvk, ns, name)
+				continue
+			}
+
+			obj, ok := update.AsUnstructured()
+			if !ok {
+				continue
+			}
+
+			action, ok := w.triageObjectUpdate(obj)
+			if ok {
+				st.Dispatch(action)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
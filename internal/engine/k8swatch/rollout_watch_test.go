@@ -0,0 +1,194 @@
+package k8swatch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/k8s/testyaml"
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/internal/testutils"
+	"github.com/tilt-dev/tilt/internal/testutils/manifestbuilder"
+	"github.com/tilt-dev/tilt/internal/testutils/tempdir"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+func TestRolloutWatchProgressDeadlineExceeded(t *testing.T) {
+	f := newRWFixture(t)
+	defer f.TearDown()
+
+	m := f.addManifest("sancho")
+	f.addDeployedUID(m, "deploy-uid")
+	f.waitForWatch()
+
+	f.kClient.EmitObject(f.deployment("deploy-uid", "ProgressDeadlineExceeded"))
+	action := f.assertNextAction()
+	assert.Equal(t, model.ManifestName("sancho"), action.ManifestName)
+	assert.Equal(t, "Deployment", action.Ref.Kind)
+	assert.Equal(t, "Deployment sancho rollout failed: ProgressDeadlineExceeded: timed out", action.Error)
+
+	// Once the rollout recovers, the error is cleared.
+	f.kClient.EmitObject(f.deployment("deploy-uid", "NewReplicaSetAvailable"))
+	action = f.assertNextAction()
+	assert.Equal(t, "", action.Error)
+}
+
+func TestRolloutWatchIgnoresHealthyRollouts(t *testing.T) {
+	f := newRWFixture(t)
+	defer f.TearDown()
+
+	m := f.addManifest("sancho")
+	f.addDeployedUID(m, "deploy-uid")
+	f.waitForWatch()
+
+	f.kClient.EmitObject(f.deployment("deploy-uid", "NewReplicaSetAvailable"))
+	f.kClient.EmitObject(f.deployment("other-uid", "ProgressDeadlineExceeded"))
+	f.kClient.EmitObject(f.deployment("deploy-uid", "ProgressDeadlineExceeded"))
+
+	action := f.assertNextAction()
+	assert.Equal(t, types.UID("deploy-uid"), action.Ref.UID)
+	assert.Len(t, f.rolloutActions(), 1)
+}
+
+func TestRolloutWatchObjectBeforeUID(t *testing.T) {
+	f := newRWFixture(t)
+	defer f.TearDown()
+
+	m := f.addManifest("sancho")
+	f.rw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+	f.waitForWatch()
+
+	f.kClient.EmitObject(f.deployment("deploy-uid", "ProgressDeadlineExceeded"))
+	require.Eventually(t, func() bool {
+		f.rw.mu.RLock()
+		defer f.rw.mu.RUnlock()
+		return f.rw.knownObjects["deploy-uid"] != nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, f.rolloutActions(), 0)
+
+	// When the UID shows up, report the failure we saw earlier.
+	f.addDeployedUID(m, "deploy-uid")
+	action := f.assertNextAction()
+	assert.Equal(t, model.ManifestName("sancho"), action.ManifestName)
+	assert.Contains(t, action.Error, "ProgressDeadlineExceeded")
+}
+
+type rwFixture struct {
+	*tempdir.TempDirFixture
+	t       *testing.T
+	kClient *k8s.FakeK8sClient
+	rw      *RolloutWatcher
+	ctx     context.Context
+	cancel  func()
+	store   *store.TestingStore
+	seen    int
+}
+
+func newRWFixture(t *testing.T) *rwFixture {
+	kClient := k8s.NewFakeK8sClient()
+	ctx, _, _ := testutils.CtxAndAnalyticsForTest()
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &rwFixture{
+		TempDirFixture: tempdir.NewTempDirFixture(t),
+		t:              t,
+		kClient:        kClient,
+		rw:             NewRolloutWatcher(k8s.NewFakeContextClients(ctx, kClient)),
+		ctx:            ctx,
+		cancel:         cancel,
+		store:          store.NewTestingStore(),
+	}
+}
+
+func (f *rwFixture) TearDown() {
+	f.kClient.TearDown()
+	f.cancel()
+	f.TempDirFixture.TearDown()
+	f.store.AssertNoErrorActions(f.t)
+}
+
+func (f *rwFixture) addManifest(mn model.ManifestName) model.Manifest {
+	state := f.store.LockMutableStateForTesting()
+	defer f.store.UnlockMutableState()
+
+	m := manifestbuilder.New(f, mn).
+		WithK8sYAML(testyaml.SanchoYAML).
+		Build()
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+	return m
+}
+
+func (f *rwFixture) addDeployedUID(m model.Manifest, uid types.UID) {
+	defer f.rw.OnChange(f.ctx, f.store, store.LegacyChangeSummary())
+
+	state := f.store.LockMutableStateForTesting()
+	defer f.store.UnlockMutableState()
+	mState, ok := state.ManifestState(m.Name)
+	if !ok {
+		f.t.Fatalf("Unknown manifest: %s", m.Name)
+	}
+	mState.K8sRuntimeState().DeployedUIDSet[uid] = true
+}
+
+func (f *rwFixture) deployment(uid types.UID, progressingReason string) *unstructured.Unstructured {
+	status := "True"
+	if progressingReason == k8s.ProgressDeadlineExceededReason {
+		status = "False"
+	}
+
+	entities, err := k8s.ParseYAMLFromString(fmt.Sprintf(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: sancho
+  namespace: default
+  uid: %s
+  generation: 1
+status:
+  observedGeneration: 1
+  conditions:
+  - type: Progressing
+    status: "%s"
+    reason: %s
+    message: timed out
+`, uid, status, progressingReason))
+	require.NoError(f.t, err)
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(entities[0].Obj)
+	require.NoError(f.t, err)
+	return &unstructured.Unstructured{Object: content}
+}
+
+func (f *rwFixture) waitForWatch() {
+	require.Eventually(f.t, func() bool {
+		return f.kClient.ObjectWatchCount() == len(rolloutGVKs)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (f *rwFixture) rolloutActions() []RolloutStatusAction {
+	var result []RolloutStatusAction
+	for _, a := range f.store.Actions() {
+		if rsa, ok := a.(RolloutStatusAction); ok {
+			result = append(result, rsa)
+		}
+	}
+	return result
+}
+
+func (f *rwFixture) assertNextAction() RolloutStatusAction {
+	var actions []RolloutStatusAction
+	require.Eventually(f.t, func() bool {
+		actions = f.rolloutActions()
+		return len(actions) > f.seen
+	}, time.Second, 10*time.Millisecond)
+
+	action := actions[f.seen]
+	f.seen++
+	return action
+}
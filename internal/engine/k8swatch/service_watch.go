@@ -15,20 +15,24 @@ import (
 )
 
 type ServiceWatcher struct {
-	kCli         k8s.Client
-	ownerFetcher k8s.OwnerFetcher
+	clients *k8s.ContextClients
 
 	mu                sync.RWMutex
 	watcherKnownState watcherKnownState
-	knownServices     map[types.UID]*v1.Service
+	knownServices     map[types.UID]knownService
 }
 
-func NewServiceWatcher(kCli k8s.Client, ownerFetcher k8s.OwnerFetcher, cfgNS k8s.Namespace) *ServiceWatcher {
+// A service, and the client for the cluster it's in.
+type knownService struct {
+	service *v1.Service
+	kCli    k8s.Client
+}
+
+func NewServiceWatcher(clients *k8s.ContextClients) *ServiceWatcher {
 	return &ServiceWatcher{
-		kCli:              kCli,
-		ownerFetcher:      ownerFetcher,
-		watcherKnownState: newWatcherKnownState(cfgNS),
-		knownServices:     make(map[types.UID]*v1.Service),
+		clients:           clients,
+		watcherKnownState: newWatcherKnownState(clients),
+		knownServices:     make(map[types.UID]knownService),
 	}
 }
 
@@ -65,18 +69,20 @@ func (w *ServiceWatcher) OnChange(ctx context.Context, st store.RStore, _ store.
 	}
 }
 
-func (w *ServiceWatcher) setupWatch(ctx context.Context, st store.RStore, ns k8s.Namespace) {
-	ch, err := w.kCli.WatchServices(ctx, ns)
+func (w *ServiceWatcher) setupWatch(ctx context.Context, st store.RStore, cns clusterNamespace) {
+	kCli := w.clients.For(cns.kubeContext).Client
+	ch, err := kCli.WatchServices(ctx, cns.namespace)
 	if err != nil {
-		err = errors.Wrapf(err, "Error watching services. Are you connected to kubernetes?\nTry running `kubectl get services -n %q`", ns)
+		err = errors.Wrapf(err, "Error watching services. Are you connected to kubernetes?\nTry running `kubectl get services %s`",
+			w.watcherKnownState.kubectlFlags(cns))
 		st.Dispatch(store.NewErrorAction(err))
 		return
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
-	w.watcherKnownState.namespaceWatches[ns] = namespaceWatch{cancel: cancel}
+	w.watcherKnownState.namespaceWatches[cns] = namespaceWatch{cancel: cancel}
 
-	go w.dispatchServiceChangesLoop(ctx, ch, st)
+	go w.dispatchServiceChangesLoop(ctx, kCli, ch, st)
 }
 
 // When new UIDs are deployed, go through all our known services and dispatch
@@ -87,14 +93,14 @@ func (w *ServiceWatcher) setupNewUIDs(ctx context.Context, st store.RStore, newU
 	for uid, mn := range newUIDs {
 		w.watcherKnownState.knownDeployedUIDs[uid] = mn
 
-		service, ok := w.knownServices[uid]
+		known, ok := w.knownServices[uid]
 		if !ok {
 			continue
 		}
 
-		err := DispatchServiceChange(st, service, mn, w.kCli.NodeIP(ctx))
+		err := DispatchServiceChange(st, known.service, mn, known.kCli.NodeIP(ctx))
 		if err != nil {
-			logger.Get(ctx).Infof("error resolving service url %s: %v", service.Name, err)
+			logger.Get(ctx).Infof("error resolving service url %s: %v", known.service.Name, err)
 		}
 	}
 }
@@ -103,12 +109,12 @@ func (w *ServiceWatcher) setupNewUIDs(ctx context.Context, st store.RStore, newU
 //
 // The division between triageServiceUpdate and recordServiceUpdate is a bit artificial,
 // but is designed this way to be consistent with PodWatcher and EventWatchManager.
-func (w *ServiceWatcher) triageServiceUpdate(service *v1.Service) model.ManifestName {
+func (w *ServiceWatcher) triageServiceUpdate(service *v1.Service, kCli k8s.Client) model.ManifestName {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
 	uid := service.UID
-	w.knownServices[uid] = service
+	w.knownServices[uid] = knownService{service: service, kCli: kCli}
 
 	manifestName, ok := w.watcherKnownState.knownDeployedUIDs[uid]
 	if !ok {
@@ -118,7 +124,7 @@ func (w *ServiceWatcher) triageServiceUpdate(service *v1.Service) model.Manifest
 	return manifestName
 }
 
-func (w *ServiceWatcher) dispatchServiceChangesLoop(ctx context.Context, ch <-chan *v1.Service, st store.RStore) {
+func (w *ServiceWatcher) dispatchServiceChangesLoop(ctx context.Context, kCli k8s.Client, ch <-chan *v1.Service, st store.RStore) {
 	for {
 		select {
 		case service, ok := <-ch:
@@ -126,12 +132,12 @@ func (w *ServiceWatcher) dispatchServiceChangesLoop(ctx context.Context, ch <-ch
 				return
 			}
 
-			manifestName := w.triageServiceUpdate(service)
+			manifestName := w.triageServiceUpdate(service, kCli)
 			if manifestName == "" {
 				continue
 			}
 
-			err := DispatchServiceChange(st, service, manifestName, w.kCli.NodeIP(ctx))
+			err := DispatchServiceChange(st, service, manifestName, kCli.NodeIP(ctx))
 			if err != nil {
 				logger.Get(ctx).Infof("error resolving service url %s: %v", service.Name, err)
 			}
@@ -125,8 +125,7 @@ func newSWFixture(t *testing.T) *swFixture {
 	ctx, _, _ := testutils.CtxAndAnalyticsForTest()
 	ctx, cancel := context.WithCancel(ctx)
 
-	of := k8s.ProvideOwnerFetcher(ctx, kClient)
-	sw := NewServiceWatcher(kClient, of, k8s.DefaultNamespace)
+	sw := NewServiceWatcher(k8s.NewFakeContextClients(ctx, kClient))
 	st := store.NewTestingStore()
 
 	return &swFixture{
@@ -2,6 +2,7 @@ package k8swatch
 
 import (
 	"context"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/types"
 
@@ -12,30 +13,44 @@ import (
 
 // Common utility methods for watching kubernetes resources
 type watcherTaskList struct {
-	watchableNamespaces []k8s.Namespace
-	setupNamespaces     []k8s.Namespace
-	teardownNamespaces  []k8s.Namespace
+	watchableNamespaces []clusterNamespace
+	setupNamespaces     []clusterNamespace
+	teardownNamespaces  []clusterNamespace
 	newUIDs             map[types.UID]model.ManifestName
 }
 
+// A namespace in one of the kubeconfig contexts that we deploy to.
+type clusterNamespace struct {
+	kubeContext k8s.KubeContext
+	namespace   k8s.Namespace
+}
+
 type namespaceWatch struct {
 	cancel context.CancelFunc
 }
 
 type watcherKnownState struct {
-	cfgNS             k8s.Namespace
-	namespaceWatches  map[k8s.Namespace]namespaceWatch
+	clients           *k8s.ContextClients
+	namespaceWatches  map[clusterNamespace]namespaceWatch
 	knownDeployedUIDs map[types.UID]model.ManifestName
 }
 
-func newWatcherKnownState(cfgNS k8s.Namespace) watcherKnownState {
+func newWatcherKnownState(clients *k8s.ContextClients) watcherKnownState {
 	return watcherKnownState{
-		cfgNS:             cfgNS,
-		namespaceWatches:  make(map[k8s.Namespace]namespaceWatch),
+		clients:           clients,
+		namespaceWatches:  make(map[clusterNamespace]namespaceWatch),
 		knownDeployedUIDs: make(map[types.UID]model.ManifestName),
 	}
 }
 
+// The flags to pass to kubectl to look at a namespace, for error messages.
+func (ks *watcherKnownState) kubectlFlags(cns clusterNamespace) string {
+	if cns.kubeContext == ks.clients.DefaultContext() {
+		return fmt.Sprintf("-n %q", cns.namespace)
+	}
+	return fmt.Sprintf("--context %q -n %q", cns.kubeContext, cns.namespace)
+}
+
 // Diff the contents of the engine state against the deployed UIDs that the
 // watcher already knows about, and create a task list of things to do.
 //
@@ -43,23 +58,25 @@ func newWatcherKnownState(cfgNS k8s.Namespace) watcherKnownState {
 func (ks *watcherKnownState) createTaskList(state store.EngineState) watcherTaskList {
 	newUIDs := make(map[types.UID]model.ManifestName)
 	seenUIDs := make(map[types.UID]bool)
-	namespaces := make(map[k8s.Namespace]bool)
+	namespaces := make(map[clusterNamespace]bool)
 	for _, mt := range state.Targets() {
 		if !mt.Manifest.IsK8s() {
 			continue
 		}
 
 		name := mt.Manifest.Name
+		kTarget := mt.Manifest.K8sTarget()
+		cc := ks.clients.For(k8s.KubeContext(kTarget.KubeContext))
 
-		for _, obj := range mt.Manifest.K8sTarget().ObjectRefs {
+		for _, obj := range kTarget.ObjectRefs {
 			namespace := k8s.Namespace(obj.Namespace)
 			if namespace == "" {
-				namespace = ks.cfgNS
+				namespace = cc.ConfigNamespace
 			}
 			if namespace == "" {
 				namespace = k8s.DefaultNamespace
 			}
-			namespaces[namespace] = true
+			namespaces[clusterNamespace{kubeContext: cc.Context, namespace: namespace}] = true
 		}
 
 		// Collect all the new UIDs
@@ -92,9 +109,9 @@ func (ks *watcherKnownState) createTaskList(state store.EngineState) watcherTask
 		}
 	}
 
-	var watchableNamespaces []k8s.Namespace
-	var setupNamespaces []k8s.Namespace
-	var teardownNamespaces []k8s.Namespace
+	var watchableNamespaces []clusterNamespace
+	var setupNamespaces []clusterNamespace
+	var teardownNamespaces []clusterNamespace
 
 	for needed := range namespaces {
 		watchableNamespaces = append(watchableNamespaces, needed)
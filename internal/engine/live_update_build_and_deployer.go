@@ -256,6 +256,14 @@ func (lubad *LiveUpdateBuildAndDeployer) containerUpdaterForSpecs(specs []model.
 		return lubad.ecu
 	}
 
+	// The local Docker daemon can only reach containers in the cluster that
+	// Tilt started with, so other clusters always get updated with exec.
+	for _, kTarget := range model.ExtractK8sTargets(specs) {
+		if kTarget.KubeContext != "" {
+			return lubad.ecu
+		}
+	}
+
 	if lubad.runtime == container.RuntimeDocker && lubad.env.UsesLocalDockerRegistry() {
 		return lubad.dcu
 	}
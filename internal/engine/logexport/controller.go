@@ -0,0 +1,226 @@
+package logexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/tilt-dev/tilt/internal/build"
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+	"github.com/tilt-dev/tilt/pkg/model/logstore"
+	"github.com/tilt-dev/tilt/pkg/webview"
+)
+
+// How much log text we send in one request.
+const exportMaxBytes = 1000 * 1000
+
+// How long we wait before trying again after an export fails.
+const exportRetryDelay = 10 * time.Second
+
+const exportTimeout = 10 * time.Second
+
+// Sends Tilt's logs to an OpenTelemetry collector, as they come in.
+//
+// Each log segment becomes a log record, with the manifest name, span ID,
+// and source as attributes, so that a log viewer can line up service
+// logs with Tilt's builds.
+type Controller struct {
+	clock     build.Clock
+	client    *http.Client
+	tiltBuild model.TiltBuild
+
+	// The first log that we haven't exported yet.
+	checkpoint logstore.Checkpoint
+	retryAt    time.Time
+}
+
+var _ store.Subscriber = &Controller{}
+
+func NewController(clock build.Clock, tiltBuild model.TiltBuild) *Controller {
+	return &Controller{
+		clock:     clock,
+		client:    &http.Client{Timeout: exportTimeout},
+		tiltBuild: tiltBuild,
+	}
+}
+
+func (c *Controller) OnChange(ctx context.Context, st store.RStore, _ store.ChangeSummary) {
+	state := st.RLockState()
+	settings := state.LogExportSettings
+	st.RUnlockState()
+
+	if !settings.Enabled || c.clock.Now().Before(c.retryAt) {
+		return
+	}
+
+	for {
+		// Reading older logs can go to the archive on disk, so read
+		// from a snapshot after we let go of the state.
+		state := st.RLockState()
+		to := state.LogStore.Checkpoint()
+		snap := state.LogStore.Snapshot(c.checkpoint, exportMaxBytes)
+		st.RUnlockState()
+
+		list, err := snap.ToLogListRange(c.checkpoint, to, exportMaxBytes)
+		if err != nil {
+			logger.Get(ctx).Debugf("Reading logs to export: %v", err)
+			return
+		}
+
+		if list.FromCheckpoint == -1 {
+			// We're caught up.
+			return
+		}
+
+		err = c.export(ctx, settings, list)
+		if err != nil {
+			// Keep the checkpoint where it is, so that we send these logs again later.
+			logger.Get(ctx).Debugf("Exporting logs: %v", err)
+			c.retryAt = c.clock.Now().Add(exportRetryDelay)
+			return
+		}
+		c.checkpoint = logstore.Checkpoint(list.ToCheckpoint)
+	}
+}
+
+func (c *Controller) export(ctx context.Context, settings model.LogExportSettings, list *webview.LogList) error {
+	req := c.toRequest(list)
+	if len(req.ResourceLogs[0].ScopeLogs[0].LogRecords) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	scheme := "https"
+	if settings.Insecure {
+		scheme = "http"
+	}
+	url := fmt.Sprintf("%s://%s/v1/logs", scheme, settings.Address)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("POST %s: %s: %s", url, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+func (c *Controller) toRequest(list *webview.LogList) otlpLogsRequest {
+	records := make([]otlpLogRecord, 0, len(list.Segments))
+	for _, seg := range list.Segments {
+		text := strings.TrimSuffix(seg.Text, "\n")
+		if text == "" {
+			continue
+		}
+
+		ts := time.Now()
+		if seg.Time != nil {
+			t, err := ptypes.Timestamp(seg.Time)
+			if err == nil {
+				ts = t
+			}
+		}
+
+		mn := ""
+		if span, ok := list.Spans[seg.SpanId]; ok {
+			mn = span.ManifestName
+		}
+
+		level := logger.LevelFromProtoID(int32(seg.Level))
+		severity, severityText := severityForLevel(level)
+		attrs := []otlpKeyValue{
+			stringAttribute("tilt.manifest_name", mn),
+			stringAttribute("tilt.span_id", seg.SpanId),
+			stringAttribute("tilt.log_source", string(logstore.SourceForSpanID(logstore.SpanID(seg.SpanId)))),
+		}
+		attrs = append(attrs, fieldAttributes(seg.Fields)...)
+
+		records = append(records, otlpLogRecord{
+			TimeUnixNano:   strconv.FormatInt(ts.UnixNano(), 10),
+			SeverityNumber: severity,
+			SeverityText:   severityText,
+			Body:           otlpAnyValue{StringValue: text},
+			Attributes:     attrs,
+		})
+	}
+
+	return otlpLogsRequest{
+		ResourceLogs: []otlpResourceLogs{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpKeyValue{
+						stringAttribute("service.name", "tilt"),
+						stringAttribute("service.version", c.tiltBuild.Version),
+					},
+				},
+				ScopeLogs: []otlpScopeLogs{
+					{
+						Scope:      otlpScope{Name: "tilt.dev/logs"},
+						LogRecords: records,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Fields that a log was written with, like the fields of a JSON log line.
+// Tilt's own bookkeeping fields are left out.
+func fieldAttributes(fields map[string]string) []otlpKeyValue {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		switch key {
+		case logger.FieldNameProgressID, logger.FieldNameProgressMustPrint, logger.FieldNameBuildEvent, logger.FieldNameRaw:
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]otlpKeyValue, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, stringAttribute(key, fields[key]))
+	}
+	return attrs
+}
+
+func severityForLevel(level logger.Level) (int, string) {
+	switch level {
+	case logger.ErrorLvl:
+		return severityError, "ERROR"
+	case logger.WarnLvl:
+		return severityWarn, "WARN"
+	case logger.VerboseLvl:
+		return severityDebug4, "DEBUG4"
+	case logger.DebugLvl:
+		return severityDebug, "DEBUG"
+	}
+	return severityInfo, "INFO"
+}
@@ -0,0 +1,193 @@
+package logexport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+	"github.com/tilt-dev/tilt/pkg/model/logstore"
+)
+
+func TestExportLogs(t *testing.T) {
+	f := newFixture(t)
+	f.enable()
+
+	f.log("fe", "build:1", logger.InfoLvl, nil, "building fe\n")
+	f.log("fe", "pod:fe-123", logger.ErrorLvl, logger.Fields{"port": "8080", logger.FieldNameRaw: "{}"}, "connection refused\n")
+	f.onChange()
+
+	records := f.records()
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "building fe", records[0].Body.StringValue)
+	assert.Equal(t, severityInfo, records[0].SeverityNumber)
+	assert.Equal(t, []otlpKeyValue{
+		stringAttribute("tilt.manifest_name", "fe"),
+		stringAttribute("tilt.span_id", "build:1"),
+		stringAttribute("tilt.log_source", "build"),
+	}, records[0].Attributes)
+
+	assert.Equal(t, "connection refused", records[1].Body.StringValue)
+	assert.Equal(t, severityError, records[1].SeverityNumber)
+	assert.Equal(t, "ERROR", records[1].SeverityText)
+	assert.Equal(t, []otlpKeyValue{
+		stringAttribute("tilt.manifest_name", "fe"),
+		stringAttribute("tilt.span_id", "pod:fe-123"),
+		stringAttribute("tilt.log_source", "pod"),
+		stringAttribute("port", "8080"),
+	}, records[1].Attributes)
+
+	// Only new logs are sent the next time.
+	f.log("fe", "pod:fe-123", logger.InfoLvl, nil, "listening\n")
+	f.onChange()
+
+	records = f.records()
+	require.Len(t, records, 3)
+	assert.Equal(t, "listening", records[2].Body.StringValue)
+}
+
+func TestExportLogsDisabled(t *testing.T) {
+	f := newFixture(t)
+
+	f.log("fe", "build:1", logger.InfoLvl, nil, "building fe\n")
+	f.onChange()
+
+	assert.Empty(t, f.records())
+}
+
+func TestExportLogsRetriesAfterFailure(t *testing.T) {
+	f := newFixture(t)
+	f.enable()
+	f.setStatus(http.StatusServiceUnavailable)
+
+	f.log("fe", "build:1", logger.InfoLvl, nil, "building fe\n")
+	f.onChange()
+	assert.Empty(t, f.records())
+
+	// Wait before trying again.
+	f.setStatus(http.StatusOK)
+	f.onChange()
+	assert.Empty(t, f.records())
+
+	f.clock.now = f.clock.now.Add(exportRetryDelay)
+	f.onChange()
+	records := f.records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "building fe", records[0].Body.StringValue)
+}
+
+type fixture struct {
+	t     *testing.T
+	ctx   context.Context
+	st    *store.TestingStore
+	clock *fakeClock
+	c     *Controller
+	addr  string
+
+	mu       sync.Mutex
+	status   int
+	requests []otlpLogsRequest
+}
+
+func newFixture(t *testing.T) *fixture {
+	l := logger.NewLogger(logger.DebugLvl, os.Stdout)
+	ctx := logger.WithLogger(context.Background(), l)
+
+	st := store.NewTestingStore()
+	st.SetState(*store.NewState())
+
+	clock := &fakeClock{now: time.Unix(1551202573, 0)}
+	f := &fixture{
+		t:      t,
+		ctx:    ctx,
+		st:     st,
+		clock:  clock,
+		c:      NewController(clock, model.TiltBuild{Version: "0.0.1"}),
+		status: http.StatusOK,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	f.addr = u.Host
+
+	return f
+}
+
+func (f *fixture) handle(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, "/v1/logs", r.URL.Path)
+	assert.Equal(f.t, "application/json", r.Header.Get("Content-Type"))
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.status != http.StatusOK {
+		w.WriteHeader(f.status)
+		return
+	}
+
+	var req otlpLogsRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	assert.NoError(f.t, err)
+	f.requests = append(f.requests, req)
+}
+
+func (f *fixture) setStatus(status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = status
+}
+
+func (f *fixture) enable() {
+	f.st.WithState(func(state *store.EngineState) {
+		state.LogExportSettings = model.LogExportSettings{
+			Enabled:  true,
+			Address:  f.addr,
+			Insecure: true,
+		}
+	})
+}
+
+func (f *fixture) log(mn model.ManifestName, spanID logstore.SpanID, level logger.Level, fields logger.Fields, msg string) {
+	f.st.WithState(func(state *store.EngineState) {
+		state.LogStore.Append(store.NewLogAction(mn, spanID, level, fields, []byte(msg)), nil)
+	})
+}
+
+func (f *fixture) onChange() {
+	f.c.OnChange(f.ctx, f.st, store.LegacyChangeSummary())
+}
+
+func (f *fixture) records() []otlpLogRecord {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var records []otlpLogRecord
+	for _, req := range f.requests {
+		for _, rl := range req.ResourceLogs {
+			assert.Contains(f.t, rl.Resource.Attributes, stringAttribute("service.name", "tilt"))
+			for _, sl := range rl.ScopeLogs {
+				records = append(records, sl.LogRecords...)
+			}
+		}
+	}
+	return records
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
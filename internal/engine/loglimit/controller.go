@@ -0,0 +1,58 @@
+package loglimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tilt-dev/tilt/internal/store"
+)
+
+// Makes sure that rate-limited resources say how many lines they dropped.
+//
+// The log store adds the message for a burst of dropped lines when anything
+// logs after the burst's one-second window. If everything goes quiet after
+// a burst, nothing would, so whenever the store has dropped lines waiting
+// for a message, we set a timer for the end of their window.
+type Controller struct {
+	mu sync.Mutex
+
+	// When the timer we've set fires. Zero if there's no timer.
+	timerAt time.Time
+}
+
+var _ store.Subscriber = &Controller{}
+
+func NewController() *Controller {
+	return &Controller{}
+}
+
+func (c *Controller) OnChange(ctx context.Context, st store.RStore, _ store.ChangeSummary) {
+	state := st.RLockState()
+	deadline, ok := state.LogStore.DroppedLogsDeadline()
+	st.RUnlockState()
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.timerAt.IsZero() && !deadline.Before(c.timerAt) {
+		// We'll check again when the timer we already have fires.
+		return
+	}
+
+	c.timerAt = deadline
+	time.AfterFunc(time.Until(deadline), func() {
+		c.mu.Lock()
+		if c.timerAt.Equal(deadline) {
+			c.timerAt = time.Time{}
+		}
+		c.mu.Unlock()
+
+		if ctx.Err() != nil {
+			return
+		}
+		st.Dispatch(store.FlushDroppedLogsAction{Time: deadline})
+	})
+}
@@ -0,0 +1,40 @@
+package loglimit
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+func TestFlushesDroppedLogsWhenWindowCloses(t *testing.T) {
+	st := store.NewTestingStore()
+	state := st.LockMutableStateForTesting()
+	state.LogStore.SetLimits(map[model.ManifestName]model.LogLimit{"noisy": {LinesPerSecond: 1}})
+	start := time.Now().Add(-time.Second)
+	for i := 0; i < 3; i++ {
+		state.LogStore.Append(store.NewLogActionWithTime("noisy", "pod:noisy", logger.InfoLvl, nil, []byte("noisy\n"), start), nil)
+	}
+	st.UnlockMutableState()
+
+	c := NewController()
+	c.OnChange(context.Background(), st, store.ChangeSummary{})
+
+	action := st.WaitForAction(t, reflect.TypeOf(store.FlushDroppedLogsAction{}))
+	assert.Equal(t, start.Add(time.Second), action.(store.FlushDroppedLogsAction).Time)
+}
+
+func TestNoTimerWithoutDroppedLogs(t *testing.T) {
+	st := store.NewTestingStore()
+	c := NewController()
+	c.OnChange(context.Background(), st, store.ChangeSummary{})
+
+	assert.True(t, c.timerAt.IsZero())
+	assert.Empty(t, st.Actions())
+}
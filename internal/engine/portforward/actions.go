@@ -0,0 +1,31 @@
+package portforward
+
+import (
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+type PortForwardStatusAction struct {
+	ManifestName model.ManifestName
+	Status       store.PortForwardStatus
+
+	// True if the port-forward has shut down, and its status should be removed.
+	Stopped bool
+}
+
+func (PortForwardStatusAction) Action() {}
+
+func NewPortForwardStatusAction(mn model.ManifestName, status store.PortForwardStatus) PortForwardStatusAction {
+	return PortForwardStatusAction{
+		ManifestName: mn,
+		Status:       status,
+	}
+}
+
+func NewPortForwardStoppedAction(mn model.ManifestName, status store.PortForwardStatus) PortForwardStatusAction {
+	return PortForwardStatusAction{
+		ManifestName: mn,
+		Status:       status,
+		Stopped:      true,
+	}
+}
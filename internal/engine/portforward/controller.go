@@ -17,15 +17,21 @@ import (
 )
 
 type Controller struct {
-	kClient k8s.Client
+	clients *k8s.ContextClients
 
-	activeForwards map[k8s.PodID]portForwardEntry
+	activeForwards       map[podForwardKey]portForwardEntry
+	activeTargetForwards map[targetForwardKey]targetForwardEntry
+
+	// How often to check for changes to the pods behind a port-forward target.
+	targetResolveInterval time.Duration
 }
 
-func NewController(kClient k8s.Client) *Controller {
+func NewController(clients *k8s.ContextClients) *Controller {
 	return &Controller{
-		kClient:        kClient,
-		activeForwards: make(map[k8s.PodID]portForwardEntry),
+		clients:               clients,
+		activeForwards:        make(map[podForwardKey]portForwardEntry),
+		activeTargetForwards:  make(map[targetForwardKey]targetForwardEntry),
+		targetResolveInterval: time.Second,
 	}
 }
 
@@ -35,7 +41,7 @@ func (m *Controller) diff(ctx context.Context, st store.RStore) (toStart []portF
 	state := st.RLockState()
 	defer st.RUnlockState()
 
-	statePods := make(map[k8s.PodID]bool, len(state.ManifestTargets))
+	statePods := make(map[podForwardKey]bool, len(state.ManifestTargets))
 
 	// Find all the port-forwards that need to be created.
 	for _, mt := range state.Targets() {
@@ -53,15 +59,19 @@ func (m *Controller) diff(ctx context.Context, st store.RStore) (toStart []portF
 		}
 
 		forwards := populatePortForwards(manifest, pod)
-		if len(forwards) == 0 {
+		reverseForwards := manifest.K8sTarget().ReverseForwards
+		if len(forwards) == 0 && len(reverseForwards) == 0 {
 			continue
 		}
 
-		statePods[podID] = true
+		kubeContext := k8s.KubeContext(manifest.K8sTarget().KubeContext)
+		key := podForwardKey{kubeContext: kubeContext, namespace: pod.Namespace, podID: podID}
+		statePods[key] = true
 
-		oldEntry, isActive := m.activeForwards[podID]
+		oldEntry, isActive := m.activeForwards[key]
 		if isActive {
-			if cmp.Equal(oldEntry.forwards, forwards, cmp.AllowUnexported(model.PortForward{})) {
+			if cmp.Equal(oldEntry.forwards, forwards, cmp.AllowUnexported(model.PortForward{})) &&
+				cmp.Equal(oldEntry.reverseForwards, reverseForwards) {
 				continue
 			}
 			toShutdown = append(toShutdown, oldEntry)
@@ -69,16 +79,18 @@ func (m *Controller) diff(ctx context.Context, st store.RStore) (toStart []portF
 
 		ctx, cancel := context.WithCancel(ctx)
 		entry := portForwardEntry{
-			podID:     podID,
-			name:      ms.Name,
-			namespace: pod.Namespace,
-			forwards:  forwards,
-			ctx:       ctx,
-			cancel:    cancel,
+			podID:           podID,
+			name:            ms.Name,
+			kubeContext:     kubeContext,
+			namespace:       pod.Namespace,
+			forwards:        forwards,
+			reverseForwards: reverseForwards,
+			ctx:             ctx,
+			cancel:          cancel,
 		}
 
 		toStart = append(toStart, entry)
-		m.activeForwards[podID] = entry
+		m.activeForwards[key] = entry
 	}
 
 	// Find all the port-forwards that aren't in the manifest anymore
@@ -96,6 +108,75 @@ func (m *Controller) diff(ctx context.Context, st store.RStore) (toStart []portF
 	return toStart, toShutdown
 }
 
+// Figure out the diff between the port-forwards with targets in the data store
+// and the ones that are currently active.
+func (m *Controller) diffTargets(ctx context.Context, st store.RStore) (toStart []targetForwardEntry, toShutdown []targetForwardEntry) {
+	state := st.RLockState()
+	defer st.RUnlockState()
+
+	stateKeys := make(map[targetForwardKey]bool)
+
+	for _, mt := range state.Targets() {
+		manifest := mt.Manifest
+		if !manifest.IsK8s() {
+			continue
+		}
+
+		kTarget := manifest.K8sTarget()
+		kubeContext := k8s.KubeContext(kTarget.KubeContext)
+		for i, forward := range kTarget.PortForwards {
+			if forward.Target == "" {
+				continue
+			}
+
+			target, err := k8s.ParsePortForwardTarget(forward.Target)
+			if err != nil {
+				// The Tiltfile validates targets, so this should never happen.
+				continue
+			}
+
+			key := targetForwardKey{name: manifest.Name, index: i}
+			stateKeys[key] = true
+
+			namespace := targetNamespace(kTarget, m.clients.For(kubeContext).ConfigNamespace)
+			oldEntry, isActive := m.activeTargetForwards[key]
+			if isActive {
+				if cmp.Equal(oldEntry.forward, forward, cmp.AllowUnexported(model.PortForward{})) &&
+					oldEntry.kubeContext == kubeContext &&
+					oldEntry.namespace == namespace {
+					continue
+				}
+				toShutdown = append(toShutdown, oldEntry)
+			}
+
+			ctx, cancel := context.WithCancel(ctx)
+			entry := targetForwardEntry{
+				name:        manifest.Name,
+				kubeContext: kubeContext,
+				namespace:   namespace,
+				forward:     forward,
+				target:      target,
+				ctx:         ctx,
+				cancel:      cancel,
+			}
+
+			toStart = append(toStart, entry)
+			m.activeTargetForwards[key] = entry
+		}
+	}
+
+	for key, value := range m.activeTargetForwards {
+		if stateKeys[key] {
+			continue
+		}
+
+		toShutdown = append(toShutdown, value)
+		delete(m.activeTargetForwards, key)
+	}
+
+	return toStart, toShutdown
+}
+
 func (m *Controller) OnChange(ctx context.Context, st store.RStore, _ store.ChangeSummary) {
 	toStart, toShutdown := m.diff(ctx, st)
 	for _, entry := range toShutdown {
@@ -115,10 +196,49 @@ func (m *Controller) OnChange(ctx context.Context, st store.RStore, _ store.Chan
 			forward := forward
 			go m.startPortForwardLoop(ctx, entry, forward)
 		}
+
+		for _, rf := range entry.reverseForwards {
+			entry := entry
+			rf := rf
+			go m.startReverseForwardLoop(ctx, entry, rf)
+		}
+	}
+
+	toStartTargets, toShutdownTargets := m.diffTargets(ctx, st)
+	for _, entry := range toShutdownTargets {
+		entry.cancel()
+	}
+
+	for _, entry := range toStartTargets {
+		ctx := logger.CtxWithLogHandler(entry.ctx, spanLogActionWriter{
+			Store:        st,
+			SpanID:       entry.spanID(),
+			ManifestName: entry.name,
+		})
+		go m.startTargetForwardLoop(ctx, st, entry)
 	}
 }
 
 func (m *Controller) startPortForwardLoop(ctx context.Context, entry portForwardEntry, forward model.PortForward) {
+	m.retryWithBackoff(ctx, entry.name, func() error {
+		return m.onePortForward(ctx, entry, forward)
+	})
+}
+
+// Tunnels connections from the relay pod to the local port.
+func (m *Controller) startReverseForwardLoop(ctx context.Context, entry portForwardEntry, rf model.ReverseForward) {
+	m.retryWithBackoff(ctx, entry.name, func() error {
+		kCli := m.clients.For(entry.kubeContext).Client
+		pf, err := kCli.CreateReverseForwarder(ctx, entry.namespace, entry.podID, rf.LocalPort, k8s.ReverseForwardTunnelPort)
+		if err != nil {
+			return err
+		}
+		return pf.ForwardPorts()
+	})
+}
+
+// Runs the port-forward until the context is canceled, reconnecting whenever it fails.
+func (m *Controller) retryWithBackoff(ctx context.Context, name model.ManifestName, run func() error) {
 	originalBackoff := wait.Backoff{
 		Steps:    1000,
 		Duration: 50 * time.Millisecond,
@@ -130,7 +250,7 @@ func (m *Controller) startPortForwardLoop(ctx context.Context, entry portForward
 
 	for {
 		start := time.Now()
-		err := m.onePortForward(ctx, entry, forward)
+		err := run()
 		if ctx.Err() != nil {
 			// If the context was canceled, we're satisfied.
 			// Ignore any errors.
@@ -139,7 +259,7 @@ func (m *Controller) startPortForwardLoop(ctx context.Context, entry portForward
 
 		// Otherwise, repeat the loop, maybe logging the error
 		if err != nil {
-			logger.Get(ctx).Infof("Reconnecting... Error port-forwarding %s: %v", entry.name, err)
+			logger.Get(ctx).Infof("Reconnecting... Error port-forwarding %s: %v", name, err)
 		}
 
 		// If this failed in less than a second, then we should advance the backoff.
@@ -156,7 +276,8 @@ func (m *Controller) onePortForward(ctx context.Context, entry portForwardEntry,
 	ns := entry.namespace
 	podID := entry.podID
 
-	pf, err := m.kClient.CreatePortForwarder(ctx, ns, podID, forward.LocalPort, forward.ContainerPort, forward.Host)
+	kCli := m.clients.For(entry.kubeContext).Client
+	pf, err := kCli.CreatePortForwarder(ctx, ns, podID, forward.LocalPort, forward.ContainerPort, forward.Host)
 	if err != nil {
 		return err
 	}
@@ -170,13 +291,25 @@ func (m *Controller) onePortForward(ctx context.Context, entry portForwardEntry,
 
 var _ store.Subscriber = &Controller{}
 
+// Pod names are only unique within a namespace of a single cluster,
+// so two resources in different contexts may have pods with the same ID.
+type podForwardKey struct {
+	kubeContext k8s.KubeContext
+	namespace   k8s.Namespace
+	podID       k8s.PodID
+}
+
 type portForwardEntry struct {
-	name      model.ManifestName
-	namespace k8s.Namespace
-	podID     k8s.PodID
-	forwards  []model.PortForward
-	ctx       context.Context
-	cancel    func()
+	name        model.ManifestName
+	kubeContext k8s.KubeContext
+	namespace   k8s.Namespace
+	podID       k8s.PodID
+	forwards    []model.PortForward
+	ctx         context.Context
+	cancel      func()
+
+	// Tunnels from the pod back to the local machine, if this is a relay pod.
+	reverseForwards []model.ReverseForward
 }
 
 // Extract the port-forward specs from the manifest. If any of them
@@ -184,7 +317,7 @@ type portForwardEntry struct {
 // Quietly drop forwards that we can't populate.
 func populatePortForwards(m model.Manifest, pod store.Pod) []model.PortForward {
 	cPorts := pod.AllContainerPorts()
-	fwds := m.K8sTarget().PortForwards
+	fwds := podPortForwards(m)
 	forwards := make([]model.PortForward, 0, len(fwds))
 	for _, forward := range fwds {
 		if forward.ContainerPort == 0 {
@@ -205,8 +338,20 @@ func populatePortForwards(m model.Manifest, pod store.Pod) []model.PortForward {
 	return forwards
 }
 
+// The port-forward specs that connect to the resource's most recent pod,
+// rather than to a target.
+func podPortForwards(m model.Manifest) []model.PortForward {
+	var result []model.PortForward
+	for _, forward := range m.K8sTarget().PortForwards {
+		if forward.Target == "" {
+			result = append(result, forward)
+		}
+	}
+	return result
+}
+
 func PortForwardsAreValid(m model.Manifest, pod store.Pod) bool {
-	expectedFwds := m.K8sTarget().PortForwards
+	expectedFwds := podPortForwards(m)
 	actualFwds := populatePortForwards(m, pod)
 	return len(actualFwds) == len(expectedFwds)
 }
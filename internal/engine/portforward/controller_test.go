@@ -8,7 +8,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/tilt-dev/tilt/internal/k8s"
 	"github.com/tilt-dev/tilt/internal/store"
@@ -73,6 +77,108 @@ func TestPortForward(t *testing.T) {
 		"Expected first port-forward to be canceled")
 }
 
+func TestPortForwardOtherContext(t *testing.T) {
+	f := newPLCFixture(t)
+	defer f.TearDown()
+
+	state := f.st.LockMutableStateForTesting()
+	m := model.Manifest{
+		Name: "fe",
+	}
+	m = m.WithDeployTarget(model.K8sTarget{
+		KubeContext: "data",
+		PortForwards: []model.PortForward{
+			{
+				LocalPort:     8080,
+				ContainerPort: 8081,
+			},
+		},
+	})
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+
+	mt := state.ManifestTargets["fe"]
+	mt.State.RuntimeState = store.NewK8sRuntimeStateWithPods(mt.Manifest,
+		store.Pod{PodID: "pod-id", Phase: v1.PodRunning})
+	f.st.UnlockMutableState()
+
+	f.onChange()
+	assert.Equal(t, 1, len(f.plc.activeForwards))
+
+	dataCli := f.clients.For("data").Client.(*k8s.FakeK8sClient)
+	assert.Equal(t, "pod-id", dataCli.LastForwardPortPodID.String())
+	assert.Equal(t, "", f.kCli.LastForwardPortPodID.String())
+}
+
+func TestPortForwardSamePodIDInTwoContexts(t *testing.T) {
+	f := newPLCFixture(t)
+	defer f.TearDown()
+
+	state := f.st.LockMutableStateForTesting()
+	for _, kubeContext := range []string{"", "data"} {
+		m := model.Manifest{
+			Name: model.ManifestName(fmt.Sprintf("fe-%s", kubeContext)),
+		}
+		m = m.WithDeployTarget(model.K8sTarget{
+			KubeContext: kubeContext,
+			PortForwards: []model.PortForward{
+				{
+					LocalPort:     8080,
+					ContainerPort: 8081,
+				},
+			},
+		})
+		state.UpsertManifestTarget(store.NewManifestTarget(m))
+
+		mt := state.ManifestTargets[m.Name]
+		mt.State.RuntimeState = store.NewK8sRuntimeStateWithPods(mt.Manifest,
+			store.Pod{PodID: "pod-id", Namespace: "default", Phase: v1.PodRunning})
+	}
+	f.st.UnlockMutableState()
+
+	f.onChange()
+	assert.Equal(t, 2, len(f.plc.activeForwards))
+
+	dataCli := f.clients.For("data").Client.(*k8s.FakeK8sClient)
+	assert.Equal(t, "pod-id", dataCli.LastForwardPortPodID.String())
+	assert.Equal(t, "pod-id", f.kCli.LastForwardPortPodID.String())
+}
+
+func TestReverseForward(t *testing.T) {
+	f := newPLCFixture(t)
+	defer f.TearDown()
+
+	state := f.st.LockMutableStateForTesting()
+	m := model.Manifest{
+		Name: "db-tunnel",
+	}
+	m = m.WithDeployTarget(model.K8sTarget{
+		ReverseForwards: []model.ReverseForward{
+			{
+				LocalPort:   5432,
+				ServiceName: "db-tunnel",
+				ServicePort: 5432,
+			},
+		},
+	})
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+
+	mt := state.ManifestTargets["db-tunnel"]
+	mt.State.RuntimeState = store.NewK8sRuntimeStateWithPods(mt.Manifest,
+		store.Pod{PodID: "relay-pod", Phase: v1.PodRunning})
+	f.st.UnlockMutableState()
+
+	f.onChange()
+	assert.Equal(t, 1, len(f.plc.activeForwards))
+	assert.Equal(t, 1, f.kCli.CreateReverseForwardCallCount)
+	assert.Equal(t, "relay-pod", f.kCli.LastReverseForwardPodID.String())
+	assert.Equal(t, 5432, f.kCli.LastReverseForwardLocalPort)
+	assert.Equal(t, k8s.ReverseForwardTunnelPort, f.kCli.LastReverseForwardRemotePort)
+
+	// Nothing changed, so we shouldn't reconnect.
+	f.onChange()
+	assert.Equal(t, 1, f.kCli.CreateReverseForwardCallCount)
+}
+
 func TestPortForwardAutoDiscovery(t *testing.T) {
 	f := newPLCFixture(t)
 	defer f.TearDown()
@@ -258,32 +364,167 @@ func TestPopulatePortForward(t *testing.T) {
 	}
 }
 
+func TestPortForwardToService(t *testing.T) {
+	f := newPLCFixture(t)
+	defer f.TearDown()
+
+	f.kCli.EmitService(labels.Everything(), &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "fe", Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{"app": "fe"},
+			Ports:    []v1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+		},
+	})
+	f.kCli.UpsertPod(readyPod("fe-1", "fe"))
+	f.kCli.UpsertPod(readyPod("fe-2", "fe"))
+	f.kCli.UpsertPod(readyPod("be-1", "be"))
+
+	state := f.st.LockMutableStateForTesting()
+	m := model.Manifest{
+		Name: "fe",
+	}
+	m = m.WithDeployTarget(model.K8sTarget{
+		PortForwards: []model.PortForward{
+			{
+				LocalPort:     8000,
+				ContainerPort: 80,
+				Target:        "svc/fe",
+			},
+		},
+	})
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+	f.st.UnlockMutableState()
+
+	f.onChange()
+	assert.Equal(t, 1, len(f.plc.activeTargetForwards))
+	assert.Equal(t, 0, len(f.plc.activeForwards))
+
+	f.assertBalancerPods("default/fe-1", "default/fe-2")
+	assert.Equal(t, 8080, f.kCli.LastForwardPortRemotePort)
+
+	// When a pod goes away, keep the same local port open, and stop sending connections to it.
+	notReady := readyPod("fe-1", "fe")
+	notReady.Status.Conditions = nil
+	f.kCli.UpsertPod(notReady)
+
+	f.assertBalancerPods("default/fe-2")
+	assert.Equal(t, 1, f.kCli.CreatePortForwardCallCount)
+}
+
+func TestPortForwardToSelectorReportsStatus(t *testing.T) {
+	f := newPLCFixture(t)
+	defer f.TearDown()
+
+	f.kCli.UpsertPod(readyPod("fe-1", "fe"))
+
+	state := f.st.LockMutableStateForTesting()
+	m := model.Manifest{
+		Name: "fe",
+	}
+	m = m.WithDeployTarget(model.K8sTarget{
+		PortForwards: []model.PortForward{
+			{
+				LocalPort: 8000,
+				Target:    "app=fe",
+			},
+		},
+	})
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+	f.st.UnlockMutableState()
+
+	f.onChange()
+	f.assertBalancerPods("default/fe-1")
+	assert.Equal(t, 5000, f.kCli.LastForwardPortRemotePort)
+
+	f.kCli.LastBalancer.SetStats(k8s.PortForwardStats{TotalConnections: 3, Errors: 1, LastError: "oh no"})
+
+	expected := store.PortForwardStatus{
+		LocalPort:        8000,
+		Target:           "app=fe",
+		Pods:             1,
+		TotalConnections: 3,
+		Errors:           1,
+		LastError:        "oh no",
+	}
+	require.Eventually(t, func() bool {
+		for _, a := range f.st.Actions() {
+			action, ok := a.(PortForwardStatusAction)
+			if ok && action.Status == expected {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	// Removing the forward from the manifest shuts it down.
+	state = f.st.LockMutableStateForTesting()
+	state.ManifestTargets["fe"].Manifest = m.WithDeployTarget(model.K8sTarget{})
+	f.st.UnlockMutableState()
+
+	f.onChange()
+	assert.Equal(t, 0, len(f.plc.activeTargetForwards))
+	require.Eventually(t, func() bool {
+		for _, a := range f.st.Actions() {
+			action, ok := a.(PortForwardStatusAction)
+			if ok && action.Stopped {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+}
+
+func readyPod(name, app string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"app": app},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "main", Ports: []v1.ContainerPort{{ContainerPort: 5000}}},
+			},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodReady, Status: v1.ConditionTrue},
+			},
+		},
+	}
+}
+
 type plcFixture struct {
 	*tempdir.TempDirFixture
-	ctx    context.Context
-	cancel func()
-	kCli   *k8s.FakeK8sClient
-	st     *store.TestingStore
-	plc    *Controller
-	out    *bufsync.ThreadSafeBuffer
+	ctx     context.Context
+	cancel  func()
+	kCli    *k8s.FakeK8sClient
+	clients *k8s.ContextClients
+	st      *store.TestingStore
+	plc     *Controller
+	out     *bufsync.ThreadSafeBuffer
 }
 
 func newPLCFixture(t *testing.T) *plcFixture {
 	f := tempdir.NewTempDirFixture(t)
 	st := store.NewTestingStore()
 	kCli := k8s.NewFakeK8sClient()
-	plc := NewController(kCli)
 
 	out := bufsync.NewThreadSafeBuffer()
 	l := logger.NewLogger(logger.DebugLvl, out)
 	ctx, cancel := context.WithCancel(context.Background())
 	ctx = logger.WithLogger(ctx, l)
+	clients := k8s.NewFakeContextClients(ctx, kCli)
+	plc := NewController(clients)
+	plc.targetResolveInterval = 5 * time.Millisecond
 	return &plcFixture{
 		TempDirFixture: f,
 		ctx:            ctx,
 		cancel:         cancel,
 		st:             st,
 		kCli:           kCli,
+		clients:        clients,
 		plc:            plc,
 		out:            out,
 	}
@@ -294,6 +535,20 @@ func (f *plcFixture) onChange() {
 	time.Sleep(10 * time.Millisecond)
 }
 
+func (f *plcFixture) assertBalancerPods(expected ...string) {
+	f.T().Helper()
+	require.Eventually(f.T(), func() bool {
+		if f.kCli.LastBalancer == nil {
+			return false
+		}
+		var actual []string
+		for _, pod := range f.kCli.LastBalancer.Pods() {
+			actual = append(actual, pod.String())
+		}
+		return assert.ObjectsAreEqual(expected, actual)
+	}, time.Second, 5*time.Millisecond, "expected balancer pods %v", expected)
+}
+
 func (f *plcFixture) TearDown() {
 	f.kCli.TearDown()
 	f.TempDirFixture.TearDown()
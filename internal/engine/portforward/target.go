@@ -0,0 +1,262 @@
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// A port-forward to a Service, a named pod, or a label selector.
+//
+// Unlike a port-forward to the resource's most recent pod, the local port
+// stays open while the pods behind it come and go.
+type targetForwardEntry struct {
+	name        model.ManifestName
+	kubeContext k8s.KubeContext
+	namespace   k8s.Namespace
+	forward     model.PortForward
+	target      k8s.PortForwardTarget
+	ctx         context.Context
+	cancel      func()
+}
+
+type targetForwardKey struct {
+	name  model.ManifestName
+	index int
+}
+
+func (e targetForwardEntry) spanID() model.LogSpanID {
+	return model.LogSpanID(fmt.Sprintf("portforward:%s", e.name))
+}
+
+// The namespace that the target lives in. We assume it's in the same
+// namespace as the resource's objects.
+func targetNamespace(kTarget model.K8sTarget, defaultNS k8s.Namespace) k8s.Namespace {
+	for _, ref := range kTarget.ObjectRefs {
+		if ref.Namespace != "" {
+			return k8s.Namespace(ref.Namespace)
+		}
+	}
+	return defaultNS
+}
+
+// Keeps the port-forward's pods in sync with the target until the entry is canceled.
+func (m *Controller) startTargetForwardLoop(ctx context.Context, st store.RStore, entry targetForwardEntry) {
+	kCli := m.clients.For(entry.kubeContext).Client
+	status := store.PortForwardStatus{
+		LocalPort: entry.forward.LocalPort,
+		Target:    entry.forward.Target,
+	}
+
+	var pf k8s.PortForwardBalancer
+	cancelPF := func() {}
+	remotePort := 0
+	lastErr := ""
+
+	defer func() {
+		cancelPF()
+		st.Dispatch(NewPortForwardStoppedAction(entry.name, status))
+	}()
+
+	for {
+		pods, port, err := resolveTarget(ctx, kCli, entry.namespace, entry.target, entry.forward.ContainerPort)
+		errMsg := ""
+		if err != nil {
+			errMsg = err.Error()
+			if errMsg != lastErr {
+				logger.Get(ctx).Infof("Error port-forwarding %s to %s: %v", entry.name, entry.forward.Target, err)
+			}
+		}
+		lastErr = errMsg
+
+		if port != 0 && port != remotePort {
+			cancelPF()
+
+			pfCtx, cancel := context.WithCancel(ctx)
+			newPF, err := kCli.CreatePortForwardBalancer(pfCtx, entry.forward.LocalPort, port, entry.forward.Host)
+			if err != nil {
+				cancel()
+				logger.Get(ctx).Infof("Error port-forwarding %s to %s: %v", entry.name, entry.forward.Target, err)
+			} else {
+				pf = newPF
+				cancelPF = cancel
+				remotePort = port
+				go m.retryWithBackoff(pfCtx, entry.name, pf.ForwardPorts)
+			}
+		}
+
+		if pf != nil {
+			pf.SetPods(pods)
+
+			stats := pf.Stats()
+			newStatus := store.PortForwardStatus{
+				LocalPort:         pf.LocalPort(),
+				Target:            entry.forward.Target,
+				Pods:              len(pods),
+				ActiveConnections: stats.ActiveConnections,
+				TotalConnections:  stats.TotalConnections,
+				Errors:            stats.Errors,
+				LastError:         stats.LastError,
+			}
+			if newStatus != status {
+				status = newStatus
+				st.Dispatch(NewPortForwardStatusAction(entry.name, status))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(m.targetResolveInterval):
+		}
+	}
+}
+
+// Finds the ready pods for a target, and the port on those pods to forward to.
+//
+// Returns a remote port of 0 if we can't determine it yet (e.g., because it's
+// a named port and there are no pods to look it up on).
+func resolveTarget(ctx context.Context, kCli k8s.Client, ns k8s.Namespace, target k8s.PortForwardTarget, containerPort int) ([]types.NamespacedName, int, error) {
+	if target.Pod != "" {
+		pod, err := kCli.PodFromInformerCache(ctx, types.NamespacedName{Name: target.Pod.String(), Namespace: ns.String()})
+		if err != nil {
+			return nil, 0, err
+		}
+		pods := readyPods([]*v1.Pod{pod})
+		return podNames(pods), podPort(pods, containerPort), nil
+	}
+
+	if target.Selector != nil {
+		pods, err := kCli.PodsFromInformerCache(ctx, ns, target.Selector)
+		if err != nil {
+			return nil, 0, err
+		}
+		pods = readyPods(pods)
+		return podNames(pods), podPort(pods, containerPort), nil
+	}
+
+	svc, err := kCli.ServiceFromInformerCache(ctx, types.NamespacedName{Name: string(target.Service), Namespace: ns.String()})
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, 0, fmt.Errorf("service %s has no pod selector", svc.Name)
+	}
+
+	pods, err := kCli.PodsFromInformerCache(ctx, ns, labels.SelectorFromSet(svc.Spec.Selector))
+	if err != nil {
+		return nil, 0, err
+	}
+	pods = readyPods(pods)
+
+	port, err := servicePort(svc, pods, containerPort)
+	if err != nil {
+		return nil, 0, err
+	}
+	return podNames(pods), port, nil
+}
+
+// Maps a port on the Service to a port on its pods, like `kubectl port-forward svc/NAME` does.
+// If the port is 0, use the Service's first port.
+func servicePort(svc *v1.Service, pods []*v1.Pod, port int) (int, error) {
+	if len(svc.Spec.Ports) == 0 {
+		return 0, fmt.Errorf("service %s has no ports", svc.Name)
+	}
+
+	sPort := svc.Spec.Ports[0]
+	if port != 0 {
+		found := false
+		for _, p := range svc.Spec.Ports {
+			if int(p.Port) == port {
+				sPort = p
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("service %s does not have port %d", svc.Name, port)
+		}
+	}
+
+	targetPort := sPort.TargetPort
+	if targetPort.Type == intstr.String {
+		for _, pod := range pods {
+			for _, c := range pod.Spec.Containers {
+				for _, cPort := range c.Ports {
+					if cPort.Name == targetPort.StrVal {
+						return int(cPort.ContainerPort), nil
+					}
+				}
+			}
+		}
+		return 0, nil
+	}
+	if targetPort.IntVal == 0 {
+		return int(sPort.Port), nil
+	}
+	return int(targetPort.IntVal), nil
+}
+
+// If the port is 0, use the first container port on the pods.
+func podPort(pods []*v1.Pod, port int) int {
+	if port != 0 {
+		return port
+	}
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			if len(c.Ports) > 0 {
+				return int(c.Ports[0].ContainerPort)
+			}
+		}
+	}
+	return 0
+}
+
+func readyPods(pods []*v1.Pod) []*v1.Pod {
+	result := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == v1.PodReady && c.Status == v1.ConditionTrue {
+				result = append(result, pod)
+				break
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func podNames(pods []*v1.Pod) []types.NamespacedName {
+	result := make([]types.NamespacedName, 0, len(pods))
+	for _, pod := range pods {
+		result = append(result, types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace})
+	}
+	return result
+}
+
+type spanLogActionWriter struct {
+	Store        store.RStore
+	SpanID       model.LogSpanID
+	ManifestName model.ManifestName
+}
+
+func (w spanLogActionWriter) Write(level logger.Level, fields logger.Fields, p []byte) error {
+	w.Store.Dispatch(store.NewLogAction(w.ManifestName, w.SpanID, level, fields, p))
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/tilt-dev/tilt/internal/dockercompose"
@@ -53,7 +52,7 @@ func (m *DockerComposeLogManager) diff(ctx context.Context, st store.RStore) (se
 		}
 
 		existing, isActive := m.watches[manifest.Name]
-		startWatchTime := time.Unix(0, 0)
+		var startWatchTime time.Time
 		if isActive {
 			select {
 			case termTime := <-existing.terminationTime:
@@ -103,32 +102,46 @@ func (m *DockerComposeLogManager) OnChange(ctx context.Context, st store.RStore,
 }
 
 func (m *DockerComposeLogManager) consumeLogs(watch dockerComposeLogWatch, st store.RStore) {
+	lastLogTime := watch.startWatchTime
 	defer func() {
-		watch.terminationTime <- time.Now()
+		watch.terminationTime <- nextWatchTime(watch.startWatchTime, lastLogTime)
 	}()
 
 	name := watch.name
-	readCloser, err := m.dcc.StreamLogs(watch.ctx, watch.dc.ConfigPaths, watch.dc.Name)
-	if err != nil {
-		logger.Get(watch.ctx).Debugf("Error streaming %s logs: %v", name, err)
-		return
+	stdout := &DockerComposeLogActionWriter{
+		store:             st,
+		manifestName:      name,
+		level:             logger.InfoLvl,
+		isStartingNewLine: true,
+		lastLogTime:       &lastLogTime,
 	}
-	defer func() {
-		_ = readCloser.Close()
-	}()
-
-	actionWriter := &DockerComposeLogActionWriter{
+	// Lots of services log everything to stderr, so stderr isn't a warning.
+	// This matches how we treat pod logs. Instead, we tag stderr with a field,
+	// so that views and exporters can still tell the streams apart.
+	stderr := &DockerComposeLogActionWriter{
 		store:             st,
 		manifestName:      name,
+		level:             logger.InfoLvl,
+		fields:            logger.Fields{logger.FieldNameStream: logger.StreamStderr},
 		isStartingNewLine: true,
+		lastLogTime:       &lastLogTime,
 	}
-	_, err = io.Copy(actionWriter, NewHardCancelReader(watch.ctx, readCloser))
+	err := m.dcc.StreamLogs(watch.ctx, watch.dc.ConfigPaths, watch.dc.Name, watch.startWatchTime, stdout, stderr)
 	if err != nil && watch.ctx.Err() == nil {
 		logger.Get(watch.ctx).Debugf("Error streaming %s logs: %v", name, err)
 		return
 	}
 }
 
+// Docker includes logs at exactly the `since` time, so the next watch starts
+// just after the last log we saw.
+func nextWatchTime(startWatchTime, lastLogTime time.Time) time.Time {
+	if lastLogTime.After(startWatchTime) {
+		return lastLogTime.Add(time.Nanosecond)
+	}
+	return startWatchTime
+}
+
 type dockerComposeLogWatch struct {
 	ctx             context.Context
 	cancel          func()
@@ -136,67 +149,83 @@ type dockerComposeLogWatch struct {
 	dc              model.DockerComposeTarget
 	startWatchTime  time.Time
 	terminationTime chan time.Time
-
-	// TODO(maia): do we need to track these? (maybe if we implement with `docker logs <cID>`...)
-	// cID             container.ID
-	// cName           container.Name
 }
 
+// Writes one stream (stdout or stderr) of a docker-compose service's logs
+// to the store.
+//
+// Docker prefixes each line with the time that it was logged. We strip the
+// timestamp, and use it as the time of the log.
 type DockerComposeLogActionWriter struct {
 	store        store.RStore
 	manifestName model.ManifestName
+	level        logger.Level
+
+	// Fields to add to every log, e.g., the stream.
+	fields logger.Fields
 
 	// If the next Write() call is on a new line. True when the writer is first
 	// created, or when the previous line ends with "\n".
 	isStartingNewLine bool
+
+	// The time of the most recent line, shared between the stdout and stderr
+	// writers of a service.
+	lastLogTime *time.Time
 }
 
 var newlineAsBytes = []byte("\n")
-var dividerAsBytes = []byte(" | ")
-var attachingToLogAsBytes = []byte("Attaching to ")
 
 func (w *DockerComposeLogActionWriter) Write(p []byte) (n int, err error) {
-	lines := bytes.Split(p, newlineAsBytes)
-	if w.shouldFilterDCLog(lines) {
-		lines = lines[1:]
+	if len(p) == 0 {
+		return 0, nil
 	}
 
-	start := 1
-	if w.isStartingNewLine {
-		start = 0
-	}
+	var logTime time.Time
+	lines := bytes.SplitAfter(p, newlineAsBytes)
+	for i, line := range lines {
+		if i == 0 && !w.isStartingNewLine {
+			continue
+		}
 
-	for i := start; i < len(lines); i++ {
-		indexOfDivider := bytes.Index(lines[i], dividerAsBytes)
-		if indexOfDivider >= 0 {
-			newStart := indexOfDivider + len(dividerAsBytes)
-			lines[i] = lines[i][newStart:]
+		t, rest, ok := splitLogTimestamp(line)
+		if !ok {
+			continue
+		}
+		lines[i] = rest
+		if logTime.IsZero() {
+			logTime = t
+		}
+		if w.lastLogTime != nil {
+			*w.lastLogTime = t
 		}
 	}
 
-	if len(lines) == 0 {
-		return len(p), nil
+	if logTime.IsZero() {
+		logTime = time.Now()
 	}
 
-	// If the last line is empty, then we're starting a newline.
-	w.isStartingNewLine = len(lines[len(lines)-1]) == 0
-	newText := bytes.Join(lines, newlineAsBytes)
-	w.store.Dispatch(store.NewLogAction(w.manifestName, SpanIDForDCService(w.manifestName), logger.InfoLvl, nil, newText))
+	w.isStartingNewLine = p[len(p)-1] == '\n'
+	newText := bytes.Join(lines, nil)
+	w.store.Dispatch(store.NewLogActionWithTime(w.manifestName, SpanIDForDCService(w.manifestName), w.level, w.fields, newText, logTime))
 	return len(p), nil
 }
 
-var _ store.Subscriber = &DockerComposeLogManager{}
-
-func (w *DockerComposeLogActionWriter) shouldFilterDCLog(lines [][]byte) bool {
-	if !w.isStartingNewLine {
-		return false
+// Splits a line like "2020-07-30T17:22:59.123456789Z hello" into its
+// timestamp and the rest of the line.
+func splitLogTimestamp(line []byte) (time.Time, []byte, bool) {
+	i := bytes.IndexByte(line, ' ')
+	if i <= 0 {
+		return time.Time{}, nil, false
 	}
-	if len(lines) == 0 {
-		return false
+	t, err := time.Parse(time.RFC3339Nano, string(line[:i]))
+	if err != nil {
+		return time.Time{}, nil, false
 	}
-	return bytes.HasPrefix(lines[0], attachingToLogAsBytes)
+	return t, line[i+1:], true
 }
 
+var _ store.Subscriber = &DockerComposeLogManager{}
+
 func SpanIDForDCService(mn model.ManifestName) logstore.SpanID {
 	return logstore.SpanID(fmt.Sprintf("dc:%s", mn))
 }
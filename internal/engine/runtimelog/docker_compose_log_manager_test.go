@@ -2,23 +2,27 @@ package runtimelog
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/logger"
 )
 
 func TestSimpleWriter(t *testing.T) {
 	st := store.NewTestingStore()
-	log := `Attaching to express-redis-docker_app_1, cache
-cache    | # oO0OoO0OoO0Oo Redis is starting oO0OoO0OoO0Oo
-cache    | # Redis version=5.0.7, bits=64, commit=00000000, modified=0, pid=1, just started
+	var lastLogTime time.Time
+	log := `2020-07-30T17:22:59.000000001Z # oO0OoO0OoO0Oo Redis is starting oO0OoO0OoO0Oo
+2020-07-30T17:22:59.000000002Z # Redis version=5.0.7, bits=64, commit=00000000, modified=0, pid=1, just started
 `
 
 	writer := &DockerComposeLogActionWriter{
 		store:             st,
+		level:             logger.InfoLvl,
 		isStartingNewLine: true,
+		lastLogTime:       &lastLogTime,
 	}
 	_, err := writer.Write([]byte(log))
 	require.NoError(t, err)
@@ -29,19 +33,22 @@ cache    | # Redis version=5.0.7, bits=64, commit=00000000, modified=0, pid=1, j
 	expected := `# oO0OoO0OoO0Oo Redis is starting oO0OoO0OoO0Oo
 # Redis version=5.0.7, bits=64, commit=00000000, modified=0, pid=1, just started
 `
-	assert.Equal(t, expected, string(actions[0].(store.LogAction).Message()))
+	action := actions[0].(store.LogAction)
+	assert.Equal(t, expected, string(action.Message()))
+	assert.Equal(t, time.Date(2020, 7, 30, 17, 22, 59, 1, time.UTC), action.Time())
+	assert.Equal(t, time.Date(2020, 7, 30, 17, 22, 59, 2, time.UTC), lastLogTime)
 }
 
 func TestBrokenLine(t *testing.T) {
 	st := store.NewTestingStore()
-	log1 := `Attaching to express-redis-docker_app_1, cache
-cache    | # oO0OoO0`
+	log1 := `2020-07-30T17:22:59.000000001Z # oO0OoO0`
 	log2 := `OoO0Oo Redis is starting oO0OoO0OoO0Oo
-cache    | # Redis version=5.0.7, bits=64, commit=00000000, modified=0, pid=1, just started
+2020-07-30T17:22:59.000000002Z # Redis version=5.0.7, bits=64, commit=00000000, modified=0, pid=1, just started
 `
 
 	writer := &DockerComposeLogActionWriter{
 		store:             st,
+		level:             logger.InfoLvl,
 		isStartingNewLine: true,
 	}
 	_, err := writer.Write([]byte(log1))
@@ -60,3 +67,56 @@ cache    | # Redis version=5.0.7, bits=64, commit=00000000, modified=0, pid=1, j
 `
 	assert.Equal(t, expected2, string(actions[1].(store.LogAction).Message()))
 }
+
+func TestWriterLevel(t *testing.T) {
+	st := store.NewTestingStore()
+	writer := &DockerComposeLogActionWriter{
+		store:             st,
+		level:             logger.WarnLvl,
+		isStartingNewLine: true,
+	}
+	_, err := writer.Write([]byte("2020-07-30T17:22:59Z connection refused\n"))
+	require.NoError(t, err)
+
+	action := st.Actions()[0].(store.LogAction)
+	assert.Equal(t, "connection refused\n", string(action.Message()))
+	assert.Equal(t, logger.WarnLvl, action.Level())
+}
+
+func TestWriterFields(t *testing.T) {
+	st := store.NewTestingStore()
+	writer := &DockerComposeLogActionWriter{
+		store:             st,
+		level:             logger.InfoLvl,
+		fields:            logger.Fields{logger.FieldNameStream: logger.StreamStderr},
+		isStartingNewLine: true,
+	}
+	_, err := writer.Write([]byte("2020-07-30T17:22:59Z connection refused\n"))
+	require.NoError(t, err)
+
+	action := st.Actions()[0].(store.LogAction)
+	assert.Equal(t, logger.InfoLvl, action.Level())
+	assert.Equal(t, logger.StreamStderr, action.Fields()[logger.FieldNameStream])
+}
+
+func TestLineWithoutTimestamp(t *testing.T) {
+	st := store.NewTestingStore()
+	writer := &DockerComposeLogActionWriter{
+		store:             st,
+		level:             logger.InfoLvl,
+		isStartingNewLine: true,
+	}
+	_, err := writer.Write([]byte("hello world\n"))
+	require.NoError(t, err)
+
+	action := st.Actions()[0].(store.LogAction)
+	assert.Equal(t, "hello world\n", string(action.Message()))
+	assert.False(t, action.Time().IsZero())
+}
+
+func TestNextWatchTime(t *testing.T) {
+	start := time.Date(2020, 7, 30, 17, 0, 0, 0, time.UTC)
+	last := time.Date(2020, 7, 30, 17, 22, 59, 0, time.UTC)
+	assert.Equal(t, start, nextWatchTime(start, time.Time{}))
+	assert.Equal(t, last.Add(time.Nanosecond), nextWatchTime(start, last))
+}
@@ -0,0 +1,172 @@
+package runtimelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// Parses JSON log lines from a container, and writes each one to the logger
+// as a readable line, with the level that the service logged it at.
+//
+// The fields of each line are attached to the log, along with the raw JSON,
+// so that nothing is lost when logs are exported.
+//
+// Lines that aren't JSON objects are written as-is.
+type jsonLogWriter struct {
+	logger     logger.Logger
+	levelField string
+	msgField   string
+
+	// A partial line, waiting for the rest of it.
+	buf []byte
+}
+
+func newJSONLogWriter(l logger.Logger, fieldNames map[string]string) *jsonLogWriter {
+	w := &jsonLogWriter{
+		logger:     l,
+		levelField: model.LogFieldLevel,
+		msgField:   model.LogFieldMsg,
+	}
+	if name, ok := fieldNames[model.LogFieldLevel]; ok {
+		w.levelField = name
+	}
+	if name, ok := fieldNames[model.LogFieldMsg]; ok {
+		w.msgField = name
+	}
+	return w
+}
+
+func (w *jsonLogWriter) Write(b []byte) (int, error) {
+	w.buf = append(w.buf, b...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i == -1 {
+			break
+		}
+		w.writeLine(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+	}
+	return len(b), nil
+}
+
+// Writes the partial line at the end of the stream, if there is one.
+func (w *jsonLogWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *jsonLogWriter) writeLine(line []byte) {
+	raw := bytes.TrimRight(line, "\r\n")
+
+	var obj map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	err := decoder.Decode(&obj)
+	if err != nil || obj == nil || decoder.More() {
+		w.logger.Write(logger.InfoLvl, line)
+		return
+	}
+
+	fields := logger.Fields{}
+	for key, val := range obj {
+		if isTiltFieldName(key) {
+			continue
+		}
+		fields[key] = jsonFieldString(val)
+	}
+	fields[logger.FieldNameRaw] = string(raw)
+
+	level := jsonLogLevel(obj[w.levelField])
+	w.logger.WithFields(fields).Write(level, []byte(w.render(obj)+"\n"))
+}
+
+// Renders the message first, followed by the other fields as key=value pairs.
+func (w *jsonLogWriter) render(obj map[string]interface{}) string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		if key == w.levelField || key == w.msgField {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys)+1)
+	if msg, ok := obj[w.msgField]; ok {
+		parts = append(parts, jsonFieldString(msg))
+	}
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", key, quoteIfNeeded(jsonFieldString(obj[key]))))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Maps the level that a service logged at onto a Tilt level.
+//
+// We only pick out warnings and errors. Everything else is shown at the info
+// level, so that Tilt never hides a service's debug logs.
+//
+// Numeric levels follow the bunyan/pino convention (40 is warn, 50 is error).
+func jsonLogLevel(val interface{}) logger.Level {
+	switch val := val.(type) {
+	case string:
+		switch strings.ToLower(val) {
+		case "warn", "warning":
+			return logger.WarnLvl
+		case "error", "err", "fatal", "panic", "critical", "crit", "alert", "emerg", "emergency", "severe":
+			return logger.ErrorLvl
+		}
+	case json.Number:
+		n, err := val.Int64()
+		if err != nil {
+			break
+		}
+		if n >= 50 {
+			return logger.ErrorLvl
+		}
+		if n >= 40 {
+			return logger.WarnLvl
+		}
+	}
+	return logger.InfoLvl
+}
+
+func jsonFieldString(val interface{}) string {
+	switch val := val.(type) {
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return string(b)
+}
+
+func quoteIfNeeded(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// Fields that Tilt uses for its own bookkeeping, which a service's fields
+// shouldn't overwrite.
+func isTiltFieldName(key string) bool {
+	switch key {
+	case logger.FieldNameRaw, logger.FieldNameProgressID, logger.FieldNameProgressMustPrint, logger.FieldNameBuildEvent:
+		return true
+	}
+	return false
+}
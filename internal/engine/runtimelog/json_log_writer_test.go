@@ -0,0 +1,87 @@
+package runtimelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+)
+
+type jsonLogLine struct {
+	level  logger.Level
+	fields logger.Fields
+	text   string
+}
+
+func TestJSONLogWriter(t *testing.T) {
+	lines, w := newTestJSONLogWriter(map[string]string{"level": "severity", "msg": "message"})
+
+	_, _ = w.Write([]byte(`{"severity":"ERROR","message":"connection refused","port":5432,"host":"db"}` + "\n"))
+
+	assert.Equal(t, []jsonLogLine{{
+		level: logger.ErrorLvl,
+		fields: logger.Fields{
+			"severity":          "ERROR",
+			"message":           "connection refused",
+			"port":              "5432",
+			"host":              "db",
+			logger.FieldNameRaw: `{"severity":"ERROR","message":"connection refused","port":5432,"host":"db"}`,
+		},
+		text: "connection refused host=db port=5432\n",
+	}}, *lines)
+}
+
+func TestJSONLogWriterDefaultFieldNames(t *testing.T) {
+	lines, w := newTestJSONLogWriter(nil)
+
+	_, _ = w.Write([]byte(`{"level":"warning","msg":"slow query","query":"SELECT 1","ms":1200.5}` + "\n"))
+
+	assert.Len(t, *lines, 1)
+	assert.Equal(t, logger.WarnLvl, (*lines)[0].level)
+	assert.Equal(t, `slow query ms=1200.5 query="SELECT 1"`+"\n", (*lines)[0].text)
+}
+
+func TestJSONLogWriterPartialAndPlainLines(t *testing.T) {
+	lines, w := newTestJSONLogWriter(nil)
+
+	_, _ = w.Write([]byte(`{"level":30,"msg":"hel`))
+	assert.Len(t, *lines, 0)
+
+	_, _ = w.Write([]byte(`lo"}` + "\nnot json\n[1, 2]\n"))
+	_, _ = w.Write([]byte(`{"level":50,"msg":"no newline"}`))
+	w.Flush()
+
+	assert.Equal(t, []string{"hello\n", "not json\n", "[1, 2]\n", "no newline\n"}, jsonLogLineTexts(*lines))
+	assert.Equal(t, logger.InfoLvl, (*lines)[0].level)
+	assert.Equal(t, logger.InfoLvl, (*lines)[1].level)
+	assert.Nil(t, (*lines)[1].fields)
+	assert.Equal(t, logger.ErrorLvl, (*lines)[3].level)
+}
+
+func TestJSONLogWriterDoesNotOverwriteTiltFields(t *testing.T) {
+	lines, w := newTestJSONLogWriter(nil)
+
+	_, _ = w.Write([]byte(`{"msg":"hi","raw":"mine","progressID":"x"}` + "\n"))
+
+	assert.Len(t, *lines, 1)
+	assert.Equal(t, `{"msg":"hi","raw":"mine","progressID":"x"}`, (*lines)[0].fields[logger.FieldNameRaw])
+	assert.NotContains(t, (*lines)[0].fields, logger.FieldNameProgressID)
+}
+
+func newTestJSONLogWriter(fieldNames map[string]string) (*[]jsonLogLine, *jsonLogWriter) {
+	lines := &[]jsonLogLine{}
+	l := logger.NewFuncLogger(false, logger.DebugLvl, func(level logger.Level, fields logger.Fields, b []byte) error {
+		*lines = append(*lines, jsonLogLine{level: level, fields: fields, text: string(b)})
+		return nil
+	})
+	return lines, newJSONLogWriter(l, fieldNames)
+}
+
+func jsonLogLineTexts(lines []jsonLogLine) []string {
+	result := []string{}
+	for _, l := range lines {
+		result = append(result, l.text)
+	}
+	return result
+}
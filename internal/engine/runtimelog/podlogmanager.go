@@ -12,6 +12,7 @@ import (
 	"github.com/tilt-dev/tilt/internal/container"
 	"github.com/tilt-dev/tilt/internal/k8s"
 	"github.com/tilt-dev/tilt/internal/store"
+	"github.com/tilt-dev/tilt/pkg/apis"
 	"github.com/tilt-dev/tilt/pkg/apis/core/v1alpha1"
 	"github.com/tilt-dev/tilt/pkg/model"
 	"github.com/tilt-dev/tilt/pkg/model/logstore"
@@ -36,11 +37,14 @@ func (m *PodLogManager) diff(ctx context.Context, st store.RStore) (setup []*Pod
 	state := st.RLockState()
 	defer st.RUnlockState()
 
-	current := map[types.NamespacedName]*PodLogStream{}
+	current := map[podLogStreamKey]*PodLogStream{}
 	for _, pls := range state.PodLogStreams {
-		current[types.NamespacedName{Name: pls.Spec.Pod, Namespace: pls.Spec.Namespace}] = pls
+		current[podLogStreamKey{
+			context: pls.Spec.Context,
+			nn:      types.NamespacedName{Name: pls.Spec.Pod, Namespace: pls.Spec.Namespace},
+		}] = pls
 	}
-	seen := map[types.NamespacedName]bool{}
+	seen := map[podLogStreamKey]bool{}
 
 	for _, mt := range state.Targets() {
 		man := mt.Manifest
@@ -51,6 +55,8 @@ func (m *PodLogManager) diff(ctx context.Context, st store.RStore) (setup []*Pod
 			continue
 		}
 
+		k8sTarget := man.K8sTarget()
+		kubeContext := k8sTarget.KubeContext
 		ms := mt.State
 		runtime := ms.K8sRuntimeState()
 		for _, pod := range runtime.PodList() {
@@ -60,19 +66,30 @@ func (m *PodLogManager) diff(ctx context.Context, st store.RStore) (setup []*Pod
 
 			podID := string(pod.PodID)
 			ns := string(pod.Namespace)
-			nn := types.NamespacedName{Name: podID, Namespace: ns}
+			key := podLogStreamKey{
+				context: kubeContext,
+				nn:      types.NamespacedName{Name: podID, Namespace: ns},
+			}
 			spec := PodLogStreamSpec{
 				Pod:       podID,
 				Namespace: ns,
+				Context:   kubeContext,
 				SinceTime: &metav1.Time{Time: state.TiltStartTime},
 				IgnoreContainers: []string{
 					string(IstioInitContainerName),
 					string(IstioSidecarContainerName),
 				},
+				LogFormat: string(k8sTarget.LogFormat),
+				LogFields: k8sTarget.LogFields,
+			}
+			name := fmt.Sprintf("%s-%s", pod.Namespace, pod.PodID)
+			if kubeContext != "" {
+				// Pods in different clusters may have the same name.
+				name = apis.SanitizeName(fmt.Sprintf("%s-%s", kubeContext, name))
 			}
 			obj := &PodLogStream{
 				ObjectMeta: ObjectMeta{
-					Name: fmt.Sprintf("%s-%s", pod.Namespace, pod.PodID),
+					Name: name,
 					Annotations: map[string]string{
 						v1alpha1.AnnotationManifest: string(man.Name),
 						v1alpha1.AnnotationSpanID:   string(SpanIDForPod(pod.PodID)),
@@ -81,10 +98,10 @@ func (m *PodLogManager) diff(ctx context.Context, st store.RStore) (setup []*Pod
 				Spec: spec,
 			}
 
-			if _, ok := current[nn]; !ok {
+			if _, ok := current[key]; !ok {
 				setup = append(setup, obj)
 			}
-			seen[nn] = true
+			seen[key] = true
 		}
 	}
 
@@ -135,6 +152,11 @@ func (m *PodLogManager) createPls(ctx context.Context, st store.RStore, pls *Pod
 	st.Dispatch(PodLogStreamCreateAction{PodLogStream: pls})
 }
 
+type podLogStreamKey struct {
+	context string
+	nn      types.NamespacedName
+}
+
 func SpanIDForPod(podID k8s.PodID) logstore.SpanID {
 	return logstore.SpanID(fmt.Sprintf("pod:%s", podID))
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/tilt-dev/tilt/internal/testutils/bufsync"
 	"github.com/tilt-dev/tilt/internal/testutils/manifestutils"
 	"github.com/tilt-dev/tilt/internal/testutils/tempdir"
+	"github.com/tilt-dev/tilt/pkg/apis/core/v1alpha1"
 	"github.com/tilt-dev/tilt/pkg/logger"
 	"github.com/tilt-dev/tilt/pkg/model"
 )
@@ -59,7 +60,35 @@ func TestLogs(t *testing.T) {
 	streamNN := types.NamespacedName{Name: fmt.Sprintf("default-%s", podID)}
 	assert.Equal(t, []reconcile.Request{
 		reconcile.Request{NamespacedName: streamNN},
-	}, f.plsc.podSource.mapPodNameToEnqueue(podNN))
+	}, f.plsc.podSource.mapPodNameToEnqueue(podKey{kubeContext: k8s.FakeKubeContext, nn: podNN}))
+}
+
+func TestLogsOtherContext(t *testing.T) {
+	f := newPLMFixture(t)
+	defer f.TearDown()
+
+	dataClient := f.clients.For("data").Client.(*k8s.FakeK8sClient)
+	dataClient.SetLogsForPodContainer(podID, cName, "hello data!")
+	f.kClient.SetLogsForPodContainer(podID, cName, "hello world!")
+
+	state := f.store.LockMutableStateForTesting()
+	pb := newPodBuilder(podID).addRunningContainer(cName, cID)
+	dataClient.UpsertPod(pb.toPod())
+
+	m := model.Manifest{Name: "server"}.WithDeployTarget(model.K8sTarget{KubeContext: "data"})
+	state.UpsertManifestTarget(manifestutils.NewManifestTargetWithPod(m, pb.toStorePod(f.ctx)))
+	f.store.UnlockMutableState()
+
+	f.onChange(podID)
+	f.AssertOutputContains("hello data!")
+	f.AssertOutputDoesNotContain("hello world!")
+
+	podNN := types.NamespacedName{Name: string(podID), Namespace: "default"}
+	streamNN := types.NamespacedName{Name: fmt.Sprintf("data-default-%s", podID)}
+	assert.Equal(t, []reconcile.Request{
+		reconcile.Request{NamespacedName: streamNN},
+	}, f.plsc.podSource.mapPodNameToEnqueue(podKey{kubeContext: "data", nn: podNN}))
+	assert.Empty(t, f.plsc.podSource.mapPodNameToEnqueue(podKey{kubeContext: k8s.FakeKubeContext, nn: podNN}))
 }
 
 func TestLogActions(t *testing.T) {
@@ -81,6 +110,29 @@ func TestLogActions(t *testing.T) {
 	f.ConsumeLogActionsUntil("hello world!")
 }
 
+func TestJSONLogs(t *testing.T) {
+	f := newPLMFixture(t)
+	defer f.TearDown()
+
+	f.kClient.SetLogsForPodContainer(podID, cName,
+		`{"severity":"warn","message":"cache miss","key":"abc"}`+"\nplain line\n")
+
+	state := f.store.LockMutableStateForTesting()
+	pb := newPodBuilder(podID).addRunningContainer(cName, cID)
+	f.kClient.UpsertPod(pb.toPod())
+
+	m := model.Manifest{Name: "server"}.WithDeployTarget(model.K8sTarget{
+		LogFormat: model.LogFormatJSON,
+		LogFields: map[string]string{"level": "severity", "msg": "message"},
+	})
+	state.UpsertManifestTarget(manifestutils.NewManifestTargetWithPod(m, pb.toStorePod(f.ctx)))
+	f.store.UnlockMutableState()
+
+	f.onChange(podID)
+	f.AssertOutputContains("cache miss key=abc\nplain line\n")
+	f.AssertOutputDoesNotContain(`"message"`)
+}
+
 func TestLogsFailed(t *testing.T) {
 	f := newPLMFixture(t)
 	defer f.TearDown()
@@ -345,6 +397,100 @@ func TestInitContainerLogs(t *testing.T) {
 	f.AssertOutputContains("hello world!")
 }
 
+func TestInitContainerLogsInOrder(t *testing.T) {
+	f := newPLMFixture(t)
+	defer f.TearDown()
+
+	state := f.store.LockMutableStateForTesting()
+
+	cNameInit := container.Name("cNameInit")
+	cNameNormal := container.Name("cNameNormal")
+	pb := newPodBuilder(podID).
+		addRunningInitContainer(cNameInit, "cID-init").
+		addRunningContainer(cNameNormal, "cID-normal")
+	f.kClient.UpsertPod(pb.toPod())
+
+	state.UpsertManifestTarget(manifestutils.NewManifestTargetWithPod(
+		model.Manifest{Name: "server"}, pb.toStorePod(f.ctx)))
+	f.store.UnlockMutableState()
+
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	f.kClient.SetLogReaderForPodContainer(podID, cNameInit, reader)
+	f.kClient.SetLogsForPodContainer(podID, cNameNormal, "hello world!")
+
+	f.onChange(podID)
+
+	_, _ = writer.Write([]byte("init world!\n"))
+	f.AssertOutputContains("init world!")
+	f.AssertOutputDoesNotContain("hello world!")
+
+	// Once the init container's logs end, we print the main container's logs.
+	_ = writer.Close()
+	f.AssertOutputContains("hello world!")
+	assert.Less(t, strings.Index(f.out.String(), "init world!"), strings.Index(f.out.String(), "hello world!"))
+}
+
+func TestPreviousContainerLogsOnRestart(t *testing.T) {
+	f := newPLMFixture(t)
+	defer f.TearDown()
+
+	state := f.store.LockMutableStateForTesting()
+
+	pb := newPodBuilder(podID).addRestartedContainer(cName, "cID-2", "cID-1")
+	f.kClient.UpsertPod(pb.toPod())
+	state.UpsertManifestTarget(manifestutils.NewManifestTargetWithPod(
+		model.Manifest{Name: "server"}, pb.toStorePod(f.ctx)))
+	f.store.UnlockMutableState()
+
+	f.kClient.SetPreviousLogsForPodContainer(podID, cName, "panic: out of cheese\n")
+	f.kClient.SetLogsForPodContainer(podID, cName, "hello world!\n")
+
+	f.onChange(podID)
+	f.AssertOutputContains("[cname (previous)] panic: out of cheese")
+	f.AssertOutputContains("hello world!")
+
+	// We only read the logs of each terminated instance once.
+	f.kClient.SetPreviousLogsForPodContainer(podID, cName, "panic: out of cheese\n")
+	f.onChange(podID)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 1, strings.Count(f.out.String(), "out of cheese"))
+}
+
+func TestPreviousContainerLogsSpec(t *testing.T) {
+	f := newPLMFixture(t)
+	defer f.TearDown()
+
+	pb := newPodBuilder(podID).addRestartedContainer(cName, "cID-2", "cID-1")
+	f.kClient.UpsertPod(pb.toPod())
+
+	f.kClient.SetPreviousLogsForPodContainer(podID, cName, "panic: out of cheese\n")
+	f.kClient.SetLogsForPodContainer(podID, cName, "hello world!\n")
+
+	nn := types.NamespacedName{Name: "previous-logs"}
+	err := f.client.Create(f.ctx, &PodLogStream{
+		ObjectMeta: ObjectMeta{
+			Name: nn.Name,
+			Annotations: map[string]string{
+				v1alpha1.AnnotationManifest: "server",
+				v1alpha1.AnnotationSpanID:   string(SpanIDForPod(podID)),
+			},
+		},
+		Spec: PodLogStreamSpec{
+			Pod:       string(podID),
+			Namespace: "default",
+			Previous:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = f.plsc.Reconcile(f.ctx, reconcile.Request{NamespacedName: nn})
+	require.NoError(t, err)
+
+	f.AssertOutputContains("panic: out of cheese")
+	f.AssertOutputDoesNotContain("hello world!")
+}
+
 func TestIstioContainerLogs(t *testing.T) {
 	f := newPLMFixture(t)
 	defer f.TearDown()
@@ -441,6 +587,7 @@ type plmFixture struct {
 	ctx     context.Context
 	client  ctrlclient.Client
 	kClient *k8s.FakeK8sClient
+	clients *k8s.ContextClients
 	plm     *PodLogManager
 	plsc    *PodLogStreamController
 	cancel  func()
@@ -460,11 +607,13 @@ func newPLMFixture(t *testing.T) *plmFixture {
 	st := newPLMStore(t, out)
 	fc := fake.NewTiltClient()
 	plm := NewPodLogManager(fc)
-	plsc := NewPodLogStreamController(ctx, fc, st, kClient)
+	clients := k8s.NewFakeContextClients(ctx, kClient)
+	plsc := NewPodLogStreamController(ctx, fc, st, clients)
 
 	return &plmFixture{
 		TempDirFixture: f,
 		kClient:        kClient,
+		clients:        clients,
 		client:         fc,
 		plm:            plm,
 		plsc:           plsc,
@@ -488,7 +637,7 @@ func (f *plmFixture) onChange(podID k8s.PodID) {
 		assert.NoError(f.T(), err)
 	}
 
-	for _, req := range f.plsc.podSource.mapPodNameToEnqueue(podNN) {
+	for _, req := range f.enqueuedRequests(podNN) {
 		_, err := f.plsc.Reconcile(f.ctx, req)
 		assert.NoError(f.T(), err)
 	}
@@ -496,6 +645,25 @@ func (f *plmFixture) onChange(podID k8s.PodID) {
 	f.store.clearSummary()
 }
 
+// Returns the Reconcile() calls for a pod change, in whichever cluster the pod lives.
+func (f *plmFixture) enqueuedRequests(podNN types.NamespacedName) []reconcile.Request {
+	s := f.plsc.podSource
+	s.mu.Lock()
+	keys := []podKey{}
+	for pk := range s.podsToTargets {
+		if pk.nn == podNN {
+			keys = append(keys, pk)
+		}
+	}
+	s.mu.Unlock()
+
+	result := []reconcile.Request{}
+	for _, pk := range keys {
+		result = append(result, s.mapPodNameToEnqueue(pk)...)
+	}
+	return result
+}
+
 func (f *plmFixture) ConsumeLogActionsUntil(expected string) {
 	start := time.Now()
 	for time.Since(start) < time.Second {
@@ -591,6 +759,18 @@ func (pb *podBuilder) addTerminatedContainer(name container.Name, id container.I
 	return pb
 }
 
+// Adds a running container that replaced an instance that crashed.
+func (pb *podBuilder) addRestartedContainer(name container.Name, id container.ID, prevID container.ID) *podBuilder {
+	pb.addRunningContainer(name, id)
+	statuses := pb.Status.ContainerStatuses
+	statuses[len(statuses)-1].RestartCount = 1
+	statuses[len(statuses)-1].LastTerminationState.Terminated = &v1.ContainerStateTerminated{
+		ContainerID: fmt.Sprintf("containerd://%s", prevID),
+		ExitCode:    2,
+	}
+	return pb
+}
+
 func (pb *podBuilder) addTerminatedInitContainer(name container.Name, id container.ID) *podBuilder {
 	pb.addRunningInitContainer(name, id)
 	statuses := pb.Status.InitContainerStatuses
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,6 +21,7 @@ import (
 	"github.com/tilt-dev/tilt/internal/store"
 	"github.com/tilt-dev/tilt/internal/store/k8sconv"
 	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
 )
 
 var podLogHealthCheck = 15 * time.Second
@@ -32,13 +34,18 @@ type PodLogStreamController struct {
 	ctx       context.Context
 	client    ctrlclient.Client
 	st        store.RStore
-	kClient   k8s.Client
+	clients   *k8s.ContextClients
 	podSource *PodSource
 	mu        sync.Mutex
 
 	watches         map[podLogKey]PodLogWatch
 	hasClosedStream map[podLogKey]bool
-	statuses        map[types.NamespacedName]*PodLogStreamStatus
+
+	// Container instances whose logs we've read, either by following them
+	// or by reading them after they terminated.
+	hasStreamed map[podLogKey]bool
+
+	statuses map[types.NamespacedName]*PodLogStreamStatus
 
 	newTicker func(d time.Duration) *time.Ticker
 	since     func(t time.Time) time.Duration
@@ -48,15 +55,16 @@ type PodLogStreamController struct {
 var _ reconcile.Reconciler = &PodLogStreamController{}
 var _ store.TearDowner = &PodLogStreamController{}
 
-func NewPodLogStreamController(ctx context.Context, client ctrlclient.Client, st store.RStore, kClient k8s.Client) *PodLogStreamController {
+func NewPodLogStreamController(ctx context.Context, client ctrlclient.Client, st store.RStore, clients *k8s.ContextClients) *PodLogStreamController {
 	return &PodLogStreamController{
 		ctx:             ctx,
 		client:          client,
 		st:              st,
-		kClient:         kClient,
-		podSource:       NewPodSource(ctx, kClient),
+		clients:         clients,
+		podSource:       NewPodSource(ctx, clients),
 		watches:         make(map[podLogKey]PodLogWatch),
 		hasClosedStream: make(map[podLogKey]bool),
+		hasStreamed:     make(map[podLogKey]bool),
 		statuses:        make(map[types.NamespacedName]*PodLogStreamStatus),
 		newTicker:       time.NewTicker,
 		since:           time.Since,
@@ -140,7 +148,8 @@ func (r *PodLogStreamController) Reconcile(ctx context.Context, req reconcile.Re
 	r.podSource.handleReconcileRequest(ctx, req.NamespacedName, stream)
 
 	podNN := types.NamespacedName{Name: stream.Spec.Pod, Namespace: stream.Spec.Namespace}
-	pod, err := r.kClient.PodFromInformerCache(ctx, podNN)
+	kubeContext := k8s.KubeContext(stream.Spec.Context)
+	pod, err := r.clients.For(kubeContext).Client.PodFromInformerCache(ctx, podNN)
 	if (err != nil && apierrors.IsNotFound(err)) ||
 		(pod != nil && pod.DeletionTimestamp != nil && !pod.DeletionTimestamp.IsZero()) {
 		r.deleteStreams(streamName)
@@ -160,8 +169,56 @@ func (r *PodLogStreamController) Reconcile(ctx context.Context, req reconcile.Re
 	containers = append(containers, runContainers...)
 	r.ensureStatus(streamName, containers)
 
+	// Only stream logs that have happened since Tilt started.
+	//
+	// TODO(nick): We should really record when we started the `kubectl apply`,
+	// and only stream logs since that happened.
+	sinceTime := time.Time{}
+	if stream.Spec.SinceTime != nil {
+		sinceTime = stream.Spec.SinceTime.Time
+	}
+
+	lastTerminated := lastTerminatedContainerIDs(pod)
+
+	// Init containers run one at a time, before the main containers,
+	// so we wait for each init container's logs to finish before
+	// we print the logs of the containers after it.
+	initDone := []chan struct{}{}
+
 	containerWatches := make(map[podLogKey]bool)
 	for i, c := range containers {
+		isInitContainer := i < len(initContainers)
+
+		// If a container restarted before we could follow the instance that
+		// terminated, read its final logs, so that crash output isn't lost.
+		prevID := lastTerminated[c.Name]
+		prevKey := podLogKey{
+			streamName: streamName,
+			podID:      k8s.PodID(podNN.Name),
+			cID:        prevID,
+		}
+		if prevID != "" && !r.hasStreamed[prevKey] {
+			r.hasStreamed[prevKey] = true
+
+			ctx, cancel := context.WithCancel(ctx)
+			go r.consumePreviousLogs(PodLogWatch{
+				streamName:     streamName,
+				ctx:            ctx,
+				cancel:         cancel,
+				podID:          k8s.PodID(podNN.Name),
+				kubeContext:    kubeContext,
+				cName:          c.Name,
+				namespace:      k8s.Namespace(podNN.Namespace),
+				startWatchTime: sinceTime,
+				logFormat:      model.LogFormat(stream.Spec.LogFormat),
+				logFields:      stream.Spec.LogFields,
+			})
+		}
+
+		if stream.Spec.Previous {
+			continue
+		}
+
 		// Key the log watcher by the container id, so we auto-restart the
 		// watching if the container crashes.
 		key := podLogKey{
@@ -173,8 +230,6 @@ func (r *PodLogStreamController) Reconcile(ctx context.Context, req reconcile.Re
 			continue
 		}
 
-		isInitContainer := i < len(initContainers)
-
 		// We don't want to clutter the logs with a container name
 		// if it's unambiguous what container we're looking at.
 		//
@@ -185,20 +240,16 @@ func (r *PodLogStreamController) Reconcile(ctx context.Context, req reconcile.Re
 		containerWatches[key] = true
 
 		existing, isActive := r.watches[key]
-
-		// Only stream logs that have happened since Tilt started.
-		//
-		// TODO(nick): We should really record when we started the `kubectl apply`,
-		// and only stream logs since that happened.
-		startWatchTime := time.Time{}
-		if stream.Spec.SinceTime != nil {
-			startWatchTime = stream.Spec.SinceTime.Time
-		}
+		startWatchTime := sinceTime
+		waitFor := append([]chan struct{}{}, initDone...)
 
 		if isActive {
 			if existing.ctx.Err() == nil {
 				// The active pod watcher is still tailing the logs,
 				// nothing to do.
+				if isInitContainer {
+					initDone = append(initDone, existing.done)
+				}
 				continue
 			}
 
@@ -223,13 +274,22 @@ func (r *PodLogStreamController) Reconcile(ctx context.Context, req reconcile.Re
 			ctx:             ctx,
 			cancel:          cancel,
 			podID:           k8s.PodID(podNN.Name),
+			kubeContext:     kubeContext,
 			cName:           c.Name,
 			namespace:       k8s.Namespace(podNN.Namespace),
 			startWatchTime:  startWatchTime,
 			terminationTime: make(chan time.Time, 1),
+			waitFor:         waitFor,
+			done:            make(chan struct{}),
 			shouldPrefix:    shouldPrefix,
+			logFormat:       model.LogFormat(stream.Spec.LogFormat),
+			logFields:       stream.Spec.LogFields,
 		}
 		r.watches[key] = w
+		r.hasStreamed[key] = true
+		if isInitContainer {
+			initDone = append(initDone, w.done)
+		}
 
 		go r.consumeLogs(w, r.st)
 	}
@@ -257,6 +317,12 @@ func (c *PodLogStreamController) deleteStreams(streamName types.NamespacedName)
 		delete(c.watches, k)
 	}
 
+	for k := range c.hasStreamed {
+		if k.streamName == streamName {
+			delete(c.hasStreamed, k)
+		}
+	}
+
 	c.mu.Lock()
 	delete(c.statuses, streamName)
 	c.mu.Unlock()
@@ -266,8 +332,17 @@ func (m *PodLogStreamController) consumeLogs(watch PodLogWatch, st store.RStore)
 	defer func() {
 		watch.terminationTime <- m.now()
 		watch.cancel()
+		close(watch.done)
 	}()
 
+	for _, done := range watch.waitFor {
+		select {
+		case <-done:
+		case <-watch.ctx.Done():
+			return
+		}
+	}
+
 	pID := watch.podID
 	containerName := watch.cName
 	ns := watch.namespace
@@ -282,7 +357,8 @@ func (m *PodLogStreamController) consumeLogs(watch PodLogWatch, st store.RStore)
 	for retry {
 		retry = false
 		ctx, cancel := context.WithCancel(ctx)
-		readCloser, err := m.kClient.ContainerLogs(ctx, pID, containerName, ns, startReadTime)
+		kCli := m.clients.For(watch.kubeContext).Client
+		readCloser, err := kCli.ContainerLogs(ctx, pID, containerName, ns, startReadTime)
 		if err != nil {
 			cancel()
 
@@ -341,7 +417,9 @@ func (m *PodLogStreamController) consumeLogs(watch PodLogWatch, st store.RStore)
 		})
 		m.updateStatus(watch.streamName)
 
-		_, err = io.Copy(logger.Get(ctx).Writer(logger.InfoLvl), reader)
+		w, flush := newContainerLogWriter(ctx, watch)
+		_, err = io.Copy(w, reader)
+		flush()
 		_ = readCloser.Close()
 		close(done)
 		cancel()
@@ -360,6 +438,59 @@ func (m *PodLogStreamController) consumeLogs(watch PodLogWatch, st store.RStore)
 	}
 }
 
+// Reads the final logs of a container instance that has terminated.
+func (m *PodLogStreamController) consumePreviousLogs(watch PodLogWatch) {
+	defer watch.cancel()
+
+	prefix := fmt.Sprintf("[%s (previous)] ", watch.cName)
+	ctx := logger.WithLogger(watch.ctx, logger.NewPrefixedLogger(prefix, logger.Get(watch.ctx)))
+
+	kCli := m.clients.For(watch.kubeContext).Client
+	readCloser, err := kCli.PreviousContainerLogs(ctx, watch.podID, watch.cName, watch.namespace, watch.startWatchTime)
+	if err != nil {
+		logger.Get(ctx).Debugf("Error reading previous %s logs: %v", watch.podID, err)
+		return
+	}
+	defer func() {
+		_ = readCloser.Close()
+	}()
+
+	w, flush := newContainerLogWriter(ctx, watch)
+	_, _ = io.Copy(w, NewHardCancelReader(ctx, readCloser))
+	flush()
+}
+
+// Returns a writer that sends container logs to the logger, parsing them
+// first if the stream has a log format, and a func that flushes the writer.
+func newContainerLogWriter(ctx context.Context, watch PodLogWatch) (io.Writer, func()) {
+	if watch.logFormat == model.LogFormatJSON {
+		w := newJSONLogWriter(logger.Get(ctx), watch.logFields)
+		return w, w.Flush
+	}
+	return logger.Get(ctx).Writer(logger.InfoLvl), func() {}
+}
+
+// The IDs of the instances of each container that most recently terminated.
+func lastTerminatedContainerIDs(pod *v1.Pod) map[container.Name]container.ID {
+	statuses := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	result := make(map[container.Name]container.ID, len(statuses))
+	for _, cs := range statuses {
+		terminated := cs.LastTerminationState.Terminated
+		if terminated == nil {
+			continue
+		}
+
+		id, err := k8s.NormalizeContainerID(terminated.ContainerID)
+		if err != nil || id == "" {
+			continue
+		}
+		result[container.Name(cs.Name)] = id
+	}
+	return result
+}
+
 // Set up the status object for a particular stream, tracking each container individually.
 func (r *PodLogStreamController) ensureStatus(streamName types.NamespacedName, containers []store.Container) {
 	r.mu.Lock()
@@ -448,12 +579,19 @@ type PodLogWatch struct {
 
 	streamName      types.NamespacedName
 	podID           k8s.PodID
+	kubeContext     k8s.KubeContext
 	namespace       k8s.Namespace
 	cName           container.Name
 	startWatchTime  time.Time
 	terminationTime chan time.Time
 
+	waitFor []chan struct{} // the logs of earlier init containers, which we print first
+	done    chan struct{}   // closed when we stop reading logs
+
 	shouldPrefix bool // if true, we'll prefix logs with the container name
+
+	logFormat model.LogFormat
+	logFields map[string]string
 }
 
 type podLogKey struct {
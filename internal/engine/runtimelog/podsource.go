@@ -19,7 +19,7 @@ import (
 // call for any PodLogStream watching that pod.
 type PodSource struct {
 	ctx     context.Context
-	kClient k8s.Client
+	clients *k8s.ContextClients
 	handler handler.EventHandler
 	q       workqueue.RateLimitingInterface
 	mu      sync.Mutex
@@ -27,25 +27,35 @@ type PodSource struct {
 	// A map to help determine which PodLogStreams to reconcile when a Pod changes.
 	//
 	// The first key is the Pod name. The second key is the PodLogStream Name.
-	podsToTargets map[types.NamespacedName]map[types.NamespacedName]bool
+	podsToTargets map[podKey]map[types.NamespacedName]bool
 
-	watchesByNamespace map[string]podWatch
+	watchesByNamespace map[podWatchKey]podWatch
+}
+
+type podKey struct {
+	kubeContext k8s.KubeContext
+	nn          types.NamespacedName
+}
+
+type podWatchKey struct {
+	kubeContext k8s.KubeContext
+	namespace   string
 }
 
 type podWatch struct {
-	ctx       context.Context
-	cancel    func()
-	namespace string
+	ctx    context.Context
+	cancel func()
+	key    podWatchKey
 }
 
 var _ source.Source = &PodSource{}
 
-func NewPodSource(ctx context.Context, kClient k8s.Client) *PodSource {
+func NewPodSource(ctx context.Context, clients *k8s.ContextClients) *PodSource {
 	return &PodSource{
 		ctx:                ctx,
-		kClient:            kClient,
-		podsToTargets:      make(map[types.NamespacedName]map[types.NamespacedName]bool),
-		watchesByNamespace: make(map[string]podWatch),
+		clients:            clients,
+		podsToTargets:      make(map[podKey]map[types.NamespacedName]bool),
+		watchesByNamespace: make(map[podWatchKey]podWatch),
 	}
 }
 
@@ -81,20 +91,24 @@ func (s *PodSource) handleReconcileRequest(ctx context.Context, name types.Names
 		return
 	}
 
-	podNN := types.NamespacedName{Name: pls.Spec.Pod, Namespace: pls.Spec.Namespace}
-	streamMap, ok := s.podsToTargets[podNN]
+	kubeContext := s.clients.Resolve(k8s.KubeContext(pls.Spec.Context))
+	pk := podKey{
+		kubeContext: kubeContext,
+		nn:          types.NamespacedName{Name: pls.Spec.Pod, Namespace: pls.Spec.Namespace},
+	}
+	streamMap, ok := s.podsToTargets[pk]
 	if !ok {
 		streamMap = make(map[types.NamespacedName]bool)
-		s.podsToTargets[podNN] = streamMap
+		s.podsToTargets[pk] = streamMap
 	}
 	streamMap[name] = true
 
-	ns := pls.Spec.Namespace
-	_, ok = s.watchesByNamespace[ns]
+	wk := podWatchKey{kubeContext: kubeContext, namespace: pls.Spec.Namespace}
+	_, ok = s.watchesByNamespace[wk]
 	if !ok {
 		ctx, cancel := context.WithCancel(ctx)
-		pw := podWatch{ctx: ctx, cancel: cancel, namespace: ns}
-		s.watchesByNamespace[ns] = pw
+		pw := podWatch{ctx: ctx, cancel: cancel, key: wk}
+		s.watchesByNamespace[wk] = pw
 		go s.doWatch(pw)
 	}
 }
@@ -103,7 +117,8 @@ func (s *PodSource) handleReconcileRequest(ctx context.Context, name types.Names
 func (s *PodSource) doWatch(pw podWatch) {
 	defer pw.cancel()
 
-	podCh, err := s.kClient.WatchPods(s.ctx, k8s.Namespace(pw.namespace))
+	kCli := s.clients.For(pw.key.kubeContext).Client
+	podCh, err := kCli.WatchPods(s.ctx, k8s.Namespace(pw.key.namespace))
 	if err != nil {
 		logger.Get(pw.ctx).Errorf("watching pods: %v", err)
 		return
@@ -118,31 +133,31 @@ func (s *PodSource) doWatch(pw podWatch) {
 			if !ok {
 				return
 			}
-			s.handlePod(pod)
+			s.handlePod(pw.key.kubeContext, pod)
 			continue
 		}
 	}
 }
 
-func (s *PodSource) mapPodNameToEnqueue(podNN types.NamespacedName) []reconcile.Request {
+func (s *PodSource) mapPodNameToEnqueue(pk podKey) []reconcile.Request {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	result := []reconcile.Request{}
-	for streamName := range s.podsToTargets[podNN] {
+	for streamName := range s.podsToTargets[pk] {
 		result = append(result, reconcile.Request{NamespacedName: streamName})
 	}
 	return result
 }
 
 // Turn all pod events into Reconcile() calls.
-func (s *PodSource) handlePod(obj k8s.ObjectUpdate) {
+func (s *PodSource) handlePod(kubeContext k8s.KubeContext, obj k8s.ObjectUpdate) {
 	podNN, ok := obj.AsNamespacedName()
 	if !ok {
 		return
 	}
 
-	requests := s.mapPodNameToEnqueue(podNN)
+	requests := s.mapPodNameToEnqueue(podKey{kubeContext: kubeContext, nn: podNN})
 
 	s.mu.Lock()
 	q := s.q
@@ -9,9 +9,12 @@ import (
 	"github.com/tilt-dev/tilt/internal/engine/dockerprune"
 	"github.com/tilt-dev/tilt/internal/engine/exit"
 	"github.com/tilt-dev/tilt/internal/engine/fswatch"
+	"github.com/tilt-dev/tilt/internal/engine/k8sgc"
 	"github.com/tilt-dev/tilt/internal/engine/k8srollout"
 	"github.com/tilt-dev/tilt/internal/engine/k8swatch"
 	"github.com/tilt-dev/tilt/internal/engine/local"
+	"github.com/tilt-dev/tilt/internal/engine/logexport"
+	"github.com/tilt-dev/tilt/internal/engine/loglimit"
 	"github.com/tilt-dev/tilt/internal/engine/metrics"
 	"github.com/tilt-dev/tilt/internal/engine/portforward"
 	"github.com/tilt-dev/tilt/internal/engine/runtimelog"
@@ -52,6 +55,8 @@ func ProvideSubscribers(
 	tp *prompt.TerminalPrompt,
 	pw *k8swatch.PodWatcher,
 	sw *k8swatch.ServiceWatcher,
+	orw *k8swatch.ObjectReadinessWatcher,
+	rw *k8swatch.RolloutWatcher,
 	plm *runtimelog.PodLogManager,
 	pfc *portforward.Controller,
 	fsms *fswatch.ManifestSubscriber,
@@ -70,6 +75,9 @@ func ProvideSubscribers(
 	ec *exit.Controller,
 	mc *metrics.Controller,
 	mmc *metrics.ModeController,
+	gc *k8sgc.GarbageCollector,
+	lec *logexport.Controller,
+	llc *loglimit.Controller,
 ) []store.Subscriber {
 	apiSubscribers := ProvideSubscribersAPIOnly(hudsc, tscm, cb, ts)
 
@@ -78,6 +86,8 @@ func ProvideSubscribers(
 		tp,
 		pw,
 		sw,
+		orw,
+		rw,
 		plm,
 		pfc,
 		fsms,
@@ -96,6 +106,9 @@ func ProvideSubscribers(
 		ec,
 		mc,
 		mmc,
+		gc,
+		lec,
+		llc,
 	}
 	return append(apiSubscribers, legacySubscribers...)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/tilt-dev/wmclient/pkg/analytics"
+	"k8s.io/apimachinery/pkg/types"
 
 	tiltanalytics "github.com/tilt-dev/tilt/internal/analytics"
 	"github.com/tilt-dev/tilt/internal/container"
@@ -22,6 +23,7 @@ import (
 	"github.com/tilt-dev/tilt/internal/engine/k8swatch"
 	"github.com/tilt-dev/tilt/internal/engine/local"
 	"github.com/tilt-dev/tilt/internal/engine/metrics"
+	"github.com/tilt-dev/tilt/internal/engine/portforward"
 	"github.com/tilt-dev/tilt/internal/engine/runtimelog"
 	"github.com/tilt-dev/tilt/internal/hud"
 	"github.com/tilt-dev/tilt/internal/hud/prompt"
@@ -32,18 +34,20 @@ import (
 	"github.com/tilt-dev/tilt/internal/token"
 	"github.com/tilt-dev/tilt/pkg/logger"
 	"github.com/tilt-dev/tilt/pkg/model"
+	"github.com/tilt-dev/tilt/pkg/model/logstore"
 )
 
 // TODO(nick): maybe this should be called 'BuildEngine' or something?
 // Upper seems like a poor and undescriptive name.
 type Upper struct {
-	store *store.Store
+	store       *store.Store
+	kubeContext k8s.KubeContext
 }
 
 type ServiceWatcherMaker func(context.Context, *store.Store) error
 type PodWatcherMaker func(context.Context, *store.Store) error
 
-func NewUpper(ctx context.Context, st *store.Store, subs []store.Subscriber) (Upper, error) {
+func NewUpper(ctx context.Context, st *store.Store, subs []store.Subscriber, kubeContext k8s.KubeContext) (Upper, error) {
 	// There's not really a good reason to add all the subscribers
 	// in NewUpper(), but it's as good a place as any.
 	for _, sub := range subs {
@@ -54,7 +58,8 @@ func NewUpper(ctx context.Context, st *store.Store, subs []store.Subscriber) (Up
 	}
 
 	return Upper{
-		store: st,
+		store:       st,
+		kubeContext: kubeContext,
 	}, nil
 }
 
@@ -72,6 +77,7 @@ func (u Upper) Start(
 	analyticsUserOpt analytics.Opt,
 	token token.Token,
 	cloudAddress string,
+	logArchive *logstore.Archive,
 ) error {
 
 	startTime := time.Now()
@@ -94,6 +100,8 @@ func (u Upper) Start(
 		Token:            token,
 		CloudAddress:     cloudAddress,
 		TerminalMode:     initTerminalMode,
+		KubeContext:      u.kubeContext,
+		LogArchive:       logArchive,
 	})
 }
 
@@ -138,8 +146,18 @@ func upperReducerFn(ctx context.Context, state *store.EngineState, action store.
 		handlePodDeleteAction(ctx, state, action)
 	case store.PodResetRestartsAction:
 		handlePodResetRestartsAction(state, action)
+	case store.ClearLogsAction:
+		handleClearLogsAction(state, action)
+	case store.FlushDroppedLogsAction:
+		state.LogStore.FlushDroppedLogs(action.Time)
 	case k8swatch.ServiceChangeAction:
 		handleServiceEvent(ctx, state, action)
+	case k8swatch.ObjectReadinessAction:
+		handleObjectReadinessAction(state, action)
+	case k8swatch.RolloutStatusAction:
+		handleRolloutStatusAction(state, action)
+	case portforward.PortForwardStatusAction:
+		handlePortForwardStatusAction(state, action)
 	case store.K8sEventAction:
 		handleK8sEvent(ctx, state, action)
 	case buildcontrol.BuildCompleteAction:
@@ -248,6 +266,10 @@ func handleBuildStarted(ctx context.Context, state *store.EngineState, action bu
 		ms.LiveUpdatedContainerIDs = container.NewIDSet()
 	}
 
+	if state.UpdateSettings.ClearLogsOnRebuild() {
+		state.LogStore.Clear(mn)
+	}
+
 	state.CurrentlyBuilding[mn] = true
 	state.RemoveFromTriggerQueue(mn)
 }
@@ -369,6 +391,7 @@ func handleBuildCompleted(ctx context.Context, engineState *store.EngineState, c
 	bs.Error = err
 	bs.FinishTime = cb.FinishTime
 	bs.BuildTypes = cb.Result.BuildTypes()
+	bs.K8sDiff = cb.Result.K8sDiff()
 	if bs.SpanID != "" {
 		bs.WarningCount = len(engineState.LogStore.Warnings(bs.SpanID))
 	}
@@ -431,8 +454,15 @@ func handleBuildCompleted(ctx context.Context, engineState *store.EngineState, c
 
 		if err == nil {
 			state.HasEverDeployedSuccessfully = true
+
+			// Alerts from before the deploy are about the old pods.
+			state.FatalLogAlert = ""
+			ms.LogAlertCount = 0
+			ms.LastLogAlert = ""
 		}
 
+		state.ObjectReadinessKeys = manifest.K8sTarget().ObjectReadinessKeys()
+
 		ms.RuntimeState = state
 	}
 
@@ -503,6 +533,9 @@ func handleConfigsReloadStarted(
 	}
 
 	state.TiltfileState.CurrentBuild = status
+	if state.UpdateSettings.ClearLogsOnRebuild() {
+		state.LogStore.Clear(model.TiltfileManifestName)
+	}
 	state.RemoveFromTriggerQueue(model.TiltfileManifestName)
 	state.StartedTiltfileLoadCount++
 }
@@ -559,6 +592,11 @@ func handleConfigsReloaded(
 		}
 	}
 
+	// Add log export settings if they exist, even if execution failed.
+	if event.LogExportSettings.Enabled || event.Err == nil {
+		state.LogExportSettings = event.LogExportSettings
+	}
+
 	// if the ConfigsReloadedAction came from a unit test, there might not be a current build
 	if !b.Empty() {
 		b.FinishTime = event.FinishTime
@@ -652,6 +690,24 @@ func handleConfigsReloaded(
 
 	state.UpdateSettings = event.UpdateSettings
 
+	logLimits := make(map[model.ManifestName]model.LogLimit)
+	logAlertMatchers := make(map[model.ManifestName]*store.LogAlertMatcher)
+	for _, mt := range state.Targets() {
+		if !mt.Manifest.LogLimit.Empty() {
+			logLimits[mt.Manifest.Name] = mt.Manifest.LogLimit
+		}
+		if !mt.Manifest.LogAlerts.Empty() {
+			// The Tiltfile already checked that the patterns compile.
+			matcher, err := store.NewLogAlertMatcher(mt.Manifest.LogAlerts)
+			if err == nil {
+				logAlertMatchers[mt.Manifest.Name] = matcher
+			}
+		}
+	}
+	state.LogAlertMatchers = logAlertMatchers
+	state.LogStore.SetMaxLength(state.UpdateSettings.MaxLogLength())
+	state.LogStore.SetLimits(logLimits)
+
 	// Remove pending file changes that were consumed by this build.
 	for file, modTime := range state.PendingConfigFileChanges {
 		if store.BeforeOrEqual(modTime, state.TiltfileState.LastBuild().StartTime) {
@@ -665,7 +721,43 @@ func handleLogAction(state *store.EngineState, action store.LogAction) {
 	if ok && manifest.Source == model.ManifestSourceMetrics {
 		return
 	}
-	state.LogStore.Append(action, state.Secrets)
+
+	matcher, ok := state.LogAlertMatchers[action.ManifestName()]
+	if !ok || !store.IsLogAlertSource(action.SpanID()) {
+		state.LogStore.Append(action, state.Secrets)
+		return
+	}
+
+	actions, alerts := store.SplitLogAlerts(matcher, action)
+	for _, a := range actions {
+		state.LogStore.Append(a, state.Secrets)
+	}
+	handleLogAlerts(state, action.ManifestName(), alerts)
+}
+
+// Counts the runtime log lines that matched an alert pattern, and puts
+// the resource in an error state if one of them was fatal.
+func handleLogAlerts(state *store.EngineState, mn model.ManifestName, alerts []store.LogAlert) {
+	if len(alerts) == 0 {
+		return
+	}
+
+	ms, ok := state.ManifestState(mn)
+	if !ok {
+		return
+	}
+
+	for _, alert := range alerts {
+		line := string(state.Secrets.Scrub([]byte(alert.Line)))
+		ms.LogAlertCount++
+		ms.LastLogAlert = line
+
+		if alert.Fatal && ms.IsK8s() {
+			runtime := ms.K8sRuntimeState()
+			runtime.FatalLogAlert = line
+			ms.RuntimeState = runtime
+		}
+	}
 }
 
 func handleExitAction(state *store.EngineState, action exit.Action) {
@@ -690,6 +782,94 @@ func handleServiceEvent(ctx context.Context, state *store.EngineState, action k8
 	runtime.LBs[k8s.ServiceName(service.Name)] = action.URL
 }
 
+func handleObjectReadinessAction(state *store.EngineState, action k8swatch.ObjectReadinessAction) {
+	ms, ok := state.ManifestState(action.ManifestName)
+	if !ok {
+		return
+	}
+
+	runtime := ms.K8sRuntimeState()
+	if runtime.ReadyObjects == nil {
+		runtime.ReadyObjects = make(map[string]bool)
+	}
+
+	key := action.Check.Key()
+	wasReady, seen := runtime.ReadyObjects[key]
+	runtime.ReadyObjects[key] = action.Ready
+	if runtime.AllObjectsReady() {
+		runtime.LastObjectsReadyTime = time.Now()
+	}
+	ms.RuntimeState = runtime
+
+	if seen && wasReady == action.Ready {
+		return
+	}
+
+	ref := action.Check.Ref
+	msg := fmt.Sprintf("%s %s is ready\n", ref.Kind, ref.Name)
+	if !action.Ready {
+		msg = fmt.Sprintf("%s %s is not ready: %s\n", ref.Kind, ref.Name, action.Reason)
+	}
+	spanID := model.LogSpanID(fmt.Sprintf("readiness:%s", action.ManifestName))
+	handleLogAction(state, store.NewLogAction(action.ManifestName, spanID, logger.InfoLvl, nil, []byte(msg)))
+}
+
+func handleRolloutStatusAction(state *store.EngineState, action k8swatch.RolloutStatusAction) {
+	ms, ok := state.ManifestState(action.ManifestName)
+	if !ok {
+		return
+	}
+
+	runtime := ms.K8sRuntimeState()
+	if runtime.RolloutErrors == nil {
+		runtime.RolloutErrors = make(map[types.UID]string)
+	}
+
+	uid := action.Ref.UID
+	oldErr := runtime.RolloutErrors[uid]
+	if action.Error == "" {
+		delete(runtime.RolloutErrors, uid)
+	} else {
+		runtime.RolloutErrors[uid] = action.Error
+	}
+	ms.RuntimeState = runtime
+
+	if oldErr == action.Error {
+		return
+	}
+
+	spanID := model.LogSpanID(fmt.Sprintf("events:%s", action.ManifestName))
+	if action.Error == "" {
+		msg := fmt.Sprintf("%s %s is progressing again\n", action.Ref.Kind, action.Ref.Name)
+		handleLogAction(state, store.NewLogAction(action.ManifestName, spanID, logger.InfoLvl, nil, []byte(msg)))
+		return
+	}
+	handleLogAction(state, store.NewLogAction(action.ManifestName, spanID, logger.ErrorLvl, nil, []byte(action.Error+"\n")))
+}
+
+func handlePortForwardStatusAction(state *store.EngineState, action portforward.PortForwardStatusAction) {
+	ms, ok := state.ManifestState(action.ManifestName)
+	if !ok || !ms.IsK8s() {
+		return
+	}
+
+	runtime := ms.K8sRuntimeState()
+	if runtime.PortForwardStatuses == nil {
+		runtime.PortForwardStatuses = make(map[int]store.PortForwardStatus)
+	}
+
+	port := action.Status.LocalPort
+	if action.Stopped {
+		// Don't delete the status of a newer port-forward on the same port.
+		if runtime.PortForwardStatuses[port].Target == action.Status.Target {
+			delete(runtime.PortForwardStatuses, port)
+		}
+	} else {
+		runtime.PortForwardStatuses[port] = action.Status
+	}
+	ms.RuntimeState = runtime
+}
+
 func handleK8sEvent(ctx context.Context, state *store.EngineState, action store.K8sEventAction) {
 	// TODO(nick): I think we whould so something more intelligent here, where we
 	// have special treatment for different types of events, e.g.:
@@ -729,6 +909,10 @@ func handleInitAction(ctx context.Context, engineState *store.EngineState, actio
 	engineState.CloudAddress = action.CloudAddress
 	engineState.Token = action.Token
 	engineState.TerminalMode = action.TerminalMode
+	engineState.KubeContext = action.KubeContext
+	if action.LogArchive != nil {
+		engineState.LogStore.SetArchive(action.LogArchive)
+	}
 }
 
 func handleHudExitAction(state *store.EngineState, action hud.ExitAction) {
@@ -854,6 +1038,16 @@ func handleMetricsDashboardAction(state *store.EngineState, action metrics.Metri
 	state.MetricsServing.GrafanaHost = action.GrafanaHost
 }
 
+func handleClearLogsAction(state *store.EngineState, action store.ClearLogsAction) {
+	if len(action.ManifestNames) == 0 {
+		state.LogStore.ClearAll()
+		return
+	}
+	for _, mn := range action.ManifestNames {
+		state.LogStore.Clear(mn)
+	}
+}
+
 func handleOverrideTriggerModeAction(ctx context.Context, state *store.EngineState,
 	action server.OverrideTriggerModeAction) {
 	// TODO(maia): in this implementation, overrides do NOT persist across Tiltfile loads
@@ -49,9 +49,12 @@ import (
 	"github.com/tilt-dev/tilt/internal/engine/dockerprune"
 	"github.com/tilt-dev/tilt/internal/engine/exit"
 	"github.com/tilt-dev/tilt/internal/engine/fswatch"
+	"github.com/tilt-dev/tilt/internal/engine/k8sgc"
 	"github.com/tilt-dev/tilt/internal/engine/k8srollout"
 	"github.com/tilt-dev/tilt/internal/engine/k8swatch"
 	"github.com/tilt-dev/tilt/internal/engine/local"
+	"github.com/tilt-dev/tilt/internal/engine/logexport"
+	"github.com/tilt-dev/tilt/internal/engine/loglimit"
 	"github.com/tilt-dev/tilt/internal/engine/metrics"
 	"github.com/tilt-dev/tilt/internal/engine/portforward"
 	"github.com/tilt-dev/tilt/internal/engine/runtimelog"
@@ -2657,26 +2660,6 @@ func TestDockerComposeRecordsRunLogs(t *testing.T) {
 	})
 }
 
-func TestDockerComposeFiltersRunLogs(t *testing.T) {
-	f := newTestFixture(t)
-	m, _ := f.setupDCFixture()
-	expected := "Attaching to snack\n"
-	output := make(chan string, 1)
-	output <- expected
-	defer close(output)
-	f.setDCRunLogOutput(m.DockerComposeTarget(), output)
-
-	f.loadAndStart()
-	f.waitForCompletedBuildCount(2)
-
-	// recorded on manifest state
-	f.withState(func(es store.EngineState) {
-		ms, _ := es.ManifestState(m.ManifestName())
-		spanID := ms.DCRuntimeState().SpanID
-		assert.NotContains(t, es.LogStore.SpanLog(spanID), expected)
-	})
-}
-
 // NOTE(nick): The weird structure of this test is vesigial from when
 // we inferred crash from ContainerState rather than sequences of events.
 func TestDockerComposeDetectsCrashes(t *testing.T) {
@@ -2769,7 +2752,7 @@ func TestEmptyTiltfile(t *testing.T) {
 		err := f.upper.Start(f.ctx, []string{}, model.TiltBuild{}, store.EngineModeUp,
 			f.JoinPath("Tiltfile"), store.TerminalModeHUD,
 			analytics.OptIn, token.Token("unit test token"),
-			"nonexistent.example.com")
+			"nonexistent.example.com", nil)
 		closeCh <- err
 	}()
 	f.WaitUntil("build is set", func(st store.EngineState) bool {
@@ -2803,7 +2786,7 @@ func TestUpperStart(t *testing.T) {
 	go func() {
 		err := f.upper.Start(f.ctx, []string{"foo", "bar"}, model.TiltBuild{},
 			store.EngineModeUp, f.JoinPath("Tiltfile"), store.TerminalModeHUD,
-			analytics.OptIn, tok, cloudAddress)
+			analytics.OptIn, tok, cloudAddress, nil)
 		closeCh <- err
 	}()
 	f.WaitUntil("init action processed", func(state store.EngineState) bool {
@@ -3563,6 +3546,174 @@ fail('x')`)
 	assert.NoError(t, err)
 }
 
+func TestHandleObjectReadinessAction(t *testing.T) {
+	check := model.K8sObjectReadiness{
+		Ref:       v1.ObjectReference{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: "my-cert"},
+		Condition: "Ready",
+	}
+	kTarget := model.K8sTarget{
+		Name:             "cert",
+		YAML:             "fake-yaml",
+		PodReadinessMode: model.PodReadinessIgnore,
+		ObjectReadiness:  []model.K8sObjectReadiness{check},
+	}
+	m := model.Manifest{Name: "cert"}.WithDeployTarget(kTarget)
+
+	state := store.NewState()
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+	ms, _ := state.ManifestState("cert")
+	runtime := ms.K8sRuntimeState()
+	runtime.HasEverDeployedSuccessfully = true
+	ms.RuntimeState = runtime
+	assert.False(t, ms.RuntimeState.HasEverBeenReadyOrSucceeded())
+
+	handleObjectReadinessAction(state, k8swatch.NewObjectReadinessAction("cert", check, false, "condition Ready is False"))
+	assert.Equal(t, model.RuntimeStatusPending, ms.RuntimeState.RuntimeStatus())
+
+	handleObjectReadinessAction(state, k8swatch.NewObjectReadinessAction("cert", check, true, ""))
+	assert.True(t, ms.RuntimeState.HasEverBeenReadyOrSucceeded())
+	assert.Equal(t, model.RuntimeStatusOK, ms.RuntimeState.RuntimeStatus())
+
+	logs := state.LogStore.ManifestLog("cert")
+	assert.Contains(t, logs, "Certificate my-cert is not ready: condition Ready is False")
+	assert.Contains(t, logs, "Certificate my-cert is ready")
+}
+
+func TestHandleRolloutStatusAction(t *testing.T) {
+	f := tempdir.NewTempDirFixture(t)
+	defer f.TearDown()
+
+	m := manifestbuilder.New(f, "sancho").
+		WithK8sYAML(SanchoYAML).
+		Build()
+
+	state := store.NewState()
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+	ms, _ := state.ManifestState("sancho")
+	runtime := ms.K8sRuntimeState()
+	runtime.HasEverDeployedSuccessfully = true
+	runtime.DeployedUIDSet.Add("deploy-uid")
+	ms.RuntimeState = runtime
+
+	ref := v1.ObjectReference{Kind: "Deployment", Name: "sancho", UID: "deploy-uid"}
+	msg := "Deployment sancho rollout failed: ProgressDeadlineExceeded"
+	handleRolloutStatusAction(state, k8swatch.NewRolloutStatusAction("sancho", ref, msg))
+	assert.Equal(t, model.RuntimeStatusError, ms.RuntimeState.RuntimeStatus())
+	assert.EqualError(t, ms.RuntimeState.RuntimeStatusError(), msg)
+
+	handleRolloutStatusAction(state, k8swatch.NewRolloutStatusAction("sancho", ref, ""))
+	assert.NotEqual(t, model.RuntimeStatusError, ms.RuntimeState.RuntimeStatus())
+
+	logs := state.LogStore.ManifestLog("sancho")
+	assert.Contains(t, logs, msg)
+	assert.Contains(t, logs, "Deployment sancho is progressing again")
+}
+
+func TestHandleLogActionAlerts(t *testing.T) {
+	f := tempdir.NewTempDirFixture(t)
+	defer f.TearDown()
+
+	m := manifestbuilder.New(f, "sancho").
+		WithK8sYAML(SanchoYAML).
+		Build()
+	m.LogAlerts = model.LogAlerts{
+		Patterns:      []string{"OutOfMemory"},
+		FatalPatterns: []string{"^panic:"},
+	}
+
+	state := store.NewState()
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+	matcher, err := store.NewLogAlertMatcher(m.LogAlerts)
+	require.NoError(t, err)
+	state.LogAlertMatchers = map[model.ManifestName]*store.LogAlertMatcher{"sancho": matcher}
+
+	ms, _ := state.ManifestState("sancho")
+	runtime := ms.K8sRuntimeState()
+	runtime.HasEverDeployedSuccessfully = true
+	runtime.PodReadinessMode = model.PodReadinessIgnore
+	ms.RuntimeState = runtime
+
+	spanID := model.LogSpanID("pod:sancho-1")
+	handleLogAction(state, store.NewLogAction("sancho", spanID, logger.InfoLvl, nil,
+		[]byte("starting\nwarning: OutOfMemory soon\n")))
+	assert.Equal(t, 1, ms.LogAlertCount)
+	assert.Equal(t, "warning: OutOfMemory soon", ms.LastLogAlert)
+	assert.Equal(t, []string{"warning: OutOfMemory soon\n"}, state.LogStore.Warnings(spanID))
+	assert.Equal(t, model.RuntimeStatusOK, ms.RuntimeState.RuntimeStatus())
+
+	// Tilt's own messages about the resource aren't alerts.
+	handleLogAction(state, store.NewLogAction("sancho", "events:sancho", logger.InfoLvl, nil,
+		[]byte("panic: not from the pod\n")))
+	assert.Equal(t, 1, ms.LogAlertCount)
+
+	handleLogAction(state, store.NewLogAction("sancho", spanID, logger.InfoLvl, nil,
+		[]byte("panic: oh no\n")))
+	assert.Equal(t, 2, ms.LogAlertCount)
+	assert.Equal(t, model.RuntimeStatusError, ms.RuntimeState.RuntimeStatus())
+	assert.EqualError(t, ms.RuntimeState.RuntimeStatusError(), "Log alert: panic: oh no")
+}
+
+func TestHandleClearLogsAction(t *testing.T) {
+	state := store.NewState()
+	state.LogStore.Append(store.NewLogAction("sancho", "pod:sancho-1", logger.InfoLvl, nil, []byte("old sancho\n")), nil)
+	state.LogStore.Append(store.NewLogAction("dulce", "pod:dulce-1", logger.InfoLvl, nil, []byte("old dulce\n")), nil)
+
+	handleClearLogsAction(state, store.ClearLogsAction{ManifestNames: []model.ManifestName{"sancho"}})
+	assert.Equal(t, "", state.LogStore.TailSpan(10, "pod:sancho-1"))
+	assert.Equal(t, "old dulce\n", state.LogStore.TailSpan(10, "pod:dulce-1"))
+
+	handleClearLogsAction(state, store.ClearLogsAction{})
+	assert.Equal(t, "", state.LogStore.TailSpan(10, "pod:dulce-1"))
+}
+
+func TestClearLogsOnRebuild(t *testing.T) {
+	f := tempdir.NewTempDirFixture(t)
+	defer f.TearDown()
+
+	m := manifestbuilder.New(f, "foobar").
+		WithLocalServeCmd("foo").
+		Build()
+
+	state := store.NewState()
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+	state.UpdateSettings = state.UpdateSettings.WithClearLogsOnRebuild(true)
+	state.LogStore.Append(store.NewLogAction("foobar", "localserve:1", logger.InfoLvl, nil, []byte("old foobar\n")), nil)
+
+	handleBuildStarted(context.Background(), state, buildcontrol.BuildStartedAction{
+		ManifestName: "foobar",
+		StartTime:    time.Now(),
+		SpanID:       "build:1",
+	})
+	state.LogStore.Append(store.NewLogAction("foobar", "localserve:1", logger.InfoLvl, nil, []byte("new foobar\n")), nil)
+	assert.Equal(t, "new foobar\n", state.LogStore.TailSpan(10, "localserve:1"))
+	assert.Equal(t, "old foobar\nnew foobar\n", state.LogStore.ManifestLog("foobar"))
+}
+
+func TestHandlePortForwardStatusAction(t *testing.T) {
+	f := tempdir.NewTempDirFixture(t)
+	defer f.TearDown()
+
+	m := manifestbuilder.New(f, "sancho").
+		WithK8sYAML(SanchoYAML).
+		Build()
+
+	state := store.NewState()
+	state.UpsertManifestTarget(store.NewManifestTarget(m))
+	ms, _ := state.ManifestState("sancho")
+
+	status := store.PortForwardStatus{LocalPort: 8000, Target: "svc/sancho", Pods: 2, TotalConnections: 3}
+	handlePortForwardStatusAction(state, portforward.NewPortForwardStatusAction("sancho", status))
+	assert.Equal(t, []store.PortForwardStatus{status}, ms.K8sRuntimeState().SortedPortForwardStatuses())
+
+	// A stopped port-forward with a different target doesn't remove the new one's status.
+	old := store.PortForwardStatus{LocalPort: 8000, Target: "app=sancho"}
+	handlePortForwardStatusAction(state, portforward.NewPortForwardStoppedAction("sancho", old))
+	assert.Equal(t, []store.PortForwardStatus{status}, ms.K8sRuntimeState().SortedPortForwardStatuses())
+
+	handlePortForwardStatusAction(state, portforward.NewPortForwardStoppedAction("sancho", status))
+	assert.Empty(t, ms.K8sRuntimeState().SortedPortForwardStatuses())
+}
+
 func TestHandleTiltfileTriggerQueue(t *testing.T) {
 	f := newTestFixture(t)
 	defer f.TearDown()
@@ -3744,11 +3895,12 @@ func newTestFixture(t *testing.T) *testFixture {
 
 	dockerClient := docker.NewFakeClient()
 
-	ns := k8s.Namespace("default")
 	kCli := k8s.NewFakeK8sClient()
-	of := k8s.ProvideOwnerFetcher(ctx, kCli)
-	pw := k8swatch.NewPodWatcher(kCli, of, ns)
-	sw := k8swatch.NewServiceWatcher(kCli, of, ns)
+	clients := k8s.NewFakeContextClients(ctx, kCli)
+	pw := k8swatch.NewPodWatcher(clients)
+	sw := k8swatch.NewServiceWatcher(clients)
+	orw := k8swatch.NewObjectReadinessWatcher(clients)
+	rw := k8swatch.NewRolloutWatcher(clients)
 
 	fSub := fixtureSub{ch: make(chan bool, 1000)}
 	st := store.NewStore(UpperReducer, store.LogActionsFlag(false))
@@ -3765,17 +3917,17 @@ func newTestFixture(t *testing.T) *testFixture {
 	env := k8s.EnvDockerDesktop
 	cdc := controllers.ProvideDeferredClient()
 	plm := runtimelog.NewPodLogManager(cdc)
-	plsc := runtimelog.NewPodLogStreamController(ctx, cdc, st, kCli)
+	plsc := runtimelog.NewPodLogStreamController(ctx, cdc, st, clients)
 	ccb := controllers.NewClientBuilder(cdc).WithUncached(&v1alpha1.FileWatch{})
 	fwms := fswatch.NewManifestSubscriber(cdc)
-	pfc := portforward.NewController(kCli)
+	pfc := portforward.NewController(clients)
 	au := engineanalytics.NewAnalyticsUpdater(ta, engineanalytics.CmdTags{})
 	ar := engineanalytics.ProvideAnalyticsReporter(ta, st, kCli, env)
 	fakeDcc := dockercompose.NewFakeDockerComposeClient(t, ctx)
-	k8sContextExt := k8scontext.NewExtension("fake-context", env)
+	k8sContextExt := k8scontext.NewExtension("fake-context", env, nil)
 	versionExt := version.NewExtension(model.TiltBuild{Version: "0.5.0"})
 	configExt := config.NewExtension("up")
-	tfl := tiltfile.ProvideTiltfileLoader(ta, kCli, k8sContextExt, versionExt, configExt, fakeDcc, "localhost", feature.MainDefaults, env)
+	tfl := tiltfile.ProvideTiltfileLoader(ta, kCli, k8s.DefaultNamespace, k8sContextExt, versionExt, configExt, fakeDcc, "localhost", feature.MainDefaults, env)
 	cc := configs.NewConfigsController(tfl, dockerClient)
 	dcw := dcwatch.NewEventWatcher(fakeDcc, dockerClient)
 	dclm := runtimelog.NewDockerComposeLogManager(fakeDcc)
@@ -3783,7 +3935,7 @@ func newTestFixture(t *testing.T) *testFixture {
 	serverOptions, err := server.ProvideTiltServerOptions(ctx, "localhost", 0, model.TiltBuild{}, memconn)
 	require.NoError(t, err)
 	hudsc := server.ProvideHeadsUpServerController(0, serverOptions, &server.HeadsUpServer{}, assets.NewFakeServer(), model.WebURL{})
-	ewm := k8swatch.NewEventWatchManager(kCli, of, ns)
+	ewm := k8swatch.NewEventWatchManager(clients)
 	tcum := cloud.NewStatusManager(httptest.NewFakeClientEmptyJSON(), clock)
 	fe := cmd.NewFakeExecer()
 	fpm := cmd.NewFakeProberManager()
@@ -3842,8 +3994,11 @@ func newTestFixture(t *testing.T) *testFixture {
 	mc := metrics.NewController(de, model.TiltBuild{}, "")
 	mcc := metrics.NewModeController("localhost", user.NewFakePrefs())
 
-	subs := ProvideSubscribers(hudsc, tscm, cb, h, ts, tp, pw, sw, plm, pfc, fwms, bc, cc, dcw, dclm, ar, au, ewm, tcum, dp, tc, lsc, podm, ec, mc, mcc)
-	ret.upper, err = NewUpper(ctx, st, subs)
+	gc := k8sgc.NewGarbageCollector(clients)
+	lec := logexport.NewController(clock, model.TiltBuild{})
+	llc := loglimit.NewController()
+	subs := ProvideSubscribers(hudsc, tscm, cb, h, ts, tp, pw, sw, orw, rw, plm, pfc, fwms, bc, cc, dcw, dclm, ar, au, ewm, tcum, dp, tc, lsc, podm, ec, mc, mcc, gc, lec, llc)
+	ret.upper, err = NewUpper(ctx, st, subs, clients.DefaultContext())
 	require.NoError(t, err)
 
 	go func() {
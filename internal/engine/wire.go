@@ -32,6 +32,8 @@ var DeployerBaseWireSet = wire.NewSet(
 	build.NewDockerImageBuilder,
 	build.NewExecCustomBuilder,
 	wire.Bind(new(build.CustomBuilder), new(*build.ExecCustomBuilder)),
+	build.NewExecImageAttester,
+	wire.Bind(new(build.ImageAttester), new(*build.ExecImageAttester)),
 
 	// BuildOrder
 	NewLocalTargetBuildAndDeployer,
@@ -63,6 +65,7 @@ func provideBuildAndDeployer(
 	ctx context.Context,
 	docker docker.Client,
 	kClient k8s.Client,
+	clients *k8s.ContextClients,
 	dir *dirs.TiltDevDir,
 	env k8s.Env,
 	updateMode buildcontrol.UpdateModeFlag,
@@ -82,6 +85,7 @@ func provideImageBuildAndDeployer(
 	ctx context.Context,
 	docker docker.Client,
 	kClient k8s.Client,
+	clients *k8s.ContextClients,
 	env k8s.Env,
 	dir *dirs.TiltDevDir,
 	clock build.Clock,
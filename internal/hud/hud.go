@@ -173,6 +173,17 @@ func (h *Hud) handleScreenEvent(ctx context.Context, dispatch func(action store.
 				escape()
 			case r == 'R': // hidden key for recovering from printf junk during demos
 				h.r.screen.Sync()
+			case r == 'c': // [C]lear logs
+				h.recordInteraction("clear_logs")
+				if h.currentViewState.TabState == view.TabAllLog {
+					dispatch(store.ClearLogsAction{})
+					break
+				}
+				if len(h.currentView.Resources) == 0 {
+					break
+				}
+				_, selected := h.selectedResource()
+				dispatch(store.ClearLogsAction{ManifestNames: []model.ManifestName{selected.Name}})
 			case r == 'x':
 				h.recordInteraction("cycle_view_log_state")
 				h.currentViewState.CycleViewLogState()
@@ -2,13 +2,17 @@ package hud
 
 import (
 	"bytes"
+	"context"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/gdamore/tcell"
+	"github.com/stretchr/testify/assert"
 
+	"github.com/tilt-dev/tilt/internal/hud/view"
 	"github.com/tilt-dev/tilt/internal/rty"
+	"github.com/tilt-dev/tilt/internal/store"
 	"github.com/tilt-dev/tilt/internal/testutils"
 	"github.com/tilt-dev/tilt/pkg/model"
 )
@@ -24,3 +28,30 @@ func TestRenderInit(t *testing.T) {
 	hud := NewHud(r, model.WebURL(*webURL), ta)
 	hud.(*Hud).refresh(ctx) // Ensure we render without error
 }
+
+func TestClearLogsKey(t *testing.T) {
+	logs := new(bytes.Buffer)
+	_, _, ta := testutils.ForkedCtxAndAnalyticsForTest(logs)
+	ctx := context.Background()
+
+	r := NewRenderer(time.Now)
+	r.rty = rty.NewRTY(tcell.NewSimulationScreen(""), t)
+	webURL, _ := url.Parse("http://localhost:10350")
+	hud := NewHud(r, model.WebURL(*webURL), ta).(*Hud)
+	hud.currentView = view.View{Resources: []view.Resource{{Name: "fe"}, {Name: "be"}}}
+
+	var actions []store.Action
+	dispatch := func(action store.Action) { actions = append(actions, action) }
+	key := tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone)
+
+	hud.currentViewState.TabState = view.TabAllLog
+	hud.handleScreenEvent(ctx, dispatch, key)
+
+	hud.currentViewState.TabState = view.TabBuildLog
+	hud.handleScreenEvent(ctx, dispatch, key)
+
+	assert.Equal(t, []store.Action{
+		store.ClearLogsAction{},
+		store.ClearLogsAction{ManifestNames: []model.ManifestName{"fe"}},
+	}, actions)
+}
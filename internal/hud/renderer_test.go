@@ -572,6 +572,38 @@ func TestBuildHistory(t *testing.T) {
 	rtf.run("multiple build history entries", 80, 20, v, vs)
 }
 
+func TestK8sDiff(t *testing.T) {
+	rtf := newRendererTestFixture(t)
+	ts := time.Now().Add(-30 * time.Second)
+
+	v := newView(view.Resource{
+		Name: "vigoda",
+		BuildHistory: []model.BuildRecord{
+			{
+				Edits:      []string{"main.go"},
+				StartTime:  ts.Add(-10 * time.Second),
+				FinishTime: ts,
+				K8sDiff: `--- deployment.apps/vigoda (live)
++++ deployment.apps/vigoda (deployed)
+@@ -6,1 +6,1 @@
+-  replicas: 1
++  replicas: 2
+`,
+			},
+		},
+		ResourceInfo: view.K8sResourceInfo{
+			PodName:            "vigoda-pod",
+			PodStatus:          "Running",
+			RunStatus:          model.RuntimeStatusOK,
+			PodUpdateStartTime: ts,
+			PodCreationTime:    ts.Add(-time.Minute),
+		},
+		LastDeployTime: ts,
+	})
+	vs := fakeViewState(1, view.CollapseNo)
+	rtf.run("k8s diff of last deploy", 80, 20, v, vs)
+}
+
 func TestDockerComposeUpExpanded(t *testing.T) {
 	rtf := newRendererTestFixture(t)
 
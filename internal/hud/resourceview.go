@@ -159,11 +159,19 @@ func (v *ResourceView) titleTextName() rty.Component {
 }
 
 func (v *ResourceView) warnings() []string {
+	var warnings []string
 	spanID := v.res.LastBuild().SpanID
-	if spanID == "" {
-		return nil
+	if spanID != "" {
+		warnings = v.logReader.Warnings(spanID)
+	}
+	if v.res.LogAlertCount > 0 {
+		noun := "alert"
+		if v.res.LogAlertCount > 1 {
+			noun = "alerts"
+		}
+		warnings = append(warnings, fmt.Sprintf("%d log %s: %s\n", v.res.LogAlertCount, noun, v.res.LastLogAlert))
 	}
-	return v.logReader.Warnings(spanID)
+	return warnings
 }
 
 func (v *ResourceView) titleText() rty.Component {
@@ -211,6 +219,7 @@ func (v *ResourceView) resourceExpandedPane() rty.Component {
 	rhs.Add(v.resourceExpandedHistory())
 	rhs.Add(v.resourceExpanded())
 	rhs.Add(v.resourceExpandedEndpoints())
+	rhs.Add(v.resourceExpandedK8sDiff())
 	rhs.Add(v.resourceExpandedError())
 	l.AddDynamic(rhs)
 	return l
@@ -414,6 +423,29 @@ func (v *ResourceView) resourceExpandedHistory() rty.Component {
 	return l
 }
 
+// Shows what the last deploy changed in the cluster, if anything.
+func (v *ResourceView) resourceExpandedK8sDiff() rty.Component {
+	diff := strings.TrimRight(v.res.LastBuild().K8sDiff, "\n")
+	if diff == "" {
+		return rty.NewConcatLayout(rty.DirVert)
+	}
+
+	l := rty.NewConcatLayout(rty.DirVert)
+	l.Add(rty.NewStringBuilder().Fg(cLightText).Text("CHANGES:").Build())
+
+	indentPane := rty.NewConcatLayout(rty.DirHor)
+	indentPane.Add(rty.TextString(strings.Repeat(" ", 3)))
+
+	diffPane := rty.NewConcatLayout(rty.DirVert)
+	for _, line := range strings.Split(diff, "\n") {
+		diffPane.Add(rty.TextString(line))
+	}
+	indentPane.Add(rty.NewMaxLengthLayout(diffPane, rty.DirVert, MaxInlineErrHeight))
+	l.Add(indentPane)
+
+	return l
+}
+
 func (v *ResourceView) resourceExpandedError() rty.Component {
 	errPane, ok := v.resourceExpandedBuildError()
 	isWarnings := false
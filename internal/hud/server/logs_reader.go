@@ -2,9 +2,18 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 
@@ -32,11 +41,6 @@ type WebsocketReader struct {
 	handler      ViewHandler
 }
 
-func newWebsocketReaderForLogs(conn WebsocketConn, persistent bool, resources []string, p *hud.IncrementalPrinter) *WebsocketReader {
-	ls := NewLogStreamer(resources, p)
-	return newWebsocketReader(conn, persistent, ls)
-}
-
 func newWebsocketReader(conn WebsocketConn, persistent bool, handler ViewHandler) *WebsocketReader {
 	return &WebsocketReader{
 		conn:         conn,
@@ -55,7 +59,68 @@ type LogStreamer struct {
 	logstore   *logstore.LogStore
 	checkpoint logstore.Checkpoint
 	resources  model.ManifestNameSet // if present, resource(s) to stream logs for
+	filter     logstore.Filter       // if present, only print segments that match
 	printer    *hud.IncrementalPrinter
+	json       *json.Encoder // if present, print segments as JSON lines instead
+}
+
+// One line of `tilt logs --json` output.
+type jsonLogLine struct {
+	Time         time.Time          `json:"time"`
+	ManifestName model.ManifestName `json:"manifest_name"`
+	SpanID       string             `json:"span_id"`
+	Level        string             `json:"level"`
+	Source       logstore.Source    `json:"source"`
+	Text         string             `json:"text"`
+	Fields       map[string]string  `json:"fields,omitempty"`
+}
+
+// Filters for `tilt logs`, in the format of the /api/logs/search query params.
+type LogFilterParams struct {
+	Grep     string
+	Level    string
+	Since    string
+	SpanType string
+
+	// Only logs with these fields, each formatted as key=value.
+	Fields []string
+
+	// Only logs since each resource's last build, or since its logs were
+	// last cleared.
+	SinceLastBuild bool
+}
+
+func (p LogFilterParams) Empty() bool {
+	return p.Grep == "" &&
+		p.Level == "" &&
+		p.Since == "" &&
+		p.SpanType == "" &&
+		len(p.Fields) == 0 &&
+		!p.SinceLastBuild
+}
+
+func (p LogFilterParams) query(resources []string) url.Values {
+	query := url.Values{}
+	for key, val := range map[string]string{
+		"grep":      p.Grep,
+		"level":     p.Level,
+		"since":     p.Since,
+		"span_type": p.SpanType,
+	} {
+		if val != "" {
+			query.Set(key, val)
+		}
+	}
+	for _, field := range p.Fields {
+		query.Add("field", field)
+	}
+	if p.SinceLastBuild {
+		query.Set("since_last_build", "true")
+	}
+	for _, r := range resources {
+		query.Add("resource", r)
+	}
+	return query
 }
 
 func NewLogStreamer(resources []string, p *hud.IncrementalPrinter) *LogStreamer {
@@ -71,9 +136,13 @@ func NewLogStreamer(resources []string, p *hud.IncrementalPrinter) *LogStreamer
 	}
 }
 
+// Prints each segment as a line of JSON, rather than as formatted text.
+func (ls *LogStreamer) withJSONOutput(w io.Writer) *LogStreamer {
+	ls.json = json.NewEncoder(w)
+	return ls
+}
+
 func (ls *LogStreamer) Handle(v proto_webview.View) error {
-	// if printing logs for only one resource, don't need resource name prefix
-	suppressPrefix := len(ls.resources) == 1
 	fromCheckpoint := logstore.Checkpoint(v.LogList.FromCheckpoint)
 	toCheckpoint := logstore.Checkpoint(v.LogList.ToCheckpoint)
 
@@ -85,27 +154,218 @@ func (ls *LogStreamer) Handle(v proto_webview.View) error {
 	segments := v.LogList.Segments
 	if fromCheckpoint < ls.checkpoint {
 		// The server is re-sending some logs we already have, so slice them off.
-		deleteCount := ls.checkpoint - fromCheckpoint
+		deleteCount := int(ls.checkpoint - fromCheckpoint)
+		if deleteCount >= len(segments) {
+			return nil
+		}
 		segments = segments[deleteCount:]
 	}
 
+	ls.print(segments, v.LogList.Spans)
+
+	if toCheckpoint > ls.checkpoint {
+		ls.checkpoint = toCheckpoint
+	}
+
+	return nil
+}
+
+// Prints the matches from one page of /api/logs/search.
+func (ls *LogStreamer) handleSearchResponse(resp logSearchResponse) error {
+	segments := make([]*proto_webview.LogSegment, 0, len(resp.Matches))
+	spans := make(map[string]*proto_webview.LogSpan)
+	for _, m := range resp.Matches {
+		ts, err := ptypes.TimestampProto(m.Time)
+		if err != nil {
+			return err
+		}
+		segments = append(segments, &proto_webview.LogSegment{
+			SpanId: m.SpanID,
+			Time:   ts,
+			Text:   m.Text,
+			Level:  proto_webview.LogLevel(proto_webview.LogLevel_value[m.Level]),
+			Fields: m.Fields,
+		})
+		spans[m.SpanID] = &proto_webview.LogSpan{ManifestName: m.ManifestName.String()}
+	}
+
+	ls.print(segments, spans)
+
+	to := logstore.Checkpoint(resp.ToCheckpoint)
+	if to > ls.checkpoint {
+		ls.checkpoint = to
+	}
+	return nil
+}
+
+func (ls *LogStreamer) print(segments []*proto_webview.LogSegment, spans map[string]*proto_webview.LogSpan) {
+	if ls.json != nil {
+		ls.printJSON(segments, spans)
+		return
+	}
+
+	// if printing logs for only one resource, don't need resource name prefix
+	suppressPrefix := len(ls.resources) == 1
+
+	// The server's checkpoints don't necessarily line up with our local store's,
+	// so print everything we appended.
+	localCheckpoint := ls.logstore.Checkpoint()
 	for _, seg := range segments {
+		if !ls.matchesFilter(seg, spans) {
+			continue
+		}
+
 		// TODO(maia): secrets???
-		ls.logstore.Append(webview.LogSegmentToEvent(seg, v.LogList.Spans), model.SecretSet{})
+		ls.logstore.Append(webview.LogSegmentToEvent(seg, spans), model.SecretSet{})
 	}
 
-	ls.printer.Print(ls.logstore.ContinuingLinesWithOptions(ls.checkpoint, logstore.LineOptions{
+	ls.printer.Print(ls.logstore.ContinuingLinesWithOptions(localCheckpoint, logstore.LineOptions{
 		ManifestNames:  ls.resources,
 		SuppressPrefix: suppressPrefix,
 	}))
+}
 
-	if toCheckpoint > ls.checkpoint {
-		ls.checkpoint = toCheckpoint
+func (ls *LogStreamer) matchesFilter(seg *proto_webview.LogSegment, spans map[string]*proto_webview.LogSpan) bool {
+	if ls.filter.Empty() {
+		return true
 	}
 
-	return nil
+	mn := model.ManifestName("")
+	if span, ok := spans[seg.SpanId]; ok {
+		mn = model.ManifestName(span.ManifestName)
+	}
+
+	return ls.filter.Matches(logstore.LogSegment{
+		SpanID: logstore.SpanID(seg.SpanId),
+		Time:   segmentTime(seg),
+		Text:   []byte(seg.Text),
+		Level:  logger.LevelFromProtoID(int32(seg.Level)),
+		Fields: seg.Fields,
+	}, mn)
+}
+
+// Prints one line of JSON per segment.
+//
+// Segments are usually whole lines, but a program that prints a partial line
+// (like a progress bar) can produce a segment without a trailing newline.
+func (ls *LogStreamer) printJSON(segments []*proto_webview.LogSegment, spans map[string]*proto_webview.LogSpan) {
+	for _, seg := range segments {
+		span, ok := spans[seg.SpanId]
+		if !ok {
+			continue
+		}
+
+		mn := model.ManifestName(span.ManifestName)
+		if len(ls.resources) > 0 && !ls.resources[mn] {
+			continue
+		}
+		if !ls.matchesFilter(seg, spans) {
+			continue
+		}
+
+		spanID := logstore.SpanID(seg.SpanId)
+		err := ls.json.Encode(jsonLogLine{
+			Time:         segmentTime(seg),
+			ManifestName: mn,
+			SpanID:       seg.SpanId,
+			Level:        strings.ToLower(seg.Level.String()),
+			Source:       logstore.SourceForSpanID(spanID),
+			Text:         strings.TrimSuffix(seg.Text, "\n"),
+			Fields:       seg.Fields,
+		})
+		if err != nil {
+			// The writer is stdout, so there's nowhere better to report this.
+			return
+		}
+	}
 }
-func StreamLogs(ctx context.Context, follow bool, url model.WebURL, resources []string, printer *hud.IncrementalPrinter) error {
+
+// Segments without a timestamp are treated as new.
+func segmentTime(seg *proto_webview.LogSegment) time.Time {
+	if seg.Time != nil {
+		t, err := ptypes.Timestamp(seg.Time)
+		if err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
+
+// Streams logs from a running Tilt.
+//
+// If jsonOut is set, logs are printed there as JSON lines, instead of through the printer.
+func StreamLogs(ctx context.Context, follow bool, history bool, url model.WebURL, resources []string, filter LogFilterParams, printer *hud.IncrementalPrinter, jsonOut io.Writer) error {
+	newStreamer := func() *LogStreamer {
+		ls := NewLogStreamer(resources, printer)
+		if jsonOut != nil {
+			ls = ls.withJSONOutput(jsonOut)
+		}
+		return ls
+	}
+
+	if !filter.Empty() {
+		return streamFilteredLogs(ctx, follow, history, url, resources, filter, newStreamer)
+	}
+
+	ls := newStreamer()
+	if history {
+		// The previous session's checkpoints are numbered separately,
+		// so they get their own streamer.
+		err := fetchLogHistory(ctx, url, "previous", newStreamer())
+		if err != nil {
+			return err
+		}
+
+		err = fetchLogHistory(ctx, url, "current", ls)
+		if err != nil {
+			return err
+		}
+	}
+
+	return listenForLogs(ctx, follow, url, ls)
+}
+
+// Searches the logs on the server, so that we don't need to download all of them.
+//
+// The search only covers logs that exist when we start. If we're following,
+// we filter new logs from the websocket on our end.
+func streamFilteredLogs(ctx context.Context, follow bool, history bool, url model.WebURL, resources []string, filter LogFilterParams, newStreamer func() *LogStreamer) error {
+	query := filter.query(resources)
+
+	// Check the filters before we talk to the server, so that typos get a quick error.
+	logFilter, err := logFilterFromQuery(query, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ls := newStreamer()
+	ls.filter = logFilter
+
+	// The last build is always in the current session.
+	if history && !filter.SinceLastBuild {
+		// The previous session's checkpoints are numbered separately,
+		// so they get their own streamer.
+		err := fetchLogSearch(ctx, url, "previous", query, newStreamer())
+		if err != nil {
+			return err
+		}
+	}
+
+	err = fetchLogSearch(ctx, url, "current", query, ls)
+	if err != nil {
+		return err
+	}
+
+	if !follow {
+		return nil
+	}
+
+	// The websocket starts by re-sending the logs we've already searched,
+	// which the streamer skips because they're before its checkpoint.
+	return listenForLogs(ctx, follow, url, ls)
+}
+
+func listenForLogs(ctx context.Context, follow bool, url model.WebURL, ls *LogStreamer) error {
 	url.Scheme = "ws"
 	url.Path = "/ws/view"
 	logger.Get(ctx).Debugf("connecting to %s", url.String())
@@ -116,10 +376,115 @@ func StreamLogs(ctx context.Context, follow bool, url model.WebURL, resources []
 	}
 	defer conn.Close()
 
-	wsr := newWebsocketReaderForLogs(conn, follow, resources, printer)
+	wsr := newWebsocketReader(conn, follow, ls)
 	return wsr.Listen(ctx)
 }
 
+// Pages through the log history of a Tilt session, from the beginning.
+func fetchLogHistory(ctx context.Context, url model.WebURL, session string, ls *LogStreamer) error {
+	url.Scheme = "http"
+	url.Path = "/api/logs"
+
+	unmarshaller := jsonpb.Unmarshaler{}
+	from := int32(0)
+	for {
+		url.RawQuery = fmt.Sprintf("session=%s&from=%d", session, from)
+		logger.Get(ctx).Debugf("fetching %s", url.String())
+
+		logList, err := fetchLogPage(ctx, url, unmarshaller)
+		if err != nil {
+			return err
+		}
+		if logList.FromCheckpoint == -1 {
+			return nil
+		}
+
+		err = ls.Handle(proto_webview.View{LogList: logList})
+		if err != nil {
+			return err
+		}
+		from = logList.ToCheckpoint
+	}
+}
+
+func fetchLogPage(ctx context.Context, url model.WebURL, unmarshaller jsonpb.Unmarshaler) (*proto_webview.LogList, error) {
+	body, err := getLogs(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	logList := &proto_webview.LogList{}
+	err = unmarshaller.Unmarshal(body, logList)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading log history")
+	}
+	return logList, nil
+}
+
+// Pages through the matches of a log search in a Tilt session, from the beginning.
+func fetchLogSearch(ctx context.Context, url model.WebURL, session string, query url.Values, ls *LogStreamer) error {
+	url.Scheme = "http"
+	url.Path = "/api/logs/search"
+
+	from := 0
+	for {
+		query.Set("session", session)
+		query.Set("from", strconv.Itoa(from))
+		url.RawQuery = query.Encode()
+		logger.Get(ctx).Debugf("fetching %s", url.String())
+
+		resp, err := fetchLogSearchPage(ctx, url)
+		if err != nil {
+			return err
+		}
+
+		err = ls.handleSearchResponse(resp)
+		if err != nil {
+			return err
+		}
+
+		if !resp.HasMore {
+			return nil
+		}
+		from = resp.ToCheckpoint
+	}
+}
+
+func fetchLogSearchPage(ctx context.Context, url model.WebURL) (logSearchResponse, error) {
+	body, err := getLogs(ctx, url)
+	if err != nil {
+		return logSearchResponse{}, err
+	}
+	defer body.Close()
+
+	resp := logSearchResponse{}
+	err = json.NewDecoder(body).Decode(&resp)
+	if err != nil {
+		return logSearchResponse{}, errors.Wrap(err, "reading log search")
+	}
+	return resp, nil
+}
+
+func getLogs(ctx context.Context, url model.WebURL) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching logs %s", url.String())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("fetching logs %s: %s", url.String(), strings.TrimSpace(string(body)))
+	}
+	return resp.Body, nil
+}
+
 func (wsr *WebsocketReader) Listen(ctx context.Context) error {
 	done := make(chan struct{})
 	go func() {
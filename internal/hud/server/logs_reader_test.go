@@ -2,14 +2,20 @@ package server
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tilt-dev/tilt/pkg/logger"
 	"github.com/tilt-dev/tilt/pkg/model"
+	"github.com/tilt-dev/tilt/pkg/model/logstore"
 
 	"github.com/tilt-dev/tilt/internal/hud"
 	proto_webview "github.com/tilt-dev/tilt/pkg/webview"
@@ -81,6 +87,26 @@ func TestLogStreamerCheckpointHandling(t *testing.T) {
 	f.assertExpectedLogLines(expected)
 }
 
+func TestLogStreamerServerCheckpointsDontStartAtZero(t *testing.T) {
+	f := newLogStreamerFixture(t)
+	resourceNames := []string{"foo", "foo", "bar", "bar", "foo", "bar"}
+
+	// The server has truncated its logs, so its first checkpoint isn't 0.
+	view := f.newViewWithLogsForManifests(alphabet[:3], resourceNames[:3], 100)
+	f.handle(view)
+
+	// Re-sends one segment we already have.
+	view = f.newViewWithLogsForManifests(alphabet[2:6], resourceNames[2:6], view.LogList.ToCheckpoint-1)
+	f.handle(view)
+
+	// Re-sends only segments we already have.
+	view = f.newViewWithLogsForManifests(alphabet[4:6], resourceNames[4:6], view.LogList.ToCheckpoint-2)
+	f.handle(view)
+
+	expected := f.expectedLinesWithPrefixes(alphabet[:6], resourceNames)
+	f.assertExpectedLogLines(expected)
+}
+
 func TestLogStreamerFiltersOnResourceNamesSingle(t *testing.T) {
 	f := newLogStreamerFixture(t).withResourceNames("foo")
 	manifestNames := []string{"foo", "", "foo", "bar"}
@@ -119,6 +145,101 @@ func TestLogStreamerCheckpointHandlingWithFiltering(t *testing.T) {
 	f.assertExpectedLogLines(expected)
 }
 
+func TestLogStreamerFiltersWebsocketLogs(t *testing.T) {
+	f := newLogStreamerFixture(t)
+	f.ls.filter = logstore.Filter{Pattern: regexp.MustCompile(`^(bravo|delta)$`)}
+
+	view := f.newViewWithLogsForManifest(alphabet[:4], "foo", 0)
+	f.handle(view)
+
+	expected := f.expectedLinesWithPrefix([]string{"bravo", "delta"}, "foo")
+	f.assertExpectedLogLines(expected)
+}
+
+func TestLogStreamerSearchThenFollow(t *testing.T) {
+	f := newLogStreamerFixture(t)
+	f.ls.filter = logstore.Filter{Pattern: regexp.MustCompile(`o$`)}
+
+	// The search found matches among the first 4 logs.
+	err := f.ls.handleSearchResponse(logSearchResponse{
+		Matches: []logSearchMatch{
+			{Checkpoint: 1, SpanID: spanID("foo"), ManifestName: "foo", Time: time.Now(), Level: "INFO", Text: "bravo\n"},
+			{Checkpoint: 2, SpanID: spanID("foo"), ManifestName: "foo", Time: time.Now(), Level: "INFO", Text: "echo\n"},
+		},
+		FromCheckpoint: 0,
+		ToCheckpoint:   4,
+	})
+	require.NoError(t, err)
+
+	// The websocket re-sends the logs we searched, then sends new ones.
+	view := f.newViewWithLogsForManifest([]string{"alpha", "bravo", "echo", "delta", "romeo", "lima"}, "foo", 0)
+	f.handle(view)
+
+	expected := f.expectedLinesWithPrefix([]string{"bravo", "echo", "romeo"}, "foo")
+	f.assertExpectedLogLines(expected)
+}
+
+func TestLogStreamerSearchFields(t *testing.T) {
+	f := newLogStreamerFixture(t)
+	stderr := map[string]string{logger.FieldNameStream: logger.StreamStderr}
+	f.ls.filter = logstore.Filter{Fields: stderr}
+
+	err := f.ls.handleSearchResponse(logSearchResponse{
+		Matches: []logSearchMatch{
+			{Checkpoint: 1, SpanID: spanID("foo"), ManifestName: "foo", Time: time.Now(), Level: "INFO", Text: "bravo\n", Fields: stderr},
+		},
+		FromCheckpoint: 0,
+		ToCheckpoint:   2,
+	})
+	require.NoError(t, err)
+
+	expected := f.expectedLinesWithPrefix([]string{"bravo"}, "foo")
+	f.assertExpectedLogLines(expected)
+}
+
+func TestLogStreamerJSON(t *testing.T) {
+	f := newLogStreamerFixture(t).withResourceNames("foo")
+	f.ls.withJSONOutput(f.fakeStdout)
+
+	ts := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	pbTs, err := ptypes.TimestampProto(ts)
+	require.NoError(t, err)
+
+	f.handle(proto_webview.View{
+		LogList: &proto_webview.LogList{
+			Spans: map[string]*proto_webview.LogSpan{
+				"build:1":    {ManifestName: "foo"},
+				"pod:foo-1":  {ManifestName: "foo"},
+				"pod:bar-1":  {ManifestName: "bar"},
+				"tiltfile:1": {ManifestName: "(Tiltfile)"},
+			},
+			Segments: []*proto_webview.LogSegment{
+				{SpanId: "tiltfile:1", Time: pbTs, Text: "loading\n", Level: proto_webview.LogLevel_INFO},
+				{SpanId: "build:1", Time: pbTs, Text: "building\n", Level: proto_webview.LogLevel_INFO},
+				{SpanId: "pod:bar-1", Time: pbTs, Text: "bar ready\n", Level: proto_webview.LogLevel_INFO},
+				{SpanId: "pod:foo-1", Time: pbTs, Text: "connection refused\n", Level: proto_webview.LogLevel_ERROR,
+					Fields: map[string]string{"port": "8080"}},
+			},
+			FromCheckpoint: 0,
+			ToCheckpoint:   4,
+		},
+	})
+
+	var lines []jsonLogLine
+	decoder := json.NewDecoder(f.fakeStdout)
+	for decoder.More() {
+		var line jsonLogLine
+		require.NoError(t, decoder.Decode(&line))
+		lines = append(lines, line)
+	}
+
+	assert.Equal(t, []jsonLogLine{
+		{Time: ts, ManifestName: "foo", SpanID: "build:1", Level: "info", Source: logstore.SourceBuild, Text: "building"},
+		{Time: ts, ManifestName: "foo", SpanID: "pod:foo-1", Level: "error", Source: logstore.SourcePod, Text: "connection refused",
+			Fields: map[string]string{"port": "8080"}},
+	}, lines)
+}
+
 type logStreamerFixture struct {
 	t          *testing.T
 	fakeStdout *bytes.Buffer
@@ -257,3 +378,14 @@ func (f *logStreamerFixture) expectedLinesWithPrefixes(messages []string, prefix
 func spanID(mn string) string {
 	return fmt.Sprintf("spanID-%s", mn)
 }
+
+func TestLogFilterParamsQuery(t *testing.T) {
+	p := LogFilterParams{Level: "warn", SinceLastBuild: true}
+	assert.Equal(t, "level=warn&resource=fe&since_last_build=true", p.query([]string{"fe"}).Encode())
+	assert.False(t, p.Empty())
+	assert.True(t, LogFilterParams{}.Empty())
+
+	p = LogFilterParams{Fields: []string{"stream=stderr", "a=b"}}
+	assert.Equal(t, "field=stream%3Dstderr&field=a%3Db", p.query(nil).Encode())
+	assert.False(t, p.Empty())
+}
@@ -9,7 +9,11 @@ import (
 	"log"
 	"net/http"
 	_ "net/http/pprof"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/gorilla/mux"
@@ -25,12 +29,23 @@ import (
 	"github.com/tilt-dev/tilt/internal/k8s"
 	"github.com/tilt-dev/tilt/internal/store"
 	"github.com/tilt-dev/tilt/pkg/assets"
+	"github.com/tilt-dev/tilt/pkg/logger"
 	"github.com/tilt-dev/tilt/pkg/model"
+	"github.com/tilt-dev/tilt/pkg/model/logstore"
 	proto_webview "github.com/tilt-dev/tilt/pkg/webview"
 )
 
 const TiltTokenCookieName = "Tilt-Token"
 
+// The most log text we send in one page of /api/logs.
+const logPageMaxBytes = 1000 * 1000
+
+// The number of matches we send in one page of /api/logs/search, by default and at most.
+const (
+	logSearchDefaultLimit = 100
+	logSearchMaxLimit     = 1000
+)
+
 type analyticsPayload struct {
 	Verb string            `json:"verb"`
 	Name string            `json:"name"`
@@ -51,6 +66,10 @@ type overrideTriggerModePayload struct {
 	TriggerMode   int      `json:"trigger_mode"`
 }
 
+type clearLogsPayload struct {
+	ManifestNames []string `json:"manifest_names"`
+}
+
 type actionPayload struct {
 	Type            string             `json:"type"`
 	ManifestName    model.ManifestName `json:"manifest_name"`
@@ -58,6 +77,23 @@ type actionPayload struct {
 	VisibleRestarts int                `json:"visible_restarts"`
 }
 
+type logSearchMatch struct {
+	Checkpoint   int                `json:"checkpoint"`
+	SpanID       string             `json:"span_id"`
+	ManifestName model.ManifestName `json:"manifest_name"`
+	Time         time.Time          `json:"time"`
+	Level        string             `json:"level"`
+	Text         string             `json:"text"`
+	Fields       map[string]string  `json:"fields,omitempty"`
+}
+
+type logSearchResponse struct {
+	Matches        []logSearchMatch `json:"matches"`
+	FromCheckpoint int              `json:"from_checkpoint"`
+	ToCheckpoint   int              `json:"to_checkpoint"`
+	HasMore        bool             `json:"has_more"`
+}
+
 type HeadsUpServer struct {
 	ctx               context.Context
 	store             *store.Store
@@ -65,6 +101,7 @@ type HeadsUpServer struct {
 	a                 *tiltanalytics.TiltAnalytics
 	metrics           *metrics.ModeController
 	uploader          cloud.SnapshotUploader
+	clients           *k8s.ContextClients
 	numWebsocketConns int32
 }
 
@@ -74,7 +111,8 @@ func ProvideHeadsUpServer(
 	assetServer assets.Server,
 	analytics *tiltanalytics.TiltAnalytics,
 	metrics *metrics.ModeController,
-	uploader cloud.SnapshotUploader) (*HeadsUpServer, error) {
+	uploader cloud.SnapshotUploader,
+	clients *k8s.ContextClients) (*HeadsUpServer, error) {
 	r := mux.NewRouter().UseEncodedPath()
 	s := &HeadsUpServer{
 		ctx:      ctx,
@@ -83,10 +121,15 @@ func ProvideHeadsUpServer(
 		a:        analytics,
 		metrics:  metrics,
 		uploader: uploader,
+		clients:  clients,
 	}
 
 	r.HandleFunc("/api/view", s.ViewJSON)
 	r.HandleFunc("/api/dump/engine", s.DumpEngineJSON)
+	r.HandleFunc("/api/diff", s.HandleDiff)
+	r.HandleFunc("/api/logs", s.HandleLogs)
+	r.HandleFunc("/api/logs/search", s.HandleLogSearch)
+	r.HandleFunc("/api/logs/clear", s.HandleClearLogs).Methods("POST")
 	r.HandleFunc("/api/analytics", s.HandleAnalytics)
 	r.HandleFunc("/api/analytics_opt", s.HandleAnalyticsOpt)
 	r.HandleFunc("/api/metrics_opt", s.HandleMetricsOpt)
@@ -156,6 +199,358 @@ func (s *HeadsUpServer) DumpEngineJSON(w http.ResponseWriter, req *http.Request)
 	}
 }
 
+// Prints the changes that Tilt would make to the live objects in the cluster
+// if it re-applied the last deploy of each resource, or of the resource in
+// the `resource` query param.
+//
+// The diff comes from a dry-run against the cluster with the configured
+// apply mode, so it also shows changes that others made since the deploy.
+func (s *HeadsUpServer) HandleDiff(w http.ResponseWriter, req *http.Request) {
+	targets, mode, ok := s.diffTargets(w, req.URL.Query().Get("resource"))
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	for _, t := range targets {
+		_, _ = fmt.Fprintf(w, "==> %s\n", t.name)
+		if t.yaml == "" {
+			_, _ = fmt.Fprintf(w, "Not deployed yet\n")
+			continue
+		}
+
+		entities, err := k8s.ParseYAMLFromString(t.yaml)
+		if err != nil {
+			_, _ = fmt.Fprintf(w, "Error reading deployed YAML: %v\n", err)
+			continue
+		}
+
+		kCli := s.clients.For(t.kubeContext).Client
+		diff, err := kCli.Diff(req.Context(), entities, mode)
+		switch {
+		case err != nil:
+			_, _ = fmt.Fprintf(w, "Error computing diff: %v\n", err)
+		case diff == "":
+			_, _ = fmt.Fprintf(w, "No changes\n")
+		default:
+			_, _ = fmt.Fprintf(w, "%s", diff)
+		}
+	}
+}
+
+type diffTarget struct {
+	name        model.ManifestName
+	kubeContext k8s.KubeContext
+
+	// The YAML of the last deploy, or empty if the resource hasn't deployed yet.
+	yaml string
+}
+
+// Reads what we need to diff each resource from the engine state,
+// so that we don't hold the state lock while we talk to the cluster.
+//
+// If the resource name is invalid, writes an error response and returns false.
+func (s *HeadsUpServer) diffTargets(w http.ResponseWriter, name string) ([]diffTarget, model.K8sApplyMode, bool) {
+	state := s.store.RLockState()
+	defer s.store.RUnlockState()
+
+	mode := state.UpdateSettings.K8sApplyMode()
+	targets := state.Targets()
+	if name != "" {
+		mt, ok := state.ManifestTargets[model.ManifestName(name)]
+		if !ok {
+			http.Error(w, fmt.Sprintf("no manifest found with name '%s'", name), http.StatusNotFound)
+			return nil, mode, false
+		}
+		if !mt.Manifest.IsK8s() {
+			http.Error(w, fmt.Sprintf("resource '%s' does not deploy to Kubernetes", name), http.StatusBadRequest)
+			return nil, mode, false
+		}
+		targets = []*store.ManifestTarget{mt}
+	}
+
+	var result []diffTarget
+	for _, mt := range targets {
+		if !mt.Manifest.IsK8s() {
+			continue
+		}
+
+		kTarget := mt.Manifest.K8sTarget()
+		t := diffTarget{
+			name:        mt.Manifest.Name,
+			kubeContext: s.clients.Resolve(k8s.KubeContext(kTarget.KubeContext)),
+		}
+		if bs, ok := mt.State.BuildStatuses[kTarget.ID()]; ok {
+			if r, ok := bs.LastResult.(store.K8sBuildResult); ok {
+				t.yaml = r.AppliedYAML
+			}
+		}
+		result = append(result, t)
+	}
+	return result, mode, true
+}
+
+// Returns one page of log history, starting at the checkpoint in the `from`
+// query param. Clients page through the history by asking again from the
+// returned toCheckpoint, until the response has a fromCheckpoint of -1.
+//
+// If the `before` query param is set, returns the page of logs that ends just
+// before that checkpoint instead, so that clients can page backwards from
+// the oldest logs they have.
+//
+// If the `session` query param is "previous", pages through the logs of
+// the last Tilt session instead.
+func (s *HeadsUpServer) HandleLogs(w http.ResponseWriter, req *http.Request) {
+	from := 0
+	if fromParam := req.URL.Query().Get("from"); fromParam != "" {
+		var err error
+		from, err = strconv.Atoi(fromParam)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid from checkpoint %q", fromParam), http.StatusBadRequest)
+			return
+		}
+	}
+
+	before := -1
+	if beforeParam := req.URL.Query().Get("before"); beforeParam != "" {
+		var err error
+		before, err = strconv.Atoi(beforeParam)
+		if err != nil || before < 0 {
+			http.Error(w, fmt.Sprintf("invalid before checkpoint %q", beforeParam), http.StatusBadRequest)
+			return
+		}
+	}
+
+	session := req.URL.Query().Get("session")
+	if session != "" && session != "current" && session != "previous" {
+		http.Error(w, fmt.Sprintf("invalid session %q (expected current or previous)", session), http.StatusBadRequest)
+		return
+	}
+
+	// Reading the archive goes to disk, so only hold the state lock
+	// while we take a snapshot of the logs we need.
+	state := s.store.RLockState()
+	var snap *logstore.Snapshot
+	if before >= 0 {
+		snap = state.LogStore.SnapshotBefore(logstore.Checkpoint(before), logPageMaxBytes)
+	} else {
+		snap = state.LogStore.Snapshot(logstore.Checkpoint(from), logPageMaxBytes)
+	}
+	to := state.LogStore.Checkpoint()
+	s.store.RUnlockState()
+
+	var logList *proto_webview.LogList
+	var err error
+	switch {
+	case session == "previous":
+		logList, err = snap.ToPreviousSessionLogList(logstore.Checkpoint(from), logPageMaxBytes)
+	case before >= 0:
+		logList, err = snap.ToLogListBefore(logstore.Checkpoint(before), logPageMaxBytes)
+	default:
+		logList, err = snap.ToLogListRange(logstore.Checkpoint(from), to, logPageMaxBytes)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error reading logs: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	jsEncoder := &runtime.JSONPb{OrigName: false, EmitDefaults: true}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = jsEncoder.NewEncoder(w).Encode(logList)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error rendering logs payload: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// Searches the logs, including logs that are only in the archive.
+//
+// Each match comes back with its checkpoint, so that the web UI can
+// jump to it without loading all the logs around it.
+func (s *HeadsUpServer) HandleLogSearch(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	filter, err := logFilterFromQuery(query, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	from := 0
+	if fromParam := query.Get("from"); fromParam != "" {
+		from, err = strconv.Atoi(fromParam)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid from checkpoint %q", fromParam), http.StatusBadRequest)
+			return
+		}
+	}
+
+	limit := logSearchDefaultLimit
+	if limitParam := query.Get("limit"); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			http.Error(w, fmt.Sprintf("invalid limit %q", limitParam), http.StatusBadRequest)
+			return
+		}
+		if limit > logSearchMaxLimit {
+			limit = logSearchMaxLimit
+		}
+	}
+
+	sinceLastBuild := false
+	if param := query.Get("since_last_build"); param != "" {
+		sinceLastBuild, err = strconv.ParseBool(param)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid since_last_build %q", param), http.StatusBadRequest)
+			return
+		}
+	}
+
+	session := query.Get("session")
+	if session != "" && session != "current" && session != "previous" {
+		http.Error(w, fmt.Sprintf("invalid session %q (expected current or previous)", session), http.StatusBadRequest)
+		return
+	}
+
+	// Scanning the archive goes to disk, so only hold the state lock
+	// while we take a snapshot of the logs in memory.
+	state := s.store.RLockState()
+	if sinceLastBuild && session != "previous" {
+		filter.FromCheckpoints = lastBuildCheckpoints(state)
+	}
+	snap := state.LogStore.SearchSnapshot(logstore.Checkpoint(from))
+	s.store.RUnlockState()
+
+	var result logstore.SearchResult
+	switch {
+	case session == "previous" && sinceLastBuild:
+		// The last build is always in the current session.
+	case session == "previous":
+		result, err = snap.SearchPreviousSession(filter, logstore.Checkpoint(from), limit)
+	default:
+		result, err = snap.Search(filter, logstore.Checkpoint(from), limit)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error searching logs: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	resp := logSearchResponse{
+		Matches:        make([]logSearchMatch, 0, len(result.Matches)),
+		FromCheckpoint: int(result.From),
+		ToCheckpoint:   int(result.To),
+		HasMore:        result.HasMore,
+	}
+	for _, m := range result.Matches {
+		resp.Matches = append(resp.Matches, logSearchMatch{
+			Checkpoint:   int(m.Checkpoint),
+			SpanID:       string(m.Segment.SpanID),
+			ManifestName: m.ManifestName,
+			Time:         m.Segment.Time,
+			Level:        proto_webview.LogLevel(m.Segment.Level.ToProtoID()).String(),
+			Text:         m.Segment.String(),
+			Fields:       m.Segment.Fields,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error rendering search payload: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// Returns the checkpoint that each manifest's last build started at,
+// or that its logs were last cleared at, whichever is later.
+func lastBuildCheckpoints(state store.EngineState) map[model.ManifestName]logstore.Checkpoint {
+	result := map[model.ManifestName]logstore.Checkpoint{
+		"": state.LogStore.ClearCheckpoint(""),
+	}
+
+	names := append([]model.ManifestName{model.TiltfileManifestName}, state.ManifestDefinitionOrder...)
+	for _, mn := range names {
+		ms, ok := state.ManifestState(mn)
+		if !ok {
+			continue
+		}
+		c := state.LogStore.ClearCheckpoint(mn)
+
+		spanID := ms.CurrentBuild.SpanID
+		if spanID == "" {
+			spanID = ms.LastBuild().SpanID
+		}
+		if start, ok := state.LogStore.SpanStartCheckpoint(spanID); ok && start > c {
+			c = start
+		}
+		result[mn] = c
+	}
+	return result
+}
+
+// Reads a log filter from the query params of a search request.
+//
+// `since` may be a duration, like 10m, or a timestamp in RFC3339 format.
+// `resource` may be repeated. `field` may be repeated, and looks like key=value.
+func logFilterFromQuery(query url.Values, now time.Time) (logstore.Filter, error) {
+	filter := logstore.Filter{}
+
+	if grep := query.Get("grep"); grep != "" {
+		pattern, err := regexp.Compile(grep)
+		if err != nil {
+			return filter, fmt.Errorf("invalid grep pattern %q: %v", grep, err)
+		}
+		filter.Pattern = pattern
+	}
+
+	if level := query.Get("level"); level != "" {
+		minLevel, err := logger.LevelFromName(level)
+		if err != nil {
+			return filter, err
+		}
+		filter.MinLevel = minLevel
+	}
+
+	if since := query.Get("since"); since != "" {
+		d, err := time.ParseDuration(since)
+		if err == nil {
+			filter.Since = now.Add(-d)
+		} else {
+			t, err := time.Parse(time.RFC3339, since)
+			if err != nil {
+				return filter, fmt.Errorf("invalid since %q (expected a duration, like 10m, or an RFC3339 timestamp)", since)
+			}
+			filter.Since = t
+		}
+	}
+
+	if spanType := query.Get("span_type"); spanType != "" {
+		st, err := logstore.SpanTypeFromName(spanType)
+		if err != nil {
+			return filter, err
+		}
+		filter.SpanType = st
+	}
+
+	if fields := query["field"]; len(fields) > 0 {
+		filter.Fields = make(logger.Fields, len(fields))
+		for _, field := range fields {
+			parts := strings.SplitN(field, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return filter, fmt.Errorf("invalid field %q (expected key=value)", field)
+			}
+			filter.Fields[parts[0]] = parts[1]
+		}
+	}
+
+	if resources := query["resource"]; len(resources) > 0 {
+		filter.ManifestNames = make(model.ManifestNameSet, len(resources))
+		for _, r := range resources {
+			filter.ManifestNames[model.ManifestName(r)] = true
+		}
+	}
+
+	return filter, nil
+}
+
 func (s *HeadsUpServer) SnapshotJSON(w http.ResponseWriter, req *http.Request) {
 	state := s.store.RLockState()
 	view, err := webview.StateToProtoView(state, 0)
@@ -357,6 +752,30 @@ func (s *HeadsUpServer) HandleOverrideTriggerMode(w http.ResponseWriter, req *ht
 	})
 }
 
+// Hides the logs that the given manifests have so far, or all logs if no
+// manifests are given. The logs aren't deleted.
+func (s *HeadsUpServer) HandleClearLogs(w http.ResponseWriter, req *http.Request) {
+	var payload clearLogsPayload
+
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&payload)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error parsing JSON payload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	err = checkManifestsExist(s.store, payload.ManifestNames)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.store.Dispatch(store.ClearLogsAction{
+		ManifestNames: model.ManifestNames(payload.ManifestNames),
+	})
+}
+
 /* -- SNAPSHOT: SENDING SNAPSHOT TO SERVER -- */
 type snapshotURLJson struct {
 	Url string `json:"url"`
@@ -3,6 +3,7 @@ package server_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -28,8 +29,11 @@ import (
 	"github.com/tilt-dev/tilt/internal/cloud"
 	"github.com/tilt-dev/tilt/internal/cloud/cloudurl"
 	"github.com/tilt-dev/tilt/internal/hud/server"
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/k8s/testyaml"
 	"github.com/tilt-dev/tilt/internal/store"
 	"github.com/tilt-dev/tilt/pkg/assets"
+	"github.com/tilt-dev/tilt/pkg/logger"
 	"github.com/tilt-dev/tilt/pkg/model"
 	proto_webview "github.com/tilt-dev/tilt/pkg/webview"
 )
@@ -173,6 +177,230 @@ func TestHandleTriggerTiltfileOK(t *testing.T) {
 	require.Equal(t, http.StatusOK, status, "handler returned wrong status code")
 }
 
+func TestHandleDiff(t *testing.T) {
+	f := newTestFixture(t)
+	f.kClient.DiffResult = "--- Deployment/foo (live)\n+++ Deployment/foo (tilt)\n"
+
+	state := f.st.LockMutableStateForTesting()
+	state.UpdateSettings = state.UpdateSettings.WithK8sApplyMode(model.K8sApplyModeServerSide)
+	for _, name := range []string{"foo", "baz"} {
+		m := model.Manifest{Name: model.ManifestName(name)}.WithDeployTarget(model.K8sTarget{Name: model.TargetName(name)})
+		state.UpsertManifestTarget(store.NewManifestTarget(m))
+	}
+	fooTarget := state.ManifestTargets["foo"].Manifest.K8sTarget()
+	state.ManifestTargets["foo"].State.MutableBuildStatus(fooTarget.ID()).LastResult = store.K8sBuildResult{
+		AppliedYAML: testyaml.SanchoYAML,
+	}
+	f.st.UnlockMutableState()
+
+	status, respBody := f.makeReq("/api/diff", f.serv.HandleDiff, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, respBody, "==> foo\n--- Deployment/foo (live)\n")
+	assert.Contains(t, respBody, "==> baz\nNot deployed yet\n")
+
+	// The diff is computed on demand, with the configured apply mode.
+	assert.Equal(t, model.K8sApplyModeServerSide, f.kClient.DiffMode)
+	require.Len(t, f.kClient.DiffEntities, 1)
+	assert.Equal(t, "sancho", f.kClient.DiffEntities[0].Name())
+
+	f.kClient.DiffResult = ""
+	status, respBody = f.makeReq("/api/diff?resource=foo", f.serv.HandleDiff, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "==> foo\nNo changes\n", respBody)
+}
+
+func TestHandleDiffNoManifestWithName(t *testing.T) {
+	f := newTestFixture(t)
+
+	status, respBody := f.makeReq("/api/diff?resource=foo", f.serv.HandleDiff, http.MethodGet, "")
+	require.Equal(t, http.StatusNotFound, status)
+	require.Contains(t, respBody, "no manifest found with name 'foo'")
+}
+
+func TestHandleLogs(t *testing.T) {
+	f := newTestFixture(t)
+
+	state := f.st.LockMutableStateForTesting()
+	for _, msg := range []string{"first\n", "second\n"} {
+		state.LogStore.Append(store.NewLogAction("fe", "fe", logger.InfoLvl, nil, []byte(msg)), nil)
+	}
+	f.st.UnlockMutableState()
+
+	status, respBody := f.makeReq("/api/logs?from=1", f.serv.HandleLogs, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, status)
+	assert.NotContains(t, respBody, "first")
+	assert.Contains(t, respBody, `"text":"second\n"`)
+	assert.Contains(t, respBody, `"fromCheckpoint":1`)
+	assert.Contains(t, respBody, `"toCheckpoint":2`)
+
+	status, respBody = f.makeReq("/api/logs?before=1", f.serv.HandleLogs, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, respBody, `"text":"first\n"`)
+	assert.NotContains(t, respBody, "second")
+	assert.Contains(t, respBody, `"fromCheckpoint":0`)
+	assert.Contains(t, respBody, `"toCheckpoint":1`)
+
+	// There's no archive, so there's no previous session.
+	status, respBody = f.makeReq("/api/logs?session=previous", f.serv.HandleLogs, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, respBody, `"fromCheckpoint":-1`)
+}
+
+func TestHandleLogsBadParams(t *testing.T) {
+	f := newTestFixture(t)
+
+	status, respBody := f.makeReq("/api/logs?from=foo", f.serv.HandleLogs, http.MethodGet, "")
+	require.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, respBody, `invalid from checkpoint "foo"`)
+
+	status, respBody = f.makeReq("/api/logs?before=-1", f.serv.HandleLogs, http.MethodGet, "")
+	require.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, respBody, `invalid before checkpoint "-1"`)
+
+	status, respBody = f.makeReq("/api/logs?session=foo", f.serv.HandleLogs, http.MethodGet, "")
+	require.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, respBody, `invalid session "foo"`)
+}
+
+func TestHandleLogSearch(t *testing.T) {
+	f := newTestFixture(t)
+
+	state := f.st.LockMutableStateForTesting()
+	state.LogStore.Append(store.NewLogAction("fe", "fe", logger.InfoLvl, nil, []byte("listening on :8080\n")), nil)
+	state.LogStore.Append(store.NewLogAction("be", "be", logger.WarnLvl, nil, []byte("connection refused\n")), nil)
+	state.LogStore.Append(store.NewLogAction("fe", "fe", logger.ErrorLvl, nil, []byte("connection reset\n")), nil)
+	f.st.UnlockMutableState()
+
+	status, respBody := f.makeReq("/api/logs/search?grep=conn.*re&resource=fe", f.serv.HandleLogSearch, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, status)
+
+	var resp struct {
+		Matches []struct {
+			Checkpoint   int    `json:"checkpoint"`
+			ManifestName string `json:"manifest_name"`
+			Level        string `json:"level"`
+			Text         string `json:"text"`
+		} `json:"matches"`
+		ToCheckpoint int  `json:"to_checkpoint"`
+		HasMore      bool `json:"has_more"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(respBody), &resp))
+	require.Len(t, resp.Matches, 1)
+	assert.Equal(t, 2, resp.Matches[0].Checkpoint)
+	assert.Equal(t, "fe", resp.Matches[0].ManifestName)
+	assert.Equal(t, "ERROR", resp.Matches[0].Level)
+	assert.Equal(t, "connection reset\n", resp.Matches[0].Text)
+	assert.Equal(t, 3, resp.ToCheckpoint)
+	assert.False(t, resp.HasMore)
+
+	status, respBody = f.makeReq("/api/logs/search?level=warn&limit=1", f.serv.HandleLogSearch, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, status)
+	require.NoError(t, json.Unmarshal([]byte(respBody), &resp))
+	require.Len(t, resp.Matches, 1)
+	assert.Equal(t, 1, resp.Matches[0].Checkpoint)
+	assert.Equal(t, 2, resp.ToCheckpoint)
+	assert.True(t, resp.HasMore)
+}
+
+func TestHandleLogSearchFields(t *testing.T) {
+	f := newTestFixture(t)
+
+	stderr := logger.Fields{logger.FieldNameStream: logger.StreamStderr}
+	state := f.st.LockMutableStateForTesting()
+	state.LogStore.Append(store.NewLogAction("db", "dc:db", logger.InfoLvl, nil, []byte("ready\n")), nil)
+	state.LogStore.Append(store.NewLogAction("db", "dc:db", logger.InfoLvl, stderr, []byte("slow query\n")), nil)
+	f.st.UnlockMutableState()
+
+	status, respBody := f.makeReq("/api/logs/search?field=stream%3Dstderr", f.serv.HandleLogSearch, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, status)
+
+	var resp struct {
+		Matches []struct {
+			Text   string            `json:"text"`
+			Fields map[string]string `json:"fields"`
+		} `json:"matches"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(respBody), &resp))
+	require.Len(t, resp.Matches, 1)
+	assert.Equal(t, "slow query\n", resp.Matches[0].Text)
+	assert.Equal(t, map[string]string(stderr), resp.Matches[0].Fields)
+}
+
+func TestHandleLogSearchBadParams(t *testing.T) {
+	f := newTestFixture(t)
+
+	for _, tc := range []struct {
+		query    string
+		expected string
+	}{
+		{"grep=(", `invalid grep pattern "("`},
+		{"level=loud", `unknown log level "loud"`},
+		{"since=yesterday", `invalid since "yesterday"`},
+		{"span_type=deploy", `unknown span type "deploy"`},
+		{"field=stream", `invalid field "stream"`},
+		{"limit=0", `invalid limit "0"`},
+		{"session=foo", `invalid session "foo"`},
+		{"since_last_build=maybe", `invalid since_last_build "maybe"`},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			status, respBody := f.makeReq("/api/logs/search?"+tc.query, f.serv.HandleLogSearch, http.MethodGet, "")
+			require.Equal(t, http.StatusBadRequest, status)
+			assert.Contains(t, respBody, tc.expected)
+		})
+	}
+}
+
+func TestHandleLogSearchSinceLastBuild(t *testing.T) {
+	f := newTestFixture(t).withDummyManifests("fe", "be")
+
+	state := f.st.LockMutableStateForTesting()
+	state.LogStore.Append(store.NewLogAction("fe", "build:1", logger.InfoLvl, nil, []byte("first fe build\n")), nil)
+	state.LogStore.Append(store.NewLogAction("be", "build:2", logger.InfoLvl, nil, []byte("first be build\n")), nil)
+	state.LogStore.Append(store.NewLogAction("fe", "build:3", logger.InfoLvl, nil, []byte("second fe build\n")), nil)
+	state.LogStore.Append(store.NewLogAction("be", "pod:be", logger.InfoLvl, nil, []byte("be running\n")), nil)
+	state.LogStore.Clear("be")
+	state.LogStore.Append(store.NewLogAction("be", "pod:be", logger.InfoLvl, nil, []byte("be still running\n")), nil)
+	fe, _ := state.ManifestState("fe")
+	fe.CurrentBuild = model.BuildRecord{SpanID: "build:3"}
+	be, _ := state.ManifestState("be")
+	be.AddCompletedBuild(model.BuildRecord{SpanID: "build:2"})
+	f.st.UnlockMutableState()
+
+	status, respBody := f.makeReq("/api/logs/search?since_last_build=true", f.serv.HandleLogSearch, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, status)
+
+	var resp struct {
+		Matches []struct {
+			Text string `json:"text"`
+		} `json:"matches"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(respBody), &resp))
+	var texts []string
+	for _, m := range resp.Matches {
+		texts = append(texts, m.Text)
+	}
+	assert.Equal(t, []string{"second fe build\n", "be still running\n"}, texts)
+}
+
+func TestHandleClearLogs(t *testing.T) {
+	f := newTestFixture(t).withDummyManifests("foo", "bar")
+
+	status, _ := f.makeReq("/api/logs/clear", f.serv.HandleClearLogs, http.MethodPost, `{"manifest_names":["foo"]}`)
+	require.Equal(t, http.StatusOK, status, "handler returned wrong status code")
+
+	a := store.WaitForAction(t, reflect.TypeOf(store.ClearLogsAction{}), f.getActions)
+	assert.Equal(t, store.ClearLogsAction{ManifestNames: []model.ManifestName{"foo"}}, a)
+}
+
+func TestHandleClearLogsReturnsErrorForBadManifest(t *testing.T) {
+	f := newTestFixture(t).withDummyManifests("foo")
+
+	status, respBody := f.makeReq("/api/logs/clear", f.serv.HandleClearLogs, http.MethodPost, `{"manifest_names":["bar"]}`)
+	require.Equal(t, http.StatusBadRequest, status, "handler returned wrong status code")
+	require.Contains(t, respBody, "no manifest found with name 'bar'")
+	store.AssertNoActionOfType(t, reflect.TypeOf(store.ClearLogsAction{}), f.getActions)
+}
+
 func TestSendToTriggerQueue_manualManifest(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("TODO(nick): fix this")
@@ -412,6 +640,7 @@ type serverFixture struct {
 	getActions   func() []store.Action
 	snapshotHTTP *fakeHTTPClient
 	up           *user.FakePrefs
+	kClient      *k8s.FakeK8sClient
 }
 
 func newTestFixture(t *testing.T) *serverFixture {
@@ -427,7 +656,9 @@ func newTestFixture(t *testing.T) *serverFixture {
 	uploader := cloud.NewSnapshotUploader(snapshotHTTP, addr)
 	up := user.NewFakePrefs()
 	mcc := metrics.NewModeController("localhost", up)
-	serv, err := server.ProvideHeadsUpServer(context.Background(), st, assets.NewFakeServer(), ta, mcc, uploader)
+	kClient := k8s.NewFakeK8sClient()
+	clients := k8s.NewFakeContextClients(context.Background(), kClient)
+	serv, err := server.ProvideHeadsUpServer(context.Background(), st, assets.NewFakeServer(), ta, mcc, uploader, clients)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -441,6 +672,7 @@ func newTestFixture(t *testing.T) *serverFixture {
 		getActions:   getActions,
 		snapshotHTTP: snapshotHTTP,
 		up:           up,
+		kClient:      kClient,
 	}
 }
 
@@ -126,6 +126,10 @@ type Resource struct {
 
 	ResourceInfo ResourceInfoView
 
+	// Runtime log lines that matched an alert pattern since the last deploy.
+	LogAlertCount int
+	LastLogAlert  string
+
 	IsTiltfile bool
 }
 
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/tilt-dev/tilt/internal/cloud/cloudurl"
+	"github.com/tilt-dev/tilt/internal/k8s"
 	"github.com/tilt-dev/tilt/internal/ospath"
 	"github.com/tilt-dev/tilt/internal/store"
 	"github.com/tilt-dev/tilt/pkg/logger"
@@ -120,9 +121,12 @@ func StateToProtoView(s store.EngineState, logCheckpoint logstore.Checkpoint) (*
 			TriggerMode:        int32(mt.Manifest.TriggerMode),
 			HasPendingChanges:  hasPendingChanges,
 			Queued:             s.ManifestInTriggerQueue(name),
+			LogAlertCount:      int32(ms.LogAlertCount),
+			LastLogAlert:       ms.LastLogAlert,
+			LogClearCheckpoint: int32(s.LogStore.ClearCheckpoint(name)),
 		}
 
-		err = protoPopulateResourceInfoView(mt, r)
+		err = protoPopulateResourceInfoView(mt, s.KubeContext, r)
 		if err != nil {
 			return nil, err
 		}
@@ -136,6 +140,7 @@ func StateToProtoView(s store.EngineState, logCheckpoint logstore.Checkpoint) (*
 	}
 
 	ret.LogList = logList
+	ret.LogClearCheckpoint = int32(s.LogStore.ClearCheckpoint(""))
 	ret.NeedsAnalyticsNudge = NeedsNudge(s)
 	ret.RunningTiltBuild = &proto_webview.TiltBuild{
 		Version:   s.TiltBuildInfo.Version,
@@ -200,8 +205,9 @@ func tiltfileResourceProtoView(s store.EngineState) (*proto_webview.Resource, er
 		BuildHistory: []*proto_webview.BuildRecord{
 			pltfb,
 		},
-		RuntimeStatus: string(model.RuntimeStatusNotApplicable),
-		UpdateStatus:  string(s.TiltfileState.UpdateStatus(model.TriggerModeAuto)),
+		RuntimeStatus:      string(model.RuntimeStatusNotApplicable),
+		UpdateStatus:       string(s.TiltfileState.UpdateStatus(model.TriggerModeAuto)),
+		LogClearCheckpoint: int32(s.LogStore.ClearCheckpoint(store.TiltfileManifestName)),
 	}
 	start, err := timeToProto(ctfb.StartTime)
 	if err != nil {
@@ -219,7 +225,7 @@ func tiltfileResourceProtoView(s store.EngineState) (*proto_webview.Resource, er
 	return tr, nil
 }
 
-func protoPopulateResourceInfoView(mt *store.ManifestTarget, r *proto_webview.Resource) error {
+func protoPopulateResourceInfoView(mt *store.ManifestTarget, defaultKubeContext k8s.KubeContext, r *proto_webview.Resource) error {
 	r.UpdateStatus = string(mt.UpdateStatus())
 	r.RuntimeStatus = string(model.RuntimeStatusNotApplicable)
 
@@ -250,6 +256,10 @@ func protoPopulateResourceInfoView(mt *store.ManifestTarget, r *proto_webview.Re
 	if mt.Manifest.IsK8s() {
 		kState := mt.State.K8sRuntimeState()
 		pod := kState.MostRecentPod()
+		kubeContext := mt.Manifest.K8sTarget().KubeContext
+		if kubeContext == "" {
+			kubeContext = string(defaultKubeContext)
+		}
 		r.K8SResourceInfo = &proto_webview.K8SResourceInfo{
 			PodName:            pod.PodID.String(),
 			PodCreationTime:    pod.StartedAt.String(),
@@ -259,6 +269,19 @@ func protoPopulateResourceInfoView(mt *store.ManifestTarget, r *proto_webview.Re
 			AllContainersReady: pod.AllContainersReady(),
 			PodRestarts:        int32(pod.VisibleContainerRestarts()),
 			DisplayNames:       mt.Manifest.K8sTarget().DisplayNames,
+			KubeContext:        string(kubeContext),
+		}
+
+		for _, pfs := range kState.SortedPortForwardStatuses() {
+			r.K8SResourceInfo.PortForwardStatuses = append(r.K8SResourceInfo.PortForwardStatuses, &proto_webview.PortForwardStatus{
+				LocalPort:         int32(pfs.LocalPort),
+				Target:            pfs.Target,
+				Pods:              int32(pfs.Pods),
+				ActiveConnections: int32(pfs.ActiveConnections),
+				TotalConnections:  int32(pfs.TotalConnections),
+				Errors:            int32(pfs.Errors),
+				LastError:         pfs.LastError,
+			})
 		}
 
 		r.RuntimeStatus = string(kState.RuntimeStatus())
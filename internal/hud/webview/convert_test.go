@@ -96,6 +96,81 @@ func TestStateToWebViewPortForwards(t *testing.T) {
 	assert.Equal(t, expected, res.EndpointLinks)
 }
 
+func TestStateToWebViewPortForwardStatuses(t *testing.T) {
+	m := model.Manifest{
+		Name: "foo",
+	}.WithDeployTarget(model.K8sTarget{
+		PortForwards: []model.PortForward{
+			{LocalPort: 8000, ContainerPort: 80, Target: "svc/foo"},
+		},
+	})
+	state := newState([]model.Manifest{m})
+	mt := state.ManifestTargets[m.Name]
+	kState := mt.State.K8sRuntimeState()
+	kState.PortForwardStatuses[8000] = store.PortForwardStatus{
+		LocalPort:        8000,
+		Target:           "svc/foo",
+		Pods:             2,
+		TotalConnections: 5,
+		Errors:           1,
+		LastError:        "connection refused",
+	}
+	mt.State.RuntimeState = kState
+
+	v := stateToProtoView(t, *state)
+
+	res, _ := findResource(m.Name, v)
+	expected := []*proto_webview.PortForwardStatus{
+		&proto_webview.PortForwardStatus{
+			LocalPort:        8000,
+			Target:           "svc/foo",
+			Pods:             2,
+			TotalConnections: 5,
+			Errors:           1,
+			LastError:        "connection refused",
+		},
+	}
+	assert.Equal(t, expected, res.K8SResourceInfo.PortForwardStatuses)
+}
+
+func TestStateToWebViewLogAlerts(t *testing.T) {
+	m := model.Manifest{
+		Name: "foo",
+	}.WithDeployTarget(model.K8sTarget{})
+	state := newState([]model.Manifest{m})
+	ms := state.ManifestTargets[m.Name].State
+	ms.LogAlertCount = 3
+	ms.LastLogAlert = "panic: oh no"
+
+	v := stateToProtoView(t, *state)
+
+	res, _ := findResource(m.Name, v)
+	assert.Equal(t, int32(3), res.LogAlertCount)
+	assert.Equal(t, "panic: oh no", res.LastLogAlert)
+}
+
+func TestStateToWebViewLogClearCheckpoints(t *testing.T) {
+	m := model.Manifest{
+		Name: "foo",
+	}.WithDeployTarget(model.K8sTarget{})
+	state := newState([]model.Manifest{m})
+	state.LogStore.Append(store.NewLogAction("foo", "pod:foo", logger.InfoLvl, nil, []byte("hello\n")), nil)
+	state.LogStore.Append(store.NewLogAction("", "", logger.InfoLvl, nil, []byte("world\n")), nil)
+	state.LogStore.Clear("foo")
+
+	v := stateToProtoView(t, *state)
+
+	res, _ := findResource(m.Name, v)
+	assert.Equal(t, int32(2), res.LogClearCheckpoint)
+	tf, _ := findResource(store.TiltfileManifestName, v)
+	assert.Equal(t, int32(0), tf.LogClearCheckpoint)
+	assert.Equal(t, int32(0), v.LogClearCheckpoint)
+
+	state.LogStore.ClearAll()
+	v = stateToProtoView(t, *state)
+	assert.Equal(t, int32(2), v.LogClearCheckpoint)
+}
+
 func TestStateToWebViewLinksAndPortForwards(t *testing.T) {
 	m := model.Manifest{
 		Name: "foo",
@@ -170,6 +245,20 @@ func TestStateToViewK8sTargetsIncludeDisplayNames(t *testing.T) {
 	assert.Equal(t, r.K8SResourceInfo.DisplayNames, displayNames)
 }
 
+func TestStateToViewK8sKubeContext(t *testing.T) {
+	app := model.Manifest{Name: "app"}.WithDeployTarget(model.K8sTarget{})
+	data := model.Manifest{Name: "data"}.WithDeployTarget(model.K8sTarget{KubeContext: "data-cluster"})
+	state := newState([]model.Manifest{app, data})
+	state.KubeContext = "app-cluster"
+	v := stateToProtoView(t, *state)
+
+	r, _ := findResource(app.Name, v)
+	assert.Equal(t, "app-cluster", r.K8SResourceInfo.KubeContext)
+
+	r, _ = findResource(data.Name, v)
+	assert.Equal(t, "data-cluster", r.K8SResourceInfo.KubeContext)
+}
+
 func TestStateToViewTiltfileLog(t *testing.T) {
 	es := newState([]model.Manifest{})
 	spanID := configs.SpanIDForLoadCount(1)
@@ -310,6 +399,7 @@ func TestBuildHistory(t *testing.T) {
 		FinishTime: time.Now().Add(-19 * time.Minute),
 		Reason:     model.BuildReasonFlagCrash,
 		BuildTypes: []model.BuildType{model.BuildTypeImage, model.BuildTypeK8s},
+		K8sDiff:    "-  replicas: 1\n+  replicas: 2\n",
 	}
 	buildRecords := []model.BuildRecord{br1, br2, br3}
 	expectedUpdateTypes := [][]proto_webview.UpdateType{
@@ -335,6 +425,7 @@ func TestBuildHistory(t *testing.T) {
 		require.Equal(t, mustTimeToProto(expected.FinishTime), actual.FinishTime)
 		require.Equal(t, i == 2, actual.IsCrashRebuild)
 		require.ElementsMatch(t, expectedUpdateTypes[i], actual.UpdateTypes)
+		require.Equal(t, expected.K8sDiff, actual.K8SDiff)
 	}
 }
 
@@ -141,6 +141,7 @@ func ToProtoBuildRecord(br model.BuildRecord, logStore *logstore.LogStore) (*pro
 		UpdateTypes:    updateTypes,
 		IsCrashRebuild: br.Reason.IsCrashOnly(),
 		SpanId:         string(br.SpanID),
+		K8SDiff:        br.K8sDiff,
 	}, nil
 }
 
@@ -34,6 +34,7 @@ import (
 
 	"github.com/tilt-dev/tilt/internal/container"
 	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
 )
 
 type Namespace string
@@ -70,9 +71,16 @@ type Client interface {
 	// Tries to update them in-place if possible. But for certain resource types,
 	// we might need to fallback to deleting and re-creating them.
 	//
+	// In server-side mode, fields owned by other managers are reported as warnings,
+	// and left to those managers unless the mode forces conflicts.
+	//
 	// Returns entities in the order that they were applied (which may be different
 	// than they were passed in) and with UUIDs from the Kube API
-	Upsert(ctx context.Context, entities []K8sEntity, timeout time.Duration) ([]K8sEntity, error)
+	Upsert(ctx context.Context, entities []K8sEntity, timeout time.Duration, mode model.K8sApplyMode) ([]K8sEntity, error)
+
+	// Returns a unified diff between the live objects in the cluster and
+	// the objects we'd get if we applied the given entities with the given mode.
+	Diff(ctx context.Context, entities []K8sEntity, mode model.K8sApplyMode) (string, error)
 
 	// Deletes all given entities.
 	//
@@ -83,14 +91,42 @@ type Client interface {
 	GetMetaByReference(ctx context.Context, ref v1.ObjectReference) (ObjectMeta, error)
 	ListMeta(ctx context.Context, gvk schema.GroupVersionKind, ns Namespace) ([]ObjectMeta, error)
 
+	// Lists the objects with the given label, across all namespaces and every
+	// kind that the cluster lets us list and delete.
+	//
+	// Skips kinds that we're not allowed to list.
+	ListRefsWithLabel(ctx context.Context, label model.LabelPair) ([]v1.ObjectReference, error)
+
+	// Checks the cluster's discovery data to see whether the kind lives
+	// outside of namespaces. Returns an error if the cluster doesn't know the kind.
+	IsClusterScoped(ctx context.Context, gvk schema.GroupVersionKind) (bool, error)
+
+	// Reads the data of a Secret in the cluster.
+	SecretData(ctx context.Context, ns Namespace, name string) (map[string][]byte, error)
+
 	// Streams the container logs
 	ContainerLogs(ctx context.Context, podID PodID, cName container.Name, n Namespace, startTime time.Time) (io.ReadCloser, error)
 
+	// Reads the logs of the previous instance of a container, which has terminated.
+	PreviousContainerLogs(ctx context.Context, podID PodID, cName container.Name, n Namespace, startTime time.Time) (io.ReadCloser, error)
+
 	// Opens a tunnel to the specified pod+port. Returns the tunnel's local port and a function that closes the tunnel
 	CreatePortForwarder(ctx context.Context, namespace Namespace, podID PodID, optionalLocalPort, remotePort int, host string) (PortForwarder, error)
 
+	// Opens a tunnel from the local port to a changing set of pods. Returns the tunnel,
+	// which forwards to no pods until SetPods is called.
+	CreatePortForwardBalancer(ctx context.Context, optionalLocalPort, remotePort int, host string) (PortForwardBalancer, error)
+
+	// Opens a tunnel from a relay pod's tunnel port back to the specified local port.
+	CreateReverseForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort, remotePort int) (PortForwarder, error)
+
 	WatchMeta(ctx context.Context, gvk schema.GroupVersionKind, ns Namespace) (<-chan ObjectMeta, error)
 
+	// Watches full objects of any type, including custom resources.
+	//
+	// For cluster-scoped types, the namespace is ignored.
+	WatchObjects(ctx context.Context, gvk schema.GroupVersionKind, ns Namespace) (<-chan ObjectUpdate, error)
+
 	ContainerRuntime(ctx context.Context) container.Runtime
 
 	// Some clusters support a local image registry that we can push to.
@@ -272,7 +308,7 @@ func (k *K8sClient) ToRawKubeConfigLoader() clientcmd.ClientConfig {
 	return k.clientLoader
 }
 
-func (k *K8sClient) Upsert(ctx context.Context, entities []K8sEntity, timeout time.Duration) ([]K8sEntity, error) {
+func (k *K8sClient) Upsert(ctx context.Context, entities []K8sEntity, timeout time.Duration, mode model.K8sApplyMode) ([]K8sEntity, error) {
 	result := make([]K8sEntity, 0, len(entities))
 
 	mutable, immutable := MutableAndImmutableEntities(entities)
@@ -281,7 +317,13 @@ func (k *K8sClient) Upsert(ctx context.Context, entities []K8sEntity, timeout ti
 		innerCtx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 
-		newEntity, err := k.applyEntityAndMaybeForce(innerCtx, e)
+		var newEntity []K8sEntity
+		var err error
+		if mode.IsServerSide() {
+			newEntity, err = k.serverSideApplyEntity(innerCtx, e, mode == model.K8sApplyModeServerSideForce)
+		} else {
+			newEntity, err = k.applyEntityAndMaybeForce(innerCtx, e)
+		}
 		if err != nil {
 			if ctx.Err() == context.DeadlineExceeded {
 				return nil, timeoutError(timeout)
@@ -365,6 +407,19 @@ func (k *K8sClient) helmResultToEntities(result *kube.Result) ([]K8sEntity, erro
 }
 
 func (k *K8sClient) deleteAndCreate(list kube.ResourceList) (*kube.Result, error) {
+	err := k.deleteForReplace(list)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := k.helmKubeClient.Create(list)
+	if err != nil {
+		return nil, errors.Wrap(err, "kubernetes create")
+	}
+	return result, nil
+}
+
+func (k *K8sClient) deleteForReplace(list kube.ResourceList) error {
 	// Delete is destructive, so clone first.
 	toDelete := kube.ResourceList{}
 	for _, r := range list {
@@ -383,14 +438,9 @@ func (k *K8sClient) deleteAndCreate(list kube.ResourceList) (*kube.Result, error
 		if strings.Contains(err.Error(), "object not found") {
 			continue
 		}
-		return nil, errors.Wrap(err, "kubernetes delete")
-	}
-
-	result, err := k.helmKubeClient.Create(list)
-	if err != nil {
-		return nil, errors.Wrap(err, "kubernetes create")
+		return errors.Wrap(err, "kubernetes delete")
 	}
-	return result, nil
+	return nil
 }
 
 // applyEntityAndMaybeForce `kubectl apply`'s the given entity, and if the call fails with
@@ -501,6 +551,14 @@ func (k *K8sClient) Delete(ctx context.Context, entities []K8sEntity) error {
 }
 
 func (k *K8sClient) gvr(ctx context.Context, gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	rm, err := k.restMapping(ctx, gvk)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return rm.Resource, nil
+}
+
+func (k *K8sClient) restMapping(ctx context.Context, gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
 	rm, err := k.drm.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
 		// The REST mapper doesn't have any sort of internal invalidation
@@ -516,10 +574,18 @@ func (k *K8sClient) gvr(ctx context.Context, gvk schema.GroupVersionKind) (schem
 
 		rm, err = k.drm.RESTMapping(gvk.GroupKind(), gvk.Version)
 		if err != nil {
-			return schema.GroupVersionResource{}, errors.Wrapf(err, "error mapping %s/%s", gvk.Group, gvk.Kind)
+			return nil, errors.Wrapf(err, "error mapping %s/%s", gvk.Group, gvk.Kind)
 		}
 	}
-	return rm.Resource, nil
+	return rm, nil
+}
+
+func (k *K8sClient) IsClusterScoped(ctx context.Context, gvk schema.GroupVersionKind) (bool, error) {
+	rm, err := k.restMapping(ctx, gvk)
+	if err != nil {
+		return false, err
+	}
+	return rm.Scope.Name() == meta.RESTScopeNameRoot, nil
 }
 
 func (k *K8sClient) ListMeta(ctx context.Context, gvk schema.GroupVersionKind, ns Namespace) ([]ObjectMeta, error) {
@@ -542,14 +608,87 @@ func (k *K8sClient) ListMeta(ctx context.Context, gvk schema.GroupVersionKind, n
 	return result, nil
 }
 
+func (k *K8sClient) ListRefsWithLabel(ctx context.Context, label model.LabelPair) ([]v1.ObjectReference, error) {
+	// If some API groups fail discovery (e.g., an aggregated API server is down),
+	// we still get the groups that succeeded.
+	resourceLists, err := k.discovery.ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, errors.Wrap(err, "listing API resources")
+	}
+
+	selector := fmt.Sprintf("%s=%s", label.Key, label.Value)
+	seen := make(map[string]bool)
+	var result []v1.ObjectReference
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+
+		for _, resource := range resourceList.APIResources {
+			// Skip subresources, like pods/log.
+			if strings.Contains(resource.Name, "/") || !hasVerbs(resource.Verbs, "list", "delete") {
+				continue
+			}
+
+			metaList, err := k.metadata.Resource(gv.WithResource(resource.Name)).
+				Namespace("").
+				List(ctx, metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				if apierrors.IsForbidden(err) || apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err) {
+					continue
+				}
+				return nil, errors.Wrapf(err, "listing %s", resource.Name)
+			}
+
+			for _, item := range metaList.Items {
+				// Some kinds are served by more than one API group.
+				if seen[string(item.UID)] {
+					continue
+				}
+				seen[string(item.UID)] = true
+				result = append(result, v1.ObjectReference{
+					APIVersion: gv.String(),
+					Kind:       resource.Kind,
+					Namespace:  item.Namespace,
+					Name:       item.Name,
+					UID:        item.UID,
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+func hasVerbs(verbs metav1.Verbs, want ...string) bool {
+	for _, w := range want {
+		found := false
+		for _, v := range verbs {
+			if v == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 func (k *K8sClient) GetMetaByReference(ctx context.Context, ref v1.ObjectReference) (ObjectMeta, error) {
 	gvk := ReferenceGVK(ref)
-	gvr, err := k.gvr(ctx, gvk)
+	rm, err := k.restMapping(ctx, gvk)
 	if err != nil {
 		return nil, err
 	}
+	gvr := rm.Resource
 
+	// Like kubectl, a namespaced object without a namespace lives in the default namespace.
 	namespace := ref.Namespace
+	if namespace == "" && rm.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace = k.configNamespace.String()
+	}
 	name := ref.Name
 	resourceVersion := ref.ResourceVersion
 	uid := ref.UID
@@ -567,6 +706,21 @@ func (k *K8sClient) GetMetaByReference(ctx context.Context, ref v1.ObjectReferen
 	return &meta, nil
 }
 
+func (k *K8sClient) SecretData(ctx context.Context, ns Namespace, name string) (map[string][]byte, error) {
+	secret, err := k.core.Secrets(ns.String()).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+	for key, data := range secret.Data {
+		result[key] = data
+	}
+	for key, data := range secret.StringData {
+		result[key] = []byte(data)
+	}
+	return result, nil
+}
+
 // Tests whether a string is a valid version for a k8s resource type.
 // from https://kubernetes.io/docs/tasks/access-kubernetes-api/custom-resources/custom-resource-definition-versioning/#version-priority
 // Versions start with a v followed by a number, an optional beta or alpha designation, and optional additional numeric
@@ -10,13 +10,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"helm.sh/helm/v3/pkg/kube"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/resource"
+	dfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	restfake "k8s.io/client-go/rest/fake"
@@ -24,6 +31,8 @@ import (
 
 	"github.com/tilt-dev/tilt/internal/k8s/testyaml"
 	"github.com/tilt-dev/tilt/internal/testutils"
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
 )
 
 func TestEmptyNamespace(t *testing.T) {
@@ -126,6 +135,137 @@ func TestUpsertToTerminatingNamespaceForbidden(t *testing.T) {
 	assert.Equal(t, 0, len(f.helmKube.creates))
 }
 
+func TestServerSideApply(t *testing.T) {
+	f := newClientTestFixture(t)
+	sancho := MustParseYAMLFromString(t, testyaml.SanchoYAML)
+
+	result, err := f.client.Upsert(f.ctx, sancho, time.Minute, model.K8sApplyModeServerSide)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "sancho", result[0].Name())
+	assert.NotEmpty(t, result[0].UID())
+
+	require.Len(t, f.patches, 1)
+	assert.Equal(t, types.ApplyPatchType, f.patches[0].GetPatchType())
+	assert.Equal(t, "default", f.patches[0].GetNamespace())
+	assert.Equal(t, 0, len(f.helmKube.updates))
+}
+
+func TestServerSideApplyFieldConflict(t *testing.T) {
+	f := newClientTestFixture(t)
+	sancho := MustParseYAMLFromString(t, testyaml.SanchoYAML)
+
+	f.patchErrs = []error{replicasConflict()}
+
+	out := bytes.NewBuffer(nil)
+	ctx := logger.WithLogger(f.ctx, logger.NewLogger(logger.InfoLvl, out))
+	_, err := f.client.Upsert(ctx, sancho, time.Minute, model.K8sApplyModeServerSide)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "Deployment sancho has fields that are managed by someone else")
+	assert.Contains(t, out.String(), `.spec.replicas: conflict with "kube-controller-manager" using apps/v1`)
+	assert.Contains(t, out.String(), "Tilt will leave these fields to their current manager")
+
+	// The second patch leaves out the conflicting field.
+	require.Len(t, f.patches, 2)
+	assert.Contains(t, string(f.patches[0].GetPatch()), "replicas")
+	assert.NotContains(t, string(f.patches[1].GetPatch()), "replicas")
+	assert.Contains(t, string(f.patches[1].GetPatch()), "gcr.io/some-project-162817/sancho")
+}
+
+func TestRemoveFieldPath(t *testing.T) {
+	newObj := func() map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					"example.com/owner": "me",
+					"example.com":       "you",
+				},
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "app", "image": "app-image"},
+							map[string]interface{}{"name": "sidecar", "image": "sidecar-image"},
+						},
+					},
+				},
+				"ports": []interface{}{
+					map[string]interface{}{"containerPort": int64(80), "protocol": "TCP"},
+				},
+				"finalizers": []interface{}{"a", "b"},
+			},
+		}
+	}
+
+	cases := []struct {
+		path     string
+		expected func(obj map[string]interface{})
+	}{
+		{".spec.replicas", func(obj map[string]interface{}) {
+			delete(obj["spec"].(map[string]interface{}), "replicas")
+		}},
+		{".metadata.annotations.example.com/owner", func(obj map[string]interface{}) {
+			delete(obj["metadata"].(map[string]interface{})["annotations"].(map[string]interface{}), "example.com/owner")
+		}},
+		{`.spec.template.spec.containers[name="sidecar"].image`, func(obj map[string]interface{}) {
+			containers := obj["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})
+			delete(containers[1].(map[string]interface{}), "image")
+		}},
+		{`.spec.template.spec.containers[name="app"]`, func(obj map[string]interface{}) {
+			spec := obj["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})
+			spec["containers"] = spec["containers"].([]interface{})[1:]
+		}},
+		{`.spec.ports[containerPort=80,protocol="TCP"]`, func(obj map[string]interface{}) {
+			obj["spec"].(map[string]interface{})["ports"] = []interface{}{}
+		}},
+		{`.spec.finalizers[="b"]`, func(obj map[string]interface{}) {
+			obj["spec"].(map[string]interface{})["finalizers"] = []interface{}{"a"}
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			expected := newObj()
+			c.expected(expected)
+
+			actual, ok := removeFieldPath(newObj(), c.path)
+			require.True(t, ok)
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	_, ok := removeFieldPath(newObj(), `.spec.template.spec.containers[name="missing"].image`)
+	assert.False(t, ok)
+}
+
+func TestServerSideApplyImmutableField(t *testing.T) {
+	f := newClientTestFixture(t)
+	sancho := MustParseYAMLFromString(t, testyaml.SanchoYAML)
+
+	f.patchErrs = []error{fmt.Errorf(`Deployment.apps "sancho" is invalid: spec.selector: Invalid value: field is immutable`)}
+
+	_, err := f.client.Upsert(f.ctx, sancho, time.Minute, model.K8sApplyModeServerSide)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(f.helmKube.deletes))
+	assert.Equal(t, 0, len(f.helmKube.creates))
+	require.Len(t, f.patches, 2)
+}
+
+func TestServerSideApplyError(t *testing.T) {
+	f := newClientTestFixture(t)
+	sancho := MustParseYAMLFromString(t, testyaml.SanchoYAML)
+
+	f.patchErrs = []error{fmt.Errorf("namespace sancho-ns is being terminated")}
+
+	_, err := f.client.Upsert(f.ctx, sancho, time.Minute, model.K8sApplyModeServerSide)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kubernetes server-side apply: namespace sancho-ns is being terminated")
+	assert.Equal(t, 0, len(f.helmKube.deletes))
+}
+
 func TestGetGroup(t *testing.T) {
 	for _, test := range []struct {
 		name          string
@@ -153,6 +293,7 @@ type fakeHelmKubeClient struct {
 	updates   kube.ResourceList
 	creates   kube.ResourceList
 	deletes   kube.ResourceList
+	dryRuns   kube.ResourceList
 	updateErr error
 }
 
@@ -167,6 +308,10 @@ func (c *fakeHelmKubeClient) Apply(target kube.ResourceList) (*kube.Result, erro
 	c.updates = append(c.updates, target...)
 	return &kube.Result{Updated: target}, nil
 }
+func (c *fakeHelmKubeClient) DryRunApply(target kube.ResourceList) (kube.ResourceList, error) {
+	c.dryRuns = append(c.dryRuns, target...)
+	return target, nil
+}
 func (c *fakeHelmKubeClient) Delete(l kube.ResourceList) (*kube.Result, []error) {
 	c.deletes = append(c.deletes, l...)
 	return &kube.Result{Deleted: l}, nil
@@ -198,6 +343,16 @@ func (c *fakeHelmKubeClient) Build(r io.Reader, validate bool) (kube.ResourceLis
 	return list, nil
 }
 
+func replicasConflict() error {
+	return apierrors.NewApplyConflict([]metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kube-controller-manager" using apps/v1`,
+			Field:   ".spec.replicas",
+		},
+	}, "Apply failed with 1 conflict")
+}
+
 type clientTestFixture struct {
 	t           *testing.T
 	ctx         context.Context
@@ -205,6 +360,10 @@ type clientTestFixture struct {
 	tracker     ktesting.ObjectTracker
 	watchNotify chan watch.Interface
 	helmKube    *fakeHelmKubeClient
+
+	// Server-side apply patches, and errors to return from them.
+	patches   []ktesting.PatchAction
+	patchErrs []error
 }
 
 func newClientTestFixture(t *testing.T) *clientTestFixture {
@@ -239,8 +398,12 @@ func newClientTestFixture(t *testing.T) *clientTestFixture {
 	helmKube := &fakeHelmKubeClient{}
 	ret.helmKube = helmKube
 
+	dcs := dfake.NewSimpleDynamicClient(scheme.Scheme)
+	dcs.PrependReactor("patch", "*", ret.patchReaction)
+
 	ret.client = K8sClient{
 		env:               EnvUnknown,
+		dynamic:           dcs,
 		core:              core,
 		portForwardClient: &FakePortForwardClient{},
 		runtimeAsync:      runtimeAsync,
@@ -252,6 +415,29 @@ func newClientTestFixture(t *testing.T) *clientTestFixture {
 	return ret
 }
 
+func (c *clientTestFixture) patchReaction(action ktesting.Action) (bool, runtime.Object, error) {
+	patch := action.(ktesting.PatchAction)
+	c.patches = append(c.patches, patch)
+
+	if len(c.patchErrs) > 0 {
+		err := c.patchErrs[0]
+		c.patchErrs = c.patchErrs[1:]
+		return true, nil, err
+	}
+
+	entities, err := ParseYAMLFromString(string(patch.GetPatch()))
+	if err != nil {
+		return true, nil, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(entities[0].Obj)
+	if err != nil {
+		return true, nil, err
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetUID(types.UID(uuid.New().String()))
+	return true, obj, nil
+}
+
 func (c clientTestFixture) k8sUpsert(ctx context.Context, entities []K8sEntity) ([]K8sEntity, error) {
-	return c.client.Upsert(ctx, entities, time.Minute)
+	return c.client.Upsert(ctx, entities, time.Minute, model.K8sApplyModeClientSide)
 }
@@ -0,0 +1,114 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+)
+
+// A client for one kubeconfig context, along with the helpers
+// that are tied to that cluster.
+type ContextClient struct {
+	Context         KubeContext
+	Client          Client
+	ConfigNamespace Namespace
+	OwnerFetcher    OwnerFetcher
+}
+
+// Creates a client for a kubeconfig context that Tilt didn't start with.
+type ContextClientFactory func(ctx context.Context, kubeContext KubeContext) (Client, Namespace)
+
+// Holds a Kubernetes client for each kubeconfig context that we deploy to.
+//
+// Most Tiltfiles deploy everything to the context that Tilt started with,
+// but resources can target other contexts in the same kubeconfig with
+// k8s_yaml(context=...) or k8s_resource(context=...).
+//
+// Clients for other contexts are created lazily.
+type ContextClients struct {
+	globalCtx      context.Context
+	defaultContext KubeContext
+	factory        ContextClientFactory
+
+	mu      sync.Mutex
+	clients map[KubeContext]ContextClient
+}
+
+func ProvideContextClients(ctx context.Context, kubeContext KubeContext, kCli Client, cfgNS Namespace, ownerFetcher OwnerFetcher) *ContextClients {
+	return NewContextClients(ctx, ContextClient{
+		Context:         kubeContext,
+		Client:          kCli,
+		ConfigNamespace: cfgNS,
+		OwnerFetcher:    ownerFetcher,
+	}, newClientForContext)
+}
+
+func NewContextClients(ctx context.Context, defaultClient ContextClient, factory ContextClientFactory) *ContextClients {
+	return &ContextClients{
+		globalCtx:      ctx,
+		defaultContext: defaultClient.Context,
+		factory:        factory,
+		clients: map[KubeContext]ContextClient{
+			defaultClient.Context: defaultClient,
+		},
+	}
+}
+
+// The context that Tilt started with.
+func (c *ContextClients) DefaultContext() KubeContext {
+	return c.defaultContext
+}
+
+// Normalizes a context from a K8sTarget, where the empty string
+// means the context that Tilt started with.
+func (c *ContextClients) Resolve(kubeContext KubeContext) KubeContext {
+	if kubeContext == "" {
+		return c.defaultContext
+	}
+	return kubeContext
+}
+
+func (c *ContextClients) Default() ContextClient {
+	return c.For(c.defaultContext)
+}
+
+// Returns the client for the given context, creating it if necessary.
+func (c *ContextClients) For(kubeContext KubeContext) ContextClient {
+	kubeContext = c.Resolve(kubeContext)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cc, ok := c.clients[kubeContext]
+	if ok {
+		return cc
+	}
+
+	kCli, ns := c.factory(c.globalCtx, kubeContext)
+	cc = ContextClient{
+		Context:         kubeContext,
+		Client:          kCli,
+		ConfigNamespace: ns,
+		OwnerFetcher:    ProvideOwnerFetcher(c.globalCtx, kCli),
+	}
+	c.clients[kubeContext] = cc
+	return cc
+}
+
+// Builds a client the same way we build the client for the current context,
+// but with the context overridden.
+func newClientForContext(ctx context.Context, kubeContext KubeContext) (Client, Namespace) {
+	override := KubeContextOverride(kubeContext)
+	clientLoader := ProvideClientConfig(override)
+	config, err := ProvideKubeConfig(clientLoader, override)
+	if err != nil {
+		return &explodingClient{err: err}, ""
+	}
+
+	env := ProvideEnv(ctx, config)
+	restConfig := ProvideRESTConfig(clientLoader)
+	clientset := ProvideClientset(restConfig)
+	pfClient := ProvidePortForwardClient(restConfig, clientset)
+	ns := ProvideConfigNamespace(clientLoader)
+	kCli := ProvideK8sClient(ctx, env, restConfig, clientset, pfClient, ns, ProvideMinikubeClient(kubeContext), clientLoader)
+	return kCli, ns
+}
@@ -0,0 +1,263 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// The number of unchanged lines to show around each change.
+const diffContextLines = 3
+
+// Fields that the server manages, and that would show up as noise in every diff.
+var diffIgnoredFields = [][]string{
+	{"status"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "uid"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+	{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"},
+	{"metadata", "annotations", "deployment.kubernetes.io/revision"},
+}
+
+// Diff computes a unified diff between the live objects in the cluster and
+// the objects that we'd get if we applied the given entities with the given mode.
+//
+// The applied objects come from a server dry-run, so the diff includes
+// any defaulting and mutation that the server would do.
+//
+// Returns an empty string if nothing would change.
+func (k *K8sClient) Diff(ctx context.Context, entities []K8sEntity, mode model.K8sApplyMode) (string, error) {
+	var sb strings.Builder
+	for _, e := range entities {
+		ri, err := k.resourceInterface(ctx, e)
+		if err != nil {
+			return "", errors.Wrap(err, "kubernetes diff")
+		}
+
+		var live *unstructured.Unstructured
+		obj, err := ri.Get(ctx, e.Name(), metav1.GetOptions{})
+		if err == nil {
+			live = obj
+		} else if !apierrors.IsNotFound(err) {
+			return "", errors.Wrap(err, "kubernetes diff")
+		}
+
+		name := fmt.Sprintf("%s/%s", e.GVK().Kind, e.Name())
+		proposed, err := k.dryRunApply(ctx, e, mode)
+		if err != nil {
+			// e.g., an immutable field error, which we'll resolve by
+			// re-creating the object.
+			sb.WriteString(fmt.Sprintf("~~~ %s (unable to diff: %v)\n", name, err))
+			continue
+		}
+
+		d, err := diffObjects(name, live, proposed)
+		if err != nil {
+			return "", errors.Wrap(err, "kubernetes diff")
+		}
+		sb.WriteString(d)
+	}
+	return sb.String(), nil
+}
+
+// Returns the object that the server would store if we applied the entity
+// with the given mode, without changing anything in the cluster.
+func (k *K8sClient) dryRunApply(ctx context.Context, entity K8sEntity, mode model.K8sApplyMode) (*unstructured.Unstructured, error) {
+	if mode.IsServerSide() {
+		force := mode == model.K8sApplyModeServerSideForce
+		result, err := k.serverSideApply(ctx, entity, force, true)
+		if err != nil && isFieldManagerConflict(err) {
+			// Like serverSideApplyEntity, leave the conflicting fields to their owner.
+			entity, err = withoutConflictingFields(entity, fieldConflicts(err))
+			if err == nil {
+				result, err = k.serverSideApply(ctx, entity, false, true)
+			}
+		}
+		return result, err
+	}
+
+	resources, err := k.prepareUpdate(ctx, []K8sEntity{entity})
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err = k.helmKubeClient.DryRunApply(resources)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("expected 1 object from dry-run, got %d", len(resources))
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(resources[0].Object)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// Returns a unified diff of two versions of an object. If the live object is nil,
+// the object doesn't exist yet, and we only print a header.
+func diffObjects(name string, live, proposed *unstructured.Unstructured) (string, error) {
+	if live == nil {
+		return fmt.Sprintf("+++ %s (new)\n", name), nil
+	}
+
+	before, err := diffableYAML(live)
+	if err != nil {
+		return "", err
+	}
+	after, err := diffableYAML(proposed)
+	if err != nil {
+		return "", err
+	}
+
+	hunks := unifiedDiff(splitLines(before), splitLines(after), diffContextLines)
+	if hunks == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("--- %s (live)\n+++ %s (tilt)\n%s", name, name, hunks), nil
+}
+
+func diffableYAML(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	for _, field := range diffIgnoredFields {
+		unstructured.RemoveNestedField(obj.Object, field...)
+	}
+	if len(obj.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+	}
+	return SerializeSpecYAML([]K8sEntity{NewK8sEntity(obj)})
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+type diffOp struct {
+	kind byte // ' ', '-', or '+'
+	line string
+}
+
+// Computes a unified diff of two lists of lines, using the longest common subsequence.
+//
+// Kubernetes objects are small enough that the quadratic algorithm is fine.
+func unifiedDiff(a, b []string, context int) string {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+
+	var sb strings.Builder
+	for start := 0; start < len(ops); {
+		// Find the next change.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+
+		// Extend the hunk until we see more than 2*context unchanged lines.
+		hunkStart := max(first-context, start)
+		end := first
+		for k := first; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				end = k + 1
+			} else if k-end >= 2*context {
+				break
+			}
+		}
+		hunkEnd := min(end+context, len(ops))
+
+		aStart, bStart := lineNumbers(ops[:hunkStart])
+		aLen, bLen := lineNumbers(ops[hunkStart:hunkEnd])
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen)))
+		for _, op := range ops[hunkStart:hunkEnd] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.line)
+			sb.WriteByte('\n')
+		}
+		start = hunkEnd
+	}
+	return sb.String()
+}
+
+// Counts the lines of a and b in a list of ops.
+func lineNumbers(ops []diffOp) (int, int) {
+	a, b := 0, 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			a++
+		}
+		if op.kind != '-' {
+			b++
+		}
+	}
+	return a, b
+}
+
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/tilt-dev/tilt/internal/k8s/testyaml"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+func TestDiffNewObject(t *testing.T) {
+	f := newClientTestFixture(t)
+	sancho := MustParseYAMLFromString(t, testyaml.SanchoYAML)
+
+	d, err := f.client.Diff(f.ctx, sancho, model.K8sApplyModeServerSide)
+	require.NoError(t, err)
+	assert.Equal(t, "+++ Deployment/sancho (new)\n", d)
+
+	require.Len(t, f.patches, 1)
+	assert.Equal(t, 0, len(f.helmKube.updates))
+}
+
+func TestDiffFieldConflict(t *testing.T) {
+	f := newClientTestFixture(t)
+	sancho := MustParseYAMLFromString(t, testyaml.SanchoYAML)
+	f.patchErrs = []error{replicasConflict()}
+
+	_, err := f.client.Diff(f.ctx, sancho, model.K8sApplyModeServerSide)
+	require.NoError(t, err)
+
+	// Like the real apply, the dry-run leaves the conflicting field to its owner.
+	require.Len(t, f.patches, 2)
+	assert.NotContains(t, string(f.patches[1].GetPatch()), "replicas")
+}
+
+func TestDiffClientSide(t *testing.T) {
+	f := newClientTestFixture(t)
+	sancho := MustParseYAMLFromString(t, testyaml.SanchoYAML)
+
+	d, err := f.client.Diff(f.ctx, sancho, model.K8sApplyModeClientSide)
+	require.NoError(t, err)
+	assert.Equal(t, "+++ Deployment/sancho (new)\n", d)
+
+	// Client-side mode does a kubectl apply dry-run, not a server-side apply.
+	assert.Len(t, f.patches, 0)
+	assert.Len(t, f.helmKube.dryRuns, 1)
+	assert.Equal(t, 0, len(f.helmKube.updates))
+}
+
+func TestDiffDryRunError(t *testing.T) {
+	f := newClientTestFixture(t)
+	sancho := MustParseYAMLFromString(t, testyaml.SanchoYAML)
+	f.patchErrs = []error{fmt.Errorf("field is immutable")}
+
+	d, err := f.client.Diff(f.ctx, sancho, model.K8sApplyModeServerSide)
+	require.NoError(t, err)
+	assert.Equal(t, "~~~ Deployment/sancho (unable to diff: field is immutable)\n", d)
+}
+
+func TestDiffObjectsNew(t *testing.T) {
+	proposed := unstructuredFromYAML(t, testyaml.SanchoYAML)
+	d, err := diffObjects("Deployment/sancho", nil, proposed)
+	require.NoError(t, err)
+	assert.Equal(t, "+++ Deployment/sancho (new)\n", d)
+}
+
+func TestDiffObjectsUnchanged(t *testing.T) {
+	live := unstructuredFromYAML(t, testyaml.SanchoYAML)
+	live.SetResourceVersion("123")
+	live.SetUID("abc")
+	_ = unstructured.SetNestedField(live.Object, int64(1), "status", "replicas")
+
+	proposed := unstructuredFromYAML(t, testyaml.SanchoYAML)
+	d, err := diffObjects("Deployment/sancho", live, proposed)
+	require.NoError(t, err)
+	assert.Equal(t, "", d)
+}
+
+func TestDiffObjectsChanged(t *testing.T) {
+	live := unstructuredFromYAML(t, testyaml.SanchoYAML)
+	proposed := unstructuredFromYAML(t, testyaml.SanchoYAML)
+	_ = unstructured.SetNestedField(proposed.Object, int64(3), "spec", "replicas")
+
+	d, err := diffObjects("Deployment/sancho", live, proposed)
+	require.NoError(t, err)
+	assert.Contains(t, d, "--- Deployment/sancho (live)\n+++ Deployment/sancho (tilt)\n@@ ")
+	assert.Contains(t, d, "-  replicas: 1\n+  replicas: 3\n")
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"}
+	b := []string{"a", "B", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m"}
+
+	expected := `@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -10,3 +10,4 @@
+ j
+ k
+ l
++m
+`
+	assert.Equal(t, expected, unifiedDiff(a, b, 3))
+}
+
+func TestUnifiedDiffMergesNearbyHunks(t *testing.T) {
+	a := []string{"a", "b", "c", "d", "e"}
+	b := []string{"A", "b", "c", "d", "E"}
+
+	expected := `@@ -1,5 +1,5 @@
+-a
++A
+ b
+ c
+ d
+-e
++E
+`
+	assert.Equal(t, expected, unifiedDiff(a, b, 3))
+}
+
+func TestUnifiedDiffSame(t *testing.T) {
+	a := []string{"a", "b"}
+	assert.Equal(t, "", unifiedDiff(a, a, 3))
+}
+
+func unstructuredFromYAML(t *testing.T, yaml string) *unstructured.Unstructured {
+	entities, err := ParseYAMLFromString(yaml)
+	require.NoError(t, err)
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(entities[0].Obj)
+	require.NoError(t, err)
+	return &unstructured.Unstructured{Object: content}
+}
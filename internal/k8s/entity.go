@@ -101,6 +101,12 @@ func (e K8sEntity) WithNamespace(ns string) K8sEntity {
 	return newE
 }
 
+// Changes the namespace in-place. Unlike WithNamespace, doesn't make a copy,
+// so any maps keyed by the underlying object stay valid.
+func (e K8sEntity) SetNamespace(ns string) {
+	e.meta().SetNamespace(ns)
+}
+
 func (e K8sEntity) GVK() schema.GroupVersionKind {
 	gvk := e.Obj.GetObjectKind().GroupVersionKind()
 	if gvk.Empty() {
@@ -430,6 +436,62 @@ func (e K8sEntity) HasKind(kind string) bool {
 	return strings.EqualFold(e.GVK().Kind, kind)
 }
 
+var NamespaceGVK = schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"}
+
+// Kinds that don't live in a namespace.
+//
+// The authoritative source is the API server's discovery endpoint, but we need
+// to know this before we talk to the cluster (e.g., when rewriting namespaces
+// in the Tiltfile), so we keep a list of the common built-in kinds.
+var clusterScopedKinds = map[schema.GroupKind]bool{
+	{Group: "", Kind: "Namespace"}:                                                  true,
+	{Group: "", Kind: "Node"}:                                                       true,
+	{Group: "", Kind: "PersistentVolume"}:                                           true,
+	{Group: "", Kind: "ComponentStatus"}:                                            true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                       true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:               true,
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}:                           true,
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:   true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}: true,
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                 true,
+	{Group: "storage.k8s.io", Kind: "CSIDriver"}:                                    true,
+	{Group: "storage.k8s.io", Kind: "CSINode"}:                                      true,
+	{Group: "storage.k8s.io", Kind: "VolumeAttachment"}:                             true,
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                             true,
+	{Group: "node.k8s.io", Kind: "RuntimeClass"}:                                    true,
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:                              true,
+	{Group: "policy", Kind: "PodSecurityPolicy"}:                                    true,
+	{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"}:               true,
+}
+
+// Returns true if the entity is one of the well-known cluster-scoped kinds.
+//
+// Custom resources are assumed to be namespaced. At deploy time, we check
+// them against the cluster with Client.IsClusterScoped.
+func (e K8sEntity) IsClusterScoped() bool {
+	return clusterScopedKinds[e.GVK().GroupKind()]
+}
+
+// Returns the names of all namespaces that the given entities
+// are deployed to, not counting entities without an explicit namespace.
+func ReferencedNamespaces(entities []K8sEntity) []Namespace {
+	seen := make(map[Namespace]bool)
+	result := []Namespace{}
+	for _, e := range entities {
+		if e.IsClusterScoped() {
+			continue
+		}
+		ns := Namespace(e.meta().GetNamespace())
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		result = append(result, ns)
+	}
+	return result
+}
+
 func NewNamespaceEntity(name string) K8sEntity {
 	yaml := fmt.Sprintf(`apiVersion: v1
 kind: Namespace
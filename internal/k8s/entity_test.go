@@ -175,6 +175,18 @@ func TestHasNamespace(t *testing.T) {
 	assert.False(t, snack.HasNamespace(testyaml.DoggosNamespace))
 }
 
+func TestReferencedNamespaces(t *testing.T) {
+	entities, err := parseYAMLFromStrings(testyaml.DoggosDeploymentYaml, testyaml.SnackYaml,
+		testyaml.DoggosServiceYaml, testyaml.MyNamespaceYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, entities[3].IsClusterScoped())
+	assert.False(t, entities[0].IsClusterScoped())
+	assert.Equal(t, []Namespace{testyaml.DoggosNamespace}, ReferencedNamespaces(entities))
+}
+
 func TestHasKind(t *testing.T) {
 	entities, err := parseYAMLFromStrings(testyaml.DoggosDeploymentYaml, testyaml.DoggosServiceYaml)
 	if err != nil {
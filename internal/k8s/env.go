@@ -78,6 +78,14 @@ func ProvideClusterName(ctx context.Context, config *api.Config) ClusterName {
 	return ClusterName(c.Cluster)
 }
 
+// Returns the environment of a context in the kubeconfig, which
+// may be different from the current context.
+func EnvForContext(ctx context.Context, config *api.Config, kubeContext KubeContext) Env {
+	c := *config
+	c.CurrentContext = string(kubeContext)
+	return ProvideEnv(ctx, &c)
+}
+
 func ProvideEnv(ctx context.Context, config *api.Config) Env {
 	n := config.CurrentContext
 
@@ -8,6 +8,7 @@ import (
 	"github.com/docker/distribution/reference"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -21,10 +22,14 @@ type explodingClient struct {
 	err error
 }
 
-func (ec *explodingClient) Upsert(ctx context.Context, entities []K8sEntity, timeout time.Duration) ([]K8sEntity, error) {
+func (ec *explodingClient) Upsert(ctx context.Context, entities []K8sEntity, timeout time.Duration, mode model.K8sApplyMode) ([]K8sEntity, error) {
 	return nil, errors.Wrap(ec.err, "could not set up k8s client")
 }
 
+func (ec *explodingClient) Diff(ctx context.Context, entities []K8sEntity, mode model.K8sApplyMode) (string, error) {
+	return "", errors.Wrap(ec.err, "could not set up k8s client")
+}
+
 func (ec *explodingClient) Delete(ctx context.Context, entities []K8sEntity) error {
 	return errors.Wrap(ec.err, "could not set up k8s client")
 }
@@ -37,6 +42,18 @@ func (ec *explodingClient) ListMeta(ctx context.Context, gvk schema.GroupVersion
 	return nil, errors.Wrap(ec.err, "could not set up k8s client")
 }
 
+func (ec *explodingClient) ListRefsWithLabel(ctx context.Context, label model.LabelPair) ([]v1.ObjectReference, error) {
+	return nil, errors.Wrap(ec.err, "could not set up k8s client")
+}
+
+func (ec *explodingClient) IsClusterScoped(ctx context.Context, gvk schema.GroupVersionKind) (bool, error) {
+	return false, errors.Wrap(ec.err, "could not set up k8s client")
+}
+
+func (ec *explodingClient) SecretData(ctx context.Context, ns Namespace, name string) (map[string][]byte, error) {
+	return nil, errors.Wrap(ec.err, "could not set up k8s client")
+}
+
 func (ec *explodingClient) PodsWithImage(ctx context.Context, image reference.NamedTagged, n Namespace, lp []model.LabelPair) ([]v1.Pod, error) {
 	return nil, errors.Wrap(ec.err, "could not set up k8s client")
 }
@@ -49,10 +66,22 @@ func (ec *explodingClient) ContainerLogs(ctx context.Context, podID PodID, cName
 	return nil, errors.Wrap(ec.err, "could not set up k8s client")
 }
 
+func (ec *explodingClient) PreviousContainerLogs(ctx context.Context, podID PodID, cName container.Name, n Namespace, startTime time.Time) (io.ReadCloser, error) {
+	return nil, errors.Wrap(ec.err, "could not set up k8s client")
+}
+
 func (ec *explodingClient) CreatePortForwarder(ctx context.Context, namespace Namespace, podID PodID, optionalLocalPort, remotePort int, host string) (PortForwarder, error) {
 	return nil, errors.Wrap(ec.err, "could not set up k8s client")
 }
 
+func (ec *explodingClient) CreatePortForwardBalancer(ctx context.Context, optionalLocalPort, remotePort int, host string) (PortForwardBalancer, error) {
+	return nil, errors.Wrap(ec.err, "could not set up k8s client")
+}
+
+func (ec *explodingClient) CreateReverseForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort, remotePort int) (PortForwarder, error) {
+	return nil, errors.Wrap(ec.err, "could not set up k8s client")
+}
+
 func (ec *explodingClient) WatchPods(ctx context.Context, ns Namespace) (<-chan ObjectUpdate, error) {
 	return nil, errors.Wrap(ec.err, "could not set up k8s client")
 }
@@ -61,6 +90,14 @@ func (ec *explodingClient) PodFromInformerCache(ctx context.Context, nn types.Na
 	return nil, errors.Wrap(ec.err, "could not set up k8s client")
 }
 
+func (ec *explodingClient) PodsFromInformerCache(ctx context.Context, ns Namespace, selector labels.Selector) ([]*v1.Pod, error) {
+	return nil, errors.Wrap(ec.err, "could not set up k8s client")
+}
+
+func (ec *explodingClient) ServiceFromInformerCache(ctx context.Context, nn types.NamespacedName) (*v1.Service, error) {
+	return nil, errors.Wrap(ec.err, "could not set up k8s client")
+}
+
 func (ec *explodingClient) WatchServices(ctx context.Context, ns Namespace) (<-chan *v1.Service, error) {
 	return nil, errors.Wrap(ec.err, "could not set up k8s client")
 }
@@ -73,6 +110,10 @@ func (ec *explodingClient) WatchMeta(ctx context.Context, gvk schema.GroupVersio
 	return nil, errors.Wrap(ec.err, "could not set up k8s client")
 }
 
+func (ec *explodingClient) WatchObjects(ctx context.Context, gvk schema.GroupVersionKind, ns Namespace) (<-chan ObjectUpdate, error) {
+	return nil, errors.Wrap(ec.err, "could not set up k8s client")
+}
+
 func (ec *explodingClient) ContainerRuntime(ctx context.Context) container.Runtime {
 	return container.RuntimeUnknown
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -14,12 +15,14 @@ import (
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/tilt-dev/tilt/internal/container"
 	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
 )
 
 // A magic constant. If the docker client returns this constant, we always match
@@ -48,21 +51,38 @@ type FakeK8sClient struct {
 	LastPodQueryNamespace Namespace
 	LastPodQueryImage     reference.NamedTagged
 
-	PodLogsByPodAndContainer map[PodAndCName]ReaderCloser
-	LastPodLogStartTime      time.Time
-	LastPodLogContext        context.Context
-	ContainerLogsError       error
+	PodLogsByPodAndContainer         map[PodAndCName]ReaderCloser
+	PreviousPodLogsByPodAndContainer map[PodAndCName]ReaderCloser
+	LastPodLogStartTime              time.Time
+	LastPodLogContext                context.Context
+	ContainerLogsError               error
 
 	podWatches     []fakePodWatch
 	serviceWatches []fakeServiceWatch
 	eventWatches   []fakeEventWatch
+	objectWatches  []fakeObjectWatch
 	pods           map[types.NamespacedName]*v1.Pod
+	services       map[types.NamespacedName]*v1.Service
 
 	EventsWatchErr error
 
 	UpsertError      error
 	LastUpsertResult []K8sEntity
 	UpsertTimeout    time.Duration
+	UpsertMode       model.K8sApplyMode
+	// The YAML of every Upsert call, in order.
+	UpsertedYAMLs []string
+
+	DiffResult   string
+	DiffError    error
+	DiffEntities []K8sEntity
+	DiffMode     model.K8sApplyMode
+	DiffCalls    int
+	DiffDeadline time.Time
+
+	// Whether each custom kind is cluster-scoped. All other kinds
+	// are looked up in the list of well-known kinds.
+	CustomKinds map[schema.GroupKind]bool
 
 	Runtime    container.Runtime
 	Registry   container.Registry
@@ -101,9 +121,16 @@ type fakeEventWatch struct {
 	ch chan *v1.Event
 }
 
+type fakeObjectWatch struct {
+	gvk schema.GroupVersionKind
+	ns  Namespace
+	ch  chan ObjectUpdate
+}
+
 func (c *FakeK8sClient) EmitService(ls labels.Selector, s *v1.Service) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.services[types.NamespacedName{Name: s.Name, Namespace: s.Namespace}] = s
 	for _, w := range c.serviceWatches {
 		if w.ns != Namespace(s.Namespace) {
 			continue
@@ -129,6 +156,30 @@ func (c *FakeK8sClient) PodFromInformerCache(ctx context.Context, nn types.Names
 	return pod, nil
 }
 
+func (c *FakeK8sClient) PodsFromInformerCache(ctx context.Context, ns Namespace, selector labels.Selector) ([]*v1.Pod, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var result []*v1.Pod
+	for nn, pod := range c.pods {
+		if nn.Namespace == ns.String() && selector.Matches(labels.Set(pod.Labels)) {
+			result = append(result, pod)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (c *FakeK8sClient) ServiceFromInformerCache(ctx context.Context, nn types.NamespacedName) (*v1.Service, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	svc, ok := c.services[nn]
+	if !ok {
+		return nil, apierrors.NewNotFound(ServiceGVR.GroupResource(), nn.Name)
+	}
+	return svc, nil
+}
+
 func (c *FakeK8sClient) WatchServices(ctx context.Context, ns Namespace) (<-chan *v1.Service, error) {
 	c.mu.Lock()
 	ch := make(chan *v1.Service, 20)
@@ -182,6 +233,56 @@ func (c *FakeK8sClient) WatchMeta(ctx context.Context, gvk schema.GroupVersionKi
 	return make(chan ObjectMeta), nil
 }
 
+func (c *FakeK8sClient) WatchObjects(ctx context.Context, gvk schema.GroupVersionKind, ns Namespace) (<-chan ObjectUpdate, error) {
+	c.mu.Lock()
+	ch := make(chan ObjectUpdate, 20)
+	c.objectWatches = append(c.objectWatches, fakeObjectWatch{gvk, ns, ch})
+	c.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.mu.Lock()
+		var newWatches []fakeObjectWatch
+		for _, w := range c.objectWatches {
+			if w.ch != ch {
+				newWatches = append(newWatches, w)
+			}
+		}
+		c.objectWatches = newWatches
+		c.mu.Unlock()
+	}()
+	return ch, nil
+}
+
+// The number of active object watches, for tests.
+func (c *FakeK8sClient) ObjectWatchCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.objectWatches)
+}
+
+func (c *FakeK8sClient) EmitObject(obj *unstructured.Unstructured) {
+	c.emitObjectUpdate(obj, ObjectUpdate{obj: obj})
+}
+
+func (c *FakeK8sClient) EmitObjectDelete(obj *unstructured.Unstructured) {
+	c.emitObjectUpdate(obj, ObjectUpdate{obj: obj, isDelete: true})
+}
+
+func (c *FakeK8sClient) emitObjectUpdate(obj *unstructured.Unstructured, update ObjectUpdate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, w := range c.objectWatches {
+		if w.gvk != obj.GroupVersionKind() {
+			continue
+		}
+		if w.ns != "" && obj.GetNamespace() != "" && w.ns != Namespace(obj.GetNamespace()) {
+			continue
+		}
+		w.ch <- update
+	}
+}
+
 func (c *FakeK8sClient) EmitEvent(ctx context.Context, evt *v1.Event) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -243,11 +344,29 @@ func (c *FakeK8sClient) WatchPods(ctx context.Context, ns Namespace) (<-chan Obj
 
 func NewFakeK8sClient() *FakeK8sClient {
 	return &FakeK8sClient{
-		PodLogsByPodAndContainer: make(map[PodAndCName]ReaderCloser),
-		pods:                     make(map[types.NamespacedName]*v1.Pod),
+		PodLogsByPodAndContainer:         make(map[PodAndCName]ReaderCloser),
+		PreviousPodLogsByPodAndContainer: make(map[PodAndCName]ReaderCloser),
+		pods:                             make(map[types.NamespacedName]*v1.Pod),
+		services:                         make(map[types.NamespacedName]*v1.Service),
 	}
 }
 
+// The context of the fake client, in tests that need one.
+const FakeKubeContext = KubeContext("fake-context")
+
+// Creates a set of clients where the current context uses the given client,
+// and every other context gets its own FakeK8sClient.
+func NewFakeContextClients(ctx context.Context, kCli Client) *ContextClients {
+	return NewContextClients(ctx, ContextClient{
+		Context:         FakeKubeContext,
+		Client:          kCli,
+		ConfigNamespace: DefaultNamespace,
+		OwnerFetcher:    ProvideOwnerFetcher(ctx, kCli),
+	}, func(ctx context.Context, kubeContext KubeContext) (Client, Namespace) {
+		return NewFakeK8sClient(), DefaultNamespace
+	})
+}
+
 func (c *FakeK8sClient) TearDown() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -263,7 +382,7 @@ func (c *FakeK8sClient) TearDown() {
 	}
 }
 
-func (c *FakeK8sClient) Upsert(ctx context.Context, entities []K8sEntity, timeout time.Duration) ([]K8sEntity, error) {
+func (c *FakeK8sClient) Upsert(ctx context.Context, entities []K8sEntity, timeout time.Duration, mode model.K8sApplyMode) ([]K8sEntity, error) {
 	if c.UpsertError != nil {
 		return nil, c.UpsertError
 	}
@@ -272,6 +391,7 @@ func (c *FakeK8sClient) Upsert(ctx context.Context, entities []K8sEntity, timeou
 		return nil, errors.Wrap(err, "kubectl apply")
 	}
 	c.Yaml = yaml
+	c.UpsertedYAMLs = append(c.UpsertedYAMLs, yaml)
 
 	result := make([]K8sEntity, 0, len(entities))
 
@@ -286,9 +406,18 @@ func (c *FakeK8sClient) Upsert(ctx context.Context, entities []K8sEntity, timeou
 
 	c.LastUpsertResult = result
 	c.UpsertTimeout = timeout
+	c.UpsertMode = mode
 	return result, nil
 }
 
+func (c *FakeK8sClient) Diff(ctx context.Context, entities []K8sEntity, mode model.K8sApplyMode) (string, error) {
+	c.DiffEntities = entities
+	c.DiffMode = mode
+	c.DiffCalls++
+	c.DiffDeadline, _ = ctx.Deadline()
+	return c.DiffResult, c.DiffError
+}
+
 func (c *FakeK8sClient) Delete(ctx context.Context, entities []K8sEntity) error {
 	if c.DeleteError != nil {
 		err := c.DeleteError
@@ -301,6 +430,9 @@ func (c *FakeK8sClient) Delete(ctx context.Context, entities []K8sEntity) error
 		return errors.Wrap(err, "kubectl delete")
 	}
 	c.DeletedYaml = yaml
+	for _, e := range entities {
+		delete(c.entityByName, e.Name())
+	}
 	return nil
 }
 
@@ -342,6 +474,44 @@ func (c *FakeK8sClient) ListMeta(ctx context.Context, gvk schema.GroupVersionKin
 	return result, nil
 }
 
+func (c *FakeK8sClient) ListRefsWithLabel(ctx context.Context, label model.LabelPair) ([]v1.ObjectReference, error) {
+	var result []v1.ObjectReference
+	for _, entity := range c.entityByName {
+		if entity.Labels()[label.Key] == label.Value {
+			result = append(result, entity.ToObjectReference())
+		}
+	}
+	return result, nil
+}
+
+func (c *FakeK8sClient) IsClusterScoped(ctx context.Context, gvk schema.GroupVersionKind) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	gk := gvk.GroupKind()
+	if clusterScoped, ok := c.CustomKinds[gk]; ok {
+		return clusterScoped, nil
+	}
+	return clusterScopedKinds[gk], nil
+}
+
+func (c *FakeK8sClient) SecretData(ctx context.Context, ns Namespace, name string) (map[string][]byte, error) {
+	entity, ok := c.entityByName[name]
+	if ok && entity.Namespace() == ns {
+		if secret, ok := entity.Obj.(*v1.Secret); ok {
+			result := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+			for key, data := range secret.Data {
+				result[key] = data
+			}
+			for key, data := range secret.StringData {
+				result[key] = []byte(data)
+			}
+			return result, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(v1.Resource("secrets"), name)
+}
+
 func (c *FakeK8sClient) SetLogsForPodContainer(pID PodID, cName container.Name, logs string) {
 	c.SetLogReaderForPodContainer(pID, cName, strings.NewReader(logs))
 }
@@ -350,6 +520,22 @@ func (c *FakeK8sClient) SetLogReaderForPodContainer(pID PodID, cName container.N
 	c.PodLogsByPodAndContainer[PodAndCName{pID, cName}] = ReaderCloser{Reader: reader}
 }
 
+func (c *FakeK8sClient) SetPreviousLogsForPodContainer(pID PodID, cName container.Name, logs string) {
+	c.PreviousPodLogsByPodAndContainer[PodAndCName{pID, cName}] = ReaderCloser{Reader: strings.NewReader(logs)}
+}
+
+func (c *FakeK8sClient) PreviousContainerLogs(ctx context.Context, pID PodID, cName container.Name, n Namespace, startTime time.Time) (io.ReadCloser, error) {
+	if c.ContainerLogsError != nil {
+		return nil, c.ContainerLogsError
+	}
+
+	if buf, ok := c.PreviousPodLogsByPodAndContainer[PodAndCName{pID, cName}]; ok {
+		return buf, nil
+	}
+
+	return ReaderCloser{Reader: bytes.NewBuffer(nil)}, nil
+}
+
 func (c *FakeK8sClient) ContainerLogs(ctx context.Context, pID PodID, cName container.Name, n Namespace, startTime time.Time) (io.ReadCloser, error) {
 	if c.ContainerLogsError != nil {
 		return nil, c.ContainerLogsError
@@ -411,6 +597,16 @@ func (c *FakeK8sClient) CreatePortForwarder(ctx context.Context, namespace Names
 	return pfc.CreatePortForwarder(ctx, namespace, podID, optionalLocalPort, remotePort, host)
 }
 
+func (c *FakeK8sClient) CreatePortForwardBalancer(ctx context.Context, optionalLocalPort, remotePort int, host string) (PortForwardBalancer, error) {
+	pfc := &(c.FakePortForwardClient)
+	return pfc.CreatePortForwardBalancer(ctx, optionalLocalPort, remotePort, host)
+}
+
+func (c *FakeK8sClient) CreateReverseForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort, remotePort int) (PortForwarder, error) {
+	pfc := &(c.FakePortForwardClient)
+	return pfc.CreateReverseForwarder(ctx, namespace, podID, localPort, remotePort)
+}
+
 func (c *FakeK8sClient) ContainerRuntime(ctx context.Context) container.Runtime {
 	if c.Runtime != "" {
 		return c.Runtime
@@ -487,13 +683,66 @@ func (pf FakePortForwarder) ForwardPorts() error {
 	}
 }
 
+type FakePortForwardBalancer struct {
+	localPort int
+	ctx       context.Context
+	Done      chan error
+
+	mu    sync.Mutex
+	pods  []types.NamespacedName
+	stats PortForwardStats
+}
+
+func (pf *FakePortForwardBalancer) LocalPort() int {
+	return pf.localPort
+}
+
+func (pf *FakePortForwardBalancer) SetPods(pods []types.NamespacedName) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	pf.pods = append([]types.NamespacedName{}, pods...)
+}
+
+func (pf *FakePortForwardBalancer) Pods() []types.NamespacedName {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	return append([]types.NamespacedName{}, pf.pods...)
+}
+
+func (pf *FakePortForwardBalancer) ForwardPorts() error {
+	select {
+	case <-pf.ctx.Done():
+		return pf.ctx.Err()
+	case err := <-pf.Done:
+		return err
+	}
+}
+
+func (pf *FakePortForwardBalancer) SetStats(stats PortForwardStats) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	pf.stats = stats
+}
+
+func (pf *FakePortForwardBalancer) Stats() PortForwardStats {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	return pf.stats
+}
+
 type FakePortForwardClient struct {
 	CreatePortForwardCallCount int
 	LastForwardPortPodID       PodID
 	LastForwardPortRemotePort  int
 	LastForwardPortHost        string
 	LastForwarder              FakePortForwarder
+	LastBalancer               *FakePortForwardBalancer
 	LastForwardContext         context.Context
+
+	CreateReverseForwardCallCount int
+	LastReverseForwardPodID       PodID
+	LastReverseForwardLocalPort   int
+	LastReverseForwardRemotePort  int
 }
 
 func (c *FakePortForwardClient) CreatePortForwarder(ctx context.Context, namespace Namespace, podID PodID, optionalLocalPort, remotePort int, host string) (PortForwarder, error) {
@@ -511,3 +760,31 @@ func (c *FakePortForwardClient) CreatePortForwarder(ctx context.Context, namespa
 	c.LastForwarder = result
 	return result, nil
 }
+
+func (c *FakePortForwardClient) CreatePortForwardBalancer(ctx context.Context, optionalLocalPort, remotePort int, host string) (PortForwardBalancer, error) {
+	c.CreatePortForwardCallCount++
+	c.LastForwardContext = ctx
+	c.LastForwardPortRemotePort = remotePort
+	c.LastForwardPortHost = host
+
+	result := &FakePortForwardBalancer{
+		localPort: optionalLocalPort,
+		ctx:       ctx,
+		Done:      make(chan error),
+	}
+	c.LastBalancer = result
+	return result, nil
+}
+
+func (c *FakePortForwardClient) CreateReverseForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort int, remotePort int) (PortForwarder, error) {
+	c.CreateReverseForwardCallCount++
+	c.LastReverseForwardPodID = podID
+	c.LastReverseForwardLocalPort = localPort
+	c.LastReverseForwardRemotePort = remotePort
+
+	return FakePortForwarder{
+		localPort: localPort,
+		ctx:       ctx,
+		Done:      make(chan error),
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"helm.sh/helm/v3/pkg/kube"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/kubectl/pkg/cmd/apply"
 	"k8s.io/kubectl/pkg/cmd/delete"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -16,6 +17,7 @@ import (
 // We've adapted Helm's kubernetes client for our needs
 type HelmKubeClient interface {
 	Apply(target kube.ResourceList) (*kube.Result, error)
+	DryRunApply(target kube.ResourceList) (kube.ResourceList, error)
 	Delete(existing kube.ResourceList) (*kube.Result, []error)
 	Create(l kube.ResourceList) (*kube.Result, error)
 	Build(r io.Reader, validate bool) (kube.ResourceList, error)
@@ -29,6 +31,44 @@ type helmKubeClient struct {
 // Helm's update function doesn't really work for us,
 // so we use the kubectl apply code directly.
 func (c *helmKubeClient) Apply(target kube.ResourceList) (*kube.Result, error) {
+	o, err := c.applyOptions(target)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.Run()
+	if err != nil {
+		return nil, err
+	}
+	return &kube.Result{Updated: target}, nil
+}
+
+// Equivalent to `kubectl apply --dry-run=server`.
+//
+// Returns the resources with the objects that the server would have
+// stored if we had applied them.
+func (c *helmKubeClient) DryRunApply(target kube.ResourceList) (kube.ResourceList, error) {
+	o, err := c.applyOptions(target)
+	if err != nil {
+		return nil, err
+	}
+
+	discoveryClient, err := c.factory.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	o.DryRunStrategy = cmdutil.DryRunServer
+	o.DryRunVerifier = resource.NewDryRunVerifier(o.DynamicClient, discoveryClient)
+
+	// Apply refreshes each resource with the object that the server returned.
+	err = o.Run()
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+func (c *helmKubeClient) applyOptions(target kube.ResourceList) (*apply.ApplyOptions, error) {
 	f := c.factory
 	o := apply.NewApplyOptions(genericclioptions.IOStreams{
 		In:     strings.NewReader(""),
@@ -59,11 +99,7 @@ func (c *helmKubeClient) Apply(target kube.ResourceList) (*kube.Result, error) {
 	}
 
 	o.SetObjects(target)
-	err = o.Run()
-	if err != nil {
-		return nil, err
-	}
-	return &kube.Result{Updated: target}, nil
+	return o, nil
 }
 
 func newHelmKubeClient(c *K8sClient) HelmKubeClient {
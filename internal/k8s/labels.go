@@ -1,6 +1,9 @@
 package k8s
 
 import (
+	"crypto/sha256"
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/tilt-dev/tilt/pkg/model"
@@ -12,6 +15,10 @@ const ManagedByValue = "tilt"
 
 const ManifestNameLabel = "tilt-manifest"
 
+// Identifies the Tiltfile that applied an object, so that we can
+// garbage-collect objects after they're removed from the Tiltfile.
+const ApplySetLabel = "tilt.dev/apply-set"
+
 func TiltManagedByLabel() model.LabelPair {
 	return model.LabelPair{
 		Key:   ManagedByLabel,
@@ -36,3 +43,18 @@ func LabelPairsToSelector(lps []model.LabelPair) labels.Selector {
 	}
 	return ls.AsSelector()
 }
+
+// The apply-set of all the objects deployed by a Tiltfile.
+//
+// Derived from the Tiltfile path, so that it's stable across Tilt sessions,
+// and two Tiltfiles deploying to the same cluster don't collect each other's objects.
+func ApplySetID(tiltfilePath string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(tiltfilePath)))[:16]
+}
+
+func ApplySetLabelPair(applySet string) model.LabelPair {
+	return model.LabelPair{
+		Key:   ApplySetLabel,
+		Value: applySet,
+	}
+}
@@ -23,6 +23,18 @@ func (k *K8sClient) ContainerLogs(ctx context.Context, pID PodID, cName containe
 	return req.Stream(ctx)
 }
 
+func (k *K8sClient) PreviousContainerLogs(ctx context.Context, pID PodID, cName container.Name, n Namespace, startWatchTime time.Time) (io.ReadCloser, error) {
+	options := &v1.PodLogOptions{
+		Container: cName.String(),
+		Previous:  true,
+		SinceTime: &metav1.Time{
+			Time: startWatchTime,
+		},
+	}
+	req := k.core.Pods(n.String()).GetLogs(pID.String(), options)
+	return req.Stream(ctx)
+}
+
 func PodIDFromPod(pod *v1.Pod) PodID {
 	return PodID(pod.ObjectMeta.Name)
 }
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp" // registers gcp auth provider
 	"k8s.io/client-go/rest"
@@ -21,6 +23,14 @@ type PortForwardClient interface {
 	// Creates a new port-forwarder that's bound to the given context's lifecycle.
 	// When the context is canceled, the port-forwarder will close.
 	CreatePortForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort int, remotePort int, host string) (PortForwarder, error)
+
+	// Creates a new port-forwarder that balances connections across a set of pods,
+	// and is bound to the given context's lifecycle.
+	CreatePortForwardBalancer(ctx context.Context, localPort int, remotePort int, host string) (PortForwardBalancer, error)
+
+	// Creates a new port-forwarder from a relay pod's tunnel port back to a local port,
+	// bound to the given context's lifecycle.
+	CreateReverseForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort int, remotePort int) (PortForwarder, error)
 }
 
 type PortForwarder interface {
@@ -41,6 +51,29 @@ type PortForwarder interface {
 	// portforwarder that's exposed as part of the engine.
 }
 
+type PortForwardBalancer interface {
+	// The local port we're listening on.
+	LocalPort() int
+
+	// Replaces the pods that new connections are forwarded to.
+	// Connections are distributed round-robin.
+	SetPods(pods []types.NamespacedName)
+
+	// Listens on the configured port and forwards each connection to one of the pods.
+	// Returns when the listener fails or when the context passed at creation is canceled.
+	ForwardPorts() error
+
+	// Connection counts since the port-forwarder started.
+	Stats() PortForwardStats
+}
+
+type PortForwardStats struct {
+	ActiveConnections int
+	TotalConnections  int
+	Errors            int
+	LastError         string
+}
+
 type portForwarder struct {
 	*portforward.PortForwarder
 	localPort int
@@ -69,6 +102,23 @@ func (k *K8sClient) CreatePortForwarder(ctx context.Context, namespace Namespace
 	return k.portForwardClient.CreatePortForwarder(ctx, namespace, podID, localPort, remotePort, host)
 }
 
+func (k *K8sClient) CreateReverseForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort, remotePort int) (PortForwarder, error) {
+	return k.portForwardClient.CreateReverseForwarder(ctx, namespace, podID, localPort, remotePort)
+}
+
+func (k *K8sClient) CreatePortForwardBalancer(ctx context.Context, optionalLocalPort, remotePort int, host string) (PortForwardBalancer, error) {
+	localPort := optionalLocalPort
+	if localPort == 0 {
+		var err error
+		localPort, err = getAvailablePort()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find an available local port")
+		}
+	}
+
+	return k.portForwardClient.CreatePortForwardBalancer(ctx, localPort, remotePort, host)
+}
+
 type portForwardClient struct {
 	config *rest.Config
 	core   v1.CoreV1Interface
@@ -89,7 +139,7 @@ func ProvidePortForwardClient(
 	}
 }
 
-func (c portForwardClient) CreatePortForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort int, remotePort int, host string) (PortForwarder, error) {
+func (c portForwardClient) dialer(namespace Namespace, podID PodID) (httpstream.Dialer, error) {
 	transport, upgrader, err := spdy.RoundTripperFor(c.config)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting roundtripper")
@@ -101,9 +151,13 @@ func (c portForwardClient) CreatePortForwarder(ctx context.Context, namespace Na
 		Name(podID.String()).
 		SubResource("portforward")
 
-	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+	return spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL()), nil
+}
+
+func (c portForwardClient) CreatePortForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort int, remotePort int, host string) (PortForwarder, error) {
+	dialer, err := c.dialer(namespace, podID)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating dialer")
+		return nil, err
 	}
 
 	readyChan := make(chan struct{}, 1)
@@ -136,6 +190,77 @@ func (c portForwardClient) CreatePortForwarder(ctx context.Context, namespace Na
 	}, nil
 }
 
+func (c portForwardClient) CreatePortForwardBalancer(ctx context.Context, localPort int, remotePort int, host string) (PortForwardBalancer, error) {
+	b, err := portforward.NewBalancer(ctx, host, localPort, remotePort)
+	if err != nil {
+		return nil, errors.Wrap(err, "error forwarding port")
+	}
+	return portForwardBalancer{
+		Balancer:  b,
+		client:    c,
+		localPort: localPort,
+	}, nil
+}
+
+func (c portForwardClient) CreateReverseForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort int, remotePort int) (PortForwarder, error) {
+	dialer, err := c.dialer(namespace, podID)
+	if err != nil {
+		return nil, err
+	}
+
+	rf, err := portforward.NewReverseForwarder(ctx, dialer, localPort, remotePort)
+	if err != nil {
+		return nil, errors.Wrap(err, "error forwarding port")
+	}
+	return reverseForwarder{
+		ReverseForwarder: rf,
+		localPort:        localPort,
+	}, nil
+}
+
+type reverseForwarder struct {
+	*portforward.ReverseForwarder
+	localPort int
+}
+
+func (pf reverseForwarder) LocalPort() int {
+	return pf.localPort
+}
+
+type portForwardBalancer struct {
+	*portforward.Balancer
+	client    portForwardClient
+	localPort int
+}
+
+func (pf portForwardBalancer) LocalPort() int {
+	return pf.localPort
+}
+
+func (pf portForwardBalancer) SetPods(pods []types.NamespacedName) {
+	backends := make([]portforward.Backend, 0, len(pods))
+	for _, pod := range pods {
+		dialer, err := pf.client.dialer(Namespace(pod.Namespace), PodID(pod.Name))
+		if err != nil {
+			// This only fails if the REST config is broken, which would have
+			// failed much earlier. Skip the pod rather than fail all of them.
+			continue
+		}
+		backends = append(backends, portforward.Backend{Name: pod.String(), Dialer: dialer})
+	}
+	pf.Balancer.SetBackends(backends)
+}
+
+func (pf portForwardBalancer) Stats() PortForwardStats {
+	stats := pf.Balancer.Stats()
+	return PortForwardStats{
+		ActiveConnections: stats.ActiveConnections,
+		TotalConnections:  stats.TotalConnections,
+		Errors:            stats.Errors,
+		LastError:         stats.LastError,
+	}
+}
+
 func getAvailablePort() (int, error) {
 	l, err := net.Listen("tcp", ":0")
 	if err != nil {
@@ -166,3 +291,11 @@ type explodingPortForwardClient struct {
 func (c explodingPortForwardClient) CreatePortForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort int, remotePort int, host string) (PortForwarder, error) {
 	return nil, c.error
 }
+
+func (c explodingPortForwardClient) CreatePortForwardBalancer(ctx context.Context, localPort int, remotePort int, host string) (PortForwardBalancer, error) {
+	return nil, c.error
+}
+
+func (c explodingPortForwardClient) CreateReverseForwarder(ctx context.Context, namespace Namespace, podID PodID, localPort int, remotePort int) (PortForwarder, error) {
+	return nil, c.error
+}
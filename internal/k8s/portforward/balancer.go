@@ -0,0 +1,286 @@
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+)
+
+// A pod that the Balancer can forward connections to.
+type Backend struct {
+	// A unique name for the pod, like "namespace/name".
+	Name string
+
+	Dialer httpstream.Dialer
+}
+
+// Connection counts for a Balancer.
+type Stats struct {
+	ActiveConnections int
+	TotalConnections  int
+	Errors            int
+	LastError         string
+}
+
+type backend struct {
+	Backend
+
+	// Protects conn, so that we only dial each pod once.
+	mu   sync.Mutex
+	conn httpstream.Connection
+}
+
+// Balancer listens on a local port, and forwards each new connection
+// to one of a set of pods, round-robin.
+//
+// Unlike PortForwarder, the pods can change while the Balancer is running,
+// so the local port stays open when a pod is replaced.
+type Balancer struct {
+	ctx       context.Context
+	addresses []listenAddress
+	port      ForwardedPort
+	listeners []io.Closer
+	Ready     chan struct{}
+
+	mu        sync.Mutex
+	backends  []*backend
+	next      int
+	requestID int
+	stats     Stats
+}
+
+// NewBalancer creates a new Balancer on the given listen address.
+// If address is empty, listens on localhost.
+func NewBalancer(ctx context.Context, address string, localPort, remotePort int) (*Balancer, error) {
+	if address == "" {
+		address = "localhost"
+	}
+	parsedAddresses, err := parseAddresses([]string{address})
+	if err != nil {
+		return nil, err
+	}
+	parsedPorts, err := parsePorts([]string{fmt.Sprintf("%d:%d", localPort, remotePort)})
+	if err != nil {
+		return nil, err
+	}
+	return &Balancer{
+		ctx:       ctx,
+		addresses: parsedAddresses,
+		port:      parsedPorts[0],
+		Ready:     make(chan struct{}),
+	}, nil
+}
+
+// SetBackends replaces the pods that new connections are forwarded to.
+//
+// Connections to pods that were removed are closed.
+func (b *Balancer) SetBackends(backends []Backend) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	existing := make(map[string]*backend, len(b.backends))
+	for _, be := range b.backends {
+		existing[be.Name] = be
+	}
+
+	newBackends := make([]*backend, 0, len(backends))
+	for _, be := range backends {
+		old, ok := existing[be.Name]
+		if ok {
+			newBackends = append(newBackends, old)
+			delete(existing, be.Name)
+			continue
+		}
+		newBackends = append(newBackends, &backend{Backend: be})
+	}
+
+	for _, old := range existing {
+		old.close()
+	}
+	b.backends = newBackends
+}
+
+// Stats returns a snapshot of the connection counts.
+func (b *Balancer) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// ForwardPorts listens on the local port, and forwards connections until the
+// context is canceled.
+func (b *Balancer) ForwardPorts() error {
+	defer b.close()
+
+	var errs []error
+	for _, addr := range b.addresses {
+		listener, err := net.Listen(addr.protocol, net.JoinHostPort(addr.address, strconv.Itoa(int(b.port.Local))))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		b.listeners = append(b.listeners, listener)
+		go b.waitForConnection(listener)
+	}
+
+	if len(b.listeners) == 0 {
+		return fmt.Errorf("unable to listen on port %d: %v", b.port.Local, errs)
+	}
+	close(b.Ready)
+
+	<-b.ctx.Done()
+	return nil
+}
+
+func (b *Balancer) close() {
+	for _, l := range b.listeners {
+		if err := l.Close(); err != nil {
+			logger.Get(b.ctx).Debugf("error closing listener: %v", err)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, be := range b.backends {
+		be.close()
+	}
+}
+
+func (b *Balancer) waitForConnection(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !strings.Contains(strings.ToLower(err.Error()), "use of closed network connection") {
+				logger.Get(b.ctx).Debugf("error accepting connection on port %d: %v", b.port.Local, err)
+			}
+			return
+		}
+		go b.handleConnection(conn)
+	}
+}
+
+func (b *Balancer) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	b.mu.Lock()
+	b.stats.TotalConnections++
+	b.stats.ActiveConnections++
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		b.stats.ActiveConnections--
+		b.mu.Unlock()
+	}()
+
+	// Try each pod at most once. If a pod has gone away, we can retry
+	// on the next one without the client noticing.
+	tried := make(map[*backend]bool)
+	for {
+		be, requestID := b.nextBackend(tried)
+		if be == nil {
+			if len(tried) == 0 {
+				b.recordError(errors.New("no pods available"))
+			}
+			return
+		}
+		tried[be] = true
+
+		streamConn, err := be.connect()
+		if err != nil {
+			b.recordError(fmt.Errorf("connecting to %s: %v", be.Name, err))
+			continue
+		}
+
+		err = forwardConnection(b.ctx, streamConn, requestID, conn, b.port)
+		if err == nil {
+			return
+		}
+
+		b.recordError(fmt.Errorf("%s: %v", be.Name, err))
+
+		var sErr streamError
+		if !errors.As(err, &sErr) {
+			return
+		}
+
+		// The connection to the pod is probably dead, so re-dial next time.
+		be.reset(streamConn)
+	}
+}
+
+// Picks the next pod round-robin, skipping ones we've already tried.
+func (b *Balancer) nextBackend(tried map[*backend]bool) (*backend, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := 0; i < len(b.backends); i++ {
+		be := b.backends[(b.next+i)%len(b.backends)]
+		if tried[be] {
+			continue
+		}
+		b.next = (b.next + i + 1) % len(b.backends)
+		id := b.requestID
+		b.requestID++
+		return be, id
+	}
+	return nil, 0
+}
+
+func (b *Balancer) recordError(err error) {
+	logger.Get(b.ctx).Debugf("port-forward %d: %v", b.port.Local, err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.Errors++
+	b.stats.LastError = err.Error()
+}
+
+func (be *backend) connect() (httpstream.Connection, error) {
+	be.mu.Lock()
+	defer be.mu.Unlock()
+
+	if be.conn != nil {
+		select {
+		case <-be.conn.CloseChan():
+			be.conn = nil
+		default:
+			return be.conn, nil
+		}
+	}
+
+	conn, _, err := be.Dialer.Dial(PortForwardProtocolV1Name)
+	if err != nil {
+		return nil, err
+	}
+	be.conn = conn
+	return conn, nil
+}
+
+// Close the connection, if it's still the current one.
+func (be *backend) reset(conn httpstream.Connection) {
+	be.mu.Lock()
+	defer be.mu.Unlock()
+	if be.conn == conn {
+		be.conn.Close()
+		be.conn = nil
+	}
+}
+
+func (be *backend) close() {
+	be.mu.Lock()
+	defer be.mu.Unlock()
+	if be.conn != nil {
+		be.conn.Close()
+		be.conn = nil
+	}
+}
@@ -0,0 +1,187 @@
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+func TestBalancerRoundRobin(t *testing.T) {
+	f := newBalancerFixture(t)
+	defer f.TearDown()
+
+	f.b.SetBackends([]Backend{f.backend("a", false), f.backend("b", false)})
+
+	assert.Equal(t, "a", f.request())
+	assert.Equal(t, "b", f.request())
+	assert.Equal(t, "a", f.request())
+
+	stats := f.b.Stats()
+	assert.Equal(t, 3, stats.TotalConnections)
+	assert.Equal(t, 0, stats.Errors)
+}
+
+func TestBalancerRetriesOnAnotherPod(t *testing.T) {
+	f := newBalancerFixture(t)
+	defer f.TearDown()
+
+	f.b.SetBackends([]Backend{f.backend("a", true), f.backend("b", false)})
+
+	assert.Equal(t, "b", f.request())
+
+	stats := f.b.Stats()
+	assert.Equal(t, 1, stats.TotalConnections)
+	assert.Equal(t, 1, stats.Errors)
+	assert.Contains(t, stats.LastError, "a: error creating error stream")
+}
+
+func TestBalancerNoPods(t *testing.T) {
+	f := newBalancerFixture(t)
+	defer f.TearDown()
+
+	assert.Equal(t, "", f.request())
+	assert.Equal(t, "no pods available", f.b.Stats().LastError)
+
+	// The port stays open, so connections work as soon as a pod appears.
+	f.b.SetBackends([]Backend{f.backend("a", false)})
+	assert.Equal(t, "a", f.request())
+}
+
+func TestBalancerSetBackendsClosesRemovedPods(t *testing.T) {
+	f := newBalancerFixture(t)
+	defer f.TearDown()
+
+	a := f.backend("a", false)
+	f.b.SetBackends([]Backend{a, f.backend("b", false)})
+	assert.Equal(t, "a", f.request())
+
+	f.b.SetBackends([]Backend{f.backend("b", false)})
+	assert.True(t, a.Dialer.(*fakeStreamDialer).conn.closed)
+	assert.Equal(t, "b", f.request())
+}
+
+type balancerFixture struct {
+	t      *testing.T
+	ctx    context.Context
+	cancel func()
+	b      *Balancer
+	port   int
+	done   chan error
+}
+
+func newBalancerFixture(t *testing.T) *balancerFixture {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	ctx, cancel := context.WithCancel(newCtx())
+	b, err := NewBalancer(ctx, "127.0.0.1", port, 8080)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- b.ForwardPorts()
+	}()
+
+	select {
+	case <-b.Ready:
+	case err := <-done:
+		t.Fatal(err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for listener")
+	}
+
+	return &balancerFixture{t: t, ctx: ctx, cancel: cancel, b: b, port: port, done: done}
+}
+
+func (f *balancerFixture) backend(name string, failStreams bool) Backend {
+	return Backend{
+		Name: name,
+		Dialer: &fakeStreamDialer{conn: &fakeStreamConnection{
+			name:        name,
+			failStreams: failStreams,
+			closeChan:   make(chan bool),
+		}},
+	}
+}
+
+// Makes a request to the local port, and returns the name of the pod that answered.
+func (f *balancerFixture) request() string {
+	conn, err := net.Dial("tcp4", fmt.Sprintf("127.0.0.1:%d", f.port))
+	require.NoError(f.t, err)
+	defer conn.Close()
+
+	require.NoError(f.t, conn.SetDeadline(time.Now().Add(time.Second)))
+	out, err := ioutil.ReadAll(conn)
+	require.NoError(f.t, err)
+	return string(out)
+}
+
+func (f *balancerFixture) TearDown() {
+	f.cancel()
+	assert.NoError(f.t, <-f.done)
+}
+
+type fakeStreamDialer struct {
+	conn *fakeStreamConnection
+}
+
+func (d *fakeStreamDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	return d.conn, PortForwardProtocolV1Name, nil
+}
+
+// A connection to a pod that answers every request with its name.
+type fakeStreamConnection struct {
+	name        string
+	failStreams bool
+	closed      bool
+	closeChan   chan bool
+}
+
+func (c *fakeStreamConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	if c.failStreams {
+		return nil, errors.New("connection refused")
+	}
+	body := ""
+	if headers.Get(v1.StreamType) == v1.StreamTypeData {
+		body = c.name
+	}
+	return &fakeStream{Reader: strings.NewReader(body), headers: headers}, nil
+}
+
+func (c *fakeStreamConnection) Close() error {
+	if !c.closed {
+		c.closed = true
+		close(c.closeChan)
+	}
+	return nil
+}
+
+func (c *fakeStreamConnection) CloseChan() <-chan bool {
+	return c.closeChan
+}
+
+func (c *fakeStreamConnection) SetIdleTimeout(timeout time.Duration) {}
+
+type fakeStream struct {
+	*strings.Reader
+	headers http.Header
+}
+
+func (s *fakeStream) Write(p []byte) (int, error) { return len(p), nil }
+func (s *fakeStream) Close() error                { return nil }
+func (s *fakeStream) Reset() error                { return nil }
+func (s *fakeStream) Headers() http.Header        { return s.headers }
+func (s *fakeStream) Identifier() uint32          { return 0 }
@@ -316,17 +316,41 @@ func (pf *PortForwarder) nextRequestID() int {
 func (pf *PortForwarder) handleConnection(conn net.Conn, port ForwardedPort) {
 	defer conn.Close()
 
-	requestID := pf.nextRequestID()
+	err := forwardConnection(pf.ctx, pf.streamConn, pf.nextRequestID(), conn, port)
+	if err != nil {
+		logger.Get(pf.ctx).Debugf("%v", err)
+	}
+}
+
+// An error creating the streams for a connection. Nothing has been
+// read from the local connection yet, so it's safe to retry on another pod.
+type streamError struct {
+	err error
+}
+
+func (e streamError) Error() string { return e.err.Error() }
+
+// forwardConnection copies data between the local connection and a new stream
+// on the given connection to the remote server.
+func forwardConnection(ctx context.Context, streamConn httpstream.Connection, requestID int, conn net.Conn, port ForwardedPort) error {
+	dataStream, errorChan, err := createStreams(streamConn, requestID, port)
+	if err != nil {
+		return err
+	}
+	return copyStreams(ctx, conn, dataStream, errorChan)
+}
 
+// createStreams opens the error and data streams for one connection
+// to the remote port.
+func createStreams(streamConn httpstream.Connection, requestID int, port ForwardedPort) (httpstream.Stream, <-chan error, error) {
 	// create error stream
 	headers := http.Header{}
 	headers.Set(v1.StreamType, v1.StreamTypeError)
 	headers.Set(v1.PortHeader, fmt.Sprintf("%d", port.Remote))
 	headers.Set(v1.PortForwardRequestIDHeader, strconv.Itoa(requestID))
-	errorStream, err := pf.streamConn.CreateStream(headers)
+	errorStream, err := streamConn.CreateStream(headers)
 	if err != nil {
-		logger.Get(pf.ctx).Debugf("error creating error stream for port %d -> %d: %v", port.Local, port.Remote, err)
-		return
+		return nil, nil, streamError{fmt.Errorf("error creating error stream for port %d -> %d: %v", port.Local, port.Remote, err)}
 	}
 	// we're not writing to this stream
 	errorStream.Close()
@@ -345,19 +369,23 @@ func (pf *PortForwarder) handleConnection(conn net.Conn, port ForwardedPort) {
 
 	// create data stream
 	headers.Set(v1.StreamType, v1.StreamTypeData)
-	dataStream, err := pf.streamConn.CreateStream(headers)
+	dataStream, err := streamConn.CreateStream(headers)
 	if err != nil {
-		logger.Get(pf.ctx).Debugf("error creating forwarding stream for port %d -> %d: %v", port.Local, port.Remote, err)
-		return
+		return nil, nil, streamError{fmt.Errorf("error creating forwarding stream for port %d -> %d: %v", port.Local, port.Remote, err)}
 	}
+	return dataStream, errorChan, nil
+}
 
+// copyStreams copies data between the local connection and the data stream,
+// and returns any error reported by the remote server.
+func copyStreams(ctx context.Context, conn net.Conn, dataStream httpstream.Stream, errorChan <-chan error) error {
 	localError := make(chan struct{})
 	remoteDone := make(chan struct{})
 
 	go func() {
 		// Copy from the remote side to the local port.
 		if _, err := io.Copy(conn, dataStream); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
-			logger.Get(pf.ctx).Debugf("error copying from remote stream to local connection: %v", err)
+			logger.Get(ctx).Debugf("error copying from remote stream to local connection: %v", err)
 		}
 
 		// inform the select below that the remote copy is done
@@ -370,7 +398,7 @@ func (pf *PortForwarder) handleConnection(conn net.Conn, port ForwardedPort) {
 
 		// Copy from the local port to the remote side.
 		if _, err := io.Copy(dataStream, conn); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
-			logger.Get(pf.ctx).Debugf("error copying from local connection to remote stream: %v", err)
+			logger.Get(ctx).Debugf("error copying from local connection to remote stream: %v", err)
 			// break out of the select below without waiting for the other copy to finish
 			close(localError)
 		}
@@ -383,10 +411,7 @@ func (pf *PortForwarder) handleConnection(conn net.Conn, port ForwardedPort) {
 	}
 
 	// always expect something on errorChan (it may be nil)
-	err = <-errorChan
-	if err != nil {
-		logger.Get(pf.ctx).Debugf("%v", err)
-	}
+	return <-errorChan
 }
 
 // Close stops all listeners of PortForwarder.
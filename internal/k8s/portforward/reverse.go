@@ -0,0 +1,138 @@
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+)
+
+// The number of idle tunnels we keep open to the relay, so that
+// connections from the cluster don't wait for a new stream.
+const reverseTunnelPoolSize = 4
+
+// ReverseForwarder forwards connections from a relay pod in the cluster
+// to a port on the local machine.
+//
+// The relay listens for connections from other pods. We keep a pool of
+// streams open to the relay's tunnel port. When a connection arrives, the relay
+// pairs it with one of our streams and writes a single byte to tell us,
+// and we connect the stream to the local port.
+type ReverseForwarder struct {
+	ctx        context.Context
+	dialer     httpstream.Dialer
+	localAddr  string
+	port       ForwardedPort
+	streamConn httpstream.Connection
+
+	requestIDLock sync.Mutex
+	requestID     int
+}
+
+// NewReverseForwarder creates a new ReverseForwarder from the relay's tunnel
+// port (remotePort) to localPort on localhost.
+func NewReverseForwarder(ctx context.Context, dialer httpstream.Dialer, localPort, remotePort int) (*ReverseForwarder, error) {
+	if localPort <= 0 || localPort > 65535 {
+		return nil, fmt.Errorf("invalid local port %d", localPort)
+	}
+	if remotePort <= 0 || remotePort > 65535 {
+		return nil, fmt.Errorf("invalid remote port %d", remotePort)
+	}
+	return &ReverseForwarder{
+		ctx:       ctx,
+		dialer:    dialer,
+		localAddr: net.JoinHostPort("localhost", strconv.Itoa(localPort)),
+		port:      ForwardedPort{Local: uint16(localPort), Remote: uint16(remotePort)},
+	}, nil
+}
+
+// ForwardPorts connects to the relay and forwards connections until the context
+// is canceled or the connection to the relay is lost.
+func (rf *ReverseForwarder) ForwardPorts() error {
+	var err error
+	rf.streamConn, _, err = rf.dialer.Dial(PortForwardProtocolV1Name)
+	if err != nil {
+		return fmt.Errorf("error upgrading connection: %s", err)
+	}
+	defer rf.streamConn.Close()
+
+	errs := make(chan error, reverseTunnelPoolSize)
+	for i := 0; i < reverseTunnelPoolSize; i++ {
+		go func() {
+			errs <- rf.waitForTunnels()
+		}()
+	}
+
+	select {
+	case <-rf.ctx.Done():
+		return nil
+	case <-rf.streamConn.CloseChan():
+		return errors.New("lost connection to relay pod")
+	case err := <-errs:
+		if rf.ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+}
+
+// Keeps one idle tunnel open to the relay, opening a new one each
+// time the relay hands the current one a connection.
+func (rf *ReverseForwarder) waitForTunnels() error {
+	for {
+		dataStream, errorChan, err := createStreams(rf.streamConn, rf.nextRequestID(), rf.port)
+		if err != nil {
+			return err
+		}
+
+		// Block until the relay pairs this tunnel with a connection from the cluster.
+		buf := make([]byte, 1)
+		_, err = io.ReadFull(dataStream, buf)
+		if err != nil {
+			_ = dataStream.Reset()
+
+			// The error stream usually has a better explanation (e.g., the relay isn't listening yet).
+			if streamErr := <-errorChan; streamErr != nil {
+				return streamErr
+			}
+			return fmt.Errorf("error waiting for connections on port %d: %v", rf.port.Remote, err)
+		}
+
+		go rf.handleTunnel(dataStream, errorChan)
+	}
+}
+
+// handleTunnel copies data between the tunnel and a new connection to the local port.
+func (rf *ReverseForwarder) handleTunnel(dataStream httpstream.Stream, errorChan <-chan error) {
+	conn, err := net.Dial("tcp", rf.localAddr)
+	if err != nil {
+		logger.Get(rf.ctx).Infof("Error connecting to %s: %v", rf.localAddr, err)
+		_ = dataStream.Reset()
+		go func() {
+			for range errorChan {
+			}
+		}()
+		return
+	}
+	defer conn.Close()
+
+	err = copyStreams(rf.ctx, conn, dataStream, errorChan)
+	if err != nil {
+		logger.Get(rf.ctx).Debugf("%v", err)
+	}
+}
+
+func (rf *ReverseForwarder) nextRequestID() int {
+	rf.requestIDLock.Lock()
+	defer rf.requestIDLock.Unlock()
+	id := rf.requestID
+	rf.requestID++
+	return id
+}
@@ -0,0 +1,146 @@
+package portforward
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+func TestReverseForwarder(t *testing.T) {
+	f := newReverseFixture(t)
+	defer f.TearDown()
+
+	tunnel := f.nextTunnel()
+
+	// The relay hands us a connection from the cluster.
+	_, err := tunnel.Write([]byte{1})
+	require.NoError(t, err)
+	_, err = tunnel.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(tunnel, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(buf))
+
+	// We open a new tunnel to replace the one that was used.
+	f.nextTunnel()
+}
+
+func TestReverseForwarderInvalidPort(t *testing.T) {
+	_, err := NewReverseForwarder(context.Background(), nil, 0, 8080)
+	assert.EqualError(t, err, "invalid local port 0")
+}
+
+type reverseFixture struct {
+	t       *testing.T
+	cancel  func()
+	local   net.Listener
+	conn    *fakeRelayConnection
+	done    chan error
+	tunnels []net.Conn
+}
+
+func newReverseFixture(t *testing.T) *reverseFixture {
+	// A local server that answers "ping" with "pong".
+	local, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, err := local.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				buf := make([]byte, 4)
+				if _, err := io.ReadFull(conn, buf); err == nil && string(buf) == "ping" {
+					_, _ = conn.Write([]byte("pong"))
+				}
+			}()
+		}
+	}()
+
+	conn := &fakeRelayConnection{
+		tunnels:   make(chan net.Conn, reverseTunnelPoolSize*2),
+		closeChan: make(chan bool),
+	}
+	ctx, cancel := context.WithCancel(newCtx())
+	rf, err := NewReverseForwarder(ctx, &fakeRelayDialer{conn: conn}, local.Addr().(*net.TCPAddr).Port, 10360)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- rf.ForwardPorts()
+	}()
+
+	return &reverseFixture{t: t, cancel: cancel, local: local, conn: conn, done: done}
+}
+
+// Returns the relay's end of the next tunnel that the forwarder opens.
+func (f *reverseFixture) nextTunnel() net.Conn {
+	select {
+	case tunnel := <-f.conn.tunnels:
+		require.NoError(f.t, tunnel.SetDeadline(time.Now().Add(time.Second)))
+		f.tunnels = append(f.tunnels, tunnel)
+		return tunnel
+	case <-time.After(time.Second):
+		f.t.Fatal("timed out waiting for tunnel")
+		return nil
+	}
+}
+
+func (f *reverseFixture) TearDown() {
+	f.cancel()
+	assert.NoError(f.t, <-f.done)
+	for _, tunnel := range f.tunnels {
+		_ = tunnel.Close()
+	}
+	_ = f.local.Close()
+}
+
+type fakeRelayDialer struct {
+	conn *fakeRelayConnection
+}
+
+func (d *fakeRelayDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	return d.conn, PortForwardProtocolV1Name, nil
+}
+
+// A connection to a relay pod, where each data stream is one end of a pipe,
+// and the test holds the relay's end.
+type fakeRelayConnection struct {
+	tunnels   chan net.Conn
+	closeChan chan bool
+}
+
+func (c *fakeRelayConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	if headers.Get(v1.StreamType) != v1.StreamTypeData {
+		return &fakeStream{Reader: strings.NewReader(""), headers: headers}, nil
+	}
+	local, relay := net.Pipe()
+	c.tunnels <- relay
+	return &fakePipeStream{Conn: local, headers: headers}, nil
+}
+
+func (c *fakeRelayConnection) Close() error                         { return nil }
+func (c *fakeRelayConnection) CloseChan() <-chan bool               { return c.closeChan }
+func (c *fakeRelayConnection) SetIdleTimeout(timeout time.Duration) {}
+
+type fakePipeStream struct {
+	net.Conn
+	headers http.Header
+}
+
+func (s *fakePipeStream) Reset() error         { return s.Conn.Close() }
+func (s *fakePipeStream) Headers() http.Header { return s.headers }
+func (s *fakePipeStream) Identifier() uint32   { return 0 }
@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// The pods that a port-forward connects to, when it's not
+// the resource's most recent pod.
+//
+// Exactly one of the fields is set.
+type PortForwardTarget struct {
+	// All the ready pods that back a Service.
+	Service ServiceName
+
+	// A single pod, by name.
+	Pod PodID
+
+	// All the ready pods that match a selector.
+	Selector labels.Selector
+}
+
+// Parses a target in the form accepted by port_forward(target=...), which is
+// one of svc/NAME, pod/NAME, or a label selector like "app=frontend,tier!=cache".
+func ParsePortForwardTarget(s string) (PortForwardTarget, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return PortForwardTarget{}, fmt.Errorf("port-forward target must not be empty")
+	}
+
+	// Label keys can have a prefix too (like app.kubernetes.io/name), so only
+	// treat the target as KIND/NAME if it isn't a selector.
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) == 2 && !isLabelSelector(s) {
+		name := parts[1]
+		switch strings.ToLower(parts[0]) {
+		case "svc", "service", "services":
+			if name == "" {
+				return PortForwardTarget{}, fmt.Errorf("port-forward target %q: missing name", s)
+			}
+			return PortForwardTarget{Service: ServiceName(name)}, nil
+		case "po", "pod", "pods":
+			if name == "" {
+				return PortForwardTarget{}, fmt.Errorf("port-forward target %q: missing name", s)
+			}
+			return PortForwardTarget{Pod: PodID(name)}, nil
+		}
+
+		// A prefix without dots can't be a DNS subdomain like the prefix
+		// of a label key, so it's probably a kind we don't support.
+		if !strings.Contains(parts[0], ".") {
+			return PortForwardTarget{}, fmt.Errorf("port-forward target %q: unsupported kind %q (expected svc or pod)", s, parts[0])
+		}
+	}
+
+	selector, err := labels.Parse(s)
+	if err != nil {
+		return PortForwardTarget{}, fmt.Errorf("port-forward target %q: invalid label selector: %v", s, err)
+	}
+	if selector.Empty() {
+		return PortForwardTarget{}, fmt.Errorf("port-forward target %q: label selector matches every pod", s)
+	}
+	return PortForwardTarget{Selector: selector}, nil
+}
+
+// Whether the target uses any label selector operators.
+func isLabelSelector(s string) bool {
+	if strings.ContainsAny(s, "=!,") {
+		return true
+	}
+	return strings.Contains(s, " in ") || strings.Contains(s, " notin ")
+}
+
+func (t PortForwardTarget) String() string {
+	if t.Service != "" {
+		return fmt.Sprintf("svc/%s", t.Service)
+	}
+	if t.Pod != "" {
+		return fmt.Sprintf("pod/%s", t.Pod)
+	}
+	if t.Selector != nil {
+		return t.Selector.String()
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortForwardTarget(t *testing.T) {
+	testCases := []struct {
+		target   string
+		expected string
+	}{
+		{"svc/frontend", "svc/frontend"},
+		{"service/frontend", "svc/frontend"},
+		{"pod/frontend-abc", "pod/frontend-abc"},
+		{"app=frontend", "app=frontend"},
+		{"app=frontend,tier!=cache", "app=frontend,tier!=cache"},
+		{"app.kubernetes.io/name=frontend", "app.kubernetes.io/name=frontend"},
+		{"app.kubernetes.io/name in (frontend,web)", "app.kubernetes.io/name in (frontend,web)"},
+		{"app.kubernetes.io/name", "app.kubernetes.io/name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.target, func(t *testing.T) {
+			target, err := ParsePortForwardTarget(tc.target)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, target.String())
+		})
+	}
+}
+
+func TestParsePortForwardTargetErrors(t *testing.T) {
+	testCases := []struct {
+		target   string
+		expected string
+	}{
+		{"", "must not be empty"},
+		{"svc/", "missing name"},
+		{"deployment/frontend", `unsupported kind "deployment"`},
+		{"app in (", "invalid label selector"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.target, func(t *testing.T) {
+			_, err := ParsePortForwardTarget(tc.target)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expected)
+			}
+		})
+	}
+}
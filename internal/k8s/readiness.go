@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/tilt-dev/tilt/internal/k8s/jsonpath"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// Checks whether an object passes its readiness check.
+//
+// Returns a human-readable reason when the object isn't ready.
+func ObjectReady(obj *unstructured.Unstructured, check model.K8sObjectReadiness) (bool, string, error) {
+	if check.Condition != "" {
+		ready, reason := conditionReady(obj, check.Condition)
+		if !ready {
+			return false, reason, nil
+		}
+	}
+
+	if check.JSONPath != "" {
+		jp, err := NewJSONPath(check.JSONPath)
+		if err != nil {
+			return false, "", err
+		}
+
+		var values []string
+		err = jp.Visit(obj.Object, func(match jsonpath.Value) error {
+			values = append(values, fmt.Sprintf("%v", match.Interface()))
+			return nil
+		})
+		if err != nil {
+			// The status may not have been populated yet.
+			return false, fmt.Sprintf("%s not found", check.JSONPath), nil
+		}
+
+		if len(values) == 0 {
+			return false, fmt.Sprintf("%s not found", check.JSONPath), nil
+		}
+		for _, v := range values {
+			if !strings.EqualFold(v, "true") {
+				return false, fmt.Sprintf("%s is %q", check.JSONPath, strings.Join(values, ",")), nil
+			}
+		}
+	}
+
+	return true, "", nil
+}
+
+// Finds the status condition of the given type, and checks that it's True
+// and up-to-date with the latest spec.
+func conditionReady(obj *unstructured.Unstructured, conditionType string) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+
+		// If the controller hasn't caught up with the latest spec,
+		// the condition is stale.
+		observed, ok, _ := unstructured.NestedInt64(condition, "observedGeneration")
+		if ok && observed < obj.GetGeneration() {
+			return false, fmt.Sprintf("condition %s is out of date", conditionType)
+		}
+
+		status, _ := condition["status"].(string)
+		if status == "True" {
+			return true, ""
+		}
+
+		reason := fmt.Sprintf("condition %s is %s", conditionType, status)
+		if msg, _ := condition["message"].(string); msg != "" {
+			reason = fmt.Sprintf("%s: %s", reason, msg)
+		}
+		return false, reason
+	}
+	return false, fmt.Sprintf("condition %s not found", conditionType)
+}
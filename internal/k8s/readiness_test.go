@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+const certificateYAML = `apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: my-cert
+  generation: 2
+status:
+  conditions:
+  - type: Issuing
+    status: "False"
+  - type: Ready
+    status: "%s"
+    message: "%s"
+    observedGeneration: %d
+`
+
+func TestObjectReadyCondition(t *testing.T) {
+	obj := certificate(t, "True", "", 2)
+	ready, reason, err := ObjectReady(obj, model.K8sObjectReadiness{Condition: "Ready"})
+	require.NoError(t, err)
+	assert.True(t, ready)
+	assert.Equal(t, "", reason)
+}
+
+func TestObjectReadyConditionFalse(t *testing.T) {
+	obj := certificate(t, "False", "Waiting for issuer", 2)
+	ready, reason, err := ObjectReady(obj, model.K8sObjectReadiness{Condition: "Ready"})
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, "condition Ready is False: Waiting for issuer", reason)
+}
+
+func TestObjectReadyConditionStale(t *testing.T) {
+	obj := certificate(t, "True", "", 1)
+	ready, reason, err := ObjectReady(obj, model.K8sObjectReadiness{Condition: "Ready"})
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, "condition Ready is out of date", reason)
+}
+
+func TestObjectReadyConditionMissing(t *testing.T) {
+	obj := certificate(t, "True", "", 2)
+	ready, reason, err := ObjectReady(obj, model.K8sObjectReadiness{Condition: "Synced"})
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, "condition Synced not found", reason)
+}
+
+func TestObjectReadyJSONPath(t *testing.T) {
+	check := model.K8sObjectReadiness{JSONPath: `{.status.conditions[?(@.type=="Ready")].status}`}
+
+	ready, _, err := ObjectReady(certificate(t, "True", "", 2), check)
+	require.NoError(t, err)
+	assert.True(t, ready)
+
+	ready, reason, err := ObjectReady(certificate(t, "False", "", 2), check)
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Contains(t, reason, `is "False"`)
+}
+
+func TestObjectReadyJSONPathNotFound(t *testing.T) {
+	check := model.K8sObjectReadiness{JSONPath: `{.status.ready}`}
+	ready, reason, err := ObjectReady(certificate(t, "True", "", 2), check)
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, "{.status.ready} not found", reason)
+}
+
+func certificate(t *testing.T, status, message string, observedGeneration int) *unstructured.Unstructured {
+	return unstructuredFromYAML(t, fmt.Sprintf(certificateYAML, status, message, observedGeneration))
+}
@@ -0,0 +1,171 @@
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// The port on the relay pod that Tilt opens tunnels to.
+const ReverseForwardTunnelPort = 10360
+
+// The label that connects a reverse-forward Service to its relay pod.
+const ReverseForwardLabel = "tilt.dev/reverse-port-forward"
+
+// The relay needs a Python interpreter and nothing else.
+const reverseForwardRelayImage = "python:3.9-alpine"
+
+// The relay accepts connections from other pods on the service port, and tunnels
+// from Tilt on the tunnel port. It pairs each connection with an idle tunnel,
+// writes a single byte on the tunnel so Tilt knows to connect it to the local port,
+// then copies data in both directions.
+const reverseForwardRelayScript = `
+import os, queue, select, socket, threading
+
+tunnels = queue.Queue()
+
+def listen(port):
+    s = socket.socket(socket.AF_INET, socket.SOCK_STREAM)
+    s.setsockopt(socket.SOL_SOCKET, socket.SO_REUSEADDR, 1)
+    s.bind(("0.0.0.0", port))
+    s.listen(128)
+    return s
+
+def alive(t):
+    r, _, _ = select.select([t], [], [], 0)
+    if not r:
+        return True
+    try:
+        return t.recv(1, socket.MSG_PEEK) != b""
+    except OSError:
+        return False
+
+def pipe(src, dst):
+    try:
+        while True:
+            data = src.recv(65536)
+            if not data:
+                break
+            dst.sendall(data)
+    except OSError:
+        pass
+    try:
+        dst.shutdown(socket.SHUT_WR)
+    except OSError:
+        pass
+
+def serve(client):
+    while True:
+        t = tunnels.get()
+        if not alive(t):
+            t.close()
+            continue
+        try:
+            t.sendall(b"\x01")
+            break
+        except OSError:
+            t.close()
+    other = threading.Thread(target=pipe, args=(t, client), daemon=True)
+    other.start()
+    pipe(client, t)
+    other.join()
+    client.close()
+    t.close()
+
+def accept_tunnels(s):
+    while True:
+        t, _ = s.accept()
+        tunnels.put(t)
+
+tunnel_listener = listen(int(os.environ["TUNNEL_PORT"]))
+service_listener = listen(int(os.environ["SERVICE_PORT"]))
+threading.Thread(target=accept_tunnels, args=(tunnel_listener,), daemon=True).start()
+while True:
+    client, _ = service_listener.accept()
+    threading.Thread(target=serve, args=(client,), daemon=True).start()
+`
+
+// Creates a Service and a relay Deployment (both with the given name) that tunnel
+// connections on the service port back to Tilt.
+//
+// The Deployment only ever runs one pod, because Tilt only tunnels
+// to the resource's most recent pod.
+func NewReverseForwardEntities(name string, servicePort int) ([]K8sEntity, error) {
+	if servicePort <= 0 || servicePort > 65535 {
+		return nil, fmt.Errorf("service port %d is not in the valid range [1-65535]", servicePort)
+	}
+	if servicePort == ReverseForwardTunnelPort {
+		return nil, fmt.Errorf("service port %d is reserved for the relay's tunnel", servicePort)
+	}
+
+	labels := map[string]string{ReverseForwardLabel: name}
+	replicas := int32(1)
+	podSpec := v1.PodSpec{
+		Containers: []v1.Container{
+			{
+				Name:    "relay",
+				Image:   reverseForwardRelayImage,
+				Command: []string{"python3", "-u", "-c", reverseForwardRelayScript},
+				Env: []v1.EnvVar{
+					{Name: "SERVICE_PORT", Value: strconv.Itoa(servicePort)},
+					{Name: "TUNNEL_PORT", Value: strconv.Itoa(ReverseForwardTunnelPort)},
+				},
+				Ports: []v1.ContainerPort{
+					{Name: "service", ContainerPort: int32(servicePort)},
+					{Name: "tunnel", ContainerPort: ReverseForwardTunnelPort},
+				},
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt(servicePort)},
+					},
+				},
+			},
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+
+	svc := &v1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: v1.ServiceSpec{
+			Selector: labels,
+			Ports: []v1.ServicePort{
+				{Port: int32(servicePort), TargetPort: intstr.FromInt(servicePort)},
+			},
+		},
+	}
+
+	return []K8sEntity{NewK8sEntity(deployment), NewK8sEntity(svc)}, nil
+}
+
+// Returns the name of the reverse-forward that the entity is a relay for,
+// or an empty string if it's not a relay.
+func ReverseForwardRelayName(e K8sEntity) string {
+	if e.GVK().Kind != "Deployment" {
+		return ""
+	}
+	return e.Labels()[ReverseForwardLabel]
+}
@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestNewReverseForwardEntities(t *testing.T) {
+	entities, err := NewReverseForwardEntities("db-tunnel", 5432)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entities))
+
+	relay, svc := entities[0], entities[1]
+	assert.Equal(t, "Deployment", relay.GVK().Kind)
+	assert.Equal(t, "db-tunnel", relay.Name())
+	assert.Equal(t, "db-tunnel", ReverseForwardRelayName(relay))
+
+	assert.Equal(t, "Service", svc.GVK().Kind)
+	assert.Equal(t, "db-tunnel", svc.Name())
+	assert.Equal(t, "", ReverseForwardRelayName(svc))
+
+	spec := svc.Obj.(*v1.Service).Spec
+	assert.Equal(t, relay.Labels(), spec.Selector)
+	require.Equal(t, 1, len(spec.Ports))
+	assert.Equal(t, int32(5432), spec.Ports[0].Port)
+	assert.Equal(t, 5432, spec.Ports[0].TargetPort.IntValue())
+}
+
+func TestNewReverseForwardEntitiesInvalidPort(t *testing.T) {
+	_, err := NewReverseForwardEntities("db-tunnel", 0)
+	assert.Contains(t, err.Error(), "not in the valid range")
+
+	_, err = NewReverseForwardEntities("db-tunnel", ReverseForwardTunnelPort)
+	assert.Contains(t, err.Error(), "reserved for the relay's tunnel")
+}
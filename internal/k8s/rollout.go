@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Rollout conditions that mean a workload won't become ready without intervention.
+const (
+	ProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+	ReplicaFailureCondition        = "ReplicaFailure"
+)
+
+// Checks the status conditions of a workload (e.g., a Deployment or a StatefulSet)
+// for a failed rollout.
+//
+// Returns an empty string if the rollout hasn't failed, or is still in progress.
+func RolloutError(obj *unstructured.Unstructured) string {
+	// The controller hasn't seen the latest spec yet, so the conditions
+	// are from the previous rollout.
+	observed, ok, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if !ok || observed < obj.GetGeneration() {
+		return ""
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		cType, _ := condition["type"].(string)
+		status, _ := condition["status"].(string)
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+
+		failed := (cType == "Progressing" && status == "False" && reason == ProgressDeadlineExceededReason) ||
+			(cType == ReplicaFailureCondition && status == "True")
+		if !failed {
+			continue
+		}
+
+		if message == "" {
+			return fmt.Sprintf("%s %s rollout failed: %s", obj.GetKind(), obj.GetName(), reason)
+		}
+		return fmt.Sprintf("%s %s rollout failed: %s: %s", obj.GetKind(), obj.GetName(), reason, message)
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const deploymentStatusYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: sancho
+  generation: 2
+status:
+  observedGeneration: %d
+  conditions:
+  - type: Available
+    status: "False"
+    reason: MinimumReplicasUnavailable
+  - type: %s
+    status: "%s"
+    reason: %s
+    message: '%s'
+`
+
+func TestRolloutErrorProgressDeadlineExceeded(t *testing.T) {
+	obj := unstructuredFromYAML(t, fmt.Sprintf(deploymentStatusYAML, 2,
+		"Progressing", "False", "ProgressDeadlineExceeded", `ReplicaSet "sancho-abc" has timed out progressing.`))
+	assert.Equal(t,
+		`Deployment sancho rollout failed: ProgressDeadlineExceeded: ReplicaSet "sancho-abc" has timed out progressing.`,
+		RolloutError(obj))
+}
+
+func TestRolloutErrorReplicaFailure(t *testing.T) {
+	obj := unstructuredFromYAML(t, fmt.Sprintf(deploymentStatusYAML, 2,
+		"ReplicaFailure", "True", "FailedCreate", `pods "sancho" is forbidden: exceeded quota`))
+	assert.Equal(t,
+		`Deployment sancho rollout failed: FailedCreate: pods "sancho" is forbidden: exceeded quota`,
+		RolloutError(obj))
+}
+
+func TestRolloutErrorProgressing(t *testing.T) {
+	obj := unstructuredFromYAML(t, fmt.Sprintf(deploymentStatusYAML, 2,
+		"Progressing", "True", "ReplicaSetUpdated", `ReplicaSet "sancho-abc" is progressing.`))
+	assert.Equal(t, "", RolloutError(obj))
+}
+
+func TestRolloutErrorStaleConditions(t *testing.T) {
+	obj := unstructuredFromYAML(t, fmt.Sprintf(deploymentStatusYAML, 1,
+		"Progressing", "False", "ProgressDeadlineExceeded", `ReplicaSet "sancho-abc" has timed out progressing.`))
+	assert.Equal(t, "", RolloutError(obj))
+}
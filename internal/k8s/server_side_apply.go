@@ -0,0 +1,322 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// The field manager that owns the fields Tilt applies with server-side apply.
+const FieldManager = "tilt"
+
+// serverSideApplyEntity applies the given entity with server-side apply.
+//
+// If other managers own some of the fields we're applying (e.g., an HPA that
+// manages replicas), we print a warning. By default, we leave those fields to
+// their current manager by removing them from the entity and applying again.
+// If force is true, Tilt takes ownership of them instead, so that the cluster
+// matches the Tiltfile.
+//
+// If the update fails with an immutable field error, we delete and re-create
+// the entity.
+func (k *K8sClient) serverSideApplyEntity(ctx context.Context, entity K8sEntity, force bool) ([]K8sEntity, error) {
+	result, err := k.serverSideApply(ctx, entity, force, false)
+	if err != nil && isFieldManagerConflict(err) {
+		conflicts := fieldConflicts(err)
+		logger.Get(ctx).Warnf("%s", fieldConflictMessage(entity, conflicts))
+
+		entity, err = withoutConflictingFields(entity, conflicts)
+		if err == nil {
+			result, err = k.serverSideApply(ctx, entity, false, false)
+		}
+	}
+
+	if err != nil {
+		if !maybeImmutableFieldStderr(err.Error()) {
+			return nil, errors.Wrap(err, "kubernetes server-side apply")
+		}
+
+		logger.Get(ctx).Infof("Updating %s failed. Attempting to delete + recreate: immutable field error", entity.Name())
+		resources, err := k.prepareUpdate(ctx, []K8sEntity{entity})
+		if err != nil {
+			return nil, errors.Wrap(err, "kubernetes server-side apply")
+		}
+
+		err = k.deleteForReplace(resources)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = k.serverSideApply(ctx, entity, force, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "kubernetes server-side apply")
+		}
+		logger.Get(ctx).Infof("Succeeded!")
+	}
+
+	// The dynamic client returns unstructured objects, but Tilt needs them parsed
+	// with the current API scheme. The easiest way to do this is to serialize them
+	// to yaml and re-parse again.
+	yaml, err := SerializeSpecYAML([]K8sEntity{NewK8sEntity(result)})
+	if err != nil {
+		return nil, errors.Wrap(err, "reading kubernetes result")
+	}
+
+	parsed, err := ParseYAMLFromString(yaml)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing kubernetes result")
+	}
+	return parsed, nil
+}
+
+func (k *K8sClient) serverSideApply(ctx context.Context, entity K8sEntity, force bool, dryRun bool) (*unstructured.Unstructured, error) {
+	ri, err := k.resourceInterface(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := SerializeSpecYAML([]K8sEntity{entity})
+	if err != nil {
+		return nil, err
+	}
+
+	opts := metav1.PatchOptions{FieldManager: FieldManager, Force: &force}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return ri.Patch(ctx, entity.Name(), types.ApplyPatchType, []byte(data), opts)
+}
+
+// The dynamic client for the entity's type and namespace.
+func (k *K8sClient) resourceInterface(ctx context.Context, entity K8sEntity) (dynamic.ResourceInterface, error) {
+	rm, err := k.restMapping(ctx, entity.GVK())
+	if err != nil {
+		return nil, err
+	}
+
+	ri := k.dynamic.Resource(rm.Resource)
+	if rm.Scope.Name() == meta.RESTScopeNameNamespace {
+		return ri.Namespace(entity.NamespaceOrDefault(k.configNamespace.String())), nil
+	}
+	return ri, nil
+}
+
+func isFieldManagerConflict(err error) bool {
+	return apierrors.IsConflict(err) && len(fieldConflicts(err)) > 0
+}
+
+func fieldConflicts(err error) []metav1.StatusCause {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil
+	}
+
+	var result []metav1.StatusCause
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			result = append(result, cause)
+		}
+	}
+	return result
+}
+
+func fieldConflictMessage(entity K8sEntity, conflicts []metav1.StatusCause) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s has fields that are managed by someone else:\n", entity.GVK().Kind, entity.Name()))
+	for _, cause := range conflicts {
+		sb.WriteString(fmt.Sprintf("  %s: %s\n", cause.Field, cause.Message))
+	}
+	sb.WriteString("Tilt will leave these fields to their current manager. ")
+	sb.WriteString(fmt.Sprintf("To take ownership of them, use update_settings(k8s_apply_mode='%s').", model.K8sApplyModeServerSideForce))
+	return sb.String()
+}
+
+// Returns a copy of the entity without the fields that other managers own,
+// so that applying it doesn't conflict with them.
+func withoutConflictingFields(entity K8sEntity, conflicts []metav1.StatusCause) (K8sEntity, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(entity.DeepCopy().Obj)
+	if err != nil {
+		return K8sEntity{}, err
+	}
+
+	var v interface{} = obj
+	for _, cause := range conflicts {
+		var ok bool
+		v, ok = removeFieldPath(v, cause.Field)
+		if !ok {
+			return K8sEntity{}, fmt.Errorf("%s %s: could not remove conflicting field %s",
+				entity.GVK().Kind, entity.Name(), cause.Field)
+		}
+	}
+	return NewK8sEntity(&unstructured.Unstructured{Object: obj}), nil
+}
+
+// Removes the field at the given path from a JSON-like value. The path is in
+// the format that the API server uses to report field conflicts, e.g.,
+//
+//	.spec.template.spec.containers[name="app"].image
+//
+// Returns the new value, and false if there's no field at the path.
+func removeFieldPath(v interface{}, path string) (interface{}, bool) {
+	switch {
+	case strings.HasPrefix(path, "."):
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v, false
+		}
+
+		// Field names can contain dots (e.g., annotation keys), so match the
+		// path against the fields that exist, longest first.
+		var keys []string
+		for key := range m {
+			if strings.HasPrefix(path[1:], key) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+
+		for _, key := range keys {
+			rest := path[1+len(key):]
+			if rest == "" {
+				delete(m, key)
+				return m, true
+			}
+			if rest[0] != '.' && rest[0] != '[' {
+				continue
+			}
+
+			child, ok := removeFieldPath(m[key], rest)
+			if ok {
+				m[key] = child
+				return m, true
+			}
+		}
+		return v, false
+
+	case strings.HasPrefix(path, "["):
+		list, ok := v.([]interface{})
+		if !ok {
+			return v, false
+		}
+
+		end := closingBracket(path)
+		if end == -1 {
+			return v, false
+		}
+		selector, rest := path[1:end], path[end+1:]
+
+		for i, elem := range list {
+			if !matchesListElement(elem, i, selector) {
+				continue
+			}
+			if rest == "" {
+				return append(list[:i:i], list[i+1:]...), true
+			}
+
+			child, ok := removeFieldPath(elem, rest)
+			if ok {
+				list[i] = child
+				return list, true
+			}
+		}
+		return v, false
+	}
+	return v, false
+}
+
+// Whether a list element matches a path selector: an index like [0],
+// a set value like [="a"], or associative keys like [name="app",port=80].
+func matchesListElement(elem interface{}, index int, selector string) bool {
+	if strings.HasPrefix(selector, "=") {
+		return formatFieldValue(elem) == selector[1:]
+	}
+
+	if i, err := strconv.Atoi(selector); err == nil {
+		return i == index
+	}
+
+	m, ok := elem.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, kv := range splitOutsideQuotes(selector, ',') {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return false
+		}
+		val, ok := m[parts[0]]
+		if !ok || formatFieldValue(val) != parts[1] {
+			return false
+		}
+	}
+	return true
+}
+
+// Formats a scalar the way the API server does in field paths.
+func formatFieldValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// The index of the ] that closes the [ at the start of the path,
+// skipping any brackets in quoted strings.
+func closingBracket(path string) int {
+	inQuote := false
+	for i := 1; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			if inQuote {
+				i++
+			}
+		case '"':
+			inQuote = !inQuote
+		case ']':
+			if !inQuote {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func splitOutsideQuotes(s string, sep byte) []string {
+	var result []string
+	inQuote := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if inQuote {
+				i++
+			}
+		case '"':
+			inQuote = !inQuote
+		case sep:
+			if !inQuote {
+				result = append(result, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(result, s[start:])
+}
@@ -12,12 +12,16 @@ import (
 	v1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -41,6 +45,16 @@ type InformerSet interface {
 	//
 	// The pod should be treated as immutable (since it's a pointer to a shared cache reference).
 	PodFromInformerCache(ctx context.Context, nn types.NamespacedName) (*v1.Pod, error)
+
+	// List the pods in a namespace that match the selector, from the informer cache.
+	//
+	// If no informer has started, start one now on the given ctx.
+	PodsFromInformerCache(ctx context.Context, ns Namespace, selector labels.Selector) ([]*v1.Pod, error)
+
+	// Fetch a service from the informer cache.
+	//
+	// If no informer has started, start one now on the given ctx.
+	ServiceFromInformerCache(ctx context.Context, nn types.NamespacedName) (*v1.Service, error)
 }
 
 type informerSet struct {
@@ -86,6 +100,15 @@ func (r ObjectUpdate) AsPod() (*v1.Pod, bool) {
 	return pod, ok
 }
 
+// Returns an Unstructured object if this is an Add or Update from WatchObjects.
+func (r ObjectUpdate) AsUnstructured() (*unstructured.Unstructured, bool) {
+	if r.isDelete {
+		return nil, false
+	}
+	obj, ok := r.obj.(*unstructured.Unstructured)
+	return obj, ok
+}
+
 // Returns the object update as the NamespacedName of the pod.
 func (r ObjectUpdate) AsNamespacedName() (types.NamespacedName, bool) {
 	pod, ok := r.AsPod()
@@ -257,6 +280,40 @@ func (s *informerSet) PodFromInformerCache(ctx context.Context, nn types.Namespa
 	return pod.(*v1.Pod), nil
 }
 
+func (s *informerSet) PodsFromInformerCache(ctx context.Context, ns Namespace, selector labels.Selector) ([]*v1.Pod, error) {
+	informer, err := s.makeInformer(ctx, ns, PodGVR)
+	if err != nil {
+		return nil, errors.Wrap(err, "PodsFromInformer")
+	}
+
+	var result []*v1.Pod
+	for _, obj := range informer.GetStore().List() {
+		pod, ok := obj.(*v1.Pod)
+		if ok && selector.Matches(labels.Set(pod.Labels)) {
+			result = append(result, pod)
+		}
+	}
+	return result, nil
+}
+
+func (s *informerSet) ServiceFromInformerCache(ctx context.Context, nn types.NamespacedName) (*v1.Service, error) {
+	gvr := ServiceGVR
+	informer, err := s.makeInformer(ctx, Namespace(nn.Namespace), gvr)
+	if err != nil {
+		return nil, errors.Wrap(err, "ServiceFromInformer")
+	}
+	svc, exists, err := informer.GetStore().Get(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: nn.Name, Namespace: nn.Namespace},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), nn.Name)
+	}
+	return svc.(*v1.Service), nil
+}
+
 func (s *informerSet) WatchPods(ctx context.Context, ns Namespace) (<-chan ObjectUpdate, error) {
 	gvr := PodGVR
 	informer, err := s.makeInformer(ctx, ns, gvr)
@@ -417,6 +474,56 @@ func (kCli *K8sClient) watchMeta15Plus(ctx context.Context, gvr schema.GroupVers
 	return ch, nil
 }
 
+func (kCli *K8sClient) WatchObjects(ctx context.Context, gvk schema.GroupVersionKind, ns Namespace) (<-chan ObjectUpdate, error) {
+	rm, err := kCli.restMapping(ctx, gvk)
+	if err != nil {
+		return nil, errors.Wrap(err, "WatchObjects")
+	}
+
+	var ri dynamic.ResourceInterface = kCli.dynamic.Resource(rm.Resource)
+	if rm.Scope.Name() == meta.RESTScopeNameNamespace {
+		ri = kCli.dynamic.Resource(rm.Resource).Namespace(ns.String())
+	}
+
+	informer := cache.NewSharedInformer(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return ri.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return ri.Watch(ctx, options)
+		},
+	}, &unstructured.Unstructured{}, resyncPeriod)
+
+	ch := make(chan ObjectUpdate)
+	send := func(update ObjectUpdate) {
+		select {
+		case ch <- update:
+		case <-ctx.Done():
+		}
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			mObj, ok := obj.(*unstructured.Unstructured)
+			if ok {
+				send(ObjectUpdate{obj: mObj})
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			send(ObjectUpdate{obj: obj, isDelete: true})
+		},
+		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+			mNewObj, ok := newObj.(*unstructured.Unstructured)
+			if ok {
+				send(ObjectUpdate{obj: mNewObj})
+			}
+		},
+	})
+
+	go runInformer(ctx, fmt.Sprintf("%s-objects", rm.Resource.Resource), informer)
+
+	return ch, nil
+}
+
 func runInformer(ctx context.Context, name string, informer cache.SharedInformer) {
 	originalDuration := 3 * time.Second
 	originalBackoff := wait.Backoff{
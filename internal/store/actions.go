@@ -77,6 +77,21 @@ func NewLogAction(mn model.ManifestName, spanID logstore.SpanID, level logger.Le
 	}
 }
 
+// Like NewLogAction, but for a log that was written earlier, e.g., a line
+// that Docker recorded with its own timestamp.
+func NewLogActionWithTime(mn model.ManifestName, spanID logstore.SpanID, level logger.Level, fields logger.Fields, b []byte, timestamp time.Time) LogAction {
+	action := NewLogAction(mn, spanID, level, fields, b)
+	action.timestamp = timestamp
+	return action
+}
+
+// A copy of the action with a different level and message.
+func (le LogAction) withLevelAndMessage(level logger.Level, b []byte) LogAction {
+	le.level = level
+	le.msg = append([]byte{}, b...)
+	return le
+}
+
 func NewGlobalLogAction(level logger.Level, b []byte) LogAction {
 	return LogAction{
 		mn:        "",
@@ -102,10 +117,16 @@ func (kEvt K8sEventAction) ToLogAction(mn model.ManifestName) LogAction {
 	msg := fmt.Sprintf("[K8s EVENT: %s] %s\n",
 		objRefHumanReadable(kEvt.Event.InvolvedObject), kEvt.Event.Message)
 
+	// Highlight warnings (e.g., failed scheduling or volume provisioning).
+	level := logger.InfoLvl
+	if kEvt.Event.Type == v1.EventTypeWarning {
+		level = logger.WarnLvl
+	}
+
 	return LogAction{
 		mn:        mn,
 		spanID:    logstore.SpanID(fmt.Sprintf("events:%s", mn)),
-		level:     logger.InfoLvl,
+		level:     level,
 		timestamp: kEvt.Event.LastTimestamp.Time,
 		msg:       []byte(msg),
 	}
@@ -160,6 +181,22 @@ func NewPodResetRestartsAction(podID k8s.PodID, mn model.ManifestName, visibleRe
 
 func (PodResetRestartsAction) Action() {}
 
+// The user wants to hide the logs that these manifests have so far.
+// If ManifestNames is empty, hide all logs.
+type ClearLogsAction struct {
+	ManifestNames []model.ManifestName
+}
+
+func (ClearLogsAction) Action() {}
+
+// The one-second window of a rate-limited manifest has closed, so the log
+// store should say how many lines it dropped.
+type FlushDroppedLogsAction struct {
+	Time time.Time
+}
+
+func (FlushDroppedLogsAction) Action() {}
+
 type PanicAction struct {
 	Err error
 }
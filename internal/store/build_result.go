@@ -3,9 +3,11 @@ package store
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/docker/distribution/reference"
 	dockertypes "github.com/docker/docker/api/types"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/tilt-dev/tilt/internal/container"
@@ -133,6 +135,16 @@ type K8sBuildResult struct {
 	// Hashes of the pod template specs that we deployed to a Kubernetes cluster.
 	PodTemplateSpecHashes []k8s.PodTemplateSpecHash
 
+	// References to the objects that we deployed to a Kubernetes cluster.
+	DeployedRefs []v1.ObjectReference
+
+	// A unified diff between the live objects and the objects we deployed.
+	Diff string
+
+	// The YAML that we applied, before the server filled in any defaults.
+	// Lets us diff what we deployed against the live objects later.
+	AppliedYAML string
+
 	AppliedEntitiesText string
 }
 
@@ -140,10 +152,12 @@ func (r K8sBuildResult) TargetID() model.TargetID   { return r.id }
 func (r K8sBuildResult) BuildType() model.BuildType { return model.BuildTypeK8s }
 
 // For kubernetes deploy targets.
-func NewK8sDeployResult(id model.TargetID, uids []types.UID, hashes []k8s.PodTemplateSpecHash, appliedEntities []k8s.K8sEntity) BuildResult {
+func NewK8sDeployResult(id model.TargetID, uids []types.UID, hashes []k8s.PodTemplateSpecHash, appliedEntities []k8s.K8sEntity) K8sBuildResult {
 	// Remove verbose fields from the YAML.
+	refs := make([]v1.ObjectReference, 0, len(appliedEntities))
 	for _, e := range appliedEntities {
 		e.Clean()
+		refs = append(refs, e.ToObjectReference())
 	}
 
 	appliedEntitiesText, err := k8s.SerializeSpecYAML(appliedEntities)
@@ -155,6 +169,7 @@ func NewK8sDeployResult(id model.TargetID, uids []types.UID, hashes []k8s.PodTem
 		id:                    id,
 		DeployedUIDs:          uids,
 		PodTemplateSpecHashes: hashes,
+		DeployedRefs:          refs,
 		AppliedEntitiesText:   appliedEntitiesText,
 	}
 }
@@ -235,6 +250,18 @@ func (set BuildResultSet) BuildTypes() []model.BuildType {
 	return result
 }
 
+// The diffs of all the Kubernetes objects deployed in the result set.
+func (set BuildResultSet) K8sDiff() string {
+	diffs := []string{}
+	for _, br := range set {
+		if r, ok := br.(K8sBuildResult); ok && r.Diff != "" {
+			diffs = append(diffs, r.Diff)
+		}
+	}
+	sort.Strings(diffs)
+	return strings.Join(diffs, "")
+}
+
 // Returns a container ID iff it's the only container ID in the result set.
 // If there are multiple container IDs, we have to give up.
 func (set BuildResultSet) OneAndOnlyLiveUpdatedContainerID() container.ID {
@@ -420,6 +447,7 @@ type ContainerInfo struct {
 	ContainerID   container.ID
 	ContainerName container.Name
 	Namespace     k8s.Namespace
+	KubeContext   k8s.KubeContext
 }
 
 func (c ContainerInfo) Empty() bool {
@@ -496,6 +524,7 @@ func RunningContainersForTargetForOnePod(iTarget model.ImageTarget, runtimeState
 			ContainerID:   c.ID,
 			ContainerName: c.Name,
 			Namespace:     pod.Namespace,
+			KubeContext:   runtimeState.KubeContext,
 		})
 	}
 
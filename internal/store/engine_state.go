@@ -38,6 +38,10 @@ type EngineState struct {
 	EngineMode        EngineMode
 	TerminalMode      TerminalMode
 
+	// The kubeconfig context that Tilt started with. Resources deploy here
+	// unless they name another context.
+	KubeContext k8s.KubeContext
+
 	// For synchronizing BuildController -- wait until engine records all builds started
 	// so far before starting another build
 	StartedBuildCount int
@@ -74,6 +78,9 @@ type EngineState struct {
 	// All logs in Tilt, stored in a structured format.
 	LogStore *logstore.LogStore `testdiff:"ignore"`
 
+	// The alert patterns of each manifest, compiled when the Tiltfile loads.
+	LogAlertMatchers map[model.ManifestName]*LogAlertMatcher `json:"-" testdiff:"ignore"`
+
 	TiltfilePath string
 
 	// TODO(nick): This should be called "ConfigPaths", not "ConfigFiles",
@@ -117,6 +124,8 @@ type EngineState struct {
 	MetricsSettings model.MetricsSettings
 	MetricsServing  MetricsServing
 
+	LogExportSettings model.LogExportSettings
+
 	UserConfigState model.UserConfigState
 
 	// API-server-based data models. Stored in EngineState
@@ -461,6 +470,11 @@ type ManifestState struct {
 
 	// If the build was manually triggered, record why.
 	TriggerReason model.BuildReason
+
+	// Runtime log lines that matched an alert pattern since the last
+	// successful deploy, and the most recent one.
+	LogAlertCount int
+	LastLogAlert  string
 }
 
 func NewState() *EngineState {
@@ -571,6 +585,16 @@ func (ms *ManifestState) LastBuild() model.BuildRecord {
 	return ms.BuildHistory[0]
 }
 
+// The most recent build that deployed to Kubernetes (i.e., not a live update).
+func (ms *ManifestState) LastK8sDeploy() (model.BuildRecord, bool) {
+	for _, bs := range ms.BuildHistory {
+		if bs.HasBuildType(model.BuildTypeK8s) {
+			return bs, true
+		}
+	}
+	return model.BuildRecord{}, false
+}
+
 func (ms *ManifestState) AddCompletedBuild(bs model.BuildRecord) {
 	ms.BuildHistory = append([]model.BuildRecord{bs}, ms.BuildHistory...)
 	if len(ms.BuildHistory) > model.BuildHistoryLimit {
@@ -854,6 +878,8 @@ func StateToView(s EngineState, mu *sync.RWMutex) view.View {
 			CurrentBuild:       currentBuild,
 			Endpoints:          model.LinksToURLStrings(endpoints), // hud can't handle link names, just send URLs
 			ResourceInfo:       resourceInfoView(mt),
+			LogAlertCount:      ms.LogAlertCount,
+			LastLogAlert:       ms.LastLogAlert,
 		}
 
 		ret.Resources = append(ret.Resources, r)
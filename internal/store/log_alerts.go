@@ -0,0 +1,129 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+	"github.com/tilt-dev/tilt/pkg/model/logstore"
+)
+
+// Matches the runtime logs of a manifest against its alert patterns.
+type LogAlertMatcher struct {
+	patterns      []*regexp.Regexp
+	fatalPatterns []*regexp.Regexp
+}
+
+func NewLogAlertMatcher(alerts model.LogAlerts) (*LogAlertMatcher, error) {
+	patterns, err := compileLogAlertPatterns(alerts.Patterns)
+	if err != nil {
+		return nil, err
+	}
+	fatalPatterns, err := compileLogAlertPatterns(alerts.FatalPatterns)
+	if err != nil {
+		return nil, err
+	}
+	return &LogAlertMatcher{patterns: patterns, fatalPatterns: fatalPatterns}, nil
+}
+
+func compileLogAlertPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	result := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", p, err)
+		}
+		result = append(result, re)
+	}
+	return result, nil
+}
+
+// Returns the level of an alert for a line of logs: ErrorLvl if it matches
+// a fatal pattern, WarnLvl if it matches any other pattern.
+//
+// Returns false if the line doesn't match any pattern.
+func (m *LogAlertMatcher) Match(line []byte) (logger.Level, bool) {
+	for _, re := range m.fatalPatterns {
+		if re.Match(line) {
+			return logger.ErrorLvl, true
+		}
+	}
+	for _, re := range m.patterns {
+		if re.Match(line) {
+			return logger.WarnLvl, true
+		}
+	}
+	return logger.NoneLvl, false
+}
+
+// A line of logs that matched an alert pattern.
+type LogAlert struct {
+	Line  string
+	Fatal bool
+}
+
+// Only match lines that the resource itself logged, and not the messages
+// that Tilt adds about it (e.g., K8s events).
+func IsLogAlertSource(spanID logstore.SpanID) bool {
+	kind := strings.SplitN(string(spanID), ":", 2)[0]
+	switch kind {
+	case "pod", "dc", "localserve":
+		return true
+	}
+	return false
+}
+
+// Splits a log action into lines, and raises the level of each line
+// that matches an alert pattern.
+//
+// Adjacent lines at the same level stay in one action. Returns the new
+// actions, and the lines that matched.
+//
+// Lines are matched one action at a time, so a line that's split across
+// two actions is matched in two halves.
+func SplitLogAlerts(m *LogAlertMatcher, action LogAction) ([]LogAction, []LogAlert) {
+	var actions []LogAction
+	var alerts []LogAlert
+
+	msg := action.Message()
+	start := 0
+	curLevel := action.Level()
+	for start < len(msg) {
+		end := bytes.IndexByte(msg[start:], '\n')
+		if end == -1 {
+			end = len(msg)
+		} else {
+			end = start + end + 1
+		}
+		line := msg[start:end]
+
+		level := action.Level()
+		alertLevel, ok := m.Match(line)
+		if ok {
+			alerts = append(alerts, LogAlert{
+				Line:  strings.TrimSpace(string(line)),
+				Fatal: alertLevel == logger.ErrorLvl,
+			})
+			if !level.AsSevereAs(alertLevel) {
+				level = alertLevel
+			}
+		}
+
+		if len(actions) > 0 && level == curLevel {
+			last := &actions[len(actions)-1]
+			last.msg = append(last.msg, line...)
+		} else {
+			actions = append(actions, action.withLevelAndMessage(level, line))
+		}
+		curLevel = level
+		start = end
+	}
+
+	if len(actions) == 0 {
+		return []LogAction{action}, nil
+	}
+	return actions, alerts
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+func TestSplitLogAlerts(t *testing.T) {
+	m, err := NewLogAlertMatcher(model.LogAlerts{
+		Patterns:      []string{"OutOfMemory"},
+		FatalPatterns: []string{"^panic:"},
+	})
+	require.NoError(t, err)
+
+	action := NewLogAction("fe", "pod:fe-1", logger.InfoLvl, nil,
+		[]byte("starting\nlistening\nOutOfMemory soon\npanic: oh no\ngoroutine 1\nexit"))
+	actions, alerts := SplitLogAlerts(m, action)
+
+	require.Len(t, actions, 4)
+	assert.Equal(t, "starting\nlistening\n", string(actions[0].Message()))
+	assert.Equal(t, logger.InfoLvl, actions[0].Level())
+	assert.Equal(t, "OutOfMemory soon\n", string(actions[1].Message()))
+	assert.Equal(t, logger.WarnLvl, actions[1].Level())
+	assert.Equal(t, "panic: oh no\n", string(actions[2].Message()))
+	assert.Equal(t, logger.ErrorLvl, actions[2].Level())
+	assert.Equal(t, "goroutine 1\nexit", string(actions[3].Message()))
+	assert.Equal(t, logger.InfoLvl, actions[3].Level())
+
+	for _, a := range actions {
+		assert.Equal(t, action.SpanID(), a.SpanID())
+		assert.Equal(t, action.Time(), a.Time())
+	}
+
+	assert.Equal(t, []LogAlert{
+		{Line: "OutOfMemory soon"},
+		{Line: "panic: oh no", Fatal: true},
+	}, alerts)
+}
+
+func TestSplitLogAlertsNoMatch(t *testing.T) {
+	m, err := NewLogAlertMatcher(model.LogAlerts{Patterns: []string{"panic:"}})
+	require.NoError(t, err)
+
+	action := NewLogAction("fe", "pod:fe-1", logger.InfoLvl, nil, []byte("a\nb\n"))
+	actions, alerts := SplitLogAlerts(m, action)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "a\nb\n", string(actions[0].Message()))
+	assert.Empty(t, alerts)
+}
+
+func TestSplitLogAlertsKeepsHigherLevel(t *testing.T) {
+	m, err := NewLogAlertMatcher(model.LogAlerts{Patterns: []string{"panic:"}})
+	require.NoError(t, err)
+
+	action := NewLogAction("fe", "pod:fe-1", logger.ErrorLvl, nil, []byte("panic: oh no\n"))
+	actions, alerts := SplitLogAlerts(m, action)
+	require.Len(t, actions, 1)
+	assert.Equal(t, logger.ErrorLvl, actions[0].Level())
+	assert.Equal(t, []LogAlert{{Line: "panic: oh no"}}, alerts)
+}
+
+func TestNewLogAlertMatcherInvalid(t *testing.T) {
+	_, err := NewLogAlertMatcher(model.LogAlerts{FatalPatterns: []string{"panic(:"}})
+	assert.EqualError(t, err, "invalid pattern \"panic(:\": error parsing regexp: missing closing ): `panic(:`")
+}
+
+func TestIsLogAlertSource(t *testing.T) {
+	assert.True(t, IsLogAlertSource("pod:fe-1"))
+	assert.True(t, IsLogAlertSource("dc:fe"))
+	assert.True(t, IsLogAlertSource("localserve:1"))
+	assert.False(t, IsLogAlertSource("build:1"))
+	assert.False(t, IsLogAlertSource("events:fe"))
+	assert.False(t, IsLogAlertSource("monitor:fe-1"))
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/docker/distribution/reference"
@@ -75,6 +76,9 @@ type K8sRuntimeState struct {
 	// In many cases, this will be a Deployment UID.
 	PodAncestorUID types.UID
 
+	// The kubeconfig context that we deployed to. Empty for the context that Tilt started with.
+	KubeContext k8s.KubeContext
+
 	Pods                           map[k8s.PodID]*Pod
 	LBs                            map[k8s.ServiceName]*url.URL
 	DeployedUIDSet                 UIDSet                 // for the most recent successful deploy
@@ -84,6 +88,39 @@ type K8sRuntimeState struct {
 	HasEverDeployedSuccessfully bool
 
 	PodReadinessMode model.PodReadinessMode
+
+	// Non-pod objects that must be ready (e.g., custom resources with status conditions),
+	// and whether each one is currently ready, keyed by K8sObjectReadiness.Key().
+	ObjectReadinessKeys []string
+	ReadyObjects        map[string]bool
+
+	// The last time that all the objects with readiness checks were ready.
+	LastObjectsReadyTime time.Time
+
+	// Workloads (e.g., Deployments and StatefulSets) whose rollouts have failed,
+	// with a human-readable reason, keyed by UID.
+	RolloutErrors map[types.UID]string
+
+	// A runtime log line that matched a fatal alert pattern since the last
+	// successful deploy.
+	FatalLogAlert string
+
+	// The health of port-forwards with a target (e.g., a Service), keyed by local port.
+	PortForwardStatuses map[int]PortForwardStatus
+}
+
+// The health of a port-forward that balances connections across pods.
+type PortForwardStatus struct {
+	LocalPort int
+	Target    string
+
+	// The number of ready pods that we're forwarding to.
+	Pods int
+
+	ActiveConnections int
+	TotalConnections  int
+	Errors            int
+	LastError         string
 }
 
 func (K8sRuntimeState) RuntimeState() {}
@@ -102,7 +139,12 @@ func NewK8sRuntimeStateWithPods(m model.Manifest, pods ...Pod) K8sRuntimeState {
 
 func NewK8sRuntimeState(m model.Manifest) K8sRuntimeState {
 	return K8sRuntimeState{
+		KubeContext:                    k8s.KubeContext(m.K8sTarget().KubeContext),
 		PodReadinessMode:               m.PodReadinessMode(),
+		ObjectReadinessKeys:            m.K8sTarget().ObjectReadinessKeys(),
+		ReadyObjects:                   make(map[string]bool),
+		RolloutErrors:                  make(map[types.UID]string),
+		PortForwardStatuses:            make(map[int]PortForwardStatus),
 		Pods:                           make(map[k8s.PodID]*Pod),
 		LBs:                            make(map[k8s.ServiceName]*url.URL),
 		DeployedUIDSet:                 NewUIDSet(),
@@ -115,6 +157,12 @@ func (s K8sRuntimeState) RuntimeStatusError() error {
 	if status != model.RuntimeStatusError {
 		return nil
 	}
+	if msg := s.RolloutError(); msg != "" {
+		return fmt.Errorf("%s", msg)
+	}
+	if s.FatalLogAlert != "" {
+		return fmt.Errorf("Log alert: %s", s.FatalLogAlert)
+	}
 	pod := s.MostRecentPod()
 	return fmt.Errorf("Pod %s in error state: %s", pod.PodID, pod.Status)
 }
@@ -124,6 +172,14 @@ func (s K8sRuntimeState) RuntimeStatus() model.RuntimeStatus {
 		return model.RuntimeStatusPending
 	}
 
+	if s.RolloutError() != "" || s.FatalLogAlert != "" {
+		return model.RuntimeStatusError
+	}
+
+	if !s.AllObjectsReady() {
+		return model.RuntimeStatusPending
+	}
+
 	if s.PodReadinessMode == model.PodReadinessIgnore {
 		return model.RuntimeStatusOK
 	}
@@ -169,12 +225,53 @@ func (s K8sRuntimeState) HasEverBeenReadyOrSucceeded() bool {
 	if !s.HasEverDeployedSuccessfully {
 		return false
 	}
+	if len(s.ObjectReadinessKeys) > 0 && s.LastObjectsReadyTime.IsZero() {
+		return false
+	}
 	if s.PodReadinessMode == model.PodReadinessIgnore {
 		return true
 	}
 	return !s.LastReadyOrSucceededTime.IsZero()
 }
 
+// Returns the reason that a workload from the most recent deploy failed to roll out,
+// or an empty string if there were no failures.
+func (s K8sRuntimeState) RolloutError() string {
+	var errs []string
+	for uid, msg := range s.RolloutErrors {
+		if s.DeployedUIDSet.Contains(uid) {
+			errs = append(errs, msg)
+		}
+	}
+	if len(errs) == 0 {
+		return ""
+	}
+	sort.Strings(errs)
+	return errs[0]
+}
+
+// Returns true if all the objects with readiness checks are ready.
+func (s K8sRuntimeState) AllObjectsReady() bool {
+	for _, key := range s.ObjectReadinessKeys {
+		if !s.ReadyObjects[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the port-forward statuses, sorted by local port.
+func (s K8sRuntimeState) SortedPortForwardStatuses() []PortForwardStatus {
+	result := make([]PortForwardStatus, 0, len(s.PortForwardStatuses))
+	for _, status := range s.PortForwardStatuses {
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LocalPort < result[j].LocalPort
+	})
+	return result
+}
+
 func (s K8sRuntimeState) PodLen() int {
 	return len(s.Pods)
 }
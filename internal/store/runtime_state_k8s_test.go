@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/tilt-dev/tilt/internal/k8s"
 	"github.com/tilt-dev/tilt/internal/store"
@@ -29,6 +30,61 @@ func TestK8sRuntimeState_RuntimeStatus_PodReadinessIgnore(t *testing.T) {
 	assert.Equal(t, model.RuntimeStatusOK, s.RuntimeStatus())
 }
 
+func TestK8sRuntimeState_RuntimeStatus_ObjectReadiness(t *testing.T) {
+	s := store.K8sRuntimeState{
+		HasEverDeployedSuccessfully: true,
+		PodReadinessMode:            model.PodReadinessIgnore,
+		ObjectReadinessKeys:         []string{"Certificate/my-cert"},
+		ReadyObjects:                map[string]bool{},
+	}
+	assert.Equal(t, model.RuntimeStatusPending, s.RuntimeStatus())
+	assert.False(t, s.HasEverBeenReadyOrSucceeded())
+
+	s.ReadyObjects["Certificate/my-cert"] = true
+	s.LastObjectsReadyTime = time.Now()
+	assert.Equal(t, model.RuntimeStatusOK, s.RuntimeStatus())
+	assert.True(t, s.HasEverBeenReadyOrSucceeded())
+
+	// Once the objects have been ready, dependents can start,
+	// even if an object later becomes unready.
+	s.ReadyObjects["Certificate/my-cert"] = false
+	assert.Equal(t, model.RuntimeStatusPending, s.RuntimeStatus())
+	assert.True(t, s.HasEverBeenReadyOrSucceeded())
+}
+
+func TestK8sRuntimeState_RuntimeStatus_RolloutError(t *testing.T) {
+	s := store.K8sRuntimeState{
+		HasEverDeployedSuccessfully: true,
+		PodReadinessMode:            model.PodReadinessIgnore,
+		DeployedUIDSet:              store.NewUIDSet(),
+		RolloutErrors:               map[types.UID]string{},
+	}
+	s.DeployedUIDSet.Add("deploy-uid")
+	assert.Equal(t, model.RuntimeStatusOK, s.RuntimeStatus())
+
+	msg := "Deployment sancho rollout failed: ProgressDeadlineExceeded"
+	s.RolloutErrors["deploy-uid"] = msg
+	assert.Equal(t, model.RuntimeStatusError, s.RuntimeStatus())
+	assert.EqualError(t, s.RuntimeStatusError(), msg)
+
+	// Failures of objects that are no longer deployed don't count.
+	s.DeployedUIDSet = store.NewUIDSet()
+	s.DeployedUIDSet.Add("new-deploy-uid")
+	assert.Equal(t, model.RuntimeStatusOK, s.RuntimeStatus())
+}
+
+func TestK8sRuntimeState_RuntimeStatus_FatalLogAlert(t *testing.T) {
+	s := store.K8sRuntimeState{
+		HasEverDeployedSuccessfully: true,
+		PodReadinessMode:            model.PodReadinessIgnore,
+	}
+	assert.Equal(t, model.RuntimeStatusOK, s.RuntimeStatus())
+
+	s.FatalLogAlert = "panic: runtime error: invalid memory address"
+	assert.Equal(t, model.RuntimeStatusError, s.RuntimeStatus())
+	assert.EqualError(t, s.RuntimeStatusError(), "Log alert: panic: runtime error: invalid memory address")
+}
+
 func TestK8sRuntimeState_RuntimeStatus(t *testing.T) {
 	type tc struct {
 		name           string
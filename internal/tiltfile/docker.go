@@ -17,6 +17,7 @@ import (
 	"github.com/tilt-dev/tilt/internal/ospath"
 	"github.com/tilt-dev/tilt/internal/sliceutils"
 	"github.com/tilt-dev/tilt/internal/tiltfile/io"
+	"github.com/tilt-dev/tilt/internal/tiltfile/secrets"
 	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
 	"github.com/tilt-dev/tilt/internal/tiltfile/value"
 	"github.com/tilt-dev/tilt/pkg/model"
@@ -31,6 +32,7 @@ type dockerImage struct {
 	matchInEnvVars   bool
 	sshSpecs         []string
 	secretSpecs      []string
+	secrets          map[string]string
 	ignores          []string
 	onlys            []string
 	entrypoint       model.Cmd // optional: if specified, we override the image entrypoint/k8s command with this
@@ -64,6 +66,9 @@ type dockerImage struct {
 	outputsImageRefTo string
 
 	liveUpdate model.LiveUpdate
+
+	// Post-push commands (signing, SBOM generation)
+	attestation model.ImageAttestation
 }
 
 func (d *dockerImage) ID() model.TargetID {
@@ -99,10 +104,13 @@ func (s *tiltfileState) dockerBuild(thread *starlark.Thread, fn *starlark.Builti
 		liveUpdateVal,
 		ignoreVal,
 		onlyVal,
-		entrypoint starlark.Value
+		entrypoint,
+		signCmdVal,
+		sbomCmdVal starlark.Value
 	var buildArgs value.StringStringMap
 	var network value.Stringable
-	var ssh, secret, extraTags, cacheFrom value.StringOrStringList
+	var ssh, extraTags, cacheFrom value.StringOrStringList
+	var secret secretsOrSpecs
 	var matchInEnvVars, pullParent bool
 	var containerArgsVal starlark.Sequence
 	if err := s.unpackArgs(fn.Name(), args, kwargs,
@@ -125,6 +133,8 @@ func (s *tiltfileState) dockerBuild(thread *starlark.Thread, fn *starlark.Builti
 		"extra_tag?", &extraTags,
 		"cache_from?", &cacheFrom,
 		"pull?", &pullParent,
+		"sign_cmd?", &signCmdVal,
+		"sbom_cmd?", &sbomCmdVal,
 	); err != nil {
 		return nil, err
 	}
@@ -208,6 +218,11 @@ func (s *tiltfileState) dockerBuild(thread *starlark.Thread, fn *starlark.Builti
 		containerArgs = model.OverrideArgs{ShouldOverride: true, Args: args}
 	}
 
+	attestation, err := attestationFromValues(thread, signCmdVal, sbomCmdVal)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, extraTag := range extraTags.Values {
 		_, err := container.ParseNamed(extraTag)
 		if err != nil {
@@ -225,7 +240,8 @@ func (s *tiltfileState) dockerBuild(thread *starlark.Thread, fn *starlark.Builti
 		liveUpdate:       liveUpdate,
 		matchInEnvVars:   matchInEnvVars,
 		sshSpecs:         ssh.Values,
-		secretSpecs:      secret.Values,
+		secretSpecs:      secret.specs,
+		secrets:          secret.secrets,
 		ignores:          ignores,
 		onlys:            onlys,
 		entrypoint:       entrypointCmd,
@@ -235,6 +251,7 @@ func (s *tiltfileState) dockerBuild(thread *starlark.Thread, fn *starlark.Builti
 		extraTags:        extraTags.Values,
 		cacheFrom:        cacheFrom.Values,
 		pullParent:       pullParent,
+		attestation:      attestation,
 	}
 	err = s.buildIndex.addImage(r)
 	if err != nil {
@@ -244,6 +261,33 @@ func (s *tiltfileState) dockerBuild(thread *starlark.Thread, fn *starlark.Builti
 	return starlark.None, nil
 }
 
+// Unpacks the docker_build secret argument, which may be a BuildKit
+// secret spec (as in `docker build --secret`), a secret() value,
+// or a list of these.
+type secretsOrSpecs struct {
+	specs   []string
+	secrets map[string]string
+}
+
+var _ starlark.Unpacker = &secretsOrSpecs{}
+
+func (s *secretsOrSpecs) Unpack(v starlark.Value) error {
+	for _, item := range value.ValueOrSequenceToSlice(v) {
+		switch x := item.(type) {
+		case secrets.Secret:
+			if s.secrets == nil {
+				s.secrets = make(map[string]string)
+			}
+			s.secrets[x.Name] = x.Value
+		case starlark.String:
+			s.specs = append(s.specs, string(x))
+		default:
+			return fmt.Errorf("value should be a secret spec string, a secret, or a list of these, but found type %s", item.Type())
+		}
+	}
+	return nil
+}
+
 func (s *tiltfileState) parseOnly(val starlark.Value) ([]string, error) {
 	paths, err := parseValuesToStrings(val, "only")
 	if err != nil {
@@ -272,6 +316,7 @@ func (s *tiltfileState) customBuild(thread *starlark.Thread, fn *starlark.Builti
 	var entrypoint starlark.Value
 	var containerArgsVal starlark.Sequence
 	var skipsLocalDocker bool
+	var signCmdVal, sbomCmdVal starlark.Value
 	outputsImageRefTo := value.NewLocalPathUnpacker(thread)
 
 	err := s.unpackArgs(fn.Name(), args, kwargs,
@@ -288,6 +333,8 @@ func (s *tiltfileState) customBuild(thread *starlark.Thread, fn *starlark.Builti
 		"container_args?", &containerArgsVal,
 		"command_bat_val", &commandBatVal,
 		"outputs_image_ref_to", &outputsImageRefTo,
+		"sign_cmd?", &signCmdVal,
+		"sbom_cmd?", &sbomCmdVal,
 
 		// This is a crappy fix for https://github.com/tilt-dev/tilt/issues/4061
 		// so that we don't break things.
@@ -341,6 +388,11 @@ func (s *tiltfileState) customBuild(thread *starlark.Thread, fn *starlark.Builti
 		return nil, fmt.Errorf("Cannot specify both tag= and outputs_image_ref_to=")
 	}
 
+	attestation, err := attestationFromValues(thread, signCmdVal, sbomCmdVal)
+	if err != nil {
+		return nil, err
+	}
+
 	img := &dockerImage{
 		workDir:           starkit.AbsWorkingDir(thread),
 		configurationRef:  container.NewRefSelector(ref),
@@ -355,6 +407,7 @@ func (s *tiltfileState) customBuild(thread *starlark.Thread, fn *starlark.Builti
 		entrypoint:        entrypointCmd,
 		containerArgs:     containerArgs,
 		outputsImageRefTo: outputsImageRefTo.Value,
+		attestation:       attestation,
 	}
 
 	err = s.buildIndex.addImage(img)
@@ -394,6 +447,18 @@ func (b *customBuild) AttrNames() []string {
 	return []string{}
 }
 
+func attestationFromValues(thread *starlark.Thread, signCmdVal, sbomCmdVal starlark.Value) (model.ImageAttestation, error) {
+	signCmd, err := value.ValueToHostCmd(thread, signCmdVal, nil)
+	if err != nil {
+		return model.ImageAttestation{}, errors.Wrap(err, "Argument sign_cmd")
+	}
+	sbomCmd, err := value.ValueToHostCmd(thread, sbomCmdVal, nil)
+	if err != nil {
+		return model.ImageAttestation{}, errors.Wrap(err, "Argument sbom_cmd")
+	}
+	return model.ImageAttestation{SignCmd: signCmd, SBOMCmd: sbomCmd}, nil
+}
+
 func parseValuesToStrings(value starlark.Value, param string) ([]string, error) {
 
 	tempIgnores := starlarkValueOrSequenceToSlice(value)
@@ -473,6 +538,50 @@ func (s *tiltfileState) defaultRegistry(thread *starlark.Thread, fn *starlark.Bu
 	return starlark.None, nil
 }
 
+func (s *tiltfileState) registryRulesFn(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rules starlark.Sequence
+	if err := s.unpackArgs(fn.Name(), args, kwargs,
+		"rules", &rules); err != nil {
+		return nil, err
+	}
+
+	it := rules.Iterate()
+	defer it.Done()
+	var v starlark.Value
+	for i := 0; it.Next(&v); i++ {
+		var m value.StringStringMap
+		if err := m.Unpack(v); err != nil {
+			return nil, errors.Wrapf(err, "%s: rule %d", fn.Name(), i)
+		}
+
+		image := m["image"]
+		if image == "" {
+			return nil, fmt.Errorf("%s: rule %d: missing required key 'image'", fn.Name(), i)
+		}
+		for k := range m {
+			switch k {
+			case "image", "host", "host_from_cluster", "single_name":
+			default:
+				return nil, fmt.Errorf("%s: rule %d: unexpected key %q", fn.Name(), i, k)
+			}
+		}
+
+		reg, err := container.NewRegistryWithHostFromCluster(m["host"], m["host_from_cluster"])
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: rule %d", fn.Name(), i)
+		}
+		reg.SingleName = m["single_name"]
+
+		rule, err := container.NewRegistryRule(image, reg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: rule %d", fn.Name(), i)
+		}
+		s.registryRules = append(s.registryRules, rule)
+	}
+
+	return starlark.None, nil
+}
+
 func (s *tiltfileState) dockerignoresFromPathsAndContextFilters(source string, paths []string, ignorePatterns []string, onlys []string, dbDockerfilePath string) ([]model.Dockerignore, error) {
 	var result []model.Dockerignore
 	dupeSet := map[string]bool{}
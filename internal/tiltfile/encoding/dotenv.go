@@ -0,0 +1,221 @@
+package encoding
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.starlark.net/starlark"
+
+	tiltfile_io "github.com/tilt-dev/tilt/internal/tiltfile/io"
+	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
+	"github.com/tilt-dev/tilt/internal/tiltfile/value"
+)
+
+// reads a dotenv file into a dict
+func readDotenv(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var path starlark.String
+	var defaultValue starlark.Value
+	if err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs, "paths", &path, "default?", &defaultValue); err != nil {
+		return nil, err
+	}
+
+	localPath, err := value.ValueToAbsPath(thread, path)
+	if err != nil {
+		return nil, fmt.Errorf("Argument 0 (paths): %v", err)
+	}
+
+	contents, err := tiltfile_io.ReadFile(thread, localPath)
+	if err != nil {
+		// Return the default value if the file doesn't exist AND a default value was given
+		if os.IsNotExist(err) && defaultValue != nil {
+			return defaultValue, nil
+		}
+		return nil, err
+	}
+
+	return dotenvStringToStarlark(string(contents), path.GoString())
+}
+
+// reads dotenv from a string
+func decodeDotenv(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var contents value.Stringable
+	if err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs, "dotenv", &contents); err != nil {
+		return nil, err
+	}
+
+	return dotenvStringToStarlark(contents.Value, "")
+}
+
+func dotenvStringToStarlark(s string, source string) (starlark.Value, error) {
+	keys, values, err := parseDotenv(s)
+	if err != nil {
+		errmsg := "error parsing dotenv"
+		if source != "" {
+			errmsg += fmt.Sprintf(" from %s", source)
+		}
+		return nil, errors.Wrap(err, errmsg)
+	}
+
+	ret := starlark.NewDict(len(keys))
+	for _, k := range keys {
+		err := ret.SetKey(starlark.String(k), starlark.String(values[k]))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+var dotenvKeyRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+var dotenvVarRE = regexp.MustCompile(`\\?\$(\{[A-Za-z_][A-Za-z0-9_]*\}|[A-Za-z_][A-Za-z0-9_]*)`)
+
+// Parses a dotenv file, in the format understood by docker-compose and most dotenv libraries:
+//
+//	# comment
+//	export KEY=value      # `export` is optional, inline comments need a space before the #
+//	QUOTED="line 1\nline 2"
+//	LITERAL='no $expansion or \escapes'
+//	EXPANDED=${KEY}-suffix
+//
+// Variables in unquoted and double-quoted values are expanded from keys earlier
+// in the file, then from the environment. Returns the keys in file order.
+func parseDotenv(s string) ([]string, map[string]string, error) {
+	var keys []string
+	values := make(map[string]string)
+
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		eq := strings.Index(line, "=")
+		if eq == -1 {
+			return nil, nil, fmt.Errorf("line %d: expected KEY=VALUE, found %q", lineNo, line)
+		}
+
+		key := strings.TrimSpace(line[:eq])
+		if !dotenvKeyRE.MatchString(key) {
+			return nil, nil, fmt.Errorf("line %d: invalid key %q", lineNo, key)
+		}
+
+		expand := func(v string) string {
+			return dotenvVarRE.ReplaceAllStringFunc(v, func(m string) string {
+				if strings.HasPrefix(m, `\`) {
+					return m[1:]
+				}
+				name := strings.Trim(m[1:], "{}")
+				if v, ok := values[name]; ok {
+					return v
+				}
+				return os.Getenv(name)
+			})
+		}
+
+		var val string
+		rest := strings.TrimLeft(line[eq+1:], " \t")
+		switch {
+		case strings.HasPrefix(rest, "'"):
+			end := strings.Index(rest[1:], "'")
+			if end == -1 {
+				return nil, nil, fmt.Errorf("line %d: unterminated single-quoted value for %s", lineNo, key)
+			}
+			val = rest[1 : end+1]
+			rest = rest[end+2:]
+
+		case strings.HasPrefix(rest, `"`):
+			// Double-quoted values may span multiple lines.
+			startLine := lineNo
+			raw := rest[1:]
+			for {
+				end := closingQuote(raw)
+				if end != -1 {
+					rest = raw[end+1:]
+					raw = raw[:end]
+					break
+				}
+				i++
+				if i >= len(lines) {
+					return nil, nil, fmt.Errorf("line %d: unterminated double-quoted value for %s", startLine, key)
+				}
+				raw += "\n" + lines[i]
+			}
+			val = expand(unescapeDotenv(raw))
+
+		default:
+			if idx := inlineCommentIndex(rest); idx != -1 {
+				rest = rest[:idx]
+			}
+			val = expand(strings.TrimSpace(rest))
+			rest = ""
+		}
+
+		rest = strings.TrimSpace(rest)
+		if rest != "" && !strings.HasPrefix(rest, "#") {
+			return nil, nil, fmt.Errorf("line %d: unexpected characters after value for %s: %q", lineNo, key, rest)
+		}
+
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = val
+	}
+	return keys, values, nil
+}
+
+// Finds the first unescaped double quote.
+func closingQuote(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeDotenv(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case '"', '\\':
+			sb.WriteByte(s[i])
+		case '$':
+			// Keep the escape, so that variable expansion skips it.
+			sb.WriteString(`\$`)
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+// In unquoted values, a # only starts a comment if it follows whitespace.
+func inlineCommentIndex(s string) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '#' && (s[i-1] == ' ' || s[i-1] == '\t') {
+			return i
+		}
+	}
+	return -1
+}
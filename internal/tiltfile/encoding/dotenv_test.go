@@ -0,0 +1,149 @@
+package encoding
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/internal/testutils"
+	"github.com/tilt-dev/tilt/internal/tiltfile/io"
+)
+
+func TestReadDotenv(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	os.Setenv("TILT_DOTENV_TEST_HOME", "/home/tilt")
+	defer os.Unsetenv("TILT_DOTENV_TEST_HOME")
+
+	f.File(".env", `
+# database settings
+export DB_HOST=localhost
+DB_PORT = 5432 # inline comment
+DB_URL=postgres://${DB_HOST}:$DB_PORT/app
+PASSWORD='pa$$word#1'
+GREETING="hello\n\"world\""
+MULTILINE="line one
+line two"
+CACHE_DIR=${TILT_DOTENV_TEST_HOME}/.cache
+ESCAPED="\$DB_HOST"
+EMPTY=
+`)
+	f.File("Tiltfile", `
+load('assert.tilt', 'assert')
+
+result = read_dotenv(".env")
+
+expected = {
+  'DB_HOST': 'localhost',
+  'DB_PORT': '5432',
+  'DB_URL': 'postgres://localhost:5432/app',
+  'PASSWORD': 'pa$$word#1',
+  'GREETING': 'hello\n"world"',
+  'MULTILINE': 'line one\nline two',
+  'CACHE_DIR': '/home/tilt/.cache',
+  'ESCAPED': '$DB_HOST',
+  'EMPTY': '',
+}
+
+assert.equals(expected, result)
+assert.equals(expected.keys(), result.keys())
+`)
+
+	result, err := f.ExecFile("Tiltfile")
+	if err != nil {
+		fmt.Println(f.PrintOutput())
+	}
+	require.NoError(t, err)
+
+	rs, err := io.GetState(result)
+	require.NoError(t, err)
+	require.Contains(t, rs.Paths, f.JoinPath(".env"))
+}
+
+func TestDotenvDoesNotExist(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `result = read_dotenv(".env")`)
+	result, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), ".env")
+	testutils.AssertIsNotExist(t, err)
+
+	rs, err := io.GetState(result)
+	require.NoError(t, err)
+	require.Contains(t, rs.Paths, f.JoinPath(".env"))
+}
+
+func TestReadDotenvDefaultValue(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+load('assert.tilt', 'assert')
+
+result = read_dotenv(".env", default={'FOO': 'bar'})
+assert.equals({'FOO': 'bar'}, result)
+`)
+
+	_, err := f.ExecFile("Tiltfile")
+	if err != nil {
+		fmt.Println(f.PrintOutput())
+	}
+	require.NoError(t, err)
+}
+
+func TestMalformedDotenv(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File(".env", `
+FOO=bar
+this is not an assignment
+`)
+
+	f.File("Tiltfile", `result = read_dotenv(".env")`)
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `error parsing dotenv from .env: line 3: expected KEY=VALUE, found "this is not an assignment"`)
+}
+
+func TestDecodeDotenv(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	for _, blob := range []bool{false, true} {
+		t.Run(fmt.Sprintf("blob: %v", blob), func(t *testing.T) {
+			d := `'A=1\nB="two"\n'`
+			if blob {
+				d = fmt.Sprintf("blob(%s)", d)
+			}
+			f.File("Tiltfile", fmt.Sprintf(`
+load('assert.tilt', 'assert')
+
+observed = decode_dotenv(%s)
+assert.equals({'A': '1', 'B': 'two'}, observed)
+`, d))
+
+			_, err := f.ExecFile("Tiltfile")
+			if err != nil {
+				fmt.Println(f.PrintOutput())
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDecodeDotenvUnterminatedQuote(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `decode_dotenv('A="foo\nB=bar\n')`)
+
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error parsing dotenv: line 1: unterminated double-quoted value for A")
+}
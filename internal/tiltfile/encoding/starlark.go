@@ -13,6 +13,8 @@ func ConvertStructuredDataToStarlark(j interface{}) (starlark.Value, error) {
 		return starlark.Bool(j), nil
 	case string:
 		return starlark.String(j), nil
+	case int64:
+		return starlark.MakeInt64(j), nil
 	case float64:
 		return starlark.Float(j), nil
 	case []interface{}:
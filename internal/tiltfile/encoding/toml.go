@@ -0,0 +1,167 @@
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"go.starlark.net/starlark"
+
+	tiltfile_io "github.com/tilt-dev/tilt/internal/tiltfile/io"
+	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
+	"github.com/tilt-dev/tilt/internal/tiltfile/value"
+)
+
+// reads toml from a file
+func readTOML(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var path starlark.String
+	var defaultValue starlark.Value
+	if err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs, "paths", &path, "default?", &defaultValue); err != nil {
+		return nil, err
+	}
+
+	localPath, err := value.ValueToAbsPath(thread, path)
+	if err != nil {
+		return nil, fmt.Errorf("Argument 0 (paths): %v", err)
+	}
+
+	contents, err := tiltfile_io.ReadFile(thread, localPath)
+	if err != nil {
+		// Return the default value if the file doesn't exist AND a default value was given
+		if os.IsNotExist(err) && defaultValue != nil {
+			return defaultValue, nil
+		}
+		return nil, err
+	}
+
+	return tomlStringToStarlark(string(contents), path.GoString())
+}
+
+// reads toml from a string
+func decodeTOML(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var contents value.Stringable
+	if err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs, "toml", &contents); err != nil {
+		return nil, err
+	}
+
+	return tomlStringToStarlark(contents.Value, "")
+}
+
+func tomlStringToStarlark(s string, source string) (starlark.Value, error) {
+	var decodedTOML map[string]interface{}
+	_, err := toml.Decode(s, &decodedTOML)
+	if err != nil {
+		errmsg := "error parsing TOML"
+		if source != "" {
+			errmsg += fmt.Sprintf(" from %s", source)
+		}
+		return nil, errors.Wrap(err, errmsg)
+	}
+
+	v, err := ConvertStructuredDataToStarlark(fromTOMLValue(decodedTOML))
+	if err != nil {
+		errmsg := "error converting TOML to Starlark"
+		if source != "" {
+			errmsg += fmt.Sprintf(" from %s", source)
+		}
+		return nil, errors.Wrap(err, errmsg)
+	}
+	return v, nil
+}
+
+func encodeTOML(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var obj *starlark.Dict
+	if err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs, "obj", &obj); err != nil {
+		return nil, err
+	}
+
+	ret, err := starlarkToTOMLString(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return starlark.String(ret), nil
+}
+
+func starlarkToTOMLString(obj *starlark.Dict) (string, error) {
+	v, err := convertStarlarkToStructuredData(obj)
+	if err != nil {
+		return "", errors.Wrap(err, "error converting object from starlark")
+	}
+
+	err = checkTOMLValue(nil, v)
+	if err != nil {
+		return "", errors.Wrap(err, "error serializing object to toml")
+	}
+
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	err = enc.Encode(v)
+	if err != nil {
+		return "", errors.Wrap(err, "error serializing object to toml")
+	}
+
+	return buf.String(), nil
+}
+
+// Starlark has no date or time types, so TOML dates and times are
+// converted to strings in their TOML (RFC 3339) format.
+func fromTOMLValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = fromTOMLValue(e)
+		}
+		return v
+	case []map[string]interface{}:
+		ret := make([]interface{}, len(v))
+		for i, e := range v {
+			ret[i] = fromTOMLValue(e)
+		}
+		return ret
+	case []interface{}:
+		for i, e := range v {
+			v[i] = fromTOMLValue(e)
+		}
+		return v
+	case time.Time:
+		switch v.Location().String() {
+		case "datetime-local":
+			return v.Format("2006-01-02T15:04:05.999999999")
+		case "date-local":
+			return v.Format("2006-01-02")
+		case "time-local":
+			return v.Format("15:04:05.999999999")
+		}
+		return v.Format(time.RFC3339Nano)
+	}
+	return v
+}
+
+// The TOML encoder silently drops nil values, so check for them up front.
+func checkTOMLValue(path []string, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		return fmt.Errorf("toml: cannot encode None at key %s: TOML has no null value", strings.Join(path, "."))
+	case map[string]interface{}:
+		for k, e := range v {
+			err := checkTOMLValue(append(path, k), e)
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			err := checkTOMLValue(path, e)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
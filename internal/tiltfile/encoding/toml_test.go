@@ -0,0 +1,223 @@
+package encoding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/internal/testutils"
+	"github.com/tilt-dev/tilt/internal/tiltfile/io"
+)
+
+func TestReadTOML(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Cargo.toml", `
+[package]
+name = "hello"
+version = "0.1.0"
+edition = 2018
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+`)
+	f.File("Tiltfile", `
+load('assert.tilt', 'assert')
+
+result = read_toml("Cargo.toml")
+
+expected = {
+  'package': {
+    'name': 'hello',
+    'version': '0.1.0',
+    'edition': 2018,
+  },
+  'dependencies': {
+    'serde': {'version': '1.0', 'features': ['derive']},
+  },
+}
+
+assert.equals(expected, result)
+assert.equals('int', type(result['package']['edition']))
+`)
+
+	result, err := f.ExecFile("Tiltfile")
+	if err != nil {
+		fmt.Println(f.PrintOutput())
+	}
+	require.NoError(t, err)
+
+	rs, err := io.GetState(result)
+	require.NoError(t, err)
+	require.Contains(t, rs.Paths, f.JoinPath("Cargo.toml"))
+}
+
+func TestTOMLDoesNotExist(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `result = read_toml("dne.toml")`)
+	result, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dne.toml")
+	testutils.AssertIsNotExist(t, err)
+
+	rs, err := io.GetState(result)
+	require.NoError(t, err)
+	require.Contains(t, rs.Paths, f.JoinPath("dne.toml"))
+}
+
+func TestReadTOMLDefaultValue(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+load('assert.tilt', 'assert')
+
+result = read_toml("dne.toml", default={'tool': {}})
+assert.equals({'tool': {}}, result)
+`)
+
+	_, err := f.ExecFile("Tiltfile")
+	if err != nil {
+		fmt.Println(f.PrintOutput())
+	}
+	require.NoError(t, err)
+}
+
+func TestMalformedTOML(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("pyproject.toml", `
+[tool.poetry]
+name = "foo"
+name = "bar"
+`)
+
+	f.File("Tiltfile", `result = read_toml("pyproject.toml")`)
+	result, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error parsing TOML from pyproject.toml: toml: line 4 (last key \"tool.poetry.name\"): Key 'tool.poetry.name' has already been defined.")
+
+	rs, err := io.GetState(result)
+	require.NoError(t, err)
+	require.Contains(t, rs.Paths, f.JoinPath("pyproject.toml"))
+}
+
+func TestDecodeTOML(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	for _, blob := range []bool{false, true} {
+		t.Run(fmt.Sprintf("blob: %v", blob), func(t *testing.T) {
+			d := `'[[servers]]\nname = "alpha"\nports = [80, 443]\n'`
+			if blob {
+				d = fmt.Sprintf("blob(%s)", d)
+			}
+			f.File("Tiltfile", fmt.Sprintf(`
+load('assert.tilt', 'assert')
+
+observed = decode_toml(%s)
+assert.equals({'servers': [{'name': 'alpha', 'ports': [80, 443]}]}, observed)
+`, d))
+
+			_, err := f.ExecFile("Tiltfile")
+			if err != nil {
+				fmt.Println(f.PrintOutput())
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDecodeTOMLDateTimes(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+load('assert.tilt', 'assert')
+
+observed = decode_toml("""
+odt = 1979-05-27T00:32:00.999999-07:00
+ldt = 1979-05-27T07:32:00
+ld = 1979-05-27
+lt = 07:32:00
+
+[[releases]]
+date = 2021-03-04
+""")
+assert.equals({
+  'odt': '1979-05-27T00:32:00.999999-07:00',
+  'ldt': '1979-05-27T07:32:00',
+  'ld': '1979-05-27',
+  'lt': '07:32:00',
+  'releases': [{'date': '2021-03-04'}],
+}, observed)
+`)
+
+	_, err := f.ExecFile("Tiltfile")
+	if err != nil {
+		fmt.Println(f.PrintOutput())
+	}
+	require.NoError(t, err)
+}
+
+func TestEncodeTOML(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+load('assert.tilt', 'assert')
+
+expected = '''name = "foo"
+version = 2
+
+[settings]
+debug = true
+level = "info"
+'''
+observed = encode_toml({
+  'name': 'foo',
+  'version': 2,
+  'settings': {'debug': True, 'level': 'info'},
+})
+
+assert.equals(expected, observed)
+assert.equals(decode_toml(observed), {
+  'name': 'foo',
+  'version': 2,
+  'settings': {'debug': True, 'level': 'info'},
+})
+`)
+
+	_, err := f.ExecFile("Tiltfile")
+	if err != nil {
+		fmt.Println(f.PrintOutput())
+	}
+	require.NoError(t, err)
+}
+
+func TestEncodeTOMLNone(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `encode_toml({'a': None})`)
+
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot encode None at key a")
+}
+
+func TestEncodeTOMLNotADict(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `encode_toml(['a'])`)
+
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "for parameter obj: got list, want dict")
+}
@@ -0,0 +1,313 @@
+package io
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tilt-dev/wmclient/pkg/dirs"
+	"go.starlark.net/starlark"
+
+	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
+	"github.com/tilt-dev/tilt/pkg/logger"
+)
+
+const downloadN = "download"
+const httpGetN = "http_get"
+
+const downloadTimeout = 2 * time.Minute
+
+// How often we log how much of a download we've fetched so far.
+const downloadProgressInterval = time.Second
+
+var sha256RE = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Implements the download() and http_get() builtins.
+//
+// Downloads are stored in a content-addressed cache:
+//
+//	<cache>/sha256/<digest>/<filename>  the downloaded content
+//	<cache>/urls/<sha256 of url>        the digest last downloaded from that url
+//
+// A pinned download that's already in the cache never hits the network.
+// An unpinned download always re-fetches, but falls back to the last
+// cached copy if the fetch fails (e.g., when offline).
+type DownloadExtension struct {
+	// If empty, defaults to the downloads dir under ~/.tilt-dev
+	cacheDir string
+	client   *http.Client
+}
+
+func NewDownloadExtension() DownloadExtension {
+	return DownloadExtension{client: &http.Client{Timeout: downloadTimeout}}
+}
+
+func NewDownloadExtensionAt(cacheDir string, client *http.Client) DownloadExtension {
+	return DownloadExtension{cacheDir: cacheDir, client: client}
+}
+
+func (e DownloadExtension) OnStart(env *starkit.Environment) error {
+	err := env.AddBuiltin(downloadN, e.download)
+	if err != nil {
+		return err
+	}
+
+	return env.AddBuiltin(httpGetN, e.httpGet)
+}
+
+// Downloads a url and returns the path to the cached file.
+func (e DownloadExtension) download(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var u, checksum string
+	err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs, "url", &u, "sha256?", &checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := e.fetch(thread, fn.Name(), u, checksum)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.String(p), nil
+}
+
+// Downloads a url and returns its contents as a blob.
+func (e DownloadExtension) httpGet(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var u, checksum string
+	err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs, "url", &u, "sha256?", &checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := e.fetch(thread, fn.Name(), u, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", fn.Name())
+	}
+	return NewBlob(string(bs), fmt.Sprintf("url: %s", u)), nil
+}
+
+func (e DownloadExtension) fetch(thread *starlark.Thread, fnName, rawURL, checksum string) (string, error) {
+	ctx, err := starkit.ContextFromThread(thread)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("%s: invalid url %q: must be an http or https url", fnName, rawURL)
+	}
+
+	checksum = strings.TrimPrefix(strings.ToLower(checksum), "sha256:")
+	if checksum != "" && !sha256RE.MatchString(checksum) {
+		return "", fmt.Errorf("%s: invalid sha256 %q: must be 64 hex characters", fnName, checksum)
+	}
+
+	cacheDir, err := e.resolveCacheDir()
+	if err != nil {
+		return "", errors.Wrapf(err, "%s: finding download cache", fnName)
+	}
+
+	c := downloadCache{dir: cacheDir}
+	filename := downloadFilename(u)
+
+	// Each download gets its own progress ID, so that interactive views can
+	// update one line in place. Non-interactive output only prints the
+	// progress lines every so often, and always prints the last one.
+	l := logger.Get(ctx).WithFields(logger.Fields{logger.FieldNameProgressID: fmt.Sprintf("%s:%s", fnName, rawURL)})
+	done := l.WithFields(logger.Fields{logger.FieldNameProgressMustPrint: "1"})
+
+	if checksum != "" {
+		p := c.contentPath(checksum, filename)
+		if _, err := os.Stat(p); err == nil {
+			done.Infof("%s: using cached copy of %s (sha256:%s)", fnName, rawURL, checksum)
+			return p, nil
+		}
+	}
+
+	l.Infof("%s: downloading %s", fnName, rawURL)
+	start := time.Now()
+	progress := &downloadProgress{
+		logger: l,
+		prefix: fmt.Sprintf("%s: downloading %s", fnName, rawURL),
+		last:   start,
+	}
+	digest, size, err := e.get(ctx, c, rawURL, filename, checksum, progress)
+	if err != nil {
+		cached, ok := c.lastDownload(rawURL, filename)
+		if ok && (checksum == "" || checksum == cached) {
+			done.Warnf("%s: downloading %s: %v\nUsing cached copy (sha256:%s)", fnName, rawURL, err, cached)
+			return c.contentPath(cached, filename), nil
+		}
+		return "", errors.Wrapf(err, "%s %s", fnName, rawURL)
+	}
+
+	done.Infof("%s: downloaded %s in %s (%d bytes, sha256:%s)",
+		fnName, rawURL, time.Since(start).Truncate(time.Millisecond), size, digest)
+	return c.contentPath(digest, filename), nil
+}
+
+// Fetches a url into the cache, and returns the digest of its contents.
+//
+// If a checksum is given, the contents must match it, or else they're
+// discarded without touching the cache.
+func (e DownloadExtension) get(ctx context.Context, c downloadCache, rawURL, filename, checksum string, progress io.Writer) (string, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", 0, err
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	err = os.MkdirAll(c.dir, 0700)
+	if err != nil {
+		return "", 0, err
+	}
+
+	tmp, err := ioutil.TempFile(c.dir, "download-")
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h, progress), resp.Body)
+	closeErr := tmp.Close()
+	if err != nil {
+		return "", 0, err
+	}
+	if closeErr != nil {
+		return "", 0, closeErr
+	}
+
+	digest := hex.EncodeToString(h.Sum(nil))
+	if checksum != "" && checksum != digest {
+		return "", 0, fmt.Errorf("sha256 mismatch: expected %s, got %s", checksum, digest)
+	}
+
+	err = c.store(rawURL, digest, filename, tmp.Name())
+	if err != nil {
+		return "", 0, errors.Wrap(err, "writing download cache")
+	}
+	return digest, size, nil
+}
+
+// Logs how many bytes we've downloaded, at most once every downloadProgressInterval.
+type downloadProgress struct {
+	logger logger.Logger
+	prefix string
+	bytes  int64
+	last   time.Time
+}
+
+func (p *downloadProgress) Write(b []byte) (int, error) {
+	p.bytes += int64(len(b))
+	if now := time.Now(); now.Sub(p.last) >= downloadProgressInterval {
+		p.logger.Infof("%s (%d bytes so far)", p.prefix, p.bytes)
+		p.last = now
+	}
+	return len(b), nil
+}
+
+func (e DownloadExtension) resolveCacheDir() (string, error) {
+	if e.cacheDir != "" {
+		return e.cacheDir, nil
+	}
+
+	dir, err := dirs.UseTiltDevDir()
+	if err != nil {
+		return "", err
+	}
+	return dir.Abs("downloads")
+}
+
+type downloadCache struct {
+	dir string
+}
+
+func (c downloadCache) contentPath(digest, filename string) string {
+	return filepath.Join(c.dir, "sha256", digest, filename)
+}
+
+func (c downloadCache) urlIndexPath(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(c.dir, "urls", hex.EncodeToString(sum[:]))
+}
+
+// Moves a downloaded file into the cache, and records it as the latest
+// content for its url.
+func (c downloadCache) store(rawURL, digest, filename, tmpPath string) error {
+	p := c.contentPath(digest, filename)
+	err := os.MkdirAll(filepath.Dir(p), 0700)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmpPath, p)
+	if err != nil {
+		return err
+	}
+
+	indexPath := c.urlIndexPath(rawURL)
+	err = os.MkdirAll(filepath.Dir(indexPath), 0700)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(indexPath, []byte(digest), 0600)
+}
+
+// The digest of the last successful download of a url, if it's still in the cache.
+func (c downloadCache) lastDownload(rawURL, filename string) (string, bool) {
+	bs, err := ioutil.ReadFile(c.urlIndexPath(rawURL))
+	if err != nil {
+		return "", false
+	}
+
+	digest := strings.TrimSpace(string(bs))
+	if !sha256RE.MatchString(digest) {
+		return "", false
+	}
+
+	_, err = os.Stat(c.contentPath(digest, filename))
+	if err != nil {
+		return "", false
+	}
+	return digest, true
+}
+
+// Keep the last path element of the url, so that tools that care about
+// file extensions (e.g., helm with .tgz charts) still work.
+func downloadFilename(u *url.URL) string {
+	base := path.Base(u.Path)
+	if base == "" || base == "." || base == "/" {
+		return "download"
+	}
+	return base
+}
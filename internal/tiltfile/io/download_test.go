@@ -0,0 +1,235 @@
+package io
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
+	"github.com/tilt-dev/tilt/internal/tiltfile/starlarkstruct"
+	"github.com/tilt-dev/tilt/pkg/logger"
+)
+
+const crdContents = "kind: CustomResourceDefinition\n"
+
+var crdSHA = sha256Hex(crdContents)
+
+func TestDownload(t *testing.T) {
+	f := newDownloadFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", fmt.Sprintf(`
+load('assert.tilt', 'assert')
+
+p = download('%s/crds/crds.yaml')
+assert.equals(%q, str(read_file(p)))
+`, f.server.URL, crdContents))
+
+	_, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+	assert.Equal(t, 1, f.requests)
+	assert.FileExists(t, filepath.Join(f.cacheDir, "sha256", crdSHA, "crds.yaml"))
+	assert.Contains(t, f.logs.String(), fmt.Sprintf("download: downloaded %s/crds/crds.yaml", f.server.URL))
+	assert.Contains(t, f.logs.String(), crdSHA)
+}
+
+func TestDownloadProgressID(t *testing.T) {
+	f := newDownloadFixture(t)
+	defer f.TearDown()
+
+	url := fmt.Sprintf("%s/crds/crds.yaml", f.server.URL)
+	f.File("Tiltfile", fmt.Sprintf("download('%s')", url))
+
+	_, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+
+	require.Len(t, f.fields, 2)
+	for _, fields := range f.fields {
+		assert.Equal(t, "download:"+url, fields[logger.FieldNameProgressID])
+	}
+	assert.Equal(t, "", f.fields[0][logger.FieldNameProgressMustPrint])
+	assert.Equal(t, "1", f.fields[1][logger.FieldNameProgressMustPrint])
+}
+
+func TestHTTPGet(t *testing.T) {
+	f := newDownloadFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", fmt.Sprintf(`
+load('assert.tilt', 'assert')
+
+b = http_get('%s/crds/crds.yaml', sha256='%s')
+assert.equals('blob', type(b))
+assert.equals(%q, str(b))
+`, f.server.URL, crdSHA, crdContents))
+
+	_, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+}
+
+func TestDownloadPinnedUsesCache(t *testing.T) {
+	f := newDownloadFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", fmt.Sprintf(`
+download('%s/crds/crds.yaml', sha256='sha256:%s')
+`, f.server.URL, crdSHA))
+
+	_, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+	_, err = f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, f.requests)
+	assert.Contains(t, f.logs.String(), "using cached copy")
+}
+
+func TestDownloadChecksumMismatch(t *testing.T) {
+	f := newDownloadFixture(t)
+	defer f.TearDown()
+
+	wrongSHA := sha256Hex("something else")
+	f.File("Tiltfile", fmt.Sprintf(`
+download('%s/crds/crds.yaml', sha256='%s')
+`, f.server.URL, wrongSHA))
+
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("sha256 mismatch: expected %s, got %s", wrongSHA, crdSHA))
+
+	// The mismatched content shouldn't be cached or indexed.
+	assert.NoDirExists(t, filepath.Join(f.cacheDir, "sha256", crdSHA))
+	assert.NoDirExists(t, filepath.Join(f.cacheDir, "urls"))
+}
+
+func TestDownloadOfflineUsesLastDownload(t *testing.T) {
+	f := newDownloadFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", fmt.Sprintf(`
+load('assert.tilt', 'assert')
+
+p = download('%s/crds/crds.yaml')
+assert.equals(%q, str(read_file(p)))
+`, f.server.URL, crdContents))
+
+	_, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+
+	f.server.Close()
+
+	_, err = f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+	assert.Contains(t, f.logs.String(), fmt.Sprintf("Using cached copy (sha256:%s)", crdSHA))
+}
+
+func TestDownloadOfflineNoCache(t *testing.T) {
+	f := newDownloadFixture(t)
+	defer f.TearDown()
+
+	url := f.server.URL
+	f.server.Close()
+
+	f.File("Tiltfile", fmt.Sprintf(`
+download('%s/crds/crds.yaml')
+`, url))
+
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("download %s/crds/crds.yaml", url))
+}
+
+func TestDownloadNotFound(t *testing.T) {
+	f := newDownloadFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", fmt.Sprintf(`
+download('%s/dne.yaml')
+`, f.server.URL))
+
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status: 404 Not Found")
+}
+
+func TestDownloadInvalidArgs(t *testing.T) {
+	f := newDownloadFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `download('ftp://example.com/foo')`)
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid url "ftp://example.com/foo"`)
+
+	f.File("Tiltfile", `download('https://example.com/foo', sha256='abc')`)
+	_, err = f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid sha256 "abc"`)
+}
+
+type downloadFixture struct {
+	*starkit.Fixture
+	server   *httptest.Server
+	cacheDir string
+	requests int
+	logs     *bytes.Buffer
+	fields   []logger.Fields
+}
+
+func newDownloadFixture(t *testing.T) *downloadFixture {
+	f := &downloadFixture{logs: bytes.NewBuffer(nil)}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.requests++
+		if r.URL.Path != "/crds/crds.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(crdContents))
+	}))
+
+	cacheDir, err := ioutil.TempDir("", "tilt-downloads")
+	require.NoError(t, err)
+	f.cacheDir = cacheDir
+
+	f.Fixture = starkit.NewFixture(t,
+		NewExtension(),
+		NewDownloadExtensionAt(cacheDir, f.server.Client()),
+		starlarkstruct.NewExtension())
+	l := logger.NewFuncLogger(false, logger.InfoLvl, func(level logger.Level, fields logger.Fields, b []byte) error {
+		f.fields = append(f.fields, fields)
+		_, err := f.logs.Write(b)
+		return err
+	})
+	f.SetContext(logger.WithLogger(context.Background(), l))
+	f.UseRealFS()
+	f.File("assert.tilt", `
+def equals(expected, observed):
+	if expected != observed:
+		fail("expected: '%s'. observed: '%s'" % (expected, observed))
+
+assert = struct(equals=equals)
+`)
+	return f
+}
+
+func (f *downloadFixture) TearDown() {
+	f.server.Close()
+	_ = os.RemoveAll(f.cacheDir)
+	f.Fixture.TearDown()
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
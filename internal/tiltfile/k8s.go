@@ -13,6 +13,7 @@ import (
 	"go.starlark.net/syntax"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/tilt-dev/tilt/internal/tiltfile/links"
 
@@ -20,6 +21,8 @@ import (
 	"github.com/tilt-dev/tilt/internal/k8s"
 	"github.com/tilt-dev/tilt/internal/tiltfile/io"
 	tiltfile_k8s "github.com/tilt-dev/tilt/internal/tiltfile/k8s"
+	"github.com/tilt-dev/tilt/internal/tiltfile/k8scontext"
+	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
 	"github.com/tilt-dev/tilt/internal/tiltfile/value"
 	"github.com/tilt-dev/tilt/pkg/model"
 )
@@ -61,6 +64,26 @@ type k8sResource struct {
 	manuallyGrouped bool
 
 	links []model.Link
+
+	keepOnRemoval bool
+
+	// Set by k8s_resource(context=...). Overrides the contexts from k8s_yaml().
+	kubeContext         k8s.KubeContext
+	kubeContextOverride bool
+
+	// Set by k8s_resource(namespace=...). Overrides the namespace of all
+	// namespaced objects in the resource.
+	namespace k8s.Namespace
+
+	// Set by k8s_resource(log_format=..., log_fields=...).
+	logFormat model.LogFormat
+	logFields map[string]string
+
+	// Set by k8s_resource(log_limit=...).
+	logLimit model.LogLimit
+
+	// Set by k8s_resource(alert_patterns=..., fatal_alert_patterns=...).
+	logAlerts model.LogAlerts
 }
 
 // holds options passed to `k8s_resource` until assembly happens
@@ -77,6 +100,17 @@ type k8sResourceOptions struct {
 	manuallyGrouped   bool
 	podReadinessMode  model.PodReadinessMode
 	links             []model.Link
+	keepOnRemoval     bool
+
+	kubeContext         k8s.KubeContext
+	kubeContextOverride bool
+
+	namespace k8s.Namespace
+
+	logFormat model.LogFormat
+	logFields map[string]string
+	logLimit  model.LogLimit
+	logAlerts model.LogAlerts
 }
 
 func (r *k8sResource) addEntities(entities []k8s.K8sEntity,
@@ -103,13 +137,27 @@ func (r *k8sResource) addEntities(entities []k8s.K8sEntity,
 func (s *tiltfileState) k8sYaml(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 	var yamlValue starlark.Value
 	var allowDuplicates bool
+	var kubeContextVal string
+	var namespaceVal string
 
 	if err := s.unpackArgs(fn.Name(), args, kwargs,
 		"yaml", &yamlValue,
 		"allow_duplicates?", &allowDuplicates,
+		"context?", &kubeContextVal,
+		"namespace?", &namespaceVal,
 	); err != nil {
 		return nil, err
 	}
+
+	kubeContext, err := s.kubeContextArg(thread, fn.Name(), kubeContextVal)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := namespaceArg(fn.Name(), namespaceVal)
+	if err != nil {
+		return nil, err
+	}
 	//normalize the starlark value into a slice
 	value := starlarkValueOrSequenceToSlice(yamlValue)
 
@@ -127,7 +175,11 @@ func (s *tiltfileState) k8sYaml(thread *starlark.Thread, fn *starlark.Builtin, a
 		if len(entities) == 0 && val == "" {
 			return nil, emptyYAMLError
 		}
-		err = s.k8sObjectIndex.Append(thread, entities, allowDuplicates)
+
+		if namespace != "" {
+			setNamespace(entities, namespace)
+		}
+		err = s.k8sObjectIndex.Append(thread, entities, kubeContext, allowDuplicates)
 		if err != nil {
 			return nil, err
 		}
@@ -263,6 +315,14 @@ func (s *tiltfileState) k8sResource(thread *starlark.Thread, fn *starlark.Builti
 	var objectsVal starlark.Sequence
 	var podReadinessMode tiltfile_k8s.PodReadinessMode
 	var links links.LinkList
+	var keepOnRemoval bool
+	var kubeContextVal string
+	var namespaceVal string
+	var logFormatVal string
+	var logFields value.StringStringMap
+	var logLimitVal starlark.Value
+	var alertPatterns value.StringOrStringList
+	var fatalAlertPatterns value.StringOrStringList
 	autoInit := true
 
 	if err := s.unpackArgs(fn.Name(), args, kwargs,
@@ -276,10 +336,28 @@ func (s *tiltfileState) k8sResource(thread *starlark.Thread, fn *starlark.Builti
 		"auto_init?", &autoInit,
 		"pod_readiness?", &podReadinessMode,
 		"links?", &links,
+		"keep_on_removal?", &keepOnRemoval,
+		"context?", &kubeContextVal,
+		"namespace?", &namespaceVal,
+		"log_format?", &logFormatVal,
+		"log_fields?", &logFields,
+		"log_limit?", &logLimitVal,
+		"alert_patterns?", &alertPatterns,
+		"fatal_alert_patterns?", &fatalAlertPatterns,
 	); err != nil {
 		return nil, err
 	}
 
+	kubeContext, err := s.kubeContextArg(thread, fn.Name(), kubeContextVal)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := namespaceArg(fn.Name(), namespaceVal)
+	if err != nil {
+		return nil, err
+	}
+
 	resourceName := workload.String()
 	manuallyGrouped := false
 	if workload == "" {
@@ -293,6 +371,21 @@ func (s *tiltfileState) k8sResource(thread *starlark.Thread, fn *starlark.Builti
 		return nil, errors.Wrapf(err, "%s %q", fn.Name(), resourceName)
 	}
 
+	logFormat, err := logFormatArg(logFormatVal, logFields.AsMap())
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %q", fn.Name(), resourceName)
+	}
+
+	logLimit, err := logLimitArg(logLimitVal)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %q", fn.Name(), resourceName)
+	}
+
+	logAlerts, err := logAlertsArg(alertPatterns.Values, fatalAlertPatterns.Values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %q", fn.Name(), resourceName)
+	}
+
 	extraPodSelectors, err := podLabelsFromStarlarkValue(extraPodSelectorsVal)
 	if err != nil {
 		return nil, err
@@ -323,22 +416,271 @@ func (s *tiltfileState) k8sResource(thread *starlark.Thread, fn *starlark.Builti
 	// NOTE(nick): right now this overwrites all previously set options on this
 	// resource. Is it worthwhile to make this additive?
 	s.k8sResourceOptions[resourceName] = k8sResourceOptions{
-		newName:           string(newName),
-		portForwards:      portForwards,
-		extraPodSelectors: extraPodSelectors,
-		tiltfilePosition:  thread.CallFrame(1).Pos,
-		triggerMode:       triggerMode,
-		autoInit:          autoInit,
-		resourceDeps:      resourceDeps,
-		objects:           objects,
-		manuallyGrouped:   manuallyGrouped,
-		podReadinessMode:  podReadinessMode.Value,
-		links:             links.Links,
+		newName:             string(newName),
+		portForwards:        portForwards,
+		extraPodSelectors:   extraPodSelectors,
+		tiltfilePosition:    thread.CallFrame(1).Pos,
+		triggerMode:         triggerMode,
+		autoInit:            autoInit,
+		resourceDeps:        resourceDeps,
+		objects:             objects,
+		manuallyGrouped:     manuallyGrouped,
+		podReadinessMode:    podReadinessMode.Value,
+		links:               links.Links,
+		keepOnRemoval:       keepOnRemoval,
+		kubeContext:         kubeContext,
+		kubeContextOverride: kubeContextVal != "",
+		namespace:           namespace,
+		logFormat:           logFormat,
+		logFields:           logFields.AsMap(),
+		logLimit:            logLimit,
+		logAlerts:           logAlerts,
+	}
+
+	return starlark.None, nil
+}
+
+// Validates the log_format and log_fields passed to k8s_resource().
+func logFormatArg(logFormat string, logFields map[string]string) (model.LogFormat, error) {
+	switch model.LogFormat(logFormat) {
+	case model.LogFormatText:
+		if len(logFields) > 0 {
+			return "", fmt.Errorf("log_fields only applies to structured logs. Did you mean to set log_format='%s'?", model.LogFormatJSON)
+		}
+		return model.LogFormatText, nil
+	case model.LogFormatJSON:
+		for name := range logFields {
+			if name != model.LogFieldLevel && name != model.LogFieldMsg {
+				return "", fmt.Errorf("log_fields: unknown field %q. Allowed: {%s, %s}", name, model.LogFieldLevel, model.LogFieldMsg)
+			}
+		}
+		return model.LogFormatJSON, nil
+	}
+	return "", fmt.Errorf("log_format: invalid value %q. Allowed: {%s}", logFormat, model.LogFormatJSON)
+}
+
+// Validates the alert_patterns and fatal_alert_patterns passed to
+// k8s_resource(), which must be Go regular expressions.
+func logAlertsArg(patterns []string, fatalPatterns []string) (model.LogAlerts, error) {
+	for _, p := range patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return model.LogAlerts{}, fmt.Errorf("alert_patterns: invalid pattern %q: %v", p, err)
+		}
+	}
+	for _, p := range fatalPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return model.LogAlerts{}, fmt.Errorf("fatal_alert_patterns: invalid pattern %q: %v", p, err)
+		}
+	}
+	return model.LogAlerts{Patterns: patterns, FatalPatterns: fatalPatterns}, nil
+}
+
+// Parses the log_limit passed to k8s_resource(), a dict like
+// {'bytes': 1000000, 'lines': 10000, 'lines_per_second': 100}.
+//
+// Each key is optional, and a missing key means no limit.
+func logLimitArg(v starlark.Value) (model.LogLimit, error) {
+	var limit model.LogLimit
+	if v == nil || v == starlark.None {
+		return limit, nil
+	}
+
+	d, ok := v.(*starlark.Dict)
+	if !ok {
+		return limit, fmt.Errorf("log_limit: expected dict, got %s", v.Type())
+	}
+
+	for _, item := range d.Items() {
+		key, ok := value.AsString(item[0])
+		if !ok {
+			return limit, fmt.Errorf("log_limit: key is not a string: %s", item[0])
+		}
+
+		n, err := starlark.AsInt32(item[1])
+		if err != nil {
+			return limit, fmt.Errorf("log_limit: for key %q, expected int, got %s", key, item[1].Type())
+		}
+		if n < 0 {
+			return limit, fmt.Errorf("log_limit: for key %q, must be >= 0, got %d", key, n)
+		}
+
+		switch key {
+		case "bytes":
+			limit.Bytes = n
+		case "lines":
+			limit.Lines = n
+		case "lines_per_second":
+			limit.LinesPerSecond = n
+		default:
+			return limit, fmt.Errorf("log_limit: unknown key %q. Allowed: {bytes, lines, lines_per_second}", key)
+		}
+	}
+	return limit, nil
+}
+
+// Validates a context passed to k8s_yaml() or k8s_resource().
+//
+// Returns the empty string for the context that Tilt started with,
+// so that resources deployed there look the same as resources without a context.
+func (s *tiltfileState) kubeContextArg(thread *starlark.Thread, fnName string, kubeContext string) (k8s.KubeContext, error) {
+	if kubeContext == "" {
+		return "", nil
+	}
+
+	model, err := starkit.ModelFromThread(thread)
+	if err != nil {
+		return "", err
+	}
+
+	k8sContextState, err := k8scontext.GetState(model)
+	if err != nil {
+		return "", err
+	}
+
+	if k8s.KubeContext(kubeContext) == k8sContextState.KubeContext() {
+		return "", nil
+	}
+
+	err = k8sContextState.ValidateContext(k8s.KubeContext(kubeContext))
+	if err != nil {
+		return "", errors.Wrapf(err, "%s", fnName)
+	}
+	return k8s.KubeContext(kubeContext), nil
+}
+
+// Returns the context that a resource deploys to.
+//
+// Unless k8s_resource() sets a context, all the objects in a resource
+// must have been registered with the same context.
+func (s *tiltfileState) kubeContextForResource(r *k8sResource) (k8s.KubeContext, error) {
+	if r.kubeContextOverride {
+		return r.kubeContext, nil
+	}
+
+	var result k8s.KubeContext
+	for i, e := range r.entities {
+		kubeContext := s.k8sObjectIndex.KubeContextFor(e)
+		if i > 0 && kubeContext != result {
+			return "", fmt.Errorf("resource %q has objects from more than one context (%s and %s). "+
+				"Split them into separate resources, or set k8s_resource(context=...)",
+				r.name, displayKubeContext(result), displayKubeContext(kubeContext))
+		}
+		result = kubeContext
+	}
+	return result, nil
+}
+
+func (s *tiltfileState) defaultNamespaceFn(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if s.defaultNamespace != "" {
+		return nil, fmt.Errorf("%s: default namespace already set to %q", fn.Name(), s.defaultNamespace)
 	}
 
+	var namespaceVal string
+	if err := s.unpackArgs(fn.Name(), args, kwargs,
+		"namespace", &namespaceVal,
+	); err != nil {
+		return nil, err
+	}
+
+	namespace, err := namespaceArg(fn.Name(), namespaceVal)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("%s: namespace must not be empty", fn.Name())
+	}
+
+	s.defaultNamespace = namespace
 	return starlark.None, nil
 }
 
+func namespaceArg(fnName string, namespace string) (k8s.Namespace, error) {
+	if namespace == "" {
+		return "", nil
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", fmt.Errorf("%s: invalid namespace %q: %s", fnName, namespace, strings.Join(errs, ", "))
+	}
+	return k8s.Namespace(namespace), nil
+}
+
+// Puts all namespaced objects that don't specify a namespace into the
+// namespace from default_namespace().
+//
+// Runs before resource assembly, so that object selectors in k8s_resource()
+// see the same namespace that we deploy to.
+func (s *tiltfileState) applyDefaultNamespace() {
+	if s.defaultNamespace == "" {
+		return
+	}
+	for _, e := range s.k8sUnresourced {
+		if !e.IsClusterScoped() && e.NamespaceOrDefault("") == "" {
+			e.SetNamespace(s.defaultNamespace.String())
+		}
+	}
+}
+
+// Moves all namespaced objects into the given namespace, in-place.
+func setNamespace(entities []k8s.K8sEntity, namespace k8s.Namespace) {
+	for _, e := range entities {
+		if !e.IsClusterScoped() {
+			e.SetNamespace(namespace.String())
+		}
+	}
+}
+
+// Namespace objects declared anywhere in the Tiltfile, by name.
+func (s *tiltfileState) declaredNamespaces() map[k8s.Namespace]k8s.K8sEntity {
+	result := make(map[k8s.Namespace]k8s.K8sEntity)
+	add := func(entities []k8s.K8sEntity) {
+		for _, e := range entities {
+			if e.GVK().GroupKind() == k8s.NamespaceGVK.GroupKind() {
+				result[k8s.Namespace(e.Name())] = e
+			}
+		}
+	}
+	for _, r := range s.k8s {
+		add(r.entities)
+	}
+	add(s.k8sUnresourced)
+	return result
+}
+
+// Returns the YAML for namespaces that these entities deploy to, but don't
+// declare themselves. If the namespace is declared elsewhere in the Tiltfile,
+// we use that declaration, so that labels and annotations are preserved.
+func namespaceYAMLFor(entities []k8s.K8sEntity, declared map[k8s.Namespace]k8s.K8sEntity) (string, error) {
+	own := make(map[k8s.Namespace]bool)
+	for _, e := range entities {
+		if e.GVK().GroupKind() == k8s.NamespaceGVK.GroupKind() {
+			own[k8s.Namespace(e.Name())] = true
+		}
+	}
+
+	var namespaces []k8s.K8sEntity
+	for _, ns := range k8s.ReferencedNamespaces(entities) {
+		if own[ns] {
+			continue
+		}
+		if e, ok := declared[ns]; ok {
+			namespaces = append(namespaces, e)
+		} else {
+			namespaces = append(namespaces, k8s.NewNamespaceEntity(ns.String()))
+		}
+	}
+	if len(namespaces) == 0 {
+		return "", nil
+	}
+	return k8s.SerializeSpecYAML(namespaces)
+}
+
+func displayKubeContext(kubeContext k8s.KubeContext) string {
+	if kubeContext == "" {
+		return "the default context"
+	}
+	return fmt.Sprintf("%q", kubeContext)
+}
+
 func selectorFromSkylarkDict(d *starlark.Dict) (labels.Selector, error) {
 	ret := make(labels.Set)
 
@@ -449,16 +791,25 @@ func (s *tiltfileState) k8sKind(thread *starlark.Thread, fn *starlark.Builtin, a
 	var jpLocators tiltfile_k8s.JSONPathImageLocatorListSpec
 	var jpObjectLocator tiltfile_k8s.JSONPathImageObjectLocatorSpec
 	var podReadiness tiltfile_k8s.PodReadinessMode
+	var readyCondition, readyJSONPath string
 	if err := s.unpackArgs(fn.Name(), args, kwargs,
 		"kind", &kind,
 		"image_json_path?", &jpLocators,
 		"api_version?", &apiVersion,
 		"image_object?", &jpObjectLocator,
 		"pod_readiness?", &podReadiness,
+		"ready_condition?", &readyCondition,
+		"ready_jsonpath?", &readyJSONPath,
 	); err != nil {
 		return nil, err
 	}
 
+	if readyJSONPath != "" {
+		if _, err := k8s.NewJSONPath(readyJSONPath); err != nil {
+			return nil, fmt.Errorf("%s: for parameter %q: %v", fn.Name(), "ready_jsonpath", err)
+		}
+	}
+
 	k, err := k8s.NewPartialMatchObjectSelector(apiVersion, kind, "", "")
 	if err != nil {
 		return nil, err
@@ -493,6 +844,11 @@ func (s *tiltfileState) k8sKind(thread *starlark.Thread, fn *starlark.Builtin, a
 		kindInfo.PodReadinessMode = podReadiness.Value
 	}
 
+	if readyCondition != "" || readyJSONPath != "" {
+		kindInfo.ReadyCondition = readyCondition
+		kindInfo.ReadyJSONPath = readyJSONPath
+	}
+
 	return starlark.None, nil
 }
 
@@ -710,7 +1066,7 @@ func convertPortForwards(val starlark.Value) ([]model.PortForward, error) {
 
 func (s *tiltfileState) portForward(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 	var local, container int
-	var name, path, host string
+	var name, path, host, target string
 
 	// TODO: can specify host (see `stringToPortForward` for host validation logic)
 	if err := s.unpackArgs(fn.Name(), args, kwargs,
@@ -718,10 +1074,18 @@ func (s *tiltfileState) portForward(thread *starlark.Thread, fn *starlark.Builti
 		"container_port?", &container,
 		"name?", &name,
 		"link_path?", &path,
-		"host?", &host); err != nil {
+		"host?", &host,
+		"target?", &target); err != nil {
 		return nil, err
 	}
 
+	if target != "" {
+		_, err := k8s.ParsePortForwardTarget(target)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", fn.Name(), err)
+		}
+	}
+
 	var parsedPath *url.URL
 	if path != "" {
 		var err error
@@ -731,10 +1095,76 @@ func (s *tiltfileState) portForward(thread *starlark.Thread, fn *starlark.Builti
 		}
 	}
 	return portForward{
-		model.PortForward{LocalPort: local, ContainerPort: container, Host: host, Name: name}.WithPath(parsedPath),
+		model.PortForward{LocalPort: local, ContainerPort: container, Host: host, Name: name, Target: target}.WithPath(parsedPath),
 	}, nil
 }
 
+func (s *tiltfileState) reversePortForward(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var localPort, servicePort int
+	var serviceName, namespaceVal string
+
+	if err := s.unpackArgs(fn.Name(), args, kwargs,
+		"local_port", &localPort,
+		"service_name", &serviceName,
+		"service_port?", &servicePort,
+		"namespace?", &namespaceVal,
+	); err != nil {
+		return nil, err
+	}
+
+	if localPort <= 0 || localPort > 65535 {
+		return nil, fmt.Errorf("%s: local_port %d is not in the valid range [1-65535]", fn.Name(), localPort)
+	}
+	if errs := validation.IsDNS1035Label(serviceName); len(errs) > 0 {
+		return nil, fmt.Errorf("%s: invalid service_name %q: %s", fn.Name(), serviceName, strings.Join(errs, ", "))
+	}
+	if _, ok := s.reverseForwards[serviceName]; ok {
+		return nil, fmt.Errorf("%s: service %q already has a reverse port-forward", fn.Name(), serviceName)
+	}
+
+	namespace, err := namespaceArg(fn.Name(), namespaceVal)
+	if err != nil {
+		return nil, err
+	}
+
+	if servicePort == 0 {
+		servicePort = localPort
+	}
+
+	entities, err := k8s.NewReverseForwardEntities(serviceName, servicePort)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", fn.Name(), err)
+	}
+	if namespace != "" {
+		setNamespace(entities, namespace)
+	}
+
+	err = s.k8sObjectIndex.Append(thread, entities, "", false)
+	if err != nil {
+		return nil, err
+	}
+	s.k8sUnresourced = append(s.k8sUnresourced, entities...)
+
+	s.reverseForwards[serviceName] = model.ReverseForward{
+		LocalPort:   localPort,
+		ServiceName: serviceName,
+		ServicePort: servicePort,
+	}
+	return starlark.None, nil
+}
+
+// The reverse port-forwards whose relay pods are in the given entities.
+func (s *tiltfileState) reverseForwardsFor(entities []k8s.K8sEntity) []model.ReverseForward {
+	var result []model.ReverseForward
+	for _, e := range entities {
+		name := k8s.ReverseForwardRelayName(e)
+		if rf, ok := s.reverseForwards[name]; ok {
+			result = append(result, rf)
+		}
+	}
+	return result
+}
+
 type portForward struct {
 	model.PortForward
 }
@@ -742,6 +1172,10 @@ type portForward struct {
 var _ starlark.Value = portForward{}
 
 func (f portForward) String() string {
+	if f.Target != "" {
+		return fmt.Sprintf("port_forward(local_port=%d, container_port=%d, name=%q, target=%q)",
+			f.LocalPort, f.ContainerPort, f.Name, f.Target)
+	}
 	return fmt.Sprintf("port_forward(local_port=%d, container_port=%d, name=%q)",
 		f.LocalPort, f.ContainerPort, f.Name)
 }
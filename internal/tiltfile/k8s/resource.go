@@ -8,4 +8,12 @@ import (
 type KindInfo struct {
 	ImageLocators    []k8s.ImageLocator
 	PodReadinessMode model.PodReadinessMode
+
+	// How to tell whether objects of this kind are ready.
+	ReadyCondition string
+	ReadyJSONPath  string
+}
+
+func (k KindInfo) HasReadinessCheck() bool {
+	return k.ReadyCondition != "" || k.ReadyJSONPath != ""
 }
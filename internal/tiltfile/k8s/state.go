@@ -6,6 +6,7 @@ import (
 
 	"go.starlark.net/starlark"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/tilt-dev/tilt/internal/k8s"
 )
@@ -23,27 +24,42 @@ type ObjectSpec struct {
 	// The resource spec
 	Entity k8s.K8sEntity
 
+	// The kubeconfig context to deploy to. Empty for the context that Tilt started with.
+	KubeContext k8s.KubeContext
+
 	// The stack trace where this resource was registered.
 	// Helpful for reporting duplicates.
 	StackTrace string
 }
 
+// Identifies an object in a cluster. Objects with the same name
+// in different clusters are different objects.
+type ObjectKey struct {
+	Ref         v1.ObjectReference
+	KubeContext k8s.KubeContext
+}
+
 // Keeps track of all the Kuberentes objects registered during Tiltfile Execution.
 type State struct {
-	ObjectSpecRefs  []v1.ObjectReference
-	ObjectSpecIndex map[v1.ObjectReference]ObjectSpec
+	ObjectSpecKeys  []ObjectKey
+	ObjectSpecIndex map[ObjectKey]ObjectSpec
+
+	// The context of each object, for looking up the context
+	// after the objects have been grouped into resources.
+	contexts map[runtime.Object]k8s.KubeContext
 }
 
 func NewState() *State {
 	return &State{
-		ObjectSpecIndex: make(map[v1.ObjectReference]ObjectSpec),
+		ObjectSpecIndex: make(map[ObjectKey]ObjectSpec),
+		contexts:        make(map[runtime.Object]k8s.KubeContext),
 	}
 }
 
 func (s *State) Entities() []k8s.K8sEntity {
 	result := make([]k8s.K8sEntity, len(s.ObjectSpecIndex))
-	for i, ref := range s.ObjectSpecRefs {
-		result[i] = s.ObjectSpecIndex[ref].Entity
+	for i, key := range s.ObjectSpecKeys {
+		result[i] = s.ObjectSpecIndex[key].Entity
 	}
 	return result
 }
@@ -52,11 +68,12 @@ func (s *State) EntityCount() int {
 	return len(s.ObjectSpecIndex)
 }
 
-func (s *State) Append(t *starlark.Thread, entities []k8s.K8sEntity, dupesOK bool) error {
+func (s *State) Append(t *starlark.Thread, entities []k8s.K8sEntity, kubeContext k8s.KubeContext, dupesOK bool) error {
 	stackTrace := t.CallStack().String()
 	for _, e := range entities {
 		ref := e.ToObjectReference()
-		old, exists := s.ObjectSpecIndex[ref]
+		key := ObjectKey{Ref: ref, KubeContext: kubeContext}
+		old, exists := s.ObjectSpecIndex[key]
 		if exists && !dupesOK {
 			humanRef := ""
 			if ref.Namespace == "" {
@@ -64,16 +81,28 @@ func (s *State) Append(t *starlark.Thread, entities []k8s.K8sEntity, dupesOK boo
 			} else {
 				humanRef = fmt.Sprintf("%s %s (Namespace: %s)", ref.Kind, ref.Name, ref.Namespace)
 			}
+			if kubeContext != "" {
+				humanRef = fmt.Sprintf("%s (Context: %s)", humanRef, kubeContext)
+			}
 			return DuplicateYAMLDetectedError(humanRef, old.StackTrace)
 		}
 
 		if !exists {
-			s.ObjectSpecRefs = append(s.ObjectSpecRefs, ref)
+			s.ObjectSpecKeys = append(s.ObjectSpecKeys, key)
+		}
+		s.ObjectSpecIndex[key] = ObjectSpec{
+			Entity:      e,
+			KubeContext: kubeContext,
+			StackTrace:  stackTrace,
 		}
-		s.ObjectSpecIndex[ref] = ObjectSpec{
-			Entity:     e,
-			StackTrace: stackTrace,
+		if kubeContext != "" {
+			s.contexts[e.Obj] = kubeContext
 		}
 	}
 	return nil
 }
+
+// Returns the context that the given object was registered with.
+func (s *State) KubeContextFor(e k8s.K8sEntity) k8s.KubeContext {
+	return s.contexts[e.Obj]
+}
@@ -1,9 +1,11 @@
 package k8scontext
 
 import (
+	"context"
 	"fmt"
 
 	"go.starlark.net/starlark"
+	"k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/tilt-dev/tilt/internal/k8s"
 	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
@@ -15,17 +17,19 @@ import (
 type Extension struct {
 	context k8s.KubeContext
 	env     k8s.Env
+	config  *api.Config
 }
 
-func NewExtension(context k8s.KubeContext, env k8s.Env) Extension {
+func NewExtension(context k8s.KubeContext, env k8s.Env, config *api.Config) Extension {
 	return Extension{
 		context: context,
 		env:     env,
+		config:  config,
 	}
 }
 
 func (e Extension) NewState() interface{} {
-	return State{context: e.context, env: e.env}
+	return State{context: e.context, env: e.env, config: e.config}
 }
 
 func (e Extension) OnStart(env *starkit.Environment) error {
@@ -68,6 +72,7 @@ func (e Extension) allowK8sContexts(thread *starlark.Thread, fn *starlark.Builti
 		return State{
 			context: existing.context,
 			env:     existing.env,
+			config:  existing.config,
 			allowed: append(newContexts, existing.allowed...),
 		}
 	})
@@ -80,6 +85,7 @@ var _ starkit.StatefulExtension = &Extension{}
 type State struct {
 	context k8s.KubeContext
 	env     k8s.Env
+	config  *api.Config
 	allowed []k8s.KubeContext
 }
 
@@ -88,12 +94,44 @@ func (s State) KubeContext() k8s.KubeContext {
 }
 
 func (s State) IsAllowed() bool {
-	if s.env == k8s.EnvNone || s.env.IsDevCluster() {
+	return s.isAllowed(s.context, s.env)
+}
+
+// Checks that a context passed to k8s_yaml(context=...) exists in the kubeconfig.
+func (s State) ValidateContext(kubeContext k8s.KubeContext) error {
+	if kubeContext == s.context {
+		return nil
+	}
+	if s.config == nil {
+		return fmt.Errorf("context %q not found: no kubeconfig loaded", kubeContext)
+	}
+	if _, ok := s.config.Contexts[string(kubeContext)]; !ok {
+		return fmt.Errorf("context %q not found in kubeconfig", kubeContext)
+	}
+	return nil
+}
+
+// Whether it's safe to deploy to another context in the kubeconfig.
+// Follows the same rules as the context that Tilt started with.
+func (s State) IsContextAllowed(ctx context.Context, kubeContext k8s.KubeContext) bool {
+	if kubeContext == s.context {
+		return s.IsAllowed()
+	}
+
+	env := k8s.EnvUnknown
+	if s.config != nil {
+		env = k8s.EnvForContext(ctx, s.config, kubeContext)
+	}
+	return s.isAllowed(kubeContext, env)
+}
+
+func (s State) isAllowed(kubeContext k8s.KubeContext, env k8s.Env) bool {
+	if env == k8s.EnvNone || env.IsDevCluster() {
 		return true
 	}
 
 	for _, c := range s.allowed {
-		if c == s.context {
+		if c == kubeContext {
 			return true
 		}
 	}
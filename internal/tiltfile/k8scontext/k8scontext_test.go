@@ -1,9 +1,11 @@
 package k8scontext
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/tilt-dev/tilt/internal/k8s"
 	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
@@ -24,6 +26,29 @@ allow_k8s_contexts('gke-blorg')
 	assert.Equal(t, []k8s.KubeContext{"gke-blorg"}, MustState(model).allowed)
 }
 
+func TestOtherContexts(t *testing.T) {
+	config := api.NewConfig()
+	config.Contexts["gke-blorg"] = &api.Context{Cluster: "gke_blorg-dev_us-central1-b_blorg"}
+	config.Contexts["kind-data"] = &api.Context{Cluster: "kind-data"}
+	config.Contexts["prod"] = &api.Context{Cluster: "prod"}
+
+	f := starkit.NewFixture(t, NewExtension("gke-blorg", k8s.EnvGKE, config))
+	f.File("Tiltfile", `
+allow_k8s_contexts('gke-blorg')
+`)
+	model, err := f.ExecFile("Tiltfile")
+	assert.NoError(t, err)
+
+	state := MustState(model)
+	assert.NoError(t, state.ValidateContext("kind-data"))
+	assert.EqualError(t, state.ValidateContext("missing"), `context "missing" not found in kubeconfig`)
+
+	ctx := context.Background()
+	assert.True(t, state.IsContextAllowed(ctx, "gke-blorg"))
+	assert.True(t, state.IsContextAllowed(ctx, "kind-data"))
+	assert.False(t, state.IsContextAllowed(ctx, "prod"))
+}
+
 func NewFixture(tb testing.TB, ctx k8s.KubeContext, env k8s.Env) *starkit.Fixture {
-	return starkit.NewFixture(tb, NewExtension(ctx, env))
+	return starkit.NewFixture(tb, NewExtension(ctx, env, nil))
 }
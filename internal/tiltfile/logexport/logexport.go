@@ -0,0 +1,74 @@
+package logexport
+
+import (
+	"fmt"
+
+	"go.starlark.net/starlark"
+
+	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+type Extension struct{}
+
+func NewExtension() Extension {
+	return Extension{}
+}
+
+func (e Extension) NewState() interface{} {
+	return model.DefaultLogExportSettings()
+}
+
+func (Extension) OnStart(env *starkit.Environment) error {
+	return env.AddBuiltin("log_export_settings", setLogExportSettings)
+}
+
+// Sets where Tilt exports its logs.
+//
+// If the address changes and insecure isn't passed, logs go over plain HTTP
+// to localhost addresses and over HTTPS to everything else.
+func setLogExportSettings(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	err := starkit.SetState(thread, func(settings model.LogExportSettings) (model.LogExportSettings, error) {
+		var address string
+		var insecure starlark.Value
+		err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs,
+			"enabled?", &settings.Enabled,
+			"address?", &address,
+			"insecure?", &insecure)
+		if err != nil {
+			return model.LogExportSettings{}, err
+		}
+
+		if address != "" {
+			settings.Address = address
+			settings.Insecure = model.IsLocalhostAddress(address)
+		}
+
+		if insecure != nil && insecure != starlark.None {
+			b, ok := insecure.(starlark.Bool)
+			if !ok {
+				return model.LogExportSettings{}, fmt.Errorf("%s: for parameter insecure: got %s, want bool", fn.Name(), insecure.Type())
+			}
+			settings.Insecure = bool(b)
+		}
+		return settings, nil
+	})
+
+	return starlark.None, err
+}
+
+var _ starkit.StatefulExtension = Extension{}
+
+func MustState(model starkit.Model) model.LogExportSettings {
+	state, err := GetState(model)
+	if err != nil {
+		panic(err)
+	}
+	return state
+}
+
+func GetState(m starkit.Model) (model.LogExportSettings, error) {
+	var state model.LogExportSettings
+	err := m.Load(&state)
+	return state, err
+}
@@ -0,0 +1,60 @@
+package logexport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
+)
+
+func TestLogExportEnabled(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+	f.File("Tiltfile", "log_export_settings(enabled=True)")
+	result, err := f.ExecFile("Tiltfile")
+
+	assert.NoError(t, err)
+	assert.True(t, MustState(result).Enabled)
+	assert.Equal(t, "localhost:4318", MustState(result).Address)
+	assert.True(t, MustState(result).Insecure)
+}
+
+func TestLogExportRemoteAddressDefaultsToHTTPS(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", "log_export_settings(enabled=True, address='otel.example.com:4318')")
+	result, err := f.ExecFile("Tiltfile")
+	assert.NoError(t, err)
+	assert.False(t, MustState(result).Insecure)
+}
+
+func TestLogExportLocalhostHTTPS(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", "log_export_settings(enabled=True, address='127.0.0.1:4318', insecure=False)")
+	result, err := f.ExecFile("Tiltfile")
+	assert.NoError(t, err)
+	assert.False(t, MustState(result).Insecure)
+}
+
+func TestLogExportAddress(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+log_export_settings(enabled=True)
+log_export_settings(address='otel-collector:4318', insecure=True)
+`)
+	result, err := f.ExecFile("Tiltfile")
+	assert.NoError(t, err)
+	assert.True(t, MustState(result).Enabled)
+	assert.Equal(t, "otel-collector:4318", MustState(result).Address)
+	assert.True(t, MustState(result).Insecure)
+}
+
+func newFixture(tb testing.TB) *starkit.Fixture {
+	return starkit.NewFixture(tb, NewExtension())
+}
@@ -0,0 +1,243 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.starlark.net/starlark"
+
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/tiltfile/io"
+	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
+	"github.com/tilt-dev/tilt/internal/tiltfile/value"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// A secret value, read from a source managed by Tilt.
+//
+// Secrets can be used anywhere a string is expected (e.g., local_resource env),
+// but print as a placeholder so that they don't end up in logs by accident.
+// Every secret read during Tiltfile execution is scrubbed from Tilt's logs.
+type Secret struct {
+	Name  string
+	Key   string
+	Value string
+}
+
+var _ starlark.Value = Secret{}
+var _ starlark.HasAttrs = Secret{}
+var _ value.ImplicitStringer = Secret{}
+
+func (s Secret) String() string {
+	return fmt.Sprintf("secret(%q)", s.Name)
+}
+
+func (s Secret) Type() string {
+	return "secret"
+}
+
+func (s Secret) Freeze() {}
+
+func (s Secret) Truth() starlark.Bool {
+	return len(s.Value) > 0
+}
+
+func (s Secret) Hash() (uint32, error) {
+	return 0, fmt.Errorf("unhashable type: secret")
+}
+
+func (s Secret) ImplicitString() string {
+	return s.Value
+}
+
+func (s Secret) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "name":
+		return starlark.String(s.Name), nil
+	case "value":
+		return starlark.String(s.Value), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (s Secret) AttrNames() []string {
+	return []string{"name", "value"}
+}
+
+// Implements the secret() builtin.
+type Extension struct {
+	kCli k8s.Client
+
+	// Where we read from_k8s secrets when the Tiltfile doesn't pass a namespace.
+	configNamespace k8s.Namespace
+}
+
+func NewExtension(kCli k8s.Client, configNamespace k8s.Namespace) Extension {
+	return Extension{kCli: kCli, configNamespace: configNamespace}
+}
+
+func (e Extension) NewState() interface{} {
+	return model.SecretSet{}
+}
+
+func (e Extension) OnStart(env *starkit.Environment) error {
+	return env.AddBuiltin("secret", e.secret)
+}
+
+func (e Extension) secret(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name, fromEnv, fromK8s, key, namespace string
+	var fromFileVal, fromCmdVal starlark.Value
+	if err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs,
+		"name", &name,
+		"from_env?", &fromEnv,
+		"from_file?", &fromFileVal,
+		"from_cmd?", &fromCmdVal,
+		"from_k8s?", &fromK8s,
+		"key?", &key,
+		"namespace?", &namespace,
+	); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("%s: name must not be empty", fn.Name())
+	}
+
+	sourceCount := 0
+	for _, isSet := range []bool{fromEnv != "", fromFileVal != nil, fromCmdVal != nil, fromK8s != ""} {
+		if isSet {
+			sourceCount++
+		}
+	}
+	if sourceCount != 1 {
+		return nil, fmt.Errorf("%s: must specify exactly one of from_env, from_file, from_cmd, from_k8s", fn.Name())
+	}
+	if fromK8s == "" && (key != "" || namespace != "") {
+		return nil, fmt.Errorf("%s: key and namespace are only valid with from_k8s", fn.Name())
+	}
+
+	var val []byte
+	var err error
+	switch {
+	case fromEnv != "":
+		key = "env"
+		v, ok := os.LookupEnv(fromEnv)
+		if !ok {
+			return nil, fmt.Errorf("%s: environment variable %q not set", fn.Name(), fromEnv)
+		}
+		val = []byte(v)
+
+	case fromFileVal != nil:
+		key = "file"
+		var path string
+		path, err = value.ValueToAbsPath(thread, fromFileVal)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: from_file", fn.Name())
+		}
+		val, err = io.ReadFile(thread, path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: reading %s", fn.Name(), path)
+		}
+
+	case fromCmdVal != nil:
+		key = "cmd"
+		val, err = e.readFromCmd(thread, fromCmdVal)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", fn.Name())
+		}
+
+	case fromK8s != "":
+		if key == "" {
+			return nil, fmt.Errorf("%s: from_k8s requires a key", fn.Name())
+		}
+		ns := k8s.Namespace(namespace)
+		if ns == "" {
+			ns = e.configNamespace
+		}
+		val, err = e.readFromK8s(thread, ns, fromK8s, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", fn.Name())
+		}
+	}
+
+	err = starkit.SetState(thread, func(secrets model.SecretSet) model.SecretSet {
+		secrets.AddSecret(name, key, val)
+		return secrets
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return Secret{Name: name, Key: key, Value: string(val)}, nil
+}
+
+// Runs a command and reads the secret from its stdout.
+//
+// A trailing newline is trimmed, since most password managers (pass, op, vault)
+// print one. Stdout is never echoed to the Tiltfile log.
+func (e Extension) readFromCmd(thread *starlark.Thread, v starlark.Value) ([]byte, error) {
+	ctx, err := starkit.ContextFromThread(thread)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := value.ValueToHostCmd(thread, v, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "from_cmd")
+	}
+
+	c := exec.CommandContext(ctx, cmd.Argv[0], cmd.Argv[1:]...)
+	c.Dir = cmd.Dir
+	c.Env = append(os.Environ(), cmd.Env...)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	c.Stdout = stdout
+	c.Stderr = stderr
+
+	err = c.Run()
+	if err != nil {
+		return nil, fmt.Errorf("command %q failed.\nerror: %v\nstderr: %q", cmd, err, stderr.String())
+	}
+
+	return []byte(strings.TrimRight(stdout.String(), "\r\n")), nil
+}
+
+func (e Extension) readFromK8s(thread *starlark.Thread, ns k8s.Namespace, name, key string) ([]byte, error) {
+	ctx, err := starkit.ContextFromThread(thread)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := e.kCli.SecretData(ctx, ns, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading secret %s/%s from cluster", ns, name)
+	}
+
+	val, ok := data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no key %q", ns, name, key)
+	}
+	return val, nil
+}
+
+var _ starkit.StatefulExtension = Extension{}
+
+func MustState(model starkit.Model) model.SecretSet {
+	state, err := GetState(model)
+	if err != nil {
+		panic(err)
+	}
+	return state
+}
+
+func GetState(m starkit.Model) (model.SecretSet, error) {
+	var state model.SecretSet
+	err := m.Load(&state)
+	return state, err
+}
@@ -0,0 +1,198 @@
+package secrets
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/tilt-dev/tilt/internal/k8s"
+	"github.com/tilt-dev/tilt/internal/tiltfile/io"
+	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
+)
+
+func TestSecretFromEnv(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	os.Setenv("TILT_TEST_SECRET", "hunter2")
+	defer os.Unsetenv("TILT_TEST_SECRET")
+
+	f.File("Tiltfile", `
+s = secret('db-password', from_env='TILT_TEST_SECRET')
+print(s)
+print(s.name)
+print(s.value == 'hunter2')
+`)
+	model, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+	assert.Equal(t, "secret(\"db-password\")\ndb-password\nTrue\n", f.PrintOutput())
+	assert.NotContains(t, f.PrintOutput(), "hunter2")
+
+	secret := MustState(model)["hunter2"]
+	assert.Equal(t, "db-password", secret.Name)
+	assert.Equal(t, "env", secret.Key)
+}
+
+func TestSecretFromEnvUnset(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+secret('db-password', from_env='TILT_TEST_SECRET_UNSET')
+`)
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `environment variable "TILT_TEST_SECRET_UNSET" not set`)
+}
+
+func TestSecretFromFile(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.UseRealFS()
+	f.File("token.txt", "s3cr3t")
+	f.File("Tiltfile", `
+s = secret('token', from_file='token.txt')
+`)
+	model, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+
+	assert.Equal(t, "token", MustState(model)["s3cr3t"].Name)
+	assert.Contains(t, io.MustState(model).Paths, f.JoinPath("token.txt"))
+}
+
+func TestSecretFromCmd(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+s = secret('api-key', from_cmd='echo abc123')
+print(str(s) + ':' + s.value)
+`)
+	model, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+	assert.Equal(t, "secret(\"api-key\"):abc123\n", f.PrintOutput())
+	assert.Equal(t, "cmd", MustState(model)["abc123"].Key)
+}
+
+func TestSecretFromCmdFailure(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+secret('api-key', from_cmd='exit 1')
+`)
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `command "exit 1" failed`)
+}
+
+func TestSecretFromK8s(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.kCli.InjectEntityByName(k8s.NewK8sEntity(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: "ci"},
+		Data:       map[string][]byte{"password": []byte("pa55word")},
+	}))
+
+	f.File("Tiltfile", `
+s = secret('registry', from_k8s='registry-creds', key='password', namespace='ci')
+`)
+	model, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+
+	secret := MustState(model)["pa55word"]
+	assert.Equal(t, "registry", secret.Name)
+	assert.Equal(t, "password", secret.Key)
+}
+
+func TestSecretFromK8sConfigNamespace(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.kCli.InjectEntityByName(k8s.NewK8sEntity(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: "dev"},
+		Data:       map[string][]byte{"password": []byte("pa55word")},
+	}))
+
+	// Without a namespace, we read from the kubeconfig's namespace.
+	f.File("Tiltfile", `
+s = secret('registry', from_k8s='registry-creds', key='password')
+`)
+	model, err := f.ExecFile("Tiltfile")
+	require.NoError(t, err)
+
+	secret := MustState(model)["pa55word"]
+	assert.Equal(t, "registry", secret.Name)
+}
+
+func TestSecretFromK8sMissingKey(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.kCli.InjectEntityByName(k8s.NewK8sEntity(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: "ci"},
+		Data:       map[string][]byte{"password": []byte("pa55word")},
+	}))
+
+	f.File("Tiltfile", `
+secret('registry', from_k8s='registry-creds', key='username', namespace='ci')
+`)
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `secret ci/registry-creds has no key "username"`)
+}
+
+func TestSecretFromK8sRequiresKey(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+secret('registry', from_k8s='registry-creds')
+`)
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "from_k8s requires a key")
+}
+
+func TestSecretRequiresExactlyOneSource(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+secret('token', from_env='HOME', from_cmd='echo hi')
+`)
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must specify exactly one of from_env, from_file, from_cmd, from_k8s")
+}
+
+func TestSecretKeyWithoutK8s(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.File("Tiltfile", `
+secret('token', from_env='HOME', key='foo')
+`)
+	_, err := f.ExecFile("Tiltfile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key and namespace are only valid with from_k8s")
+}
+
+type fixture struct {
+	*starkit.Fixture
+	kCli *k8s.FakeK8sClient
+}
+
+func newFixture(tb testing.TB) fixture {
+	kCli := k8s.NewFakeK8sClient()
+	return fixture{
+		Fixture: starkit.NewFixture(tb, NewExtension(kCli, "dev"), io.NewExtension()),
+		kCli:    kCli,
+	}
+}
@@ -24,7 +24,9 @@ import (
 	"github.com/tilt-dev/tilt/internal/tiltfile/dockerprune"
 	"github.com/tilt-dev/tilt/internal/tiltfile/io"
 	"github.com/tilt-dev/tilt/internal/tiltfile/k8scontext"
+	"github.com/tilt-dev/tilt/internal/tiltfile/logexport"
 	"github.com/tilt-dev/tilt/internal/tiltfile/metrics"
+	"github.com/tilt-dev/tilt/internal/tiltfile/secrets"
 	"github.com/tilt-dev/tilt/internal/tiltfile/secretsettings"
 	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
 	"github.com/tilt-dev/tilt/internal/tiltfile/telemetry"
@@ -45,6 +47,7 @@ type TiltfileLoadResult struct {
 	TeamID              string
 	TelemetrySettings   model.TelemetrySettings
 	MetricsSettings     model.MetricsSettings
+	LogExportSettings   model.LogExportSettings
 	Secrets             model.SecretSet
 	Error               error
 	DockerPruneSettings model.DockerPruneSettings
@@ -102,6 +105,7 @@ func (tfl *FakeTiltfileLoader) PassedUserConfigState() model.UserConfigState {
 func ProvideTiltfileLoader(
 	analytics *analytics.TiltAnalytics,
 	kCli k8s.Client,
+	configNamespace k8s.Namespace,
 	k8sContextExt k8scontext.Extension,
 	versionExt version.Extension,
 	configExt *config.Extension,
@@ -110,15 +114,16 @@ func ProvideTiltfileLoader(
 	fDefaults feature.Defaults,
 	env k8s.Env) TiltfileLoader {
 	return tiltfileLoader{
-		analytics:     analytics,
-		kCli:          kCli,
-		k8sContextExt: k8sContextExt,
-		versionExt:    versionExt,
-		configExt:     configExt,
-		dcCli:         dcCli,
-		webHost:       webHost,
-		fDefaults:     fDefaults,
-		env:           env,
+		analytics:       analytics,
+		kCli:            kCli,
+		configNamespace: configNamespace,
+		k8sContextExt:   k8sContextExt,
+		versionExt:      versionExt,
+		configExt:       configExt,
+		dcCli:           dcCli,
+		webHost:         webHost,
+		fDefaults:       fDefaults,
+		env:             env,
 	}
 }
 
@@ -128,6 +133,9 @@ type tiltfileLoader struct {
 	dcCli     dockercompose.DockerComposeClient
 	webHost   model.WebHost
 
+	// The namespace of the kubeconfig context, for reading secrets.
+	configNamespace k8s.Namespace
+
 	k8sContextExt k8scontext.Extension
 	versionExt    version.Extension
 	configExt     *config.Extension
@@ -172,7 +180,7 @@ func (tfl tiltfileLoader) Load(ctx context.Context, filename string, userConfigS
 
 	localRegistry := tfl.kCli.LocalRegistry(ctx)
 
-	s := newTiltfileState(ctx, tfl.dcCli, tfl.webHost, tfl.k8sContextExt, tfl.versionExt, tfl.configExt, localRegistry, feature.FromDefaults(tfl.fDefaults))
+	s := newTiltfileState(ctx, tfl.dcCli, tfl.webHost, tfl.k8sContextExt, tfl.versionExt, tfl.configExt, secrets.NewExtension(tfl.kCli, tfl.configNamespace), localRegistry, feature.FromDefaults(tfl.fDefaults))
 
 	manifests, result, err := s.loadManifests(absFilename, userConfigState)
 
@@ -198,6 +206,10 @@ func (tfl tiltfileLoader) Load(ctx context.Context, filename string, userConfigS
 	tlr.AnalyticsOpt = aSettings.Opt
 
 	tlr.Secrets = s.extractSecrets()
+	if s.secretSettings.ScrubSecrets {
+		tiltfileSecrets, _ := secrets.GetState(result)
+		tlr.Secrets.AddAll(tiltfileSecrets)
+	}
 	tlr.FeatureFlags = s.features.ToEnabled()
 	tlr.Error = err
 	tlr.Manifests = manifests
@@ -212,6 +224,9 @@ func (tfl tiltfileLoader) Load(ctx context.Context, filename string, userConfigS
 	metricsSettings, _ := metrics.GetState(result)
 	tlr.MetricsSettings = metricsSettings
 
+	logExportSettings, _ := logexport.GetState(result)
+	tlr.LogExportSettings = logExportSettings
+
 	us, _ := updatesettings.GetState(result)
 	tlr.UpdateSettings = us
 
@@ -32,8 +32,10 @@ import (
 	tiltfile_k8s "github.com/tilt-dev/tilt/internal/tiltfile/k8s"
 	"github.com/tilt-dev/tilt/internal/tiltfile/k8scontext"
 	"github.com/tilt-dev/tilt/internal/tiltfile/loaddynamic"
+	"github.com/tilt-dev/tilt/internal/tiltfile/logexport"
 	"github.com/tilt-dev/tilt/internal/tiltfile/metrics"
 	"github.com/tilt-dev/tilt/internal/tiltfile/os"
+	"github.com/tilt-dev/tilt/internal/tiltfile/secrets"
 	"github.com/tilt-dev/tilt/internal/tiltfile/secretsettings"
 	"github.com/tilt-dev/tilt/internal/tiltfile/shlex"
 	"github.com/tilt-dev/tilt/internal/tiltfile/starkit"
@@ -66,9 +68,13 @@ type tiltfileState struct {
 	k8sContextExt k8scontext.Extension
 	versionExt    version.Extension
 	configExt     *config.Extension
+	secretsExt    secrets.Extension
 	localRegistry container.Registry
 	features      feature.FeatureSet
 
+	// set when execution starts
+	applySet string
+
 	// added to during execution
 	buildIndex     *buildIndex
 	k8sObjectIndex *tiltfile_k8s.State
@@ -88,9 +94,18 @@ type tiltfileState struct {
 	k8sResourceOptions map[string]k8sResourceOptions
 	localResources     []localResource
 
+	// reverse_port_forward() tunnels, keyed by service name
+	reverseForwards map[string]model.ReverseForward
+
 	// ensure that any images are pushed to/pulled from this registry, rewriting names if needed
 	defaultReg container.Registry
 
+	// per-image overrides of defaultReg, evaluated in order
+	registryRules container.RegistryRules
+
+	// the namespace for namespaced objects that don't specify one
+	defaultNamespace k8s.Namespace
+
 	k8sKinds map[k8s.ObjectSelector]*tiltfile_k8s.KindInfo
 
 	workloadToResourceFunction workloadToResourceFunction
@@ -135,6 +150,7 @@ func newTiltfileState(
 	k8sContextExt k8scontext.Extension,
 	versionExt version.Extension,
 	configExt *config.Extension,
+	secretsExt secrets.Extension,
 	localRegistry container.Registry,
 	features feature.FeatureSet) *tiltfileState {
 	return &tiltfileState{
@@ -144,10 +160,12 @@ func newTiltfileState(
 		k8sContextExt:             k8sContextExt,
 		versionExt:                versionExt,
 		configExt:                 configExt,
+		secretsExt:                secretsExt,
 		localRegistry:             localRegistry,
 		buildIndex:                newBuildIndex(),
 		k8sObjectIndex:            tiltfile_k8s.NewState(),
 		k8sByName:                 make(map[string]*k8sResource),
+		reverseForwards:           make(map[string]model.ReverseForward),
 		usedImages:                make(map[string]bool),
 		logger:                    logger.Get(ctx),
 		builtinCallCounts:         make(map[string]int),
@@ -178,6 +196,7 @@ func (s *tiltfileState) loadManifests(absFilename string, userConfigState model.
 	s.logger.Infof("Beginning Tiltfile execution")
 
 	s.configExt.UserConfigState = userConfigState
+	s.applySet = k8s.ApplySetID(absFilename)
 
 	dlr, err := tiltextension.NewTempDirDownloader()
 	if err != nil {
@@ -191,6 +210,7 @@ func (s *tiltfileState) loadManifests(absFilename string, userConfigState model.
 		git.NewExtension(),
 		os.NewExtension(),
 		io.NewExtension(),
+		io.NewDownloadExtension(),
 		s.k8sContextExt,
 		dockerprune.NewExtension(),
 		analytics.NewExtension(),
@@ -199,8 +219,10 @@ func (s *tiltfileState) loadManifests(absFilename string, userConfigState model.
 		starlarkstruct.NewExtension(),
 		telemetry.NewExtension(),
 		metrics.NewExtension(),
+		logexport.NewExtension(),
 		updatesettings.NewExtension(),
 		secretsettings.NewExtension(),
+		s.secretsExt,
 		encoding.NewExtension(),
 		shlex.NewExtension(),
 		watch.NewExtension(),
@@ -239,6 +261,16 @@ If you're sure you want to deploy there, add:
 	allow_k8s_contexts('%s')
 to your Tiltfile. Otherwise, switch k8s contexts and restart Tilt.`, kubeContext, kubeContext)
 		}
+
+		for _, m := range manifests {
+			kubeContext := k8s.KubeContext(m.K8sTarget().KubeContext)
+			if kubeContext != "" && !k8sContextState.IsContextAllowed(s.ctx, kubeContext) {
+				return nil, result, fmt.Errorf(`Stop! %s (used by resource %s) might be production.
+If you're sure you want to deploy there, add:
+	allow_k8s_contexts('%s')
+to your Tiltfile.`, kubeContext, m.Name, kubeContext)
+			}
+		}
 	} else {
 		manifests, err = s.translateDC(resources.dc)
 		if err != nil {
@@ -273,6 +305,13 @@ to your Tiltfile. Otherwise, switch k8s contexts and restart Tilt.`, kubeContext
 		if err != nil {
 			return nil, starkit.Model{}, err
 		}
+		yamlTarget := yamlManifest.K8sTarget()
+		yamlTarget.ApplySet = s.applySet
+		yamlTarget.NamespaceYAML, err = namespaceYAMLFor(unresourced, s.declaredNamespaces())
+		if err != nil {
+			return nil, starkit.Model{}, err
+		}
+		yamlManifest = yamlManifest.WithDeployTarget(yamlTarget)
 
 		manifests = append(manifests, yamlManifest)
 	}
@@ -301,6 +340,7 @@ const (
 	dockerBuildN     = "docker_build"
 	customBuildN     = "custom_build"
 	defaultRegistryN = "default_registry"
+	registryRulesN   = "registry_rules"
 
 	// docker compose functions
 	dockerComposeN = "docker_compose"
@@ -311,7 +351,9 @@ const (
 	filterYamlN                 = "filter_yaml"
 	k8sResourceN                = "k8s_resource"
 	portForwardN                = "port_forward"
+	reversePortForwardN         = "reverse_port_forward"
 	k8sKindN                    = "k8s_kind"
+	defaultNamespaceN           = "default_namespace"
 	k8sImageJSONPathN           = "k8s_image_json_path"
 	workloadToResourceFunctionN = "workload_to_resource_function"
 
@@ -485,6 +527,7 @@ func (s *tiltfileState) OnStart(e *starkit.Environment) error {
 		{dockerBuildN, s.dockerBuild},
 		{customBuildN, s.customBuild},
 		{defaultRegistryN, s.defaultRegistry},
+		{registryRulesN, s.registryRulesFn},
 		{dockerComposeN, s.dockerCompose},
 		{dcResourceN, s.dcResource},
 		{k8sYamlN, s.k8sYaml},
@@ -493,7 +536,9 @@ func (s *tiltfileState) OnStart(e *starkit.Environment) error {
 		{localResourceN, s.localResource},
 		{testN, s.localResource}, // test is just a fork of local resource, w/ some switches based on fn.Name()
 		{portForwardN, s.portForward},
+		{reversePortForwardN, s.reversePortForward},
 		{k8sKindN, s.k8sKind},
+		{defaultNamespaceN, s.defaultNamespaceFn},
 		{k8sImageJSONPathN, s.k8sImageJsonPath},
 		{workloadToResourceFunctionN, s.workloadToResourceFunctionFn},
 		{kustomizeN, s.kustomize},
@@ -636,6 +681,9 @@ func (s *tiltfileState) assembleDC() error {
 	if len(s.dc.services) > 0 && !s.defaultReg.Empty() {
 		return errors.New("default_registry is not supported with docker compose")
 	}
+	if len(s.dc.services) > 0 && len(s.registryRules) > 0 {
+		return errors.New("registry_rules is not supported with docker compose")
+	}
 
 	for _, svc := range s.dc.services {
 		if svc.ImageRef() != nil {
@@ -652,6 +700,8 @@ func (s *tiltfileState) assembleDC() error {
 }
 
 func (s *tiltfileState) assembleK8s() error {
+	s.applyDefaultNamespace()
+
 	err := s.assembleK8sByWorkload()
 	if err != nil {
 		return err
@@ -693,6 +743,14 @@ func (s *tiltfileState) assembleK8s() error {
 			r.autoInit = opts.autoInit
 			r.resourceDeps = opts.resourceDeps
 			r.links = opts.links
+			r.keepOnRemoval = opts.keepOnRemoval
+			r.kubeContext = opts.kubeContext
+			r.kubeContextOverride = opts.kubeContextOverride
+			r.namespace = opts.namespace
+			r.logFormat = opts.logFormat
+			r.logFields = opts.logFields
+			r.logLimit = opts.logLimit
+			r.logAlerts = opts.logAlerts
 			if opts.newName != "" && opts.newName != r.name {
 				if _, ok := s.k8sByName[opts.newName]; ok {
 					return fmt.Errorf("k8s_resource at %s specified to rename %q to %q, but there already exists a resource with that name", opts.tiltfilePosition.String(), r.name, opts.newName)
@@ -750,6 +808,9 @@ func (s *tiltfileState) assembleK8s() error {
 	}
 
 	for _, r := range s.k8s {
+		if r.namespace != "" {
+			setNamespace(r.entities, r.namespace)
+		}
 		if err := s.validateK8s(r); err != nil {
 			return err
 		}
@@ -933,15 +994,19 @@ func (s *tiltfileState) k8sResourceForName(name string) (*k8sResource, error) {
 // decideRegistry returns the image registry we should use; if detected, a pre-configured
 // local registry; otherwise, the registry specified by the user via default_registry.
 // Otherwise, we'll return the zero value of `s.defaultReg`, which is an empty registry.
+//
+// Also returns the registry_rules to apply on top of that registry. A pre-configured
+// local registry takes precedence over the rules, just as it does over default_registry.
+//
 // It has side-effects (a log line) and so should only be called once.
-func (s *tiltfileState) decideRegistry() container.Registry {
+func (s *tiltfileState) decideRegistry() (container.Registry, container.RegistryRules) {
 	if s.orchestrator() == model.OrchestratorK8s && !s.localRegistry.Empty() {
 		// If we've found a local registry in the cluster at run-time, use that
 		// instead of the default_registry (if any) declared in the Tiltfile
 		s.logger.Infof("Auto-detected local registry from environment: %s", s.localRegistry)
-		return s.localRegistry
+		return s.localRegistry, nil
 	}
-	return s.defaultReg
+	return s.defaultReg, s.registryRules
 }
 
 // Auto-infer the readiness mode
@@ -991,7 +1056,16 @@ func (s *tiltfileState) inferPodReadinessMode(r *k8sResource) model.PodReadiness
 
 	// Auto-infer based on context
 	//
-	// If the resource was
+	// If Tilt can tell when all the objects are ready without looking
+	// at pods (e.g., custom resources with status conditions),
+	// and the resource doesn't have images or pod selectors,
+	// don't wait for pods.
+	if len(r.extraPodSelectors) == 0 && len(r.dependencyIDs) == 0 &&
+		len(r.entities) > 0 && len(s.objectReadiness(r)) == len(r.entities) {
+		return model.PodReadinessIgnore
+	}
+
+	// Otherwise, if the resource was
 	// 1) manually grouped (i.e., we didn't find any images in it)
 	// 2) doesn't have pod selectors, and
 	// 3) doesn't depend on images
@@ -1003,10 +1077,34 @@ func (s *tiltfileState) inferPodReadinessMode(r *k8sResource) model.PodReadiness
 	return model.PodReadinessWait
 }
 
+// Readiness checks for the objects in this resource whose kinds
+// were registered with k8s_kind(ready_condition=..., ready_jsonpath=...).
+func (s *tiltfileState) objectReadiness(r *k8sResource) []model.K8sObjectReadiness {
+	var result []model.K8sObjectReadiness
+	for _, e := range r.entities {
+		for sel, info := range s.k8sKinds {
+			if !info.HasReadinessCheck() || !sel.Matches(e) {
+				continue
+			}
+
+			ref := e.ToObjectReference()
+			ref.UID = ""
+			result = append(result, model.K8sObjectReadiness{
+				Ref:       ref,
+				Condition: info.ReadyCondition,
+				JSONPath:  info.ReadyJSONPath,
+			})
+			break
+		}
+	}
+	return result
+}
+
 func (s *tiltfileState) translateK8s(resources []*k8sResource) ([]model.Manifest, error) {
 	var result []model.Manifest
 	locators := s.k8sImageLocatorsList()
-	registry := s.decideRegistry()
+	registry, rules := s.decideRegistry()
+	declaredNamespaces := s.declaredNamespaces()
 	for _, r := range resources {
 		mn := model.ManifestName(r.name)
 		tm, err := starlarkTriggerModeToModel(s.triggerModeForResource(r.triggerMode), r.autoInit)
@@ -1022,6 +1120,8 @@ func (s *tiltfileState) translateK8s(resources []*k8sResource) ([]model.Manifest
 			Name:                 mn,
 			TriggerMode:          tm,
 			ResourceDependencies: mds,
+			LogLimit:             r.logLimit,
+			LogAlerts:            r.logAlerts,
 		}
 
 		k8sTarget, err := k8s.NewTarget(mn.TargetName(), r.entities,
@@ -1032,9 +1132,25 @@ func (s *tiltfileState) translateK8s(resources []*k8sResource) ([]model.Manifest
 			return nil, err
 		}
 
+		k8sTarget.ApplySet = s.applySet
+		k8sTarget.ReverseForwards = s.reverseForwardsFor(r.entities)
+		k8sTarget.KeepOnRemoval = r.keepOnRemoval
+		k8sTarget.LogFormat = r.logFormat
+		k8sTarget.LogFields = r.logFields
+
+		kubeContext, err := s.kubeContextForResource(r)
+		if err != nil {
+			return nil, err
+		}
+		k8sTarget.KubeContext = string(kubeContext)
+		k8sTarget.NamespaceYAML, err = namespaceYAMLFor(r.entities, declaredNamespaces)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resource %s", r.name)
+		}
+		k8sTarget.ObjectReadiness = s.objectReadiness(r)
 		m = m.WithDeployTarget(k8sTarget)
 
-		iTargets, err := s.imgTargetsForDependencyIDs(r.dependencyIDs, registry)
+		iTargets, err := s.imgTargetsForDependencyIDs(r.dependencyIDs, registry, rules)
 		if err != nil {
 			return nil, errors.Wrapf(err, "getting image build info for %s", r.name)
 		}
@@ -1189,12 +1305,12 @@ func needsRestartContainerDeprecationError(m model.Manifest) bool {
 
 // Grabs all image targets for the given references,
 // as well as any of their transitive dependencies.
-func (s *tiltfileState) imgTargetsForDependencyIDs(ids []model.TargetID, reg container.Registry) ([]model.ImageTarget, error) {
+func (s *tiltfileState) imgTargetsForDependencyIDs(ids []model.TargetID, reg container.Registry, rules container.RegistryRules) ([]model.ImageTarget, error) {
 	claimStatus := make(map[model.TargetID]claim, len(ids))
-	return s.imgTargetsForDependencyIDsHelper(ids, claimStatus, reg)
+	return s.imgTargetsForDependencyIDsHelper(ids, claimStatus, reg, rules)
 }
 
-func (s *tiltfileState) imgTargetsForDependencyIDsHelper(ids []model.TargetID, claimStatus map[model.TargetID]claim, reg container.Registry) ([]model.ImageTarget, error) {
+func (s *tiltfileState) imgTargetsForDependencyIDsHelper(ids []model.TargetID, claimStatus map[model.TargetID]claim, reg container.Registry, rules container.RegistryRules) ([]model.ImageTarget, error) {
 	iTargets := make([]model.ImageTarget, 0, len(ids))
 	for _, id := range ids {
 		image := s.buildIndex.findBuilderByID(id)
@@ -1211,11 +1327,11 @@ func (s *tiltfileState) imgTargetsForDependencyIDsHelper(ids []model.TargetID, c
 		}
 		claimStatus[id] = claimPending
 
-		refs, err := container.NewRefSet(image.configurationRef, reg)
+		refs, err := container.NewRefSet(image.configurationRef, rules.RegistryFor(image.configurationRef, reg))
 		if err != nil {
 			return nil, errors.Wrapf(err, "Something went wrong deriving "+
 				"references for your image: %q. Check the image name (and your "+
-				"`default_registry()` call, if any) for errors", image.configurationRef)
+				"`default_registry()` and `registry_rules()` calls, if any) for errors", image.configurationRef)
 		}
 
 		iTarget := model.ImageTarget{
@@ -1231,6 +1347,10 @@ func (s *tiltfileState) imgTargetsForDependencyIDsHelper(ids []model.TargetID, c
 			iTarget.OverrideArgs = image.containerArgs
 		}
 
+		if !image.attestation.Empty() {
+			iTarget = iTarget.WithAttestation(image.attestation)
+		}
+
 		lu := image.liveUpdate
 
 		switch image.Type() {
@@ -1243,6 +1363,7 @@ func (s *tiltfileState) imgTargetsForDependencyIDsHelper(ids []model.TargetID, c
 				TargetStage: model.DockerBuildTarget(image.targetStage),
 				SSHSpecs:    image.sshSpecs,
 				SecretSpecs: image.secretSpecs,
+				Secrets:     image.secrets,
 				Network:     image.network,
 				CacheFrom:   image.cacheFrom,
 				PullParent:  image.pullParent,
@@ -1278,7 +1399,7 @@ func (s *tiltfileState) imgTargetsForDependencyIDsHelper(ids []model.TargetID, c
 			WithTiltFilename(image.workDir).
 			WithDependencyIDs(image.dependencyIDs)
 
-		depTargets, err := s.imgTargetsForDependencyIDsHelper(image.dependencyIDs, claimStatus, reg)
+		depTargets, err := s.imgTargetsForDependencyIDsHelper(image.dependencyIDs, claimStatus, reg, rules)
 		if err != nil {
 			return nil, err
 		}
@@ -1300,7 +1421,7 @@ func (s *tiltfileState) translateDC(dc dcResourceSet) ([]model.Manifest, error)
 			return nil, err
 		}
 
-		iTargets, err := s.imgTargetsForDependencyIDs(svc.DependencyIDs, container.Registry{}) // Registry not relevant to DC
+		iTargets, err := s.imgTargetsForDependencyIDs(svc.DependencyIDs, container.Registry{}, nil) // Registry not relevant to DC
 		if err != nil {
 			return nil, errors.Wrapf(err, "getting image build info for %s", svc.Name)
 		}
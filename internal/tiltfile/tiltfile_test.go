@@ -23,6 +23,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/clientcmd/api"
 
 	tiltanalytics "github.com/tilt-dev/tilt/internal/analytics"
 	"github.com/tilt-dev/tilt/internal/container"
@@ -468,6 +469,23 @@ docker_build("gcr.io/foo", "foo", secret='id=shibboleth')
 	assert.Equal(t, []string{"id=shibboleth"}, m.ImageTargets[0].BuildDetails.(model.DockerBuild).SecretSpecs)
 }
 
+func TestDockerBuildSecretValue(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFoo()
+	f.file("Tiltfile", `
+k8s_yaml('foo.yaml')
+npm_token = secret('npm', from_cmd='echo t0k3n')
+docker_build("gcr.io/foo", "foo", secret=['id=shibboleth', npm_token])
+`)
+	f.load()
+	m := f.assertNextManifest("foo")
+	db := m.ImageTargets[0].BuildDetails.(model.DockerBuild)
+	assert.Equal(t, []string{"id=shibboleth"}, db.SecretSpecs)
+	assert.Equal(t, map[string]string{"npm": "t0k3n"}, db.Secrets)
+}
+
 func TestDockerBuildNetwork(t *testing.T) {
 	f := newFixture(t)
 	defer f.TearDown()
@@ -686,6 +704,12 @@ func TestPortForward(t *testing.T) {
 		newPortForwardSuccessCase("value_constructor_host", "port_forward(8001, 443, host='elastic.local')",
 			[]model.PortForward{{LocalPort: 8001, ContainerPort: 443, Host: "elastic.local"}}),
 		newPortForwardErrorCase("value_constructor_host_wrong_type", "port_forward(8001, 443, host=54321)", "for parameter \"host\": got int, want string"),
+		newPortForwardSuccessCase("value_constructor_target_svc", "port_forward(8001, 80, target='svc/foo')",
+			[]model.PortForward{{LocalPort: 8001, ContainerPort: 80, Target: "svc/foo"}}),
+		newPortForwardSuccessCase("value_constructor_target_selector", "port_forward(8001, target='app=foo')",
+			[]model.PortForward{{LocalPort: 8001, Target: "app=foo"}}),
+		newPortForwardErrorCase("value_constructor_target_bad_kind", "port_forward(8001, target='deployment/foo')",
+			"port_forward: port-forward target \"deployment/foo\": unsupported kind \"deployment\""),
 
 		// list values
 		newPortForwardSuccessCase("list_mixed", "[8000, port_forward(8001, 443), '8002', '8003:444'],", []model.PortForward{{LocalPort: 8000}, {LocalPort: 8001, ContainerPort: 443}, {LocalPort: 8002}, {LocalPort: 8003, ContainerPort: 444}}),
@@ -2255,6 +2279,59 @@ docker_build('tilt.dev/frontend', '.')
 		m.ImageTargets[0].Refs.LocalRef().String())
 }
 
+func TestK8sKindReadyCondition(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+	f.file("cert.yaml", `apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: my-cert
+spec:
+  secretName: my-cert-tls`)
+	f.file("Tiltfile", `
+k8s_yaml('cert.yaml')
+k8s_kind('Certificate', ready_condition='Ready')
+`)
+
+	f.load()
+	m := f.assertNextManifest("my-cert", podReadiness(model.PodReadinessIgnore))
+	readiness := m.K8sTarget().ObjectReadiness
+	require.Len(t, readiness, 1)
+	assert.Equal(t, "Certificate", readiness[0].Ref.Kind)
+	assert.Equal(t, "my-cert", readiness[0].Ref.Name)
+	assert.Equal(t, "Ready", readiness[0].Condition)
+	assert.Equal(t, "", readiness[0].JSONPath)
+}
+
+func TestK8sKindReadyJSONPath(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+	f.file("topic.yaml", `apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaTopic
+metadata:
+  name: orders`)
+	f.file("Tiltfile", `
+k8s_yaml('topic.yaml')
+k8s_kind('KafkaTopic', ready_jsonpath='{.status.ready}')
+`)
+
+	f.load()
+	m := f.assertNextManifest("orders")
+	readiness := m.K8sTarget().ObjectReadiness
+	require.Len(t, readiness, 1)
+	assert.Equal(t, "{.status.ready}", readiness[0].JSONPath)
+}
+
+func TestK8sKindReadyJSONPathInvalid(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+	f.file("Tiltfile", `
+k8s_kind('KafkaTopic', ready_jsonpath='{.status.ready')
+`)
+
+	f.loadErrString("ready_jsonpath")
+}
+
 func TestExtraImageLocationOneImage(t *testing.T) {
 	f := newFixture(t)
 	defer f.TearDown()
@@ -2834,6 +2911,93 @@ default_registry('123.dkr.ecr.us-east-1.amazonaws.com', single_name='team-a/dev'
 		beTaggedRefs.LocalRef.String())
 }
 
+func TestRegistryRules(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFooAndBar()
+	f.file("Tiltfile", `
+docker_build('gcr.io/foo', 'foo')
+docker_build('gcr.io/bar', 'bar')
+k8s_yaml(['foo.yaml', 'bar.yaml'])
+default_registry('default.io')
+registry_rules([
+  {'image': 'gcr.io/bar', 'host': 'tools.io', 'host_from_cluster': 'mirror.io'},
+])
+`)
+
+	f.load()
+
+	f.assertNextManifest("foo",
+		db(image("gcr.io/foo").withLocalRef("default.io/gcr.io_foo")),
+		deployment("foo"))
+	f.assertNextManifest("bar",
+		db(image("gcr.io/bar").withLocalRef("tools.io/gcr.io_bar").withClusterRef("mirror.io/gcr.io_bar")),
+		deployment("bar"))
+}
+
+func TestRegistryRulesWithoutDefaultRegistry(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFooAndBar()
+	f.file("Tiltfile", `
+docker_build('gcr.io/foo', 'foo')
+docker_build('gcr.io/bar', 'bar')
+k8s_yaml(['foo.yaml', 'bar.yaml'])
+registry_rules([{'image': 'gcr.io/bar', 'host': 'tools.io'}])
+`)
+
+	f.load()
+
+	f.assertNextManifest("foo",
+		db(image("gcr.io/foo").withLocalRef("gcr.io/foo")),
+		deployment("foo"))
+	f.assertNextManifest("bar",
+		db(image("gcr.io/bar").withLocalRef("tools.io/gcr.io_bar")),
+		deployment("bar"))
+}
+
+func TestRegistryRulesLocalRegistryOverrides(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.kCli.Registry = container.MustNewRegistry("localhost:32000")
+
+	f.setupFoo()
+	f.file("Tiltfile", `
+registry_rules([{'image': 'gcr.io/foo', 'host': 'tools.io'}])
+docker_build('gcr.io/foo', 'foo')
+k8s_yaml('foo.yaml')
+`)
+
+	f.load()
+
+	f.assertNextManifest("foo",
+		db(image("gcr.io/foo").withLocalRef("localhost:32000/gcr.io_foo")),
+		deployment("foo"))
+}
+
+func TestRegistryRulesInvalid(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", `
+registry_rules([{'image': 'gcr.io/foo', 'host': 'invalid'}])
+`)
+	f.loadErrString("registry_rules: rule 0", "repository name must be canonical")
+}
+
+func TestRegistryRulesUnknownKey(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", `
+registry_rules([{'image': 'gcr.io/foo', 'hots': 'gcr.io'}])
+`)
+	f.loadErrString(`registry_rules: rule 0: unexpected key "hots"`)
+}
+
 func TestDefaultReadFile(t *testing.T) {
 	f := newFixture(t)
 	defer f.TearDown()
@@ -2973,6 +3137,368 @@ k8s_resource('foo', new_name='bar')
 	f.assertNextManifest("bar", deployment("foo"))
 }
 
+func TestK8sResourceKeepOnRemoval(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFooAndBar()
+	f.file("Tiltfile", `
+
+k8s_yaml(['foo.yaml', 'bar.yaml'])
+k8s_resource('foo', keep_on_removal=True)
+`)
+
+	f.load()
+	applySet := k8s.ApplySetID(f.JoinPath("Tiltfile"))
+
+	foo := f.assertNextManifest("foo", deployment("foo")).K8sTarget()
+	assert.True(t, foo.KeepOnRemoval)
+	assert.Equal(t, applySet, foo.ApplySet)
+
+	bar := f.assertNextManifest("bar", deployment("bar")).K8sTarget()
+	assert.False(t, bar.KeepOnRemoval)
+	assert.Equal(t, applySet, bar.ApplySet)
+}
+
+func TestK8sResourceLogFormat(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFooAndBar()
+	f.file("Tiltfile", `
+k8s_yaml(['foo.yaml', 'bar.yaml'])
+k8s_resource('foo', log_format='json', log_fields={'level': 'severity', 'msg': 'message'})
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo")).K8sTarget()
+	assert.Equal(t, model.LogFormatJSON, foo.LogFormat)
+	assert.Equal(t, map[string]string{"level": "severity", "msg": "message"}, foo.LogFields)
+
+	bar := f.assertNextManifest("bar", deployment("bar")).K8sTarget()
+	assert.Equal(t, model.LogFormatText, bar.LogFormat)
+	assert.Empty(t, bar.LogFields)
+}
+
+func TestK8sResourceLogFormatErrors(t *testing.T) {
+	for _, tc := range []struct {
+		args     string
+		expected string
+	}{
+		{"log_format='xml'", `log_format: invalid value "xml"`},
+		{"log_fields={'level': 'severity'}", "log_fields only applies to structured logs"},
+		{"log_format='json', log_fields={'time': 'ts'}", `log_fields: unknown field "time"`},
+	} {
+		t.Run(tc.args, func(t *testing.T) {
+			f := newFixture(t)
+			defer f.TearDown()
+
+			f.setupFoo()
+			f.file("Tiltfile", fmt.Sprintf(`
+k8s_yaml('foo.yaml')
+k8s_resource('foo', %s)
+`, tc.args))
+
+			f.loadErrString(tc.expected)
+		})
+	}
+}
+
+func TestK8sResourceLogLimit(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFooAndBar()
+	f.file("Tiltfile", `
+k8s_yaml(['foo.yaml', 'bar.yaml'])
+k8s_resource('foo', log_limit={'bytes': 100000, 'lines': 1000, 'lines_per_second': 50})
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo"))
+	assert.Equal(t, model.LogLimit{Bytes: 100000, Lines: 1000, LinesPerSecond: 50}, foo.LogLimit)
+
+	bar := f.assertNextManifest("bar", deployment("bar"))
+	assert.True(t, bar.LogLimit.Empty())
+}
+
+func TestK8sResourceAlertPatterns(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFooAndBar()
+	f.file("Tiltfile", `
+k8s_yaml(['foo.yaml', 'bar.yaml'])
+k8s_resource('foo', alert_patterns=['panic:', 'OutOfMemory'], fatal_alert_patterns='^FATAL')
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo"))
+	assert.Equal(t, model.LogAlerts{
+		Patterns:      []string{"panic:", "OutOfMemory"},
+		FatalPatterns: []string{"^FATAL"},
+	}, foo.LogAlerts)
+
+	bar := f.assertNextManifest("bar", deployment("bar"))
+	assert.True(t, bar.LogAlerts.Empty())
+}
+
+func TestK8sResourceAlertPatternsInvalid(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFoo()
+	f.file("Tiltfile", `
+k8s_yaml('foo.yaml')
+k8s_resource('foo', fatal_alert_patterns=['panic(:'])
+`)
+
+	f.loadErrString(`fatal_alert_patterns: invalid pattern "panic(:"`)
+}
+
+func TestK8sResourceLogLimitErrors(t *testing.T) {
+	for _, tc := range []struct {
+		args     string
+		expected string
+	}{
+		{"log_limit=100", "log_limit: expected dict, got int"},
+		{"log_limit={'kb': 100}", `log_limit: unknown key "kb"`},
+		{"log_limit={'bytes': '1MB'}", `log_limit: for key "bytes", expected int, got string`},
+		{"log_limit={'lines': -1}", `log_limit: for key "lines", must be >= 0`},
+	} {
+		t.Run(tc.args, func(t *testing.T) {
+			f := newFixture(t)
+			defer f.TearDown()
+
+			f.setupFoo()
+			f.file("Tiltfile", fmt.Sprintf(`
+k8s_yaml('foo.yaml')
+k8s_resource('foo', %s)
+`, tc.args))
+
+			f.loadErrString(tc.expected)
+		})
+	}
+}
+
+func TestK8sYAMLNamespace(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFoo()
+	f.file("Tiltfile", `
+k8s_yaml('foo.yaml', namespace='alice')
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo")).K8sTarget()
+	assert.Equal(t, "alice", foo.ObjectRefs[0].Namespace)
+	assert.Equal(t, []string{"alice"}, f.namespaceNames(foo.NamespaceYAML))
+}
+
+func TestK8sResourceNamespace(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFooAndBar()
+	f.file("Tiltfile", `
+k8s_yaml(['foo.yaml', 'bar.yaml'], namespace='alice')
+k8s_resource('bar', namespace='bob')
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo")).K8sTarget()
+	assert.Equal(t, "alice", foo.ObjectRefs[0].Namespace)
+	bar := f.assertNextManifest("bar", deployment("bar")).K8sTarget()
+	assert.Equal(t, "bob", bar.ObjectRefs[0].Namespace)
+	assert.Equal(t, []string{"bob"}, f.namespaceNames(bar.NamespaceYAML))
+}
+
+func TestK8sYAMLInvalidNamespace(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFoo()
+	f.file("Tiltfile", `
+k8s_yaml('foo.yaml', namespace='Not_A_Namespace')
+`)
+
+	f.loadErrString(`k8s_yaml: invalid namespace "Not_A_Namespace"`)
+}
+
+func TestDefaultNamespace(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.yaml("foo.yaml", deployment("foo"))
+	f.yaml("bar.yaml", deployment("bar", namespace("explicit")))
+	f.yaml("ns.yaml", namespace("alice"))
+	f.file("Tiltfile", `
+default_namespace('alice')
+k8s_yaml(['foo.yaml', 'bar.yaml', 'ns.yaml'])
+k8s_resource(objects=['alice:namespace'], new_name='namespaces')
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo")).K8sTarget()
+	assert.Equal(t, "alice", foo.ObjectRefs[0].Namespace)
+	assert.Equal(t, []string{"alice"}, f.namespaceNames(foo.NamespaceYAML))
+
+	bar := f.assertNextManifest("bar", deployment("bar")).K8sTarget()
+	assert.Equal(t, "explicit", bar.ObjectRefs[0].Namespace)
+	assert.Equal(t, []string{"explicit"}, f.namespaceNames(bar.NamespaceYAML))
+
+	// Namespaces are cluster-scoped, and don't need to be created first.
+	namespaces := f.assertNextManifest("namespaces", namespace("alice")).K8sTarget()
+	assert.Equal(t, "", namespaces.ObjectRefs[0].Namespace)
+	assert.Equal(t, "", namespaces.NamespaceYAML)
+}
+
+func TestDefaultNamespaceTwice(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFoo()
+	f.file("Tiltfile", `
+default_namespace('alice')
+default_namespace('bob')
+k8s_yaml('foo.yaml')
+`)
+
+	f.loadErrString(`default_namespace: default namespace already set to "alice"`)
+}
+
+func TestReversePortForward(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", `
+reverse_port_forward(5432, 'db-tunnel', namespace='alice')
+`)
+
+	f.load()
+	kTarget := f.assertNextManifest("db-tunnel").K8sTarget()
+	assert.Equal(t, []model.ReverseForward{
+		{LocalPort: 5432, ServiceName: "db-tunnel", ServicePort: 5432},
+	}, kTarget.ReverseForwards)
+
+	// ObjectRefs are in apply order, so look them up by kind.
+	refs := make(map[string]v1.ObjectReference)
+	for _, ref := range kTarget.ObjectRefs {
+		refs[ref.Kind] = ref
+	}
+	require.Equal(t, 2, len(kTarget.ObjectRefs))
+	require.Contains(t, refs, "Deployment")
+	require.Contains(t, refs, "Service")
+	assert.Equal(t, "db-tunnel", refs["Service"].Name)
+	assert.Equal(t, "alice", refs["Service"].Namespace)
+	assert.Equal(t, "alice", refs["Deployment"].Namespace)
+}
+
+func TestReversePortForwardDuplicate(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", `
+reverse_port_forward(5432, 'db-tunnel')
+reverse_port_forward(5433, 'db-tunnel')
+`)
+
+	f.loadErrString(`reverse_port_forward: service "db-tunnel" already has a reverse port-forward`)
+}
+
+func TestReversePortForwardInvalidServiceName(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", `
+reverse_port_forward(5432, 'Not_A_Service')
+`)
+
+	f.loadErrString(`reverse_port_forward: invalid service_name "Not_A_Service"`)
+}
+
+func TestK8sYAMLContext(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFooAndBar()
+	f.file("Tiltfile", `
+k8s_yaml('foo.yaml', context='kind-data')
+k8s_yaml('bar.yaml')
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo")).K8sTarget()
+	assert.Equal(t, "kind-data", foo.KubeContext)
+	bar := f.assertNextManifest("bar", deployment("bar")).K8sTarget()
+	assert.Equal(t, "", bar.KubeContext)
+}
+
+func TestK8sYAMLCurrentContext(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFoo()
+	f.file("Tiltfile", `
+k8s_yaml('foo.yaml', context='fake-context')
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo")).K8sTarget()
+	assert.Equal(t, "", foo.KubeContext)
+}
+
+func TestK8sResourceContext(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFooAndBar()
+	f.file("Tiltfile", `
+k8s_yaml(['foo.yaml', 'bar.yaml'], context='kind-data')
+k8s_resource('bar', context='fake-context')
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo")).K8sTarget()
+	assert.Equal(t, "kind-data", foo.KubeContext)
+	bar := f.assertNextManifest("bar", deployment("bar")).K8sTarget()
+	assert.Equal(t, "", bar.KubeContext)
+}
+
+func TestK8sYAMLUnknownContext(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFoo()
+	f.file("Tiltfile", `
+k8s_yaml('foo.yaml', context='missing')
+`)
+
+	f.loadErrString(`k8s_yaml: context "missing" not found in kubeconfig`)
+}
+
+func TestK8sYAMLProdContext(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.setupFoo()
+	f.file("Tiltfile", `
+k8s_yaml('foo.yaml', context='prod')
+`)
+
+	f.loadErrString("Stop! prod (used by resource foo) might be production")
+
+	f.file("Tiltfile", `
+allow_k8s_contexts('prod')
+k8s_yaml('foo.yaml', context='prod')
+`)
+
+	f.load()
+	foo := f.assertNextManifest("foo", deployment("foo")).K8sTarget()
+	assert.Equal(t, "prod", foo.KubeContext)
+}
+
 func TestK8sResourceNewNameConflict(t *testing.T) {
 	f := newFixture(t)
 	defer f.TearDown()
@@ -3828,6 +4354,49 @@ k8s_yaml('foo.yaml')
 	)
 }
 
+func TestDockerBuildAttestation(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.dockerfile("Dockerfile")
+	f.yaml("foo.yaml", deployment("foo", image("gcr.io/foo")))
+	f.file("Tiltfile", `
+docker_build('gcr.io/foo', '.',
+  sign_cmd='cosign sign --key cosign.key $TILT_IMAGE_DIGEST_REF',
+  sbom_cmd=['syft', 'attest'])
+k8s_yaml('foo.yaml')
+`)
+
+	f.load()
+	m := f.assertNextManifest("foo", db(image("gcr.io/foo")))
+	assert.Equal(t, model.ImageAttestation{
+		SignCmd: model.ToHostCmdInDir("cosign sign --key cosign.key $TILT_IMAGE_DIGEST_REF", f.Path()),
+		SBOMCmd: model.Cmd{Argv: []string{"syft", "attest"}, Dir: f.Path()},
+	}, m.ImageTargetAt(0).Attestation)
+}
+
+func TestCustomBuildAttestation(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.dockerfile("Dockerfile")
+	f.yaml("foo.yaml", deployment("foo", image("gcr.io/foo")))
+	f.file("Tiltfile", `
+custom_build('gcr.io/foo', 'docker build -t $EXPECTED_REF foo',
+ ['foo'], sign_cmd='./sign.sh')
+k8s_yaml('foo.yaml')
+`)
+
+	f.load()
+	m := f.assertNextManifest("foo", cb(
+		image("gcr.io/foo"),
+		deps(f.JoinPath("foo")),
+		cmd("docker build -t $EXPECTED_REF foo", f.Path())),
+	)
+	assert.Equal(t, model.ToHostCmdInDir("./sign.sh", f.Path()), m.ImageTargetAt(0).Attestation.SignCmd)
+	assert.True(t, m.ImageTargetAt(0).Attestation.SBOMCmd.Empty())
+}
+
 func TestCustomBuildContainerArgs(t *testing.T) {
 	f := newFixture(t)
 	defer f.TearDown()
@@ -4362,6 +4931,37 @@ k8s_yaml('secret.yaml')
 	assert.Equal(t, "d29ybGQ=", string(secrets["world"].ValueEncoded))
 }
 
+func TestSecretBuiltinScrubbed(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", `
+token = secret('api-token', from_cmd='echo s3cr3t')
+local_resource('foo', 'echo hi', env={'TOKEN': token})
+`)
+
+	f.load()
+
+	secrets := f.loadResult.Secrets
+	assert.Equal(t, "api-token", secrets["s3cr3t"].Name)
+	m := f.assertNextManifest("foo")
+	assert.Contains(t, m.LocalTarget().UpdateCmd.Env, "TOKEN=s3cr3t")
+}
+
+func TestSecretBuiltinScrubDisabled(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", `
+secret('api-token', from_cmd='echo s3cr3t')
+secret_settings(disable_scrub=True)
+`)
+
+	f.load()
+
+	assert.Empty(t, f.loadResult.Secrets)
+}
+
 func TestSecretSettingsDisableScrub(t *testing.T) {
 	f := newFixture(t)
 	defer f.TearDown()
@@ -4691,6 +5291,125 @@ func TestK8sUpsertTimeout(t *testing.T) {
 	}
 }
 
+func TestK8sApplyMode(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		tiltfile            string
+		expectErrorContains string
+		expectedMode        model.K8sApplyMode
+	}{
+		{
+			name:         "default value if func not called",
+			tiltfile:     "print('hello world')",
+			expectedMode: model.K8sApplyModeClientSide,
+		},
+		{
+			name:         "server-side",
+			tiltfile:     "update_settings(k8s_apply_mode='server-side')",
+			expectedMode: model.K8sApplyModeServerSide,
+		},
+		{
+			name:         "server-side-force",
+			tiltfile:     "update_settings(k8s_apply_mode='server-side-force')",
+			expectedMode: model.K8sApplyModeServerSideForce,
+		},
+		{
+			name:         "client-side",
+			tiltfile:     "update_settings(k8s_apply_mode='client-side')",
+			expectedMode: model.K8sApplyModeClientSide,
+		},
+		{
+			name:                "invalid mode",
+			tiltfile:            "update_settings(k8s_apply_mode='sideways')",
+			expectErrorContains: `for parameter "k8s_apply_mode": must be one of ["client-side" "server-side" "server-side-force"]; got "sideways"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			defer f.TearDown()
+
+			f.file("Tiltfile", tc.tiltfile)
+
+			if tc.expectErrorContains != "" {
+				f.loadErrString(tc.expectErrorContains)
+				return
+			}
+
+			f.load()
+			assert.Equal(t, tc.expectedMode, f.loadResult.UpdateSettings.K8sApplyMode())
+		})
+	}
+}
+
+func TestMaxLogLength(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		tiltfile            string
+		expectErrorContains string
+		expectedLength      int
+	}{
+		{
+			name:           "default value if func not called",
+			tiltfile:       "print('hello world')",
+			expectedLength: model.DefaultMaxLogLength,
+		},
+		{
+			name:           "set max log length",
+			tiltfile:       "update_settings(max_log_length=5000000)",
+			expectedLength: 5000000,
+		},
+		{
+			name:                "must be positive int",
+			tiltfile:            "update_settings(max_log_length=0)",
+			expectErrorContains: "max log length must be >= 1 byte",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			defer f.TearDown()
+
+			f.file("Tiltfile", tc.tiltfile)
+
+			if tc.expectErrorContains != "" {
+				f.loadErrString(tc.expectErrorContains)
+				return
+			}
+
+			f.load()
+			assert.Equal(t, tc.expectedLength, f.loadResult.UpdateSettings.MaxLogLength())
+		})
+	}
+}
+
+func TestClearLogsOnRebuild(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", "update_settings(clear_logs_on_rebuild=True)")
+
+	f.load()
+	assert.True(t, f.loadResult.UpdateSettings.ClearLogsOnRebuild())
+}
+
+func TestClearLogsOnRebuildNotBool(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", "update_settings(clear_logs_on_rebuild='yes')")
+
+	f.loadErrString("for parameter \"clear_logs_on_rebuild\": got starlark.String, want bool")
+}
+
+func TestK8sDeployDiff(t *testing.T) {
+	f := newFixture(t)
+	defer f.TearDown()
+
+	f.file("Tiltfile", "update_settings(k8s_deploy_diff=True)")
+
+	f.load()
+	assert.True(t, f.loadResult.UpdateSettings.K8sDeployDiff())
+}
+
 func TestUpdateSettingsCalledTwice(t *testing.T) {
 	f := newFixture(t)
 	defer f.TearDown()
@@ -5392,6 +6111,7 @@ type fixture struct {
 	kCli       *k8s.FakeK8sClient
 	k8sContext k8s.KubeContext
 	k8sEnv     k8s.Env
+	k8sConfig  *api.Config
 	webHost    model.WebHost
 
 	ta *tiltanalytics.TiltAnalytics
@@ -5402,7 +6122,7 @@ type fixture struct {
 }
 
 func (f *fixture) newTiltfileLoader() TiltfileLoader {
-	dcc := dockercompose.NewDockerComposeClient(docker.LocalEnv{})
+	dcc := dockercompose.NewDockerComposeClient(docker.LocalEnv{}, docker.NewFakeClient())
 	features := feature.Defaults{
 		"testflag_disabled": feature.Value{Enabled: false},
 		"testflag_enabled":  feature.Value{Enabled: true},
@@ -5410,10 +6130,10 @@ func (f *fixture) newTiltfileLoader() TiltfileLoader {
 		feature.Snapshots:   feature.Value{Enabled: true},
 	}
 
-	k8sContextExt := k8scontext.NewExtension(f.k8sContext, f.k8sEnv)
+	k8sContextExt := k8scontext.NewExtension(f.k8sContext, f.k8sEnv, f.k8sConfig)
 	versionExt := version.NewExtension(model.TiltBuild{Version: "0.5.0"})
 	configExt := config.NewExtension("up")
-	return ProvideTiltfileLoader(f.ta, f.kCli, k8sContextExt, versionExt, configExt, dcc, f.webHost, features, f.k8sEnv)
+	return ProvideTiltfileLoader(f.ta, f.kCli, k8s.DefaultNamespace, k8sContextExt, versionExt, configExt, dcc, f.webHost, features, f.k8sEnv)
 }
 
 func newFixture(t *testing.T) *fixture {
@@ -5433,6 +6153,7 @@ func newFixture(t *testing.T) *fixture {
 		kCli:           kCli,
 		k8sContext:     "fake-context",
 		k8sEnv:         k8s.EnvDockerDesktop,
+		k8sConfig:      newFakeKubeConfig(),
 	}
 
 	// Collect the warnings
@@ -5448,6 +6169,17 @@ func newFixture(t *testing.T) *fixture {
 	return r
 }
 
+// A kubeconfig with a local cluster and a production cluster,
+// besides the context that Tilt started with.
+func newFakeKubeConfig() *api.Config {
+	config := api.NewConfig()
+	config.CurrentContext = "fake-context"
+	config.Contexts["fake-context"] = &api.Context{Cluster: "docker-desktop"}
+	config.Contexts["kind-data"] = &api.Context{Cluster: "kind-data"}
+	config.Contexts["prod"] = &api.Context{Cluster: "prod"}
+	return config
+}
+
 func (f *fixture) file(path string, contents string) {
 	f.WriteFile(path, contents)
 }
@@ -5960,6 +6692,14 @@ func (f *fixture) assertWarnings(warnings ...string) {
 	assert.Equal(f.t, expected, f.warnings)
 }
 
+func (f *fixture) namespaceNames(y string) []string {
+	var result []string
+	for _, e := range f.entities(y) {
+		result = append(result, e.Name())
+	}
+	return result
+}
+
 func (f *fixture) entities(y string) []k8s.K8sEntity {
 	es, err := k8s.ParseYAMLFromString(y)
 	if err != nil {
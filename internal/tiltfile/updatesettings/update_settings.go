@@ -28,10 +28,15 @@ func (e Extension) OnStart(env *starkit.Environment) error {
 }
 
 func (e *Extension) updateSettings(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
-	var maxParallelUpdates, k8sUpsertTimeoutSecs starlark.Value
+	var maxParallelUpdates, k8sUpsertTimeoutSecs, maxLogLength, clearLogsOnRebuild, k8sDeployDiff starlark.Value
+	var k8sApplyMode string
 	if err := starkit.UnpackArgs(thread, fn.Name(), args, kwargs,
 		"max_parallel_updates?", &maxParallelUpdates,
-		"k8s_upsert_timeout_secs?", &k8sUpsertTimeoutSecs); err != nil {
+		"k8s_upsert_timeout_secs?", &k8sUpsertTimeoutSecs,
+		"k8s_apply_mode?", &k8sApplyMode,
+		"max_log_length?", &maxLogLength,
+		"clear_logs_on_rebuild?", &clearLogsOnRebuild,
+		"k8s_deploy_diff?", &k8sDeployDiff); err != nil {
 		return nil, err
 	}
 
@@ -53,6 +58,29 @@ func (e *Extension) updateSettings(thread *starlark.Thread, fn *starlark.Builtin
 			k8sUpsertTimeoutSecs)
 	}
 
+	mll, mllPassed, err := valueToInt(maxLogLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "update_settings: for parameter \"max_log_length\"")
+	}
+	if mllPassed && mll < 1 {
+		return nil, fmt.Errorf("max log length must be >= 1 byte (got: %d)", mll)
+	}
+
+	clor, clorPassed, err := valueToBool(clearLogsOnRebuild)
+	if err != nil {
+		return nil, errors.Wrap(err, "update_settings: for parameter \"clear_logs_on_rebuild\"")
+	}
+
+	kdd, kddPassed, err := valueToBool(k8sDeployDiff)
+	if err != nil {
+		return nil, errors.Wrap(err, "update_settings: for parameter \"k8s_deploy_diff\"")
+	}
+
+	if k8sApplyMode != "" && !isValidApplyMode(model.K8sApplyMode(k8sApplyMode)) {
+		return nil, fmt.Errorf("update_settings: for parameter \"k8s_apply_mode\": must be one of %q; got %q",
+			model.K8sApplyModes, k8sApplyMode)
+	}
+
 	err = starkit.SetState(thread, func(settings model.UpdateSettings) model.UpdateSettings {
 		if mpuPassed {
 			settings = settings.WithMaxParallelUpdates(mpu)
@@ -60,6 +88,18 @@ func (e *Extension) updateSettings(thread *starlark.Thread, fn *starlark.Builtin
 		if kutsPassed {
 			settings = settings.WithK8sUpsertTimeout(time.Duration(kuts) * time.Second)
 		}
+		if k8sApplyMode != "" {
+			settings = settings.WithK8sApplyMode(model.K8sApplyMode(k8sApplyMode))
+		}
+		if mllPassed {
+			settings = settings.WithMaxLogLength(mll)
+		}
+		if clorPassed {
+			settings = settings.WithClearLogsOnRebuild(clor)
+		}
+		if kddPassed {
+			settings = settings.WithK8sDeployDiff(kdd)
+		}
 		return settings
 	})
 
@@ -78,6 +118,26 @@ func valueToInt(v starlark.Value) (val int, wasPassed bool, err error) {
 	}
 }
 
+func valueToBool(v starlark.Value) (val bool, wasPassed bool, err error) {
+	switch x := v.(type) {
+	case nil, starlark.NoneType:
+		return false, false, nil
+	case starlark.Bool:
+		return bool(x), true, nil
+	default:
+		return false, true, fmt.Errorf("got %T, want bool", x)
+	}
+}
+
+func isValidApplyMode(mode model.K8sApplyMode) bool {
+	for _, m := range model.K8sApplyModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
 var _ starkit.StatefulExtension = Extension{}
 
 func MustState(model starkit.Model) model.UpdateSettings {
@@ -56,11 +56,8 @@ type PodLogStreamList struct {
 
 // PodLogStreamSpec defines the desired state of PodLogStream
 //
-// Translated into a PodLog query to the current Kubernetes cluster:
+// Translated into a PodLog query to a Kubernetes cluster:
 // https://pkg.go.dev/k8s.io/api/core/v1#PodLogOptions
-//
-// TODO(nick): Should all Kubernetes types have an object that describes
-// which Kubernetes context to use?
 type PodLogStreamSpec struct {
 	// The name of the pod to watch. Required.
 	Pod string `json:"pod,omitempty"`
@@ -70,6 +67,12 @@ type PodLogStreamSpec struct {
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
 
+	// The kubeconfig context of the cluster that the pod runs in.
+	// Defaults to the context that Tilt started with.
+	//
+	// +optional
+	Context string `json:"context,omitempty"`
+
 	// An RFC3339 timestamp from which to show logs. If this value
 	// precedes the time a pod was started, only logs since the pod start will be returned.
 	// If this value is in the future, no logs will be returned.
@@ -94,6 +97,31 @@ type PodLogStreamSpec struct {
 	//
 	// +optional
 	IgnoreContainers []string `json:"ignoreContainers,omitempty"`
+
+	// If true, shows the logs of the previous instance of each container
+	// (the one that most recently terminated), instead of following
+	// the current instance.
+	//
+	// Even when this is false, if a container restarts before we stream
+	// its logs, we read the final logs of the instance that terminated,
+	// so that crash output isn't lost.
+	//
+	// +optional
+	Previous bool `json:"previous,omitempty"`
+
+	// How to parse each line of the log.
+	//
+	// If empty, lines are plain text. If `json`, each line is parsed as a
+	// JSON object, and its fields are attached to the log line.
+	//
+	// +optional
+	LogFormat string `json:"logFormat,omitempty"`
+
+	// When logFormat is `json`, maps the fields that Tilt reads
+	// (`level` and `msg`) to the names of the fields in the JSON log.
+	//
+	// +optional
+	LogFields map[string]string `json:"logFields,omitempty"`
 }
 
 var _ resource.Object = &PodLogStream{}
@@ -626,6 +626,13 @@ func (in *PodLogStreamSpec) DeepCopyInto(out *PodLogStreamSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LogFields != nil {
+		in, out := &in.LogFields, &out.LogFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
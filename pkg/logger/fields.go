@@ -14,3 +14,16 @@ const FieldNameBuildEvent = "buildEvent"
 // progressMustPrint="1" indicates that this line must appear in the
 // output - e.g., a line that communicates that the upload finished.
 const FieldNameProgressMustPrint = "progressMustPrint"
+
+// The original text of a structured log line (e.g., a line of JSON),
+// before Tilt reformatted it for display.
+const FieldNameRaw = "raw"
+
+// The output stream that a log line came from, for logs that we read
+// from a process that separates them, like a container.
+//
+// Only set to "stderr". Logs without a stream field came from stdout,
+// or from somewhere that doesn't separate the streams.
+const FieldNameStream = "stream"
+
+const StreamStderr = "stderr"
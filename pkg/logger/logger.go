@@ -2,8 +2,10 @@ package logger
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 
@@ -84,6 +86,33 @@ var (
 	ErrorLvl   = Level{id: 5, severity: 500}
 )
 
+// The inverse of Level.ToProtoID. Unknown IDs map to NoneLvl.
+func LevelFromProtoID(id int32) Level {
+	for _, l := range []Level{NoneLvl, DebugLvl, VerboseLvl, InfoLvl, WarnLvl, ErrorLvl} {
+		if l.id == id {
+			return l
+		}
+	}
+	return NoneLvl
+}
+
+// Parses a level name, like "warn", as it would appear in a command-line flag.
+func LevelFromName(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return DebugLvl, nil
+	case "verbose":
+		return VerboseLvl, nil
+	case "info":
+		return InfoLvl, nil
+	case "warn", "warning":
+		return WarnLvl, nil
+	case "error":
+		return ErrorLvl, nil
+	}
+	return NoneLvl, fmt.Errorf("unknown log level %q (expected one of: debug, verbose, info, warn, error)", name)
+}
+
 type contextKey struct{}
 
 var LoggerContextKey = contextKey{}
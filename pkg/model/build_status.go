@@ -29,6 +29,11 @@ type BuildRecord struct {
 	// We count the warnings by looking up all the logs with Level=WARNING
 	// in the logstore. We store this number separately for ease of use.
 	WarningCount int
+
+	// A unified diff between the live Kubernetes objects and the objects
+	// this build deployed. Empty if the build didn't deploy to Kubernetes,
+	// or if nothing changed.
+	K8sDiff string
 }
 
 func (bs BuildRecord) Empty() bool {
@@ -21,6 +21,10 @@ type ImageTarget struct {
 	// (i.e. overrides k8s yaml "args")
 	OverrideArgs OverrideArgs
 
+	// User-supplied commands to run after the image is pushed
+	// (e.g., to sign it or attach an SBOM).
+	Attestation ImageAttestation
+
 	cachePaths []string
 
 	// TODO(nick): It might eventually make sense to represent
@@ -39,6 +43,20 @@ type OverrideArgs struct {
 	Args           []string
 }
 
+// Commands to run against an image after it's been pushed to a registry.
+//
+// Each command runs with TILT_IMAGE_REF, TILT_IMAGE_DIGEST, and TILT_IMAGE_DIGEST_REF
+// in its environment. If a command fails, the build fails, so that
+// we never deploy an image that's missing its attestations.
+type ImageAttestation struct {
+	SignCmd Cmd
+	SBOMCmd Cmd
+}
+
+func (a ImageAttestation) Empty() bool {
+	return a.SignCmd.Empty() && a.SBOMCmd.Empty()
+}
+
 func MustNewImageTarget(ref container.RefSelector) ImageTarget {
 	return ImageTarget{Refs: container.MustSimpleRefSet(ref)}
 }
@@ -197,6 +215,11 @@ func (i ImageTarget) WithOverrideCommand(cmd Cmd) ImageTarget {
 	return i
 }
 
+func (i ImageTarget) WithAttestation(a ImageAttestation) ImageTarget {
+	i.Attestation = a
+	return i
+}
+
 func (i ImageTarget) Dockerignores() []Dockerignore {
 	return append([]Dockerignore{}, i.dockerignores...)
 }
@@ -257,6 +280,10 @@ type DockerBuild struct {
 	// https://docs.docker.com/develop/develop-images/build_enhancements/#new-docker-build-secret-information
 	SecretSpecs []string
 
+	// Secret values (from the Tiltfile's secret() builtin) to pass to docker,
+	// keyed by secret ID.
+	Secrets map[string]string
+
 	Network string
 
 	PullParent bool
@@ -29,10 +29,32 @@ const PodReadinessWait PodReadinessMode = "wait"
 // Don't even wait for pods to appear.
 const PodReadinessIgnore PodReadinessMode = "ignore"
 
+// Tells Tilt how to decide whether a Kubernetes object that isn't a pod
+// (e.g., a custom resource with status conditions) is ready.
+type K8sObjectReadiness struct {
+	Ref v1.ObjectReference
+
+	// The object is ready when the status condition of this type is True.
+	Condition string
+
+	// The object is ready when this JSONPath evaluates to "true".
+	JSONPath string
+}
+
+// A unique key for the object, as declared in the Tiltfile.
+func (r K8sObjectReadiness) Key() string {
+	if r.Ref.Namespace == "" {
+		return fmt.Sprintf("%s/%s", r.Ref.Kind, r.Ref.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", r.Ref.Kind, r.Ref.Namespace, r.Ref.Name)
+}
+
 type K8sTarget struct {
 	Name         TargetName
 	YAML         string
 	PortForwards []PortForward
+	// Tunnels from a Service in the cluster back to ports on the current machine.
+	ReverseForwards []ReverseForward
 	// labels for pods that we should watch and associate with this resource
 	ExtraPodSelectors []labels.Selector
 
@@ -65,6 +87,34 @@ type K8sTarget struct {
 	// zero+ links assoc'd with this resource (to be displayed in UIs,
 	// in addition to any port forwards/LB endpoints)
 	Links []Link
+
+	// The apply-set label value for all objects deployed by this target.
+	// Used to garbage-collect objects that are removed from the Tiltfile.
+	ApplySet string
+
+	// If true, objects removed from this target are left in the cluster,
+	// rather than garbage-collected.
+	KeepOnRemoval bool
+
+	// Objects that must be ready before we consider this resource ready,
+	// in addition to its pods.
+	ObjectReadiness []K8sObjectReadiness
+
+	// The kubeconfig context to deploy to.
+	// If empty, we deploy to the context that Tilt started with.
+	KubeContext string
+
+	// Namespace objects for the namespaces that this target deploys to,
+	// but doesn't declare itself. Any that don't exist are created before deploy.
+	NamespaceYAML string
+
+	// How to parse the logs of this target's pods.
+	LogFormat LogFormat
+
+	// Maps the fields that Tilt reads from structured logs (like "level")
+	// to the names that this target's pods use. Fields that aren't
+	// mapped use Tilt's name.
+	LogFields map[string]string
 }
 
 func (k8s K8sTarget) Empty() bool { return reflect.DeepEqual(k8s, K8sTarget{}) }
@@ -78,6 +128,14 @@ func (k8s K8sTarget) HasJob() bool {
 	return false
 }
 
+func (k8s K8sTarget) ObjectReadinessKeys() []string {
+	var result []string
+	for _, r := range k8s.ObjectReadiness {
+		result = append(result, r.Key())
+	}
+	return result
+}
+
 func (k8s K8sTarget) DependencyIDs() []TargetID {
 	return k8s.dependencyIDs
 }
@@ -0,0 +1,41 @@
+package model
+
+import "net"
+
+// Log export settings configure where Tilt sends its logs, as OpenTelemetry
+// log records over OTLP/HTTP.
+// https://opentelemetry.io/docs/specs/otlp/#otlphttp
+type LogExportSettings struct {
+	Enabled bool
+
+	// The host and port of the OTLP/HTTP receiver.
+	// Logs are sent to the /v1/logs path.
+	Address string
+
+	// If true, send logs over plain HTTP instead of HTTPS.
+	//
+	// Collectors on localhost usually don't serve HTTPS, so this defaults to
+	// true for localhost addresses and false for everything else.
+	Insecure bool
+}
+
+func DefaultLogExportSettings() LogExportSettings {
+	return LogExportSettings{
+		Enabled:  false,
+		Address:  "localhost:4318",
+		Insecure: true,
+	}
+}
+
+// Reports whether the given host:port is on this machine.
+func IsLocalhostAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -1,3 +1,55 @@
 package model
 
 type LogSpanID string
+
+// How Tilt should parse the runtime logs of a resource.
+type LogFormat string
+
+// Logs are plain text, and each line is shown as-is.
+const LogFormatText LogFormat = ""
+
+// Each line is a JSON object. Tilt reads the level and message from it,
+// and shows a more readable line.
+const LogFormatJSON LogFormat = "json"
+
+// The fields that Tilt reads from a structured log line. Services may use
+// their own names for these fields, which can be mapped in the Tiltfile.
+const (
+	LogFieldLevel = "level"
+	LogFieldMsg   = "msg"
+)
+
+// Limits on the logs that Tilt keeps in memory for a resource, so that one
+// noisy resource can't push out the logs of every other resource.
+//
+// Zero means no limit.
+type LogLimit struct {
+	// The most bytes of logs to keep.
+	Bytes int
+
+	// The most lines of logs to keep.
+	Lines int
+
+	// The most lines to accept in any one second. Lines over the limit are
+	// dropped, and replaced by a message that says how many were dropped.
+	LinesPerSecond int
+}
+
+func (l LogLimit) Empty() bool {
+	return l == LogLimit{}
+}
+
+// Patterns (Go regular expressions) that Tilt looks for in the runtime logs
+// of a resource, e.g., `panic:` or `OutOfMemory`.
+type LogAlerts struct {
+	// Lines that match are shown as warnings, and counted on the resource.
+	Patterns []string
+
+	// Lines that match are shown as errors, and put the resource's runtime
+	// in an error state until its next deploy.
+	FatalPatterns []string
+}
+
+func (a LogAlerts) Empty() bool {
+	return len(a.Patterns) == 0 && len(a.FatalPatterns) == 0
+}
@@ -0,0 +1,675 @@
+package logstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+const (
+	archiveCurrentDir  = "current"
+	archivePreviousDir = "previous"
+	archiveFileExt     = ".log"
+
+	// We write records to a series of chunk files, and start a new chunk
+	// when the last one gets this big.
+	archiveChunkBytes = 8 * 1000 * 1000
+
+	// The most bytes that we keep on disk for each session. When a session
+	// goes over, we delete its oldest chunks.
+	archiveMaxBytes = 200 * 1000 * 1000
+)
+
+// An append-only store of log segments on disk, so that logs survive
+// truncation of the LogStore and restarts of Tilt.
+//
+// Records are newline-delimited JSON, written to a series of chunk files
+// that are named after the checkpoint of their first record. We keep an
+// in-memory index from checkpoint to record, so that we can page through
+// history without reading whole files. Once a session has more than
+// archiveMaxBytes on disk, we delete its oldest chunks, so the index only
+// covers the newest records.
+//
+// Writes happen in the background, so that appending never waits on the disk.
+// Records that haven't been written yet are read from memory. Reads don't hold
+// the lock while they're on the disk, so they never hold up appends either.
+//
+// The archive keeps two sessions: the current one, and the one before it.
+// Each session numbers its checkpoints from 0, like the LogStore does.
+//
+// Thread-safe.
+type Archive struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// The directory that the current session writes to.
+	dir string
+
+	// Set for testing.
+	chunkBytes int64
+	maxBytes   int64
+
+	// The chunks of the current session, oldest first. We only write to the last one.
+	// Only touched by the write loop.
+	chunks []*archiveChunk
+	writer *os.File
+
+	// The checkpoint of the first record in the index. The records before it
+	// were deleted to keep the session under maxBytes.
+	start Checkpoint
+
+	// Every record in the current session that's on disk, in checkpoint order.
+	// We only ever add entries to the end, so reads can keep using a slice of
+	// it after they let go of the lock.
+	index []archiveEntry
+
+	// Records that are waiting to be written. They come right after the index.
+	pending []archiveRecord
+
+	// The first error that we hit writing to disk. After that, we stop archiving.
+	writeErr error
+
+	// The chunks of the previous session, oldest first. Guarded by previousMu,
+	// so that indexing them doesn't hold up the current session.
+	previousMu sync.Mutex
+	previous   []*previousChunk
+
+	closing bool
+	closed  bool
+
+	// Closed when the write loop exits.
+	done chan struct{}
+}
+
+type archiveChunk struct {
+	path    string
+	size    int64
+	records int
+}
+
+// A chunk of the previous session. Most sessions are never read back, so
+// we only find the records in a chunk the first time we read from it.
+type previousChunk struct {
+	path    string
+	start   Checkpoint
+	entries []archiveEntry
+	indexed bool
+}
+
+type archiveEntry struct {
+	path   string
+	offset int64
+	size   int
+}
+
+type archiveRecord struct {
+	Checkpoint   Checkpoint         `json:"checkpoint"`
+	SpanID       SpanID             `json:"span"`
+	ManifestName model.ManifestName `json:"manifest,omitempty"`
+	Time         time.Time          `json:"time"`
+	Level        int32              `json:"level"`
+	Fields       logger.Fields      `json:"fields,omitempty"`
+	Text         string             `json:"text"`
+	Anchor       bool               `json:"anchor,omitempty"`
+}
+
+func (r archiveRecord) segment() LogSegment {
+	return LogSegment{
+		SpanID: r.SpanID,
+		Time:   r.Time,
+		Text:   []byte(r.Text),
+		Level:  logger.LevelFromProtoID(r.Level),
+		Fields: r.Fields,
+		Anchor: r.Anchor,
+
+		checkpoint: r.Checkpoint,
+	}
+}
+
+// Opens the archive in the given directory, and starts a new session.
+//
+// The last session becomes the previous session, and anything older
+// is deleted.
+func OpenArchive(dir string) (*Archive, error) {
+	current := filepath.Join(dir, archiveCurrentDir)
+	previous := filepath.Join(dir, archivePreviousDir)
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, errors.Wrap(err, "OpenArchive")
+	}
+
+	err = os.RemoveAll(previous)
+	if err != nil {
+		return nil, errors.Wrap(err, "OpenArchive")
+	}
+
+	_, err = os.Stat(current)
+	if err == nil {
+		err = os.Rename(current, previous)
+		if err != nil {
+			return nil, errors.Wrap(err, "OpenArchive")
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "OpenArchive")
+	}
+
+	err = os.MkdirAll(current, 0700)
+	if err != nil {
+		return nil, errors.Wrap(err, "OpenArchive")
+	}
+
+	previousChunks, err := listArchiveChunks(previous)
+	if err != nil {
+		return nil, errors.Wrap(err, "OpenArchive")
+	}
+
+	a := &Archive{
+		dir:        current,
+		chunkBytes: archiveChunkBytes,
+		maxBytes:   archiveMaxBytes,
+		previous:   previousChunks,
+		done:       make(chan struct{}),
+	}
+	a.cond = sync.NewCond(&a.mu)
+	go a.writeLoop()
+	return a, nil
+}
+
+// Lists the chunks in a session directory, in checkpoint order.
+func listArchiveChunks(dir string) ([]*previousChunk, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var chunks []*previousChunk
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || filepath.Ext(name) != archiveFileExt {
+			continue
+		}
+
+		start, err := strconv.ParseInt(strings.TrimSuffix(name, archiveFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		chunks = append(chunks, &previousChunk{
+			path:  filepath.Join(dir, name),
+			start: Checkpoint(start),
+		})
+	}
+
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].start < chunks[j].start
+	})
+	return chunks, nil
+}
+
+// Finds where each record in the chunk starts, without parsing them.
+//
+// If the session crashed in the middle of a write, the last record
+// may be incomplete, so we drop any text after the last newline.
+func (c *previousChunk) index() error {
+	if c.indexed {
+		return nil
+	}
+
+	f, err := os.Open(c.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	offset := int64(0)
+	for {
+		line, err := r.ReadBytes(newlineByte)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		c.entries = append(c.entries, archiveEntry{path: c.path, offset: offset, size: len(line)})
+		offset += int64(len(line))
+	}
+
+	c.indexed = true
+	return nil
+}
+
+// The checkpoint of the oldest record that's still in the current session.
+func (a *Archive) First() Checkpoint {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.start
+}
+
+// The number of segments archived in the current session, including those
+// that we deleted to stay under the size limit. This is also the checkpoint
+// of the next segment to be archived.
+func (a *Archive) Len() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.len()
+}
+
+func (a *Archive) len() int {
+	return int(a.start) + len(a.index) + len(a.pending)
+}
+
+// The number of segments in the previous session.
+//
+// Only reads the last chunk of the session.
+func (a *Archive) PreviousLen() (int, error) {
+	a.previousMu.Lock()
+	defer a.previousMu.Unlock()
+
+	if len(a.previous) == 0 {
+		return 0, nil
+	}
+	last := a.previous[len(a.previous)-1]
+	err := last.index()
+	if err != nil {
+		return 0, errors.Wrap(err, "log archive")
+	}
+	return int(last.start) + len(last.entries), nil
+}
+
+// Queues segments to be written to the archive, in checkpoint order.
+//
+// Segments that are already in the archive are skipped. Returns an error
+// if an earlier write failed.
+func (a *Archive) append(segments []LogSegment, spans map[SpanID]*Span) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed || a.closing {
+		return fmt.Errorf("log archive closed")
+	}
+	if a.writeErr != nil {
+		return a.writeErr
+	}
+
+	added := false
+	for _, segment := range segments {
+		next := Checkpoint(a.len())
+		if segment.checkpoint < next {
+			continue
+		}
+		if segment.checkpoint > next {
+			return fmt.Errorf("log archive: missing logs between checkpoints %d and %d", next, segment.checkpoint)
+		}
+
+		record := archiveRecord{
+			Checkpoint: segment.checkpoint,
+			SpanID:     segment.SpanID,
+			Time:       segment.Time,
+			Level:      segment.Level.ToProtoID(),
+			Fields:     segment.Fields,
+			Text:       string(segment.Text),
+			Anchor:     segment.Anchor,
+		}
+		if span, ok := spans[segment.SpanID]; ok {
+			record.ManifestName = span.ManifestName
+		}
+		a.pending = append(a.pending, record)
+		added = true
+	}
+
+	if added {
+		a.cond.Signal()
+	}
+	return nil
+}
+
+// Writes pending records to disk until the archive is closed.
+func (a *Archive) writeLoop() {
+	defer close(a.done)
+
+	for {
+		a.mu.Lock()
+		for len(a.pending) == 0 && !a.closing {
+			a.cond.Wait()
+		}
+		if len(a.pending) == 0 {
+			a.mu.Unlock()
+			return
+		}
+
+		// Records in the batch never change once they're queued,
+		// so it's safe to write them without the lock.
+		batch := a.pending
+		a.mu.Unlock()
+
+		entries, err := a.write(batch)
+
+		a.mu.Lock()
+		a.index = append(a.index, entries...)
+		a.pending = a.pending[len(entries):]
+		if err != nil {
+			a.writeErr = errors.Wrap(err, "log archive")
+			a.pending = nil
+		}
+		if len(a.pending) == 0 {
+			a.pending = nil
+		}
+		a.deleteOldChunks()
+		a.mu.Unlock()
+	}
+}
+
+// Writes the records to the end of the last chunk, starting new chunks as we go.
+//
+// Returns the index entries of the records that we wrote.
+func (a *Archive) write(records []archiveRecord) ([]archiveEntry, error) {
+	entries := make([]archiveEntry, 0, len(records))
+	var w *bufio.Writer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return entries, err
+		}
+		line = append(line, newlineByte)
+
+		chunk := a.lastChunk()
+		if chunk == nil || (chunk.size > 0 && chunk.size+int64(len(line)) > a.chunkBytes) {
+			if w != nil {
+				err := w.Flush()
+				if err != nil {
+					return entries, err
+				}
+			}
+
+			chunk, err = a.startChunk(record.Checkpoint)
+			if err != nil {
+				return entries, err
+			}
+			w = nil
+		}
+
+		if w == nil {
+			w = bufio.NewWriter(a.writer)
+		}
+		_, err = w.Write(line)
+		if err != nil {
+			return entries, err
+		}
+
+		entries = append(entries, archiveEntry{path: chunk.path, offset: chunk.size, size: len(line)})
+		chunk.size += int64(len(line))
+		chunk.records++
+	}
+
+	if w != nil {
+		err := w.Flush()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func (a *Archive) lastChunk() *archiveChunk {
+	if len(a.chunks) == 0 {
+		return nil
+	}
+	return a.chunks[len(a.chunks)-1]
+}
+
+// Closes the last chunk and starts a new one, named after the checkpoint of
+// its first record so that the chunks sort in order.
+func (a *Archive) startChunk(start Checkpoint) (*archiveChunk, error) {
+	if a.writer != nil {
+		err := a.writer.Close()
+		a.writer = nil
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(a.dir, fmt.Sprintf("%010d%s", start, archiveFileExt))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	a.writer = f
+	chunk := &archiveChunk{path: path}
+	a.chunks = append(a.chunks, chunk)
+	return chunk, nil
+}
+
+// Deletes the oldest chunks until the session is under maxBytes.
+// We never delete the chunk that we're writing to.
+func (a *Archive) deleteOldChunks() {
+	size := int64(0)
+	for _, chunk := range a.chunks {
+		size += chunk.size
+	}
+
+	deleted := 0
+	for size > a.maxBytes && len(a.chunks) > 1 {
+		chunk := a.chunks[0]
+		a.chunks = a.chunks[1:]
+		_ = os.Remove(chunk.path)
+
+		size -= chunk.size
+		deleted += chunk.records
+	}
+
+	if deleted > 0 {
+		a.index = append([]archiveEntry(nil), a.index[deleted:]...)
+		a.start += Checkpoint(deleted)
+	}
+}
+
+// Reads records from the current session, starting at the given checkpoint,
+// until we hit the end checkpoint or read more than maxBytes of log text.
+//
+// Skips ahead to the oldest record that we still have.
+func (a *Archive) read(from, to Checkpoint, maxBytes int) ([]archiveRecord, error) {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil, fmt.Errorf("log archive closed")
+	}
+
+	if from < a.start {
+		from = a.start
+	}
+	if to > Checkpoint(a.len()) {
+		to = Checkpoint(a.len())
+	}
+
+	// The index and the queue only change at the ends, and the records in them
+	// never change, so we can read from them after we let go of the lock.
+	start := a.start
+	index := a.index
+	pending := a.pending
+	a.mu.Unlock()
+
+	result := []archiveRecord{}
+	bytes := 0
+	end := start + Checkpoint(len(index))
+	if from < end {
+		records, err := readArchiveEntries(index, start, from, minCheckpoint(to, end), maxBytes)
+		if err != nil {
+			return nil, err
+		}
+		result = records
+		for _, record := range records {
+			bytes += len(record.Text)
+		}
+		from = end
+	}
+
+	for c := from; c < to && bytes < maxBytes; c++ {
+		record := pending[c-end]
+		result = append(result, record)
+		bytes += len(record.Text)
+	}
+	return result, nil
+}
+
+// Like read(), but from the previous session.
+func (a *Archive) readPrevious(from, to Checkpoint, maxBytes int) ([]archiveRecord, error) {
+	a.previousMu.Lock()
+	defer a.previousMu.Unlock()
+
+	result := []archiveRecord{}
+	bytes := 0
+	for i, chunk := range a.previous {
+		if from >= to || bytes >= maxBytes {
+			break
+		}
+		if i+1 < len(a.previous) && a.previous[i+1].start <= from {
+			continue
+		}
+
+		err := chunk.index()
+		if err != nil {
+			return nil, errors.Wrap(err, "log archive")
+		}
+
+		if from < chunk.start {
+			from = chunk.start
+		}
+		records, err := readArchiveEntries(chunk.entries, chunk.start, from, to, maxBytes-bytes)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, records...)
+		for _, record := range records {
+			bytes += len(record.Text)
+		}
+		from = maxCheckpoint(from, chunk.start+Checkpoint(len(chunk.entries)))
+	}
+	return result, nil
+}
+
+// Reads records from the entries, where start is the checkpoint of the first entry.
+//
+// Opens each chunk that it reads from once, and closes them all before it
+// returns. Skips the records in chunks that have been deleted.
+func readArchiveEntries(entries []archiveEntry, start, from, to Checkpoint, maxBytes int) ([]archiveRecord, error) {
+	if to > start+Checkpoint(len(entries)) {
+		to = start + Checkpoint(len(entries))
+	}
+
+	files := make(map[string]*os.File)
+	defer func() {
+		for _, f := range files {
+			if f != nil {
+				_ = f.Close()
+			}
+		}
+	}()
+
+	result := []archiveRecord{}
+	bytes := 0
+	for c := from; c < to && bytes < maxBytes; c++ {
+		entry := entries[c-start]
+		f, ok := files[entry.path]
+		if !ok {
+			var err error
+			f, err = os.Open(entry.path)
+			if os.IsNotExist(err) {
+				f = nil
+			} else if err != nil {
+				return nil, errors.Wrap(err, "log archive")
+			}
+			files[entry.path] = f
+		}
+		if f == nil {
+			continue
+		}
+
+		buf := make([]byte, entry.size)
+		_, err := f.ReadAt(buf, entry.offset)
+		if err != nil {
+			return nil, errors.Wrap(err, "log archive")
+		}
+
+		var record archiveRecord
+		err = json.Unmarshal(buf, &record)
+		if err != nil {
+			return nil, errors.Wrapf(err, "log archive: reading %s", entry.path)
+		}
+
+		record.Checkpoint = c
+		result = append(result, record)
+		bytes += len(record.Text)
+	}
+	return result, nil
+}
+
+// Returns the checkpoint where a page of records that ends at the given
+// checkpoint should start.
+//
+// Counts the bytes of the records on disk, which are a bit bigger than
+// their log text, so the page may come back smaller than maxBytes.
+func (a *Archive) pageStart(to Checkpoint, maxBytes int) Checkpoint {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	c := minCheckpoint(to, Checkpoint(a.len()))
+	end := a.start + Checkpoint(len(a.index))
+	bytes := 0
+	for c > a.start && bytes < maxBytes {
+		c--
+		if c >= end {
+			bytes += len(a.pending[c-end].Text)
+		} else {
+			bytes += a.index[c-a.start].size
+		}
+	}
+	return c
+}
+
+// Writes any pending records, then closes the chunk we're writing to.
+// The archive can't be read or written after it's closed.
+func (a *Archive) Close() error {
+	a.mu.Lock()
+	if a.closed || a.closing {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closing = true
+	a.cond.Signal()
+	a.mu.Unlock()
+
+	<-a.done
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closed = true
+
+	firstErr := a.writeErr
+	if a.writer != nil {
+		err := a.writer.Close()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		a.writer = nil
+	}
+	return firstErr
+}
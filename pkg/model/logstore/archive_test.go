@@ -0,0 +1,300 @@
+package logstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/webview"
+)
+
+func TestArchiveKeepsTruncatedLogs(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.maxLogLengthInBytes = 20
+
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), "line\n"), nil)
+	}
+	assert.True(t, l.FirstCheckpoint() < l.checkpointOffset, "expected in-memory logs to be truncated")
+	assert.Equal(t, Checkpoint(10), l.Checkpoint())
+
+	list, err := l.ToLogListRange(0, l.Checkpoint(), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), list.FromCheckpoint)
+	assert.Equal(t, int32(10), list.ToCheckpoint)
+	assert.Equal(t, strings.Repeat("line\n", 10), logListText(list))
+	assert.Equal(t, "fe", list.Spans["fe"].ManifestName)
+}
+
+func TestArchivePaging(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.maxLogLengthInBytes = 20
+
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), "line\n"), nil)
+	}
+
+	text := ""
+	pages := 0
+	from := Checkpoint(0)
+	for {
+		list, err := l.ToLogListRange(from, l.Checkpoint(), 10)
+		require.NoError(t, err)
+		if list.FromCheckpoint == -1 {
+			break
+		}
+		text += logListText(list)
+		from = Checkpoint(list.ToCheckpoint)
+		pages++
+	}
+
+	assert.Equal(t, strings.Repeat("line\n", 10), text)
+	assert.Equal(t, 5, pages)
+}
+
+func TestArchiveKeepsQuietManifestsInMemory(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.maxLogLengthInBytes = 50
+
+	l.Append(newTestLogEvent("be", time.Now(), "be started\n"), nil)
+	for i := 0; i < 20; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), fmt.Sprintf("fe %d\n", i)), nil)
+	}
+
+	// A chatty manifest doesn't push the logs of a quiet one out of memory.
+	assert.Equal(t, "be started\n", l.ManifestLog("be"))
+
+	// Reading across the gaps in memory doesn't duplicate or skip any logs.
+	expected := "be started\n"
+	for i := 0; i < 20; i++ {
+		expected += fmt.Sprintf("fe %d\n", i)
+	}
+	list, err := l.ToLogListRange(0, l.Checkpoint(), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, expected, logListText(list))
+	for i, segment := range list.Segments {
+		assert.Equal(t, int32(i), segment.Checkpoint)
+	}
+
+	result, err := l.Search(Filter{Pattern: regexp.MustCompile("^(be|fe 1)")}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{0, 2, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, matchCheckpoints(result))
+}
+
+func TestArchivePagingBackwards(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.maxLogLengthInBytes = 20
+
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), fmt.Sprintf("line %d\n", i)), nil)
+	}
+
+	text := ""
+	to := l.Checkpoint()
+	for {
+		list, err := l.ToLogListBefore(to, 20)
+		require.NoError(t, err)
+		if list.FromCheckpoint == -1 {
+			break
+		}
+		assert.Equal(t, int32(to), list.ToCheckpoint)
+		text = logListText(list) + text
+		to = Checkpoint(list.FromCheckpoint)
+	}
+
+	expected := ""
+	for i := 0; i < 10; i++ {
+		expected += fmt.Sprintf("line %d\n", i)
+	}
+	assert.Equal(t, expected, text)
+	assert.Equal(t, Checkpoint(0), to)
+}
+
+func TestArchivePreviousSession(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.Append(newTestLogEvent("fe", time.Now(), "first session\n"), nil)
+	require.NoError(t, l.CloseArchive())
+
+	l = newArchivedLogStore(t, dir)
+	l.Append(newTestLogEvent("fe", time.Now(), "second session\n"), nil)
+
+	list, err := l.ToLogListRange(0, l.Checkpoint(), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, "second session\n", logListText(list))
+
+	list, err = l.ToPreviousSessionLogList(0, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), list.FromCheckpoint)
+	assert.Equal(t, int32(1), list.ToCheckpoint)
+	assert.Equal(t, "first session\n", logListText(list))
+	assert.Equal(t, "fe", list.Spans["fe"].ManifestName)
+
+	list, err = l.ToPreviousSessionLogList(1, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int32(-1), list.FromCheckpoint)
+	require.NoError(t, l.CloseArchive())
+
+	// Sessions older than the previous one are deleted.
+	l = newArchivedLogStore(t, dir)
+	list, err = l.ToPreviousSessionLogList(0, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, "second session\n", logListText(list))
+	require.NoError(t, l.CloseArchive())
+}
+
+func TestArchiveWritesAllSpansToOneChunk(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	for i := 0; i < 100; i++ {
+		l.Append(testLogEvent{
+			name:    "fe",
+			spanID:  SpanID(fmt.Sprintf("pod:fe-%d", i)),
+			level:   logger.InfoLvl,
+			ts:      time.Now(),
+			message: "crashed\n",
+		}, nil)
+	}
+	require.NoError(t, l.CloseArchive())
+
+	// A crash-looping pod gets a new span on every restart, but not a new file.
+	files, err := ioutil.ReadDir(filepath.Join(dir, archiveCurrentDir))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	l = newArchivedLogStore(t, dir)
+	list, err := l.ToPreviousSessionLogList(0, 10000)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("crashed\n", 100), logListText(list))
+	require.NoError(t, l.CloseArchive())
+}
+
+func TestArchiveDeletesOldChunks(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.maxLogLengthInBytes = 20
+	l.archive.chunkBytes = 200
+	l.archive.maxBytes = 400
+
+	for i := 0; i < 50; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), fmt.Sprintf("line %d\n", i)), nil)
+	}
+	require.NoError(t, l.CloseArchive())
+
+	size := int64(0)
+	files, err := ioutil.ReadDir(filepath.Join(dir, archiveCurrentDir))
+	require.NoError(t, err)
+	for _, f := range files {
+		size += f.Size()
+	}
+	assert.True(t, size <= 600, "expected the archive to stay near its size limit, got %d bytes", size)
+	assert.True(t, l.FirstCheckpoint() > 0, "expected the oldest logs to be deleted")
+
+	// The previous session only has the newest logs, but keeps their checkpoints.
+	l = newArchivedLogStore(t, dir)
+	n, err := l.archive.PreviousLen()
+	require.NoError(t, err)
+	assert.Equal(t, 50, n)
+	list, err := l.ToPreviousSessionLogList(0, 10000)
+	require.NoError(t, err)
+	assert.True(t, list.FromCheckpoint > 0)
+	assert.Equal(t, int32(50), list.ToCheckpoint)
+	assert.True(t, strings.HasSuffix(logListText(list), "line 48\nline 49\n"))
+	require.NoError(t, l.CloseArchive())
+}
+
+func TestArchivePreviousSessionDropsIncompleteRecord(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.Append(newTestLogEvent("fe", time.Now(), "line 0\n"), nil)
+	l.Append(newTestLogEvent("fe", time.Now(), "line 1\n"), nil)
+	require.NoError(t, l.CloseArchive())
+
+	// Simulate a crash in the middle of a write.
+	files, err := filepath.Glob(filepath.Join(dir, archiveCurrentDir, "*"+archiveFileExt))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"checkpoint":2,"span":"fe","te`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	l = newArchivedLogStore(t, dir)
+	n, err := l.archive.PreviousLen()
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	list, err := l.ToPreviousSessionLogList(1, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), list.FromCheckpoint)
+	assert.Equal(t, int32(2), list.ToCheckpoint)
+	assert.Equal(t, "line 1\n", logListText(list))
+	require.NoError(t, l.CloseArchive())
+}
+
+func TestLogListRangeWithoutArchive(t *testing.T) {
+	l := NewLogStore()
+	l.maxLogLengthInBytes = 20
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), "line\n"), nil)
+	}
+
+	// Without an archive, we can only read what's in memory.
+	list, err := l.ToLogListRange(0, l.Checkpoint(), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int32(l.FirstCheckpoint()), list.FromCheckpoint)
+	assert.Equal(t, strings.Repeat("line\n", 4), logListText(list))
+}
+
+func newArchiveDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "logarchive")
+	require.NoError(t, err)
+	return dir
+}
+
+func newArchivedLogStore(t *testing.T, dir string) *LogStore {
+	a, err := OpenArchive(dir)
+	require.NoError(t, err)
+
+	l := NewLogStore()
+	l.SetArchive(a)
+	return l
+}
+
+func logListText(list *webview.LogList) string {
+	sb := strings.Builder{}
+	for _, segment := range list.Segments {
+		sb.WriteString(segment.Text)
+	}
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package logstore
+
+import (
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// Hides the logs that a manifest has so far from views of the log,
+// without deleting them.
+//
+// Views only show the manifest's logs from this checkpoint on.
+func (s *LogStore) Clear(mn model.ManifestName) {
+	if s.clearCheckpoints == nil {
+		s.clearCheckpoints = make(map[model.ManifestName]Checkpoint)
+	}
+	s.clearCheckpoints[mn] = s.Checkpoint()
+}
+
+// Hides the logs that every manifest has so far, including logs that
+// don't belong to any manifest.
+func (s *LogStore) ClearAll() {
+	s.clearAllCheckpoint = s.Checkpoint()
+}
+
+// The checkpoint that a manifest's logs were last cleared at, or 0 if
+// they've never been cleared.
+//
+// Logs that are over the manifest's log limit are hidden as if they'd
+// been cleared.
+func (s *LogStore) ClearCheckpoint(mn model.ManifestName) Checkpoint {
+	c := s.clearCheckpoints[mn]
+	if s.clearAllCheckpoint > c {
+		c = s.clearAllCheckpoint
+	}
+	if s.limitCheckpoints[mn] > c {
+		c = s.limitCheckpoints[mn]
+	}
+	return c
+}
+
+func (s *LogStore) hasClears() bool {
+	return s.clearAllCheckpoint > 0 || len(s.clearCheckpoints) > 0 || len(s.limitCheckpoints) > 0
+}
+
+// Returns the checkpoint of the first segment of a span that's still in memory.
+//
+// Returns false if the span has no segments in memory.
+func (s *LogStore) SpanStartCheckpoint(spanID SpanID) (Checkpoint, bool) {
+	span, ok := s.spans[spanID]
+	if !ok || span.FirstSegmentIndex < 0 || span.FirstSegmentIndex >= len(s.segments) {
+		return 0, false
+	}
+	return s.checkpointFromIndex(span.FirstSegmentIndex), true
+}
+
+// Whether the segment is from before its manifest's logs were last cleared.
+func (s *LogStore) isCleared(segment LogSegment) bool {
+	if !s.hasClears() {
+		return false
+	}
+
+	mn := model.ManifestName("")
+	if span, ok := s.spans[segment.SpanID]; ok {
+		mn = span.ManifestName
+	}
+	return segment.checkpoint < s.ClearCheckpoint(mn)
+}
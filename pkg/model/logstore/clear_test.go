@@ -0,0 +1,104 @@
+package logstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+func TestClearManifest(t *testing.T) {
+	l := NewLogStore()
+	l.Append(newGlobalTestLogEvent("1\n"), nil)
+	l.Append(newTestLogEvent("fe", time.Now(), "2\n"), nil)
+	l.Append(newTestLogEvent("be", time.Now(), "3\n"), nil)
+
+	l.Clear("fe")
+	assert.Equal(t, Checkpoint(3), l.ClearCheckpoint("fe"))
+	assert.Equal(t, Checkpoint(0), l.ClearCheckpoint("be"))
+
+	l.Append(newTestLogEvent("fe", time.Now(), "4\n"), nil)
+	assert.Equal(t, "1\n           be │ 3\n           fe │ 4\n", l.Tail(10))
+	assert.Equal(t, "4\n", l.TailSpan(10, "fe"))
+
+	// Clearing doesn't delete anything.
+	assert.Equal(t, "2\n4\n", l.ManifestLog("fe"))
+}
+
+func TestClearAll(t *testing.T) {
+	l := NewLogStore()
+	l.Append(newGlobalTestLogEvent("1\n"), nil)
+	l.Append(newTestLogEvent("fe", time.Now(), "2\n"), nil)
+
+	l.ClearAll()
+	assert.Equal(t, "", l.Tail(10))
+	assert.Equal(t, Checkpoint(2), l.ClearCheckpoint(""))
+	assert.Equal(t, Checkpoint(2), l.ClearCheckpoint("fe"))
+
+	l.Append(newGlobalTestLogEvent("3\n"), nil)
+	l.Append(newTestLogEvent("fe", time.Now(), "4\n"), nil)
+	assert.Equal(t, "3\n           fe │ 4\n", l.Tail(10))
+}
+
+func TestTailAfterClear(t *testing.T) {
+	l := NewLogStore()
+	for i := 1; i <= 3; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), fmt.Sprintf("old %d\n", i)), nil)
+	}
+	l.Clear("fe")
+	l.Append(newTestLogEvent("be", time.Now(), "be 1\n"), nil)
+	for i := 1; i <= 3; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), fmt.Sprintf("new %d\n", i)), nil)
+	}
+
+	assert.Equal(t, "new 2\nnew 3\n", l.TailSpan(2, "fe"))
+	assert.Equal(t, "new 1\nnew 2\nnew 3\n", l.TailSpan(10, "fe"))
+	assert.Equal(t, "           fe │ new 3\n", l.Tail(1))
+	assert.Equal(t, "           be │ be 1\n           fe │ new 1\n           fe │ new 2\n           fe │ new 3\n", l.Tail(10))
+}
+
+func TestClearAfterTruncate(t *testing.T) {
+	l := NewLogStore()
+	l.maxLogLengthInBytes = 20
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), "hello\n"), nil)
+	}
+	l.Clear("fe")
+	l.Append(newTestLogEvent("fe", time.Now(), "goodbye\n"), nil)
+
+	assert.Equal(t, "goodbye\n", l.TailSpan(10, "fe"))
+}
+
+func TestSpanStartCheckpoint(t *testing.T) {
+	l := NewLogStore()
+	l.Append(newGlobalTestLogEvent("1\n"), nil)
+	l.Append(newTestLogEvent("fe", time.Now(), "2\n"), nil)
+
+	c, ok := l.SpanStartCheckpoint("fe")
+	require.True(t, ok)
+	assert.Equal(t, Checkpoint(1), c)
+
+	_, ok = l.SpanStartCheckpoint("be")
+	assert.False(t, ok)
+}
+
+func TestSearchFromCheckpoints(t *testing.T) {
+	l := NewLogStore()
+	l.Append(newTestLogEvent("fe", time.Now(), "old fe\n"), nil)
+	l.Append(newTestLogEvent("be", time.Now(), "old be\n"), nil)
+	l.Append(newTestLogEvent("fe", time.Now(), "new fe\n"), nil)
+
+	f := Filter{FromCheckpoints: map[model.ManifestName]Checkpoint{"fe": 2}}
+	result, err := l.Search(f, 0, 10)
+	require.NoError(t, err)
+
+	var texts []string
+	for _, m := range result.Matches {
+		texts = append(texts, m.Segment.String())
+	}
+	assert.Equal(t, []string{"old be\n", "new fe\n"}, texts)
+}
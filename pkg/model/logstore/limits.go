@@ -0,0 +1,257 @@
+package logstore
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// The bytes and lines of logs that a manifest has in memory.
+type logUsage struct {
+	bytes int
+	lines int
+}
+
+// Counts the lines that a manifest logged in the current one-second window,
+// and the lines we dropped because they were over the limit.
+type logRate struct {
+	windowStart time.Time
+	lines       int
+
+	dropped       int
+	droppedSpanID SpanID
+}
+
+// A log event that Tilt adds when it drops logs, so that it's clear
+// that there are logs missing.
+type droppedLogsEvent struct {
+	mn     model.ManifestName
+	spanID SpanID
+	ts     time.Time
+	msg    string
+}
+
+func (e droppedLogsEvent) Message() []byte                  { return []byte(e.msg) }
+func (e droppedLogsEvent) Time() time.Time                  { return e.ts }
+func (e droppedLogsEvent) Level() logger.Level              { return logger.WarnLvl }
+func (e droppedLogsEvent) Fields() logger.Fields            { return nil }
+func (e droppedLogsEvent) ManifestName() model.ManifestName { return e.mn }
+func (e droppedLogsEvent) SpanID() SpanID                   { return e.spanID }
+
+// Sets the most bytes of logs to keep in memory, across all manifests.
+func (s *LogStore) SetMaxLength(n int) {
+	if n <= 0 {
+		n = defaultMaxLogLengthInBytes
+	}
+	s.maxLogLengthInBytes = n
+	s.ensureMaxLength()
+}
+
+// Sets the log limits of each manifest. Manifests without a limit are only
+// bounded by the max length of the whole log.
+func (s *LogStore) SetLimits(limits map[model.ManifestName]model.LogLimit) {
+	for mn, r := range s.rates {
+		if limits[mn].LinesPerSecond != s.limits[mn].LinesPerSecond {
+			s.flushDropped(mn, r, r.windowStart.Add(time.Second))
+			delete(s.rates, mn)
+		}
+	}
+	s.limits = limits
+	for mn := range limits {
+		s.ensureManifestLimit(mn)
+	}
+}
+
+// Checks the event against the rate limit of its manifest.
+//
+// Returns true if the event should be dropped. The first event after a
+// burst is preceded by a message that says how many lines were dropped.
+func (s *LogStore) rateLimited(le LogEvent) bool {
+	mn := le.ManifestName()
+	limit := s.limits[mn].LinesPerSecond
+	if mn == "" || limit <= 0 {
+		return false
+	}
+
+	if s.rates == nil {
+		s.rates = make(map[model.ManifestName]*logRate)
+	}
+	r, ok := s.rates[mn]
+	if !ok {
+		r = &logRate{}
+		s.rates[mn] = r
+	}
+
+	ts := le.Time()
+	if ts.Before(r.windowStart) || ts.Sub(r.windowStart) >= time.Second {
+		s.flushDropped(mn, r, ts)
+		r.windowStart = ts
+		r.lines = 0
+	}
+
+	n := bytes.Count(le.Message(), []byte{newlineByte})
+	if n == 0 {
+		n = 1
+	}
+	if r.lines+n > limit {
+		r.dropped += n
+		r.droppedSpanID = le.SpanID()
+		return true
+	}
+	r.lines += n
+	return false
+}
+
+// Adds the messages for the bursts of dropped lines whose one-second window
+// has closed by the given time.
+//
+// We also add a burst's message when its manifest logs again, but a manifest
+// that goes quiet after a burst (like a pod in a crash loop) may never log
+// again, so the engine calls this when the window closes.
+func (s *LogStore) FlushDroppedLogs(now time.Time) {
+	for mn, r := range s.rates {
+		if r.dropped > 0 && now.Sub(r.windowStart) >= time.Second {
+			s.flushDropped(mn, r, r.windowStart.Add(time.Second))
+		}
+	}
+}
+
+// When the earliest window with dropped lines closes, so that the engine
+// knows when to call FlushDroppedLogs.
+//
+// Returns false if there are no dropped lines waiting for a message.
+func (s *LogStore) DroppedLogsDeadline() (time.Time, bool) {
+	deadline := time.Time{}
+	for _, r := range s.rates {
+		if r.dropped == 0 {
+			continue
+		}
+		end := r.windowStart.Add(time.Second)
+		if deadline.IsZero() || end.Before(deadline) {
+			deadline = end
+		}
+	}
+	return deadline, !deadline.IsZero()
+}
+
+// Adds a message for the lines we dropped in the last burst, if any.
+func (s *LogStore) flushDropped(mn model.ManifestName, r *logRate, ts time.Time) {
+	if r.dropped == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("[Tilt] %d lines dropped (over the limit of %d lines per second)\n",
+		r.dropped, s.limits[mn].LinesPerSecond)
+	r.dropped = 0
+
+	s.appendEvent(droppedLogsEvent{mn: mn, spanID: r.droppedSpanID, ts: ts, msg: msg}, nil)
+}
+
+func (s *LogStore) addUsage(mn model.ManifestName, added []LogSegment) {
+	if s.usage == nil {
+		s.usage = make(map[model.ManifestName]logUsage)
+	}
+	u := s.usage[mn]
+	for _, segment := range added {
+		u.bytes += segment.Len()
+		if segment.StartsLine() {
+			u.lines++
+		}
+	}
+	s.usage[mn] = u
+}
+
+func (s *LogStore) recomputeUsage() {
+	s.usage = make(map[model.ManifestName]logUsage)
+	for _, segment := range s.segments {
+		span, ok := s.spans[segment.SpanID]
+		if !ok || segment.checkpoint < s.limitCheckpoints[span.ManifestName] {
+			continue
+		}
+		s.addUsage(span.ManifestName, []LogSegment{segment})
+	}
+}
+
+// If a manifest is over its limit, drop its oldest logs.
+//
+// Like the max length of the whole log, we cut down to half the limit,
+// so that we truncate rarely, in big chunks.
+//
+// If there's an archive, we hide the oldest logs from views instead, so that
+// the manifest's full history is still in the archive, and the logs of other
+// manifests are cut fairly by the max length of the whole log.
+func (s *LogStore) ensureManifestLimit(mn model.ManifestName) {
+	limit := s.limits[mn]
+	u := s.usage[mn]
+	overBytes := limit.Bytes > 0 && u.bytes > limit.Bytes
+	overLines := limit.Lines > 0 && u.lines > limit.Lines
+	if !overBytes && !overLines {
+		return
+	}
+
+	// Make sure everything we're about to drop is in the archive.
+	if s.archive != nil {
+		err := s.Flush()
+		if err != nil {
+			_ = s.archive.Close()
+			s.archive = nil
+		}
+	}
+
+	bytesToCut := 0
+	if limit.Bytes > 0 {
+		bytesToCut = u.bytes - limit.Bytes/2
+	}
+	linesToCut := 0
+	if limit.Lines > 0 {
+		linesToCut = u.lines - limit.Lines/2
+	}
+
+	newSegments := make([]LogSegment, 0, len(s.segments))
+	trimmedSegmentCount := 0
+	cutting := true
+	for _, segment := range s.segments {
+		span, ok := s.spans[segment.SpanID]
+		if cutting && ok && span.ManifestName == mn && segment.checkpoint >= s.limitCheckpoints[mn] {
+			// Only stop cutting at the start of a line, so that we never keep half a line.
+			if bytesToCut <= 0 && linesToCut <= 0 && segment.StartsLine() {
+				cutting = false
+				if s.archive != nil {
+					s.hideBefore(mn, segment.checkpoint)
+					return
+				}
+			} else {
+				bytesToCut -= segment.Len()
+				if segment.StartsLine() {
+					linesToCut--
+				}
+				trimmedSegmentCount++
+				continue
+			}
+		}
+		newSegments = append(newSegments, segment)
+	}
+
+	if s.archive != nil {
+		// We cut everything the manifest has.
+		s.hideBefore(mn, s.Checkpoint())
+		return
+	}
+
+	s.checkpointOffset += Checkpoint(trimmedSegmentCount)
+	s.segments = newSegments
+	s.recomputeDerivedValues()
+}
+
+// Hides a manifest's logs before the checkpoint from views, without
+// removing them from memory.
+func (s *LogStore) hideBefore(mn model.ManifestName, c Checkpoint) {
+	if s.limitCheckpoints == nil {
+		s.limitCheckpoints = make(map[model.ManifestName]Checkpoint)
+	}
+	s.limitCheckpoints[mn] = c
+	s.recomputeUsage()
+}
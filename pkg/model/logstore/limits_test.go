@@ -0,0 +1,170 @@
+package logstore
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+func TestLogLimitBytes(t *testing.T) {
+	l := NewLogStore()
+	l.SetLimits(map[model.ManifestName]model.LogLimit{"noisy": {Bytes: 40}})
+
+	now := time.Now()
+	l.Append(newTestLogEvent("quiet", now, "Quiet Log\n"), nil)
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("noisy", now, fmt.Sprintf("Noisy %d\n", i)), nil)
+	}
+
+	// Each noisy line is 8 bytes. When we go over 40 bytes, we cut down to 20.
+	assert.Equal(t, "Noisy 8\nNoisy 9\n", l.ManifestLog("noisy"))
+	assert.Equal(t, "Quiet Log\n", l.ManifestLog("quiet"))
+}
+
+func TestLogLimitWithArchive(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.SetLimits(map[model.ManifestName]model.LogLimit{"noisy": {Bytes: 40}})
+
+	now := time.Now()
+	expected := "Quiet Log\n"
+	l.Append(newTestLogEvent("quiet", now, "Quiet Log\n"), nil)
+	for i := 0; i < 10; i++ {
+		msg := fmt.Sprintf("Noisy %d\n", i)
+		expected += msg
+		l.Append(newTestLogEvent("noisy", now, msg), nil)
+	}
+
+	// Views only show the logs under the limit.
+	assert.Equal(t, "Noisy 8\nNoisy 9\n", l.TailSpan(10, "noisy"))
+	assert.Equal(t, "Quiet Log\n", l.TailSpan(10, "quiet"))
+	assert.Equal(t, Checkpoint(9), l.ClearCheckpoint("noisy"))
+
+	// The full history is still there, and every segment comes back exactly once.
+	list, err := l.ToLogListRange(0, l.Checkpoint(), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, expected, logListText(list))
+	for i, segment := range list.Segments {
+		assert.Equal(t, int32(i), segment.Checkpoint)
+	}
+	require.NoError(t, l.CloseArchive())
+}
+
+func TestLogLimitLines(t *testing.T) {
+	l := NewLogStore()
+	l.SetLimits(map[model.ManifestName]model.LogLimit{"noisy": {Lines: 4}})
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		l.Append(newTestLogEvent("noisy", now, fmt.Sprintf("Noisy %d\n", i)), nil)
+	}
+
+	assert.Equal(t, "Noisy 3\nNoisy 4\n", l.ManifestLog("noisy"))
+	assert.Equal(t, l.Checkpoint(), l.checkpointOffset+Checkpoint(len(l.segments)))
+}
+
+func TestLogLimitKeepsWholeLines(t *testing.T) {
+	l := NewLogStore()
+	l.SetLimits(map[model.ManifestName]model.LogLimit{"noisy": {Lines: 2}})
+
+	now := time.Now()
+	l.Append(newTestLogEvent("noisy", now, "a"), nil)
+	l.Append(newTestLogEvent("noisy", now, "b\n"), nil)
+	l.Append(newTestLogEvent("noisy", now, "c"), nil)
+	l.Append(newTestLogEvent("noisy", now, "d\n"), nil)
+	l.Append(newTestLogEvent("noisy", now, "e\n"), nil)
+
+	// We cut down to one line, including the end of the line that we cut.
+	assert.Equal(t, "e\n", l.ManifestLog("noisy"))
+}
+
+func TestLogLimitRate(t *testing.T) {
+	l := NewLogStore()
+	l.SetLimits(map[model.ManifestName]model.LogLimit{"noisy": {LinesPerSecond: 2}})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		l.Append(newTestLogEvent("noisy", start.Add(time.Duration(i)*time.Millisecond), fmt.Sprintf("Noisy %d\n", i)), nil)
+	}
+	assert.Equal(t, "Noisy 0\nNoisy 1\n", l.ManifestLog("noisy"))
+
+	l.Append(newTestLogEvent("noisy", start.Add(time.Second), "Noisy 5\n"), nil)
+	assert.Equal(t,
+		"Noisy 0\nNoisy 1\nWARNING: [Tilt] 3 lines dropped (over the limit of 2 lines per second)\nNoisy 5\n",
+		l.ManifestLog("noisy"))
+	assert.Equal(t, 1, len(l.Warnings("noisy")))
+}
+
+func TestLogLimitRateFlushedWhenBurstIsLast(t *testing.T) {
+	l := NewLogStore()
+	l.SetLimits(map[model.ManifestName]model.LogLimit{"noisy": {LinesPerSecond: 2}})
+
+	// The burst is the last thing that noisy logs.
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		l.Append(newTestLogEvent("noisy", start, fmt.Sprintf("Noisy %d\n", i)), nil)
+	}
+	deadline, ok := l.DroppedLogsDeadline()
+	require.True(t, ok)
+	assert.Equal(t, start.Add(time.Second), deadline)
+
+	// Another manifest logging in the same window doesn't flush the burst.
+	l.Append(newTestLogEvent("quiet", start.Add(time.Millisecond), "Quiet 0\n"), nil)
+	assert.Equal(t, "Noisy 0\nNoisy 1\n", l.ManifestLog("noisy"))
+
+	// Another manifest logging after the window does.
+	l.Append(newTestLogEvent("quiet", start.Add(time.Second), "Quiet 1\n"), nil)
+	assert.Equal(t,
+		"Noisy 0\nNoisy 1\nWARNING: [Tilt] 3 lines dropped (over the limit of 2 lines per second)\n",
+		l.ManifestLog("noisy"))
+	_, ok = l.DroppedLogsDeadline()
+	assert.False(t, ok)
+}
+
+func TestLogLimitRateFlushedWhenWindowCloses(t *testing.T) {
+	l := NewLogStore()
+	l.SetLimits(map[model.ManifestName]model.LogLimit{"noisy": {LinesPerSecond: 1}})
+
+	start := time.Now()
+	l.Append(newTestLogEvent("noisy", start, "Noisy 0\nNoisy 1\n"), nil)
+
+	l.FlushDroppedLogs(start.Add(500 * time.Millisecond))
+	assert.Equal(t, "", l.ManifestLog("noisy"))
+
+	l.FlushDroppedLogs(start.Add(time.Second))
+	assert.Equal(t,
+		"WARNING: [Tilt] 2 lines dropped (over the limit of 1 lines per second)\n",
+		l.ManifestLog("noisy"))
+}
+
+func TestLogLimitRateFlushedWhenLimitRemoved(t *testing.T) {
+	l := NewLogStore()
+	l.SetLimits(map[model.ManifestName]model.LogLimit{"noisy": {LinesPerSecond: 1}})
+
+	now := time.Now()
+	l.Append(newTestLogEvent("noisy", now, "Noisy 0\n"), nil)
+	l.Append(newTestLogEvent("noisy", now, "Noisy 1\nNoisy 2\n"), nil)
+
+	l.SetLimits(nil)
+	assert.Equal(t,
+		"Noisy 0\nWARNING: [Tilt] 2 lines dropped (over the limit of 1 lines per second)\n",
+		l.ManifestLog("noisy"))
+}
+
+func TestSetMaxLength(t *testing.T) {
+	l := NewLogStore()
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("noisy", time.Now(), fmt.Sprintf("Noisy %d\n", i)), nil)
+	}
+
+	l.SetMaxLength(40)
+	assert.Equal(t, "Noisy 8\nNoisy 9\n", l.ManifestLog("noisy"))
+}
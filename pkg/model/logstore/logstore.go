@@ -2,6 +2,7 @@ package logstore
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,13 +14,9 @@ import (
 	"github.com/tilt-dev/tilt/pkg/webview"
 )
 
-// All parts of Tilt should display logs incrementally.
-//
-// But the initial page load loads all the existing logs.
-// https://github.com/tilt-dev/tilt/issues/3359
-//
-// Until that issue is fixed, we cap the logs at about 1MB.
-const defaultMaxLogLengthInBytes = 1000 * 1000
+// The cap on the logs we keep in memory, unless the Tiltfile sets one with
+// update_settings(max_log_length=...).
+const defaultMaxLogLengthInBytes = model.DefaultMaxLogLength
 
 const newlineByte = byte('\n')
 
@@ -54,6 +51,11 @@ type LogSegment struct {
 	//        warning1, line2
 	// Anchor warning2, line1
 	Anchor bool
+
+	// The checkpoint that the LogStore assigned to this segment when it was appended.
+	// Checkpoints keep increasing across truncations, so there may be gaps
+	// between the checkpoints of neighboring segments.
+	checkpoint Checkpoint
 }
 
 // Whether these two log segments may be printed on the same line
@@ -153,8 +155,29 @@ type LogStore struct {
 	// for testing.
 	maxLogLengthInBytes int
 
-	// If the log is truncated, we need to adjust all checkpoints
+	// The number of segments that we've truncated. Together with the number
+	// of segments in memory, this is the checkpoint of the next segment.
 	checkpointOffset Checkpoint
+
+	// If set, we write segments to the archive before we truncate them,
+	// so that the logs we truncate from memory can still be read from disk.
+	archive *Archive
+
+	// Per-manifest limits, and the bookkeeping to enforce them.
+	// See limits.go.
+	limits map[model.ManifestName]model.LogLimit
+	usage  map[model.ManifestName]logUsage
+	rates  map[model.ManifestName]*logRate
+
+	// When there's an archive, we hide the logs that are over a manifest's
+	// limit instead of dropping them. Views hide the manifest's logs before
+	// this checkpoint.
+	limitCheckpoints map[model.ManifestName]Checkpoint
+
+	// Where the user last cleared the logs of each manifest, or of all
+	// manifests. Views hide the logs before it. See clear.go.
+	clearCheckpoints   map[model.ManifestName]Checkpoint
+	clearAllCheckpoint Checkpoint
 }
 
 func NewLogStoreForTesting(msg string) *LogStore {
@@ -176,19 +199,67 @@ func (s *LogStore) Checkpoint() Checkpoint {
 	return s.checkpointFromIndex(len(s.segments))
 }
 
-func (s *LogStore) checkpointFromIndex(index int) Checkpoint {
-	return Checkpoint(index) + s.checkpointOffset
+// Attaches an archive to the log store.
+//
+// Must be called before the store truncates any logs, so that the archive
+// has the full history of this session.
+func (s *LogStore) SetArchive(a *Archive) {
+	s.archive = a
 }
 
-func (s *LogStore) checkpointToIndex(c Checkpoint) int {
-	index := int(c - s.checkpointOffset)
-	if index < 0 {
+// The earliest checkpoint that we can read logs from, either in memory or in the archive.
+func (s *LogStore) FirstCheckpoint() Checkpoint {
+	if s.archive != nil {
+		return s.archive.First()
+	}
+	return s.checkpointFromIndex(0)
+}
+
+// The checkpoint where the archive ends. Logs before it may only be in the
+// archive. Logs after it haven't been archived yet, so they're all in memory.
+func (s *LogStore) archiveEnd() Checkpoint {
+	if s.archive == nil {
 		return 0
 	}
-	if index > len(s.segments) {
-		return len(s.segments)
+	return minCheckpoint(Checkpoint(s.archive.Len()), s.Checkpoint())
+}
+
+// Queues all in-memory segments to be written to the archive (if there is one).
+// The archive writes them in the background, so this never waits on the disk.
+func (s *LogStore) Flush() error {
+	if s.archive == nil {
+		return nil
+	}
+	start := s.checkpointToIndex(Checkpoint(s.archive.Len()))
+	return s.archive.append(s.segments[start:], s.spans)
+}
+
+// Writes all in-memory segments to the archive, then closes it.
+func (s *LogStore) CloseArchive() error {
+	if s.archive == nil {
+		return nil
+	}
+
+	err := s.Flush()
+	closeErr := s.archive.Close()
+	if err != nil {
+		return err
 	}
-	return index
+	return closeErr
+}
+
+func (s *LogStore) checkpointFromIndex(index int) Checkpoint {
+	if index < len(s.segments) {
+		return s.segments[index].checkpoint
+	}
+	return s.checkpointOffset + Checkpoint(len(s.segments))
+}
+
+// Returns the index of the first segment at or after the checkpoint.
+func (s *LogStore) checkpointToIndex(c Checkpoint) int {
+	return sort.Search(len(s.segments), func(i int) bool {
+		return s.segments[i].checkpoint >= c
+	})
 }
 
 // Find the greatest index < index corresponding to a log matching one of the manifests in mns.
@@ -240,9 +311,21 @@ func (s *LogStore) ScrubSecretsStartingAt(secrets model.SecretSet, checkpoint Ch
 	}
 
 	s.len = s.computeLen()
+	s.recomputeUsage()
 }
 
 func (s *LogStore) Append(le LogEvent, secrets model.SecretSet) {
+	s.FlushDroppedLogs(le.Time())
+	if s.rateLimited(le) {
+		return
+	}
+
+	s.appendEvent(le, secrets)
+	s.ensureManifestLimit(le.ManifestName())
+	s.ensureMaxLength()
+}
+
+func (s *LogStore) appendEvent(le LogEvent, secrets model.SecretSet) {
 	spanID := le.SpanID()
 	if spanID == "" && le.ManifestName() != "" {
 		spanID = SpanID(fmt.Sprintf("unknown:%s", le.ManifestName()))
@@ -269,24 +352,28 @@ func (s *LogStore) Append(le LogEvent, secrets model.SecretSet) {
 	}
 
 	added[0].ContinuesLine = s.computeContinuesLine(added[0], span)
+	next := s.Checkpoint()
+	for i := range added {
+		added[i].checkpoint = next + Checkpoint(i)
+	}
 
 	s.segments = append(s.segments, added...)
 	span.LastSegmentIndex = len(s.segments) - 1
 
 	s.len += len(msg)
-	s.ensureMaxLength()
+	s.addUsage(span.ManifestName, added)
 }
 
 func (s *LogStore) Empty() bool {
 	return len(s.segments) == 0
 }
 
-// Get at most N lines from the tail of the log.
+// Get at most N lines from the tail of the log, skipping logs that have been cleared.
 func (s *LogStore) Tail(n int) string {
 	return s.tailHelper(n, s.spans, true)
 }
 
-// Get at most N lines from the tail of the span.
+// Get at most N lines from the tail of the span, skipping logs that have been cleared.
 func (s *LogStore) TailSpan(n int, spanID SpanID) string {
 	spans, ok := s.idToSpanMap(spanID)
 	if !ok {
@@ -295,7 +382,7 @@ func (s *LogStore) TailSpan(n int, spanID SpanID) string {
 	return s.tailHelper(n, spans, false)
 }
 
-// Get at most N lines from the tail of the log.
+// Get at most N lines from the tail of the log, skipping logs that have been cleared.
 func (s *LogStore) tailHelper(n int, spans map[SpanID]*Span, showManifestPrefix bool) string {
 	if n <= 0 {
 		return ""
@@ -311,7 +398,7 @@ func (s *LogStore) tailHelper(n int, spans map[SpanID]*Span, showManifestPrefix
 	current := lastIndex
 	for ; current >= startIndex; current-- {
 		segment := s.segments[current]
-		if _, ok := spans[segment.SpanID]; !ok {
+		if _, ok := spans[segment.SpanID]; !ok || s.isCleared(segment) {
 			continue
 		}
 
@@ -324,11 +411,14 @@ func (s *LogStore) tailHelper(n int, spans map[SpanID]*Span, showManifestPrefix
 	}
 
 	if remaining > 0 {
-		// If there aren't enough lines, just return the whole store.
-		return s.toLogString(logOptions{
-			spans:              spans,
-			showManifestPrefix: showManifestPrefix,
-		})
+		if !s.hasClears() {
+			// If there aren't enough lines, just return the whole store.
+			return s.toLogString(logOptions{
+				spans:              spans,
+				showManifestPrefix: showManifestPrefix,
+			})
+		}
+		current = startIndex
 	}
 
 	startedSpans := make(map[SpanID]bool)
@@ -336,7 +426,7 @@ func (s *LogStore) tailHelper(n int, spans map[SpanID]*Span, showManifestPrefix
 	for i := current; i <= lastIndex; i++ {
 		segment := s.segments[i]
 		spanID := segment.SpanID
-		if _, ok := spans[segment.SpanID]; !ok {
+		if _, ok := spans[segment.SpanID]; !ok || s.isCleared(segment) {
 			continue
 		}
 
@@ -406,6 +496,8 @@ func (s *LogStore) recomputeDerivedValues() {
 			delete(s.spans, spanID)
 		}
 	}
+
+	s.recomputeUsage()
 }
 
 // Returns logs incrementally from the given checkpoint.
@@ -526,19 +618,11 @@ func (s *LogStore) ToLogList(fromCheckpoint Checkpoint) (*webview.LogList, error
 
 	segments := make([]*webview.LogSegment, 0, len(s.segments)-startIndex)
 	for i := startIndex; i < len(s.segments); i++ {
-		segment := s.segments[i]
-		time, err := ptypes.TimestampProto(segment.Time)
+		segment, err := segmentToProto(s.segments[i])
 		if err != nil {
 			return nil, errors.Wrap(err, "ToLogList")
 		}
-		segments = append(segments, &webview.LogSegment{
-			SpanId: string(segment.SpanID),
-			Level:  webview.LogLevel(segment.Level.ToProtoID()),
-			Time:   time,
-			Text:   string(segment.Text),
-			Anchor: segment.Anchor,
-			Fields: segment.Fields,
-		})
+		segments = append(segments, segment)
 	}
 
 	return &webview.LogList{
@@ -549,6 +633,68 @@ func (s *LogStore) ToLogList(fromCheckpoint Checkpoint) (*webview.LogList, error
 	}, nil
 }
 
+// Returns one page of logs between two checkpoints. See Snapshot.ToLogListRange.
+//
+// Reads the archive while holding the store, so callers that hold the store
+// lock should take a Snapshot and read from it after they release the lock.
+func (s *LogStore) ToLogListRange(from, to Checkpoint, maxBytes int) (*webview.LogList, error) {
+	return s.Snapshot(from, maxBytes).ToLogListRange(from, to, maxBytes)
+}
+
+// Returns the page of logs that ends just before the given checkpoint.
+// See Snapshot.ToLogListBefore.
+func (s *LogStore) ToLogListBefore(to Checkpoint, maxBytes int) (*webview.LogList, error) {
+	return s.SnapshotBefore(to, maxBytes).ToLogListBefore(to, maxBytes)
+}
+
+// Returns one page of logs from the previous session.
+// See Snapshot.ToPreviousSessionLogList.
+func (s *LogStore) ToPreviousSessionLogList(from Checkpoint, maxBytes int) (*webview.LogList, error) {
+	return s.Snapshot(s.Checkpoint(), 0).ToPreviousSessionLogList(from, maxBytes)
+}
+
+func recordsToLogList(records []archiveRecord) (*webview.LogList, error) {
+	list := &webview.LogList{
+		Spans:    make(map[string]*webview.LogSpan),
+		Segments: make([]*webview.LogSegment, 0, len(records)),
+	}
+	for _, record := range records {
+		if _, ok := list.Spans[string(record.SpanID)]; !ok {
+			list.Spans[string(record.SpanID)] = &webview.LogSpan{ManifestName: record.ManifestName.String()}
+		}
+
+		segment, err := segmentToProto(record.segment())
+		if err != nil {
+			return nil, err
+		}
+		list.Segments = append(list.Segments, segment)
+	}
+	return list, nil
+}
+
+func segmentToProto(segment LogSegment) (*webview.LogSegment, error) {
+	time, err := ptypes.TimestampProto(segment.Time)
+	if err != nil {
+		return nil, err
+	}
+	return &webview.LogSegment{
+		SpanId:     string(segment.SpanID),
+		Level:      webview.LogLevel(segment.Level.ToProtoID()),
+		Time:       time,
+		Text:       string(segment.Text),
+		Anchor:     segment.Anchor,
+		Fields:     segment.Fields,
+		Checkpoint: int32(segment.checkpoint),
+	}, nil
+}
+
+func minCheckpoint(a, b Checkpoint) Checkpoint {
+	if a < b {
+		return a
+	}
+	return b
+}
+
 func (s *LogStore) String() string {
 	return s.toLogString(logOptions{
 		spans:              s.spans,
@@ -771,6 +917,17 @@ func (s *LogStore) ensureMaxLength() {
 		return
 	}
 
+	// Make sure everything we're about to drop is in the archive.
+	// If we can't write to disk, stop archiving, and fall back
+	// to dropping logs.
+	if s.archive != nil {
+		err := s.Flush()
+		if err != nil {
+			_ = s.archive.Close()
+			s.archive = nil
+		}
+	}
+
 	// First, count the number of bytes in each manifest.
 	manifestByteCount := manifestByteCount{}
 	for _, segment := range s.segments {
@@ -0,0 +1,299 @@
+package logstore
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+// How much log text a single search will look through before it stops
+// and asks the caller to continue, so that a search over a big archive
+// returns in a reasonable time.
+const searchMaxScanBytes = 10 * 1000 * 1000
+
+// How much log text we read from the archive at a time while searching.
+const searchArchivePageBytes = 100 * 1000
+
+// Whether a span holds build logs or runtime logs.
+//
+// This mirrors the source filter in the web UI.
+type SpanType string
+
+const (
+	SpanTypeBuild   SpanType = "build"
+	SpanTypeRuntime SpanType = "runtime"
+)
+
+func SpanTypeForID(spanID SpanID) SpanType {
+	if strings.HasPrefix(string(spanID), "build:") {
+		return SpanTypeBuild
+	}
+	return SpanTypeRuntime
+}
+
+func SpanTypeFromName(name string) (SpanType, error) {
+	switch SpanType(name) {
+	case SpanTypeBuild, SpanTypeRuntime:
+		return SpanType(name), nil
+	}
+	return "", fmt.Errorf("unknown span type %q (expected one of: build, runtime)", name)
+}
+
+// What produced the logs in a span, as shown by `tilt logs --json`.
+type Source string
+
+const (
+	SourceBuild     Source = "build"
+	SourcePod       Source = "pod"
+	SourceContainer Source = "container"
+	SourceLocal     Source = "local"
+	SourceTiltfile  Source = "tiltfile"
+	SourceTilt      Source = "tilt"
+)
+
+// Span IDs start with the kind of span, like "build:3" or "pod:my-pod-123".
+func SourceForSpanID(spanID SpanID) Source {
+	kind := strings.SplitN(string(spanID), ":", 2)[0]
+	switch kind {
+	case "build":
+		return SourceBuild
+	case "pod", "monitor", "events":
+		return SourcePod
+	case "dc":
+		return SourceContainer
+	case "localserve":
+		return SourceLocal
+	case "tiltfile":
+		return SourceTiltfile
+	}
+	return SourceTilt
+}
+
+// Criteria for picking out log segments. The zero value matches everything.
+type Filter struct {
+	// Matched against the text of each segment, without its trailing newline.
+	Pattern *regexp.Regexp
+
+	// Only match segments at least this severe.
+	MinLevel logger.Level
+
+	// Only match segments logged at or after this time.
+	Since time.Time
+
+	SpanType SpanType
+
+	// If non-empty, only match segments from these manifests.
+	ManifestNames model.ManifestNameSet
+
+	// Only match segments of these manifests at or after the given checkpoints.
+	FromCheckpoints map[model.ManifestName]Checkpoint
+
+	// Only match segments that have all of these fields, with these values.
+	Fields logger.Fields
+}
+
+func (f Filter) Empty() bool {
+	return f.Pattern == nil &&
+		f.MinLevel == logger.NoneLvl &&
+		f.Since.IsZero() &&
+		f.SpanType == "" &&
+		len(f.ManifestNames) == 0 &&
+		len(f.FromCheckpoints) == 0 &&
+		len(f.Fields) == 0
+}
+
+func (f Filter) Matches(segment LogSegment, mn model.ManifestName) bool {
+	if len(f.ManifestNames) > 0 && !f.ManifestNames[mn] {
+		return false
+	}
+	if f.SpanType != "" && SpanTypeForID(segment.SpanID) != f.SpanType {
+		return false
+	}
+	if !segment.Level.AsSevereAs(f.MinLevel) {
+		return false
+	}
+	if !f.Since.IsZero() && segment.Time.Before(f.Since) {
+		return false
+	}
+	for key, val := range f.Fields {
+		actual, ok := segment.Fields[key]
+		if !ok || actual != val {
+			return false
+		}
+	}
+	if f.Pattern != nil && !f.Pattern.Match(bytes.TrimSuffix(segment.Text, []byte{newlineByte})) {
+		return false
+	}
+	return true
+}
+
+type SearchMatch struct {
+	Checkpoint   Checkpoint
+	ManifestName model.ManifestName
+	Segment      LogSegment
+}
+
+type SearchResult struct {
+	Matches []SearchMatch
+
+	// The interval of checkpoints that we searched, [From, To).
+	From Checkpoint
+	To   Checkpoint
+
+	// Whether there are logs after To that we haven't searched yet.
+	// To keep going, search again from To.
+	HasMore bool
+}
+
+// Finds the segments that match a filter, starting at the given checkpoint.
+// See Snapshot.Search.
+//
+// Reads the archive while holding the store, so callers that hold the store
+// lock should take a Snapshot and search it after they release the lock.
+func (s *LogStore) Search(f Filter, from Checkpoint, maxMatches int) (SearchResult, error) {
+	return s.SearchSnapshot(from).Search(f, from, maxMatches)
+}
+
+// Takes a snapshot with all the in-memory logs that a search
+// from the given checkpoint might scan.
+func (s *LogStore) SearchSnapshot(from Checkpoint) *Snapshot {
+	return s.Snapshot(from, searchMaxScanBytes)
+}
+
+// Like Search(), but over the previous session in the archive.
+func (s *LogStore) SearchPreviousSession(f Filter, from Checkpoint, maxMatches int) (SearchResult, error) {
+	return s.Snapshot(s.Checkpoint(), 0).SearchPreviousSession(f, from, maxMatches)
+}
+
+// Finds the segments that match a filter, starting at the given checkpoint.
+//
+// Searches the archive for logs that are no longer in memory. Stops after
+// maxMatches matches, or after scanning a lot of log text, so that callers
+// can page through the results. Take the snapshot with SearchSnapshot().
+//
+// A match is a single segment. Lines that were logged in pieces may be split
+// across segments, so a pattern won't match text that spans two segments.
+func (snap *Snapshot) Search(f Filter, from Checkpoint, maxMatches int) (SearchResult, error) {
+	if from < snap.first {
+		from = snap.first
+	}
+
+	sr := &searcher{filter: f, maxMatches: maxMatches, current: from}
+	if from < snap.archiveEnd {
+		err := sr.scanArchive(snap.archive.read, snap.archiveEnd)
+		if err != nil {
+			return SearchResult{}, errors.Wrap(err, "Search")
+		}
+		if !sr.done() && sr.current < snap.archiveEnd {
+			// The archive is missing some logs, so skip to the ones in memory.
+			sr.current = snap.archiveEnd
+		}
+	}
+
+	for i := snap.checkpointToIndex(sr.current); i < len(snap.segments) && !sr.done(); i++ {
+		segment := snap.segments[i]
+		sr.visit(segment, segment.checkpoint, snap.manifests[segment.SpanID])
+	}
+	if !sr.done() {
+		sr.current = snap.windowEnd
+	}
+
+	return sr.result(from, snap.end), nil
+}
+
+// Like Search(), but over the previous session in the archive.
+func (snap *Snapshot) SearchPreviousSession(f Filter, from Checkpoint, maxMatches int) (SearchResult, error) {
+	if from < 0 {
+		from = 0
+	}
+
+	end := Checkpoint(0)
+	if snap.archive != nil {
+		n, err := snap.archive.PreviousLen()
+		if err != nil {
+			return SearchResult{}, errors.Wrap(err, "SearchPreviousSession")
+		}
+		end = Checkpoint(n)
+	}
+
+	sr := &searcher{filter: f, maxMatches: maxMatches, current: from}
+	if from < end {
+		err := sr.scanArchive(snap.archive.readPrevious, end)
+		if err != nil {
+			return SearchResult{}, errors.Wrap(err, "SearchPreviousSession")
+		}
+	}
+	return sr.result(from, end), nil
+}
+
+type searcher struct {
+	filter     Filter
+	maxMatches int
+	matches    []SearchMatch
+	scanned    int
+
+	// The next checkpoint to look at.
+	current Checkpoint
+}
+
+func (sr *searcher) done() bool {
+	return len(sr.matches) >= sr.maxMatches || sr.scanned >= searchMaxScanBytes
+}
+
+// Visits the segment at the given checkpoint. There may be a gap between the
+// checkpoint we visited last and this one, if logs were truncated.
+func (sr *searcher) visit(segment LogSegment, c Checkpoint, mn model.ManifestName) {
+	if sr.filter.Matches(segment, mn) && c >= sr.filter.FromCheckpoints[mn] {
+		sr.matches = append(sr.matches, SearchMatch{
+			Checkpoint:   c,
+			ManifestName: mn,
+			Segment:      segment,
+		})
+	}
+	sr.scanned += segment.Len()
+	sr.current = c + 1
+}
+
+func (sr *searcher) scanArchive(read func(from, to Checkpoint, maxBytes int) ([]archiveRecord, error), end Checkpoint) error {
+	for sr.current < end && !sr.done() {
+		records, err := read(sr.current, end, searchArchivePageBytes)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+
+		for _, record := range records {
+			if sr.done() {
+				return nil
+			}
+			sr.visit(record.segment(), record.Checkpoint, record.ManifestName)
+		}
+	}
+	return nil
+}
+
+func (sr *searcher) result(from, end Checkpoint) SearchResult {
+	matches := sr.matches
+	if matches == nil {
+		matches = []SearchMatch{}
+	}
+	to := sr.current
+	if to < from {
+		to = from
+	}
+	return SearchResult{
+		Matches: matches,
+		From:    from,
+		To:      to,
+		HasMore: to < end,
+	}
+}
@@ -0,0 +1,189 @@
+package logstore
+
+import (
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tilt-dev/tilt/pkg/logger"
+	"github.com/tilt-dev/tilt/pkg/model"
+)
+
+func TestSearchPattern(t *testing.T) {
+	l := NewLogStore()
+	l.Append(newTestLogEvent("fe", time.Now(), "starting server\n"), nil)
+	l.Append(newTestLogEvent("be", time.Now(), "connection refused\n"), nil)
+	l.Append(newTestLogEvent("fe", time.Now(), "server listening on :8080\n"), nil)
+
+	result, err := l.Search(Filter{Pattern: regexp.MustCompile(`server`)}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{0, 2}, matchCheckpoints(result))
+	assert.Equal(t, model.ManifestName("fe"), result.Matches[1].ManifestName)
+	assert.Equal(t, "server listening on :8080\n", result.Matches[1].Segment.String())
+	assert.False(t, result.HasMore)
+	assert.Equal(t, Checkpoint(3), result.To)
+}
+
+func TestSearchLevelTimeAndSpanType(t *testing.T) {
+	start := time.Now()
+	l := NewLogStore()
+
+	old := newTestLogEvent("fe", start.Add(-time.Hour), "old warning\n")
+	old.level = logger.WarnLvl
+	l.Append(old, nil)
+
+	build := newTestLogEvent("fe", start, "build warning\n")
+	build.spanID = "build:1"
+	build.level = logger.WarnLvl
+	l.Append(build, nil)
+
+	l.Append(newTestLogEvent("fe", start, "runtime info\n"), nil)
+
+	runtimeErr := newTestLogEvent("fe", start, "runtime error\n")
+	runtimeErr.level = logger.ErrorLvl
+	l.Append(runtimeErr, nil)
+
+	result, err := l.Search(Filter{MinLevel: logger.WarnLvl}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{0, 1, 3}, matchCheckpoints(result))
+
+	result, err = l.Search(Filter{Since: start.Add(-time.Minute)}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{1, 2, 3}, matchCheckpoints(result))
+
+	result, err = l.Search(Filter{SpanType: SpanTypeBuild}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{1}, matchCheckpoints(result))
+
+	result, err = l.Search(Filter{SpanType: SpanTypeRuntime, MinLevel: logger.WarnLvl}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{0, 3}, matchCheckpoints(result))
+}
+
+func TestSourceForSpanID(t *testing.T) {
+	for spanID, expected := range map[SpanID]Source{
+		"build:3":            SourceBuild,
+		"pod:my-pod-123":     SourcePod,
+		"monitor:my-pod-123": SourcePod,
+		"dc:db":              SourceContainer,
+		"localserve:2":       SourceLocal,
+		"tiltfile:1":         SourceTiltfile,
+		"portforward:fe":     SourceTilt,
+		"":                   SourceTilt,
+	} {
+		assert.Equal(t, expected, SourceForSpanID(spanID), "span %q", spanID)
+	}
+}
+
+func TestSearchManifestNames(t *testing.T) {
+	l := NewLogStore()
+	l.Append(newTestLogEvent("fe", time.Now(), "hello\n"), nil)
+	l.Append(newTestLogEvent("be", time.Now(), "hello\n"), nil)
+
+	result, err := l.Search(Filter{ManifestNames: model.ManifestNameSet{"be": true}}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{1}, matchCheckpoints(result))
+}
+
+func TestSearchFields(t *testing.T) {
+	l := NewLogStore()
+	l.Append(newTestLogEvent("db", time.Now(), "ready\n"), nil)
+	stderr := newTestLogEvent("db", time.Now(), "slow query\n")
+	stderr.fields = logger.Fields{logger.FieldNameStream: logger.StreamStderr}
+	l.Append(stderr, nil)
+
+	result, err := l.Search(Filter{Fields: logger.Fields{logger.FieldNameStream: logger.StreamStderr}}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{1}, matchCheckpoints(result))
+
+	result, err = l.Search(Filter{Fields: logger.Fields{logger.FieldNameStream: "stdout"}}, 0, 100)
+	require.NoError(t, err)
+	assert.Empty(t, result.Matches)
+}
+
+func TestSearchPaging(t *testing.T) {
+	l := NewLogStore()
+	for i := 0; i < 5; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), "line\n"), nil)
+	}
+
+	result, err := l.Search(Filter{}, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{0, 1}, matchCheckpoints(result))
+	assert.True(t, result.HasMore)
+
+	result, err = l.Search(Filter{}, result.To, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{2, 3}, matchCheckpoints(result))
+	assert.True(t, result.HasMore)
+
+	result, err = l.Search(Filter{}, result.To, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{4}, matchCheckpoints(result))
+	assert.False(t, result.HasMore)
+}
+
+func TestSearchArchive(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.maxLogLengthInBytes = 20
+	for i := 0; i < 10; i++ {
+		msg := "line\n"
+		if i%3 == 0 {
+			msg = "match\n"
+		}
+		l.Append(newTestLogEvent("fe", time.Now(), msg), nil)
+	}
+	require.True(t, l.checkpointOffset > 0, "expected in-memory logs to be truncated")
+
+	result, err := l.Search(Filter{Pattern: regexp.MustCompile(`^match$`)}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{0, 3, 6, 9}, matchCheckpoints(result))
+	assert.Equal(t, model.ManifestName("fe"), result.Matches[0].ManifestName)
+	require.NoError(t, l.CloseArchive())
+
+	l = newArchivedLogStore(t, dir)
+	result, err = l.SearchPreviousSession(Filter{Pattern: regexp.MustCompile(`match`)}, 4, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{6, 9}, matchCheckpoints(result))
+	assert.Equal(t, Checkpoint(4), result.From)
+	assert.Equal(t, Checkpoint(10), result.To)
+	require.NoError(t, l.CloseArchive())
+}
+
+func matchCheckpoints(result SearchResult) []Checkpoint {
+	checkpoints := []Checkpoint{}
+	for _, m := range result.Matches {
+		checkpoints = append(checkpoints, m.Checkpoint)
+	}
+	return checkpoints
+}
+
+func TestSearchSnapshot(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.maxLogLengthInBytes = 20
+	for i := 0; i < 5; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), "match\n"), nil)
+	}
+
+	// Logs appended after the snapshot don't show up in the search.
+	snap := l.SearchSnapshot(0)
+	l.Append(newTestLogEvent("fe", time.Now(), "match\n"), nil)
+
+	result, err := snap.Search(Filter{Pattern: regexp.MustCompile(`^match$`)}, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{0, 1, 2, 3, 4}, matchCheckpoints(result))
+	assert.Equal(t, model.ManifestName("fe"), result.Matches[4].ManifestName)
+	assert.Equal(t, Checkpoint(5), result.To)
+	assert.False(t, result.HasMore)
+	require.NoError(t, l.CloseArchive())
+}
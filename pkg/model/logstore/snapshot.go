@@ -0,0 +1,238 @@
+package logstore
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/tilt-dev/tilt/pkg/model"
+	"github.com/tilt-dev/tilt/pkg/webview"
+)
+
+// A copy of the parts of a LogStore that a read needs, so that the read
+// can happen after the caller lets go of the store.
+//
+// Reading the archive goes to disk, and can take a while. A snapshot only
+// copies the archive pointer and a window of the in-memory segments, so
+// callers that hold the store lock take one under the lock, then read from
+// it after they've released the lock.
+//
+// A snapshot only has the in-memory segments for the read it was taken for.
+type Snapshot struct {
+	archive *Archive
+
+	// The first checkpoint we can read, and the checkpoint of the next
+	// segment to be appended, when we took the snapshot.
+	first Checkpoint
+	end   Checkpoint
+
+	// Logs before this checkpoint may only be in the archive.
+	archiveEnd Checkpoint
+
+	// A window of the segments in memory, and the manifest of each of their spans.
+	segments  []LogSegment
+	manifests map[SpanID]model.ManifestName
+
+	// The checkpoint of the first segment after the window.
+	windowEnd Checkpoint
+}
+
+// Takes a snapshot for reading logs from the given checkpoint on.
+//
+// Copies at most maxBytes of in-memory logs.
+func (s *LogStore) Snapshot(from Checkpoint, maxBytes int) *Snapshot {
+	snap := s.newSnapshot()
+	start := s.checkpointToIndex(maxCheckpoint(from, snap.archiveEnd))
+	end := start
+	for bytes := 0; end < len(s.segments) && bytes < maxBytes; end++ {
+		bytes += s.segments[end].Len()
+	}
+	snap.setWindow(s, start, end)
+	return snap
+}
+
+// Takes a snapshot for reading the logs just before the given checkpoint.
+//
+// Copies at most maxBytes of in-memory logs.
+func (s *LogStore) SnapshotBefore(to Checkpoint, maxBytes int) *Snapshot {
+	snap := s.newSnapshot()
+	end := s.checkpointToIndex(to)
+	start := end
+	for bytes := 0; start > 0 && bytes < maxBytes; start-- {
+		segment := s.segments[start-1]
+		if segment.checkpoint < snap.archiveEnd {
+			break
+		}
+		bytes += segment.Len()
+	}
+	snap.setWindow(s, start, end)
+	return snap
+}
+
+func (s *LogStore) newSnapshot() *Snapshot {
+	return &Snapshot{
+		archive:    s.archive,
+		first:      s.FirstCheckpoint(),
+		end:        s.Checkpoint(),
+		archiveEnd: s.archiveEnd(),
+	}
+}
+
+func (snap *Snapshot) setWindow(s *LogStore, start, end int) {
+	snap.segments = append([]LogSegment(nil), s.segments[start:end]...)
+	snap.manifests = make(map[SpanID]model.ManifestName)
+	for _, segment := range snap.segments {
+		if span, ok := s.spans[segment.SpanID]; ok {
+			snap.manifests[segment.SpanID] = span.ManifestName
+		}
+	}
+	snap.windowEnd = s.checkpointFromIndex(end)
+}
+
+func (snap *Snapshot) checkpointFromIndex(index int) Checkpoint {
+	if index < len(snap.segments) {
+		return snap.segments[index].checkpoint
+	}
+	return snap.windowEnd
+}
+
+func (snap *Snapshot) checkpointToIndex(c Checkpoint) int {
+	return sort.Search(len(snap.segments), func(i int) bool {
+		return snap.segments[i].checkpoint >= c
+	})
+}
+
+// Returns one page of logs between two checkpoints, reading from the archive
+// for logs that are no longer in memory.
+//
+// Stops after about maxBytes of log text, so that callers can page through
+// the full history by asking again from the returned ToCheckpoint.
+func (snap *Snapshot) ToLogListRange(from, to Checkpoint, maxBytes int) (*webview.LogList, error) {
+	if from < snap.first {
+		from = snap.first
+	}
+	if to > snap.end {
+		to = snap.end
+	}
+	if from >= to {
+		return &webview.LogList{
+			FromCheckpoint: -1,
+			ToCheckpoint:   -1,
+		}, nil
+	}
+
+	list := &webview.LogList{
+		Spans:    make(map[string]*webview.LogSpan),
+		Segments: []*webview.LogSegment{},
+	}
+	bytes := 0
+	current := from
+
+	if current < snap.archiveEnd {
+		records, err := snap.archive.read(current, minCheckpoint(to, snap.archiveEnd), maxBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "ToLogListRange")
+		}
+		list, err = recordsToLogList(records)
+		if err != nil {
+			return nil, errors.Wrap(err, "ToLogListRange")
+		}
+		for _, record := range records {
+			bytes += len(record.Text)
+		}
+		if len(records) > 0 {
+			current = records[len(records)-1].Checkpoint + 1
+		}
+		if bytes >= maxBytes {
+			list.FromCheckpoint = int32(from)
+			list.ToCheckpoint = int32(current)
+			return list, nil
+		}
+		current = minCheckpoint(to, snap.archiveEnd)
+	}
+
+	i := snap.checkpointToIndex(current)
+	for ; i < len(snap.segments) && snap.segments[i].checkpoint < to && bytes < maxBytes; i++ {
+		segment := snap.segments[i]
+		if mn, ok := snap.manifests[segment.SpanID]; ok {
+			list.Spans[string(segment.SpanID)] = &webview.LogSpan{ManifestName: mn.String()}
+		}
+
+		protoSegment, err := segmentToProto(segment)
+		if err != nil {
+			return nil, errors.Wrap(err, "ToLogListRange")
+		}
+		list.Segments = append(list.Segments, protoSegment)
+		bytes += segment.Len()
+	}
+
+	list.FromCheckpoint = int32(from)
+	list.ToCheckpoint = int32(minCheckpoint(to, snap.checkpointFromIndex(i)))
+	return list, nil
+}
+
+// Returns the page of logs that ends just before the given checkpoint,
+// so that clients can page backwards from the oldest logs they have.
+//
+// Returns a FromCheckpoint of -1 if there are no earlier logs.
+func (snap *Snapshot) ToLogListBefore(to Checkpoint, maxBytes int) (*webview.LogList, error) {
+	if to > snap.end {
+		to = snap.end
+	}
+
+	// Start with the logs in memory, then walk backwards through
+	// the archive until we have a page.
+	from := to
+	bytes := 0
+	for i := snap.checkpointToIndex(to); i > 0 && bytes < maxBytes; i-- {
+		segment := snap.segments[i-1]
+		bytes += segment.Len()
+		from = segment.checkpoint
+	}
+
+	if bytes < maxBytes && snap.archive != nil {
+		from = snap.archive.pageStart(minCheckpoint(from, snap.archiveEnd), maxBytes-bytes)
+	}
+	return snap.ToLogListRange(from, to, math.MaxInt32)
+}
+
+// Returns one page of logs from the previous session, if there's an archive.
+// Works like ToLogListRange.
+func (snap *Snapshot) ToPreviousSessionLogList(from Checkpoint, maxBytes int) (*webview.LogList, error) {
+	if snap.archive == nil {
+		return &webview.LogList{
+			FromCheckpoint: -1,
+			ToCheckpoint:   -1,
+		}, nil
+	}
+
+	if from < 0 {
+		from = 0
+	}
+	records, err := snap.archive.readPrevious(from, math.MaxInt32, maxBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "ToPreviousSessionLogList")
+	}
+	if len(records) == 0 {
+		return &webview.LogList{
+			FromCheckpoint: -1,
+			ToCheckpoint:   -1,
+		}, nil
+	}
+
+	list, err := recordsToLogList(records)
+	if err != nil {
+		return nil, errors.Wrap(err, "ToPreviousSessionLogList")
+	}
+	list.FromCheckpoint = int32(records[0].Checkpoint)
+	list.ToCheckpoint = int32(records[len(records)-1].Checkpoint + 1)
+	return list, nil
+}
+
+func maxCheckpoint(a, b Checkpoint) Checkpoint {
+	if a > b {
+		return a
+	}
+	return b
+}
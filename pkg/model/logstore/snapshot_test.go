@@ -0,0 +1,55 @@
+package logstore
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotReadsAfterStoreChanges(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.maxLogLengthInBytes = 20
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), "line\n"), nil)
+	}
+
+	snap := l.Snapshot(0, 1000)
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), "more\n"), nil)
+	}
+
+	// The snapshot still reads the logs as they were when we took it,
+	// from the archive and from memory.
+	list, err := snap.ToLogListRange(0, l.Checkpoint(), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), list.FromCheckpoint)
+	assert.Equal(t, int32(10), list.ToCheckpoint)
+	assert.Equal(t, strings.Repeat("line\n", 10), logListText(list))
+	require.NoError(t, l.CloseArchive())
+}
+
+func TestSnapshotBefore(t *testing.T) {
+	dir := newArchiveDir(t)
+	defer os.RemoveAll(dir)
+
+	l := newArchivedLogStore(t, dir)
+	l.maxLogLengthInBytes = 20
+	for i := 0; i < 10; i++ {
+		l.Append(newTestLogEvent("fe", time.Now(), "line\n"), nil)
+	}
+
+	snap := l.SnapshotBefore(l.Checkpoint(), 10)
+	list, err := snap.ToLogListBefore(l.Checkpoint(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int32(8), list.FromCheckpoint)
+	assert.Equal(t, int32(10), list.ToCheckpoint)
+	assert.Equal(t, "line\nline\n", logListText(list))
+	require.NoError(t, l.CloseArchive())
+}
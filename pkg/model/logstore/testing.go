@@ -9,6 +9,7 @@ import (
 
 type testLogEvent struct {
 	name    model.ManifestName
+	spanID  SpanID
 	level   logger.Level
 	ts      time.Time
 	fields  logger.Fields
@@ -36,6 +37,9 @@ func (l testLogEvent) Fields() logger.Fields {
 }
 
 func (l testLogEvent) SpanID() SpanID {
+	if l.spanID != "" {
+		return l.spanID
+	}
 	return SpanID(l.name)
 }
 
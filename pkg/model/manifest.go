@@ -55,6 +55,14 @@ type Manifest struct {
 	// ready at least once.
 	ResourceDependencies []ManifestName
 
+	// Limits on the logs that we keep for this manifest. Changing these
+	// doesn't invalidate a build.
+	LogLimit LogLimit
+
+	// Patterns to look for in the runtime logs of this manifest. Changing
+	// these doesn't invalidate a build.
+	LogAlerts LogAlerts
+
 	Source ManifestSource
 }
 
@@ -335,6 +343,11 @@ type PortForward struct {
 	// Optional host to bind to on the current machine (localhost by default)
 	Host string
 
+	// Optional pods to forward to, instead of the resource's most recent pod.
+	// One of "svc/NAME", "pod/NAME", or a label selector like "app=frontend".
+	// Connections are balanced across all the ready pods.
+	Target string
+
 	// Optional name of the port forward; if given, used as text of the URL
 	// displayed in the web UI (e.g. <a href="localhost:8888">Debugger</a>)
 	Name string
@@ -346,6 +359,17 @@ type PortForward struct {
 	path *url.URL
 }
 
+// A tunnel from a Service in the cluster back to a port on the current machine,
+// so that pods in the cluster can connect to a process running locally.
+type ReverseForward struct {
+	// The port on the current machine to connect to.
+	LocalPort int
+
+	// The Service that pods connect to, and the port it listens on.
+	ServiceName string
+	ServicePort int
+}
+
 func (pf PortForward) PathForAppend() string {
 	if pf.path == nil {
 		return ""
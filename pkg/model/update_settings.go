@@ -5,11 +5,46 @@ import "time"
 const (
 	DefaultMaxParallelUpdates = 3
 	DefaultK8sUpsertTimeout   = 30 * time.Second
+
+	// All parts of Tilt should display logs incrementally.
+	//
+	// But the initial page load loads all the existing logs.
+	// https://github.com/tilt-dev/tilt/issues/3359
+	//
+	// Until that issue is fixed, we cap the logs at about 1MB by default.
+	DefaultMaxLogLength = 1000 * 1000
+)
+
+// How Tilt applies objects to a Kubernetes cluster.
+type K8sApplyMode string
+
+const (
+	// Equivalent to `kubectl apply`. Tilt computes the patch locally.
+	K8sApplyModeClientSide K8sApplyMode = "client-side"
+
+	// Equivalent to `kubectl apply --server-side --field-manager=tilt`.
+	// The API server tracks which fields each manager owns. Fields owned
+	// by other managers are left to them.
+	K8sApplyModeServerSide K8sApplyMode = "server-side"
+
+	// Like K8sApplyModeServerSide, but with --force-conflicts. Tilt takes
+	// ownership of fields owned by other managers.
+	K8sApplyModeServerSideForce K8sApplyMode = "server-side-force"
 )
 
+var K8sApplyModes = []K8sApplyMode{K8sApplyModeClientSide, K8sApplyModeServerSide, K8sApplyModeServerSideForce}
+
+func (m K8sApplyMode) IsServerSide() bool {
+	return m == K8sApplyModeServerSide || m == K8sApplyModeServerSideForce
+}
+
 type UpdateSettings struct {
 	maxParallelUpdates int           // max number of updates to run concurrently
 	k8sUpsertTimeout   time.Duration // timeout for k8s upsert operations
+	k8sApplyMode       K8sApplyMode  // how to apply objects to the cluster
+	maxLogLength       int           // max bytes of logs to keep in memory
+	clearLogsOnRebuild bool          // hide a resource's old logs when it starts a new build
+	k8sDeployDiff      bool          // log a dry-run diff of the objects before each deploy
 }
 
 func (us UpdateSettings) MaxParallelUpdates() int {
@@ -46,9 +81,53 @@ func (us UpdateSettings) WithK8sUpsertTimeout(timeout time.Duration) UpdateSetti
 	return us
 }
 
+func (us UpdateSettings) K8sApplyMode() K8sApplyMode {
+	if us.k8sApplyMode == "" {
+		return K8sApplyModeClientSide
+	}
+	return us.k8sApplyMode
+}
+
+func (us UpdateSettings) WithK8sApplyMode(mode K8sApplyMode) UpdateSettings {
+	us.k8sApplyMode = mode
+	return us
+}
+
+func (us UpdateSettings) MaxLogLength() int {
+	if us.maxLogLength <= 0 {
+		return DefaultMaxLogLength
+	}
+	return us.maxLogLength
+}
+
+func (us UpdateSettings) WithMaxLogLength(n int) UpdateSettings {
+	us.maxLogLength = n
+	return us
+}
+
+func (us UpdateSettings) ClearLogsOnRebuild() bool {
+	return us.clearLogsOnRebuild
+}
+
+func (us UpdateSettings) WithClearLogsOnRebuild(clear bool) UpdateSettings {
+	us.clearLogsOnRebuild = clear
+	return us
+}
+
+func (us UpdateSettings) K8sDeployDiff() bool {
+	return us.k8sDeployDiff
+}
+
+func (us UpdateSettings) WithK8sDeployDiff(diff bool) UpdateSettings {
+	us.k8sDeployDiff = diff
+	return us
+}
+
 func DefaultUpdateSettings() UpdateSettings {
 	return UpdateSettings{
 		maxParallelUpdates: DefaultMaxParallelUpdates,
 		k8sUpsertTimeout:   DefaultK8sUpsertTimeout,
+		k8sApplyMode:       K8sApplyModeClientSide,
+		maxLogLength:       DefaultMaxLogLength,
 	}
 }
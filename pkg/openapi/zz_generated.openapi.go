@@ -1061,7 +1061,7 @@ func schema_pkg_apis_core_v1alpha1_PodLogStreamSpec(ref common.ReferenceCallback
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PodLogStreamSpec defines the desired state of PodLogStream\n\nTranslated into a PodLog query to the current Kubernetes cluster: https://pkg.go.dev/k8s.io/api/core/v1#PodLogOptions\n\nwhich Kubernetes context to use?",
+				Description: "PodLogStreamSpec defines the desired state of PodLogStream\n\nTranslated into a PodLog query to a Kubernetes cluster: https://pkg.go.dev/k8s.io/api/core/v1#PodLogOptions",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"pod": {
@@ -1078,6 +1078,13 @@ func schema_pkg_apis_core_v1alpha1_PodLogStreamSpec(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"context": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The kubeconfig context of the cluster that the pod runs in. Defaults to the context that Tilt started with.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"sinceTime": {
 						SchemaProps: spec.SchemaProps{
 							Description: "An RFC3339 timestamp from which to show logs. If this value precedes the time a pod was started, only logs since the pod start will be returned. If this value is in the future, no logs will be returned.\n\nTranslates directly to the underlying PodLogOptions.",
@@ -1114,6 +1121,36 @@ func schema_pkg_apis_core_v1alpha1_PodLogStreamSpec(ref common.ReferenceCallback
 							},
 						},
 					},
+					"previous": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If true, shows the logs of the previous instance of each container (the one that most recently terminated), instead of following the current instance.\n\nEven when this is false, if a container restarts before we stream its logs, we read the final logs of the instance that terminated, so that crash output isn't lost.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"logFormat": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How to parse each line of the log.\n\nIf empty, lines are plain text. If `json`, each line is parsed as a JSON object, and its fields are attached to the log line.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"logFields": {
+						SchemaProps: spec.SchemaProps{
+							Description: "When logFormat is `json`, maps the fields that Tilt reads (`level` and `msg`) to the names of the fields in the JSON log.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
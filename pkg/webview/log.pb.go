@@ -76,10 +76,14 @@ type LogSegment struct {
 	Anchor bool `protobuf:"varint,5,opt,name=anchor,proto3" json:"anchor,omitempty"`
 	// Context-specific optional fields for a log segment.
 	// Used for experimenting with new types of log metadata.
-	Fields               map[string]string `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Fields map[string]string `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The checkpoint of this segment in the server's log store.
+	// There may be gaps between the checkpoints of neighboring segments,
+	// if the server truncated some logs.
+	Checkpoint           int32    `protobuf:"varint,7,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LogSegment) Reset()         { *m = LogSegment{} }
@@ -149,6 +153,13 @@ func (m *LogSegment) GetFields() map[string]string {
 	return nil
 }
 
+func (m *LogSegment) GetCheckpoint() int32 {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return 0
+}
+
 type LogSpan struct {
 	ManifestName         string   `protobuf:"bytes,1,opt,name=manifest_name,json=manifestName,proto3" json:"manifest_name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -273,36 +284,37 @@ func init() {
 func init() { proto.RegisterFile("pkg/webview/log.proto", fileDescriptor_3da405ef8c289d22) }
 
 var fileDescriptor_3da405ef8c289d22 = []byte{
-	// 488 bytes of a gzipped FileDescriptorProto
+	// 497 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xdb, 0x6e, 0xd3, 0x40,
-	0x14, 0xc4, 0x8e, 0x9d, 0xcb, 0x71, 0x29, 0x66, 0xb9, 0x59, 0xe1, 0xa1, 0x56, 0x40, 0xad, 0x85,
-	0xc4, 0x5a, 0x84, 0x07, 0x2e, 0x6f, 0x04, 0x5c, 0x54, 0x88, 0x1c, 0x69, 0xcb, 0x45, 0xe2, 0x25,
-	0x72, 0x92, 0x8d, 0xb3, 0x8a, 0xed, 0xb5, 0xb2, 0x9b, 0x94, 0x7e, 0x01, 0x7f, 0xc1, 0xb7, 0x56,
-	0xbb, 0x76, 0x5a, 0x57, 0xea, 0xdb, 0x39, 0xb3, 0x73, 0x46, 0x33, 0x93, 0x18, 0x9e, 0x94, 0xeb,
-	0x34, 0xbc, 0xa0, 0xb3, 0x1d, 0xa3, 0x17, 0x61, 0xc6, 0x53, 0x5c, 0x6e, 0xb8, 0xe4, 0xa8, 0x53,
-	0x43, 0xfd, 0xa3, 0x94, 0xf3, 0x34, 0xa3, 0xa1, 0x86, 0x67, 0xdb, 0x65, 0x28, 0x59, 0x4e, 0x85,
-	0x4c, 0xf2, 0xb2, 0x62, 0x0e, 0xfe, 0x9b, 0x00, 0x63, 0x9e, 0x9e, 0xd3, 0x34, 0xa7, 0x85, 0x44,
-	0xcf, 0xa0, 0x23, 0xca, 0xa4, 0x98, 0xb2, 0x85, 0x67, 0xf8, 0x46, 0xd0, 0x23, 0x6d, 0xb5, 0x9e,
-	0x2d, 0x10, 0x06, 0x4b, 0x9d, 0x7a, 0xa6, 0x6f, 0x04, 0xce, 0xb0, 0x8f, 0x2b, 0x5d, 0xbc, 0xd7,
-	0xc5, 0x3f, 0xf6, 0xba, 0x44, 0xf3, 0x10, 0x02, 0x4b, 0xd2, 0xbf, 0xd2, 0x6b, 0x69, 0x15, 0x3d,
-	0xa3, 0x13, 0xb0, 0x33, 0xba, 0xa3, 0x99, 0x67, 0xf9, 0x46, 0x70, 0x38, 0x7c, 0x88, 0x6b, 0x97,
-	0x78, 0xcc, 0xd3, 0xb1, 0x7a, 0x20, 0xd5, 0x3b, 0x7a, 0x0a, 0xed, 0xa4, 0x98, 0xaf, 0xf8, 0xc6,
-	0xb3, 0x7d, 0x23, 0xe8, 0x92, 0x7a, 0x43, 0xef, 0xa0, 0xbd, 0x64, 0x34, 0x5b, 0x08, 0xaf, 0xed,
-	0xb7, 0x02, 0x67, 0x78, 0xd4, 0x54, 0xa8, 0x23, 0xe0, 0x53, 0xcd, 0x88, 0x0a, 0xb9, 0xb9, 0x24,
-	0x35, 0xbd, 0xff, 0x01, 0x9c, 0x06, 0x8c, 0x5c, 0x68, 0xad, 0xe9, 0x65, 0x9d, 0x50, 0x8d, 0xe8,
-	0x31, 0xd8, 0xbb, 0x24, 0xdb, 0x56, 0xf9, 0x7a, 0xa4, 0x5a, 0x3e, 0x9a, 0xef, 0x8d, 0x01, 0x86,
-	0x8e, 0x12, 0x2f, 0x93, 0x02, 0xbd, 0x80, 0xfb, 0x79, 0x52, 0xb0, 0x25, 0x15, 0x72, 0x5a, 0x24,
-	0x39, 0xad, 0x05, 0x0e, 0xf6, 0x60, 0x9c, 0xe4, 0x74, 0xf0, 0xcf, 0xd4, 0x07, 0x63, 0x26, 0x24,
-	0x7a, 0x03, 0xb6, 0xaa, 0x4f, 0x78, 0x86, 0xb6, 0xfb, 0xfc, 0x56, 0x60, 0x26, 0x24, 0x56, 0xb2,
-	0xb5, 0xd5, 0x8a, 0x89, 0x42, 0xe8, 0x8a, 0x2a, 0x88, 0xf0, 0x4c, 0x7d, 0xf5, 0xe8, 0x8e, 0x90,
-	0xe4, 0x9a, 0x84, 0x4e, 0xe0, 0xc1, 0x72, 0xc3, 0xf3, 0xe9, 0x7c, 0x45, 0xe7, 0xeb, 0x92, 0xb3,
-	0xa2, 0xea, 0xdc, 0x26, 0x87, 0x0a, 0xfe, 0x7c, 0x8d, 0x2a, 0xf7, 0x92, 0x37, 0x69, 0x96, 0xa6,
-	0x1d, 0x48, 0x7e, 0x43, 0xea, 0x7f, 0x03, 0xb8, 0xf1, 0x74, 0x47, 0x4f, 0xc7, 0xcd, 0x9e, 0x9c,
-	0xa1, 0x7b, 0xcb, 0x5b, 0x99, 0x14, 0x8d, 0xe6, 0x5e, 0x7d, 0x87, 0xee, 0xfe, 0x87, 0x45, 0x5d,
-	0xb0, 0xe2, 0x49, 0x1c, 0xb9, 0xf7, 0xd4, 0x74, 0x16, 0x9f, 0x4e, 0x5c, 0x03, 0x39, 0xd0, 0xf9,
-	0x15, 0x91, 0xd1, 0xe4, 0x3c, 0x72, 0x4d, 0xd4, 0x03, 0xfb, 0x4b, 0x34, 0xfa, 0xf9, 0xd5, 0x6d,
-	0x29, 0xc6, 0xef, 0x4f, 0x24, 0x76, 0x2d, 0x05, 0x46, 0x84, 0x4c, 0x88, 0x6b, 0x8f, 0x8e, 0xff,
-	0xbc, 0x4c, 0x99, 0x5c, 0x6d, 0x67, 0x78, 0xce, 0xf3, 0x50, 0xb2, 0x4c, 0xbe, 0x5e, 0xd0, 0x9d,
-	0x1e, 0xc2, 0xc6, 0x37, 0x30, 0x6b, 0xeb, 0x7f, 0xe4, 0xdb, 0xab, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x88, 0xab, 0xfb, 0x09, 0x19, 0x03, 0x00, 0x00,
+	0x10, 0xc5, 0x8e, 0x2f, 0xc9, 0xb8, 0x14, 0xb3, 0xdc, 0xac, 0x20, 0x51, 0x2b, 0xa0, 0xd6, 0x42,
+	0x62, 0x2d, 0xc2, 0x03, 0x97, 0x37, 0x02, 0x2e, 0x2a, 0x44, 0x8e, 0xb4, 0xe5, 0x22, 0xf1, 0x12,
+	0x39, 0xc9, 0xc6, 0x59, 0xc5, 0xf6, 0x5a, 0xf1, 0x26, 0xa5, 0x5f, 0xc0, 0x5f, 0xf1, 0x6d, 0x68,
+	0xd7, 0x4e, 0xeb, 0x4a, 0x7d, 0x9b, 0x39, 0x73, 0xe6, 0x78, 0xce, 0xb1, 0x0d, 0x8f, 0xca, 0x75,
+	0x1a, 0x5e, 0xd0, 0xd9, 0x8e, 0xd1, 0x8b, 0x30, 0xe3, 0x29, 0x2e, 0x37, 0x5c, 0x70, 0x64, 0x37,
+	0x50, 0xff, 0x28, 0xe5, 0x3c, 0xcd, 0x68, 0xa8, 0xe0, 0xd9, 0x76, 0x19, 0x0a, 0x96, 0xd3, 0x4a,
+	0x24, 0x79, 0x59, 0x33, 0x07, 0xff, 0x74, 0x80, 0x31, 0x4f, 0xcf, 0x69, 0x9a, 0xd3, 0x42, 0xa0,
+	0x27, 0x60, 0x57, 0x65, 0x52, 0x4c, 0xd9, 0xc2, 0xd3, 0x7c, 0x2d, 0xe8, 0x11, 0x4b, 0xb6, 0x67,
+	0x0b, 0x84, 0xc1, 0x90, 0xab, 0x9e, 0xee, 0x6b, 0x81, 0x33, 0xec, 0xe3, 0x5a, 0x17, 0xef, 0x75,
+	0xf1, 0xf7, 0xbd, 0x2e, 0x51, 0x3c, 0x84, 0xc0, 0x10, 0xf4, 0x8f, 0xf0, 0x3a, 0x4a, 0x45, 0xd5,
+	0xe8, 0x04, 0xcc, 0x8c, 0xee, 0x68, 0xe6, 0x19, 0xbe, 0x16, 0x1c, 0x0e, 0xef, 0xe3, 0xe6, 0x4a,
+	0x3c, 0xe6, 0xe9, 0x58, 0x0e, 0x48, 0x3d, 0x47, 0x8f, 0xc1, 0x4a, 0x8a, 0xf9, 0x8a, 0x6f, 0x3c,
+	0xd3, 0xd7, 0x82, 0x2e, 0x69, 0x3a, 0xf4, 0x16, 0xac, 0x25, 0xa3, 0xd9, 0xa2, 0xf2, 0x2c, 0xbf,
+	0x13, 0x38, 0xc3, 0xa3, 0xb6, 0x42, 0x63, 0x01, 0x9f, 0x2a, 0x46, 0x54, 0x88, 0xcd, 0x25, 0x69,
+	0xe8, 0xe8, 0x19, 0xc0, 0x7c, 0x45, 0xe7, 0xeb, 0x92, 0xb3, 0x42, 0x78, 0xb6, 0xaf, 0x05, 0x26,
+	0x69, 0x21, 0xfd, 0xf7, 0xe0, 0xb4, 0xd6, 0x90, 0x0b, 0x9d, 0x35, 0xbd, 0x6c, 0x12, 0x90, 0x25,
+	0x7a, 0x08, 0xe6, 0x2e, 0xc9, 0xb6, 0xb5, 0xff, 0x1e, 0xa9, 0x9b, 0x0f, 0xfa, 0x3b, 0x6d, 0x80,
+	0xc1, 0x96, 0x0f, 0x2f, 0x93, 0x02, 0x3d, 0x87, 0xbb, 0x79, 0x52, 0xb0, 0x25, 0xad, 0xc4, 0xb4,
+	0x48, 0x72, 0xda, 0x08, 0x1c, 0xec, 0xc1, 0x38, 0xc9, 0xe9, 0xe0, 0xaf, 0xae, 0x16, 0xc6, 0xac,
+	0x12, 0xe8, 0x35, 0x98, 0x32, 0xde, 0xca, 0xd3, 0x94, 0x9d, 0xa7, 0x37, 0x02, 0x61, 0x95, 0xc0,
+	0x52, 0xb6, 0xb1, 0x52, 0x33, 0x51, 0x08, 0xdd, 0xaa, 0x36, 0x5a, 0x79, 0xba, 0xda, 0x7a, 0x70,
+	0x4b, 0x08, 0xe4, 0x8a, 0x84, 0x4e, 0xe0, 0xde, 0x72, 0xc3, 0xf3, 0x69, 0xcb, 0x7f, 0x47, 0xf9,
+	0x3f, 0x94, 0xf0, 0xa7, 0x2b, 0x54, 0x5e, 0x2f, 0x78, 0x9b, 0x66, 0x28, 0xda, 0x81, 0xe0, 0xd7,
+	0xa4, 0xfe, 0x57, 0x80, 0xeb, 0x9b, 0x6e, 0xc9, 0xe9, 0xb8, 0x9d, 0x93, 0x33, 0x74, 0x6f, 0xdc,
+	0x56, 0x26, 0x45, 0x2b, 0xb9, 0x97, 0xdf, 0xa0, 0xbb, 0x7f, 0xf1, 0xa8, 0x0b, 0x46, 0x3c, 0x89,
+	0x23, 0xf7, 0x8e, 0xac, 0xce, 0xe2, 0xd3, 0x89, 0xab, 0x21, 0x07, 0xec, 0x9f, 0x11, 0x19, 0x4d,
+	0xce, 0x23, 0x57, 0x47, 0x3d, 0x30, 0x3f, 0x47, 0xa3, 0x1f, 0x5f, 0xdc, 0x8e, 0x64, 0xfc, 0xfa,
+	0x48, 0x62, 0xd7, 0x90, 0x60, 0x44, 0xc8, 0x84, 0xb8, 0xe6, 0xe8, 0xf8, 0xf7, 0x8b, 0x94, 0x89,
+	0xd5, 0x76, 0x86, 0xe7, 0x3c, 0x0f, 0x05, 0xcb, 0xc4, 0xab, 0x05, 0xdd, 0xa9, 0x22, 0x6c, 0xfd,
+	0x23, 0x33, 0x4b, 0x7d, 0xb1, 0x6f, 0xfe, 0x0f, 0x00, 0x19, 0xbe, 0xb3, 0x25, 0x39, 0x03, 0x00,
+	0x00,
 }
@@ -164,7 +164,10 @@ type BuildRecord struct {
 	UpdateTypes    []UpdateType         `protobuf:"varint,9,rep,packed,name=update_types,json=updateTypes,proto3,enum=webview.UpdateType" json:"update_types,omitempty"`
 	IsCrashRebuild bool                 `protobuf:"varint,7,opt,name=is_crash_rebuild,json=isCrashRebuild,proto3" json:"is_crash_rebuild,omitempty"`
 	// The span id for this build record's logs in the main logstore.
-	SpanId               string   `protobuf:"bytes,8,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	SpanId string `protobuf:"bytes,8,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	// The diff of the K8s objects deployed by this build against the
+	// objects that were live in the cluster beforehand.
+	K8SDiff              string   `protobuf:"bytes,10,opt,name=k8s_diff,json=k8sDiff,proto3" json:"k8s_diff,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -251,6 +254,13 @@ func (m *BuildRecord) GetSpanId() string {
 	return ""
 }
 
+func (m *BuildRecord) GetK8SDiff() string {
+	if m != nil {
+		return m.K8SDiff
+	}
+	return ""
+}
+
 type K8SResourceInfo struct {
 	PodName            string `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
 	PodCreationTime    string `protobuf:"bytes,2,opt,name=pod_creation_time,json=podCreationTime,proto3" json:"pod_creation_time,omitempty"`
@@ -260,8 +270,13 @@ type K8SResourceInfo struct {
 	AllContainersReady bool   `protobuf:"varint,6,opt,name=all_containers_ready,json=allContainersReady,proto3" json:"all_containers_ready,omitempty"`
 	PodRestarts        int32  `protobuf:"varint,7,opt,name=pod_restarts,json=podRestarts,proto3" json:"pod_restarts,omitempty"`
 	// The span id for this pod's logs in the main logstore
-	SpanId               string   `protobuf:"bytes,9,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
-	DisplayNames         []string `protobuf:"bytes,10,rep,name=display_names,json=displayNames,proto3" json:"display_names,omitempty"`
+	SpanId       string   `protobuf:"bytes,9,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	DisplayNames []string `protobuf:"bytes,10,rep,name=display_names,json=displayNames,proto3" json:"display_names,omitempty"`
+	// Port-forwards to a Service or label selector, which balance connections
+	// across pods.
+	PortForwardStatuses []*PortForwardStatus `protobuf:"bytes,11,rep,name=port_forward_statuses,json=portForwardStatuses,proto3" json:"port_forward_statuses,omitempty"`
+	// The kubeconfig context that this resource deploys to.
+	KubeContext          string   `protobuf:"bytes,12,opt,name=kube_context,json=kubeContext,proto3" json:"kube_context,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -355,6 +370,109 @@ func (m *K8SResourceInfo) GetDisplayNames() []string {
 	return nil
 }
 
+func (m *K8SResourceInfo) GetPortForwardStatuses() []*PortForwardStatus {
+	if m != nil {
+		return m.PortForwardStatuses
+	}
+	return nil
+}
+
+func (m *K8SResourceInfo) GetKubeContext() string {
+	if m != nil {
+		return m.KubeContext
+	}
+	return ""
+}
+
+type PortForwardStatus struct {
+	LocalPort int32 `protobuf:"varint,1,opt,name=local_port,json=localPort,proto3" json:"local_port,omitempty"`
+	// The pods we forward to, e.g., "svc/frontend" or "app=frontend".
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// The number of ready pods behind the target.
+	Pods                 int32    `protobuf:"varint,3,opt,name=pods,proto3" json:"pods,omitempty"`
+	ActiveConnections    int32    `protobuf:"varint,4,opt,name=active_connections,json=activeConnections,proto3" json:"active_connections,omitempty"`
+	TotalConnections     int32    `protobuf:"varint,5,opt,name=total_connections,json=totalConnections,proto3" json:"total_connections,omitempty"`
+	Errors               int32    `protobuf:"varint,6,opt,name=errors,proto3" json:"errors,omitempty"`
+	LastError            string   `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortForwardStatus) Reset()         { *m = PortForwardStatus{} }
+func (m *PortForwardStatus) String() string { return proto.CompactTextString(m) }
+func (*PortForwardStatus) ProtoMessage()    {}
+func (*PortForwardStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_961ad0c6909086c3, []int{3}
+}
+
+func (m *PortForwardStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortForwardStatus.Unmarshal(m, b)
+}
+func (m *PortForwardStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortForwardStatus.Marshal(b, m, deterministic)
+}
+func (m *PortForwardStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortForwardStatus.Merge(m, src)
+}
+func (m *PortForwardStatus) XXX_Size() int {
+	return xxx_messageInfo_PortForwardStatus.Size(m)
+}
+func (m *PortForwardStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortForwardStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortForwardStatus proto.InternalMessageInfo
+
+func (m *PortForwardStatus) GetLocalPort() int32 {
+	if m != nil {
+		return m.LocalPort
+	}
+	return 0
+}
+
+func (m *PortForwardStatus) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *PortForwardStatus) GetPods() int32 {
+	if m != nil {
+		return m.Pods
+	}
+	return 0
+}
+
+func (m *PortForwardStatus) GetActiveConnections() int32 {
+	if m != nil {
+		return m.ActiveConnections
+	}
+	return 0
+}
+
+func (m *PortForwardStatus) GetTotalConnections() int32 {
+	if m != nil {
+		return m.TotalConnections
+	}
+	return 0
+}
+
+func (m *PortForwardStatus) GetErrors() int32 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *PortForwardStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
 type DCResourceInfo struct {
 	ConfigPaths     []string             `protobuf:"bytes,1,rep,name=config_paths,json=configPaths,proto3" json:"config_paths,omitempty"`
 	ContainerStatus string               `protobuf:"bytes,2,opt,name=container_status,json=containerStatus,proto3" json:"container_status,omitempty"`
@@ -371,7 +489,7 @@ func (m *DCResourceInfo) Reset()         { *m = DCResourceInfo{} }
 func (m *DCResourceInfo) String() string { return proto.CompactTextString(m) }
 func (*DCResourceInfo) ProtoMessage()    {}
 func (*DCResourceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{3}
+	return fileDescriptor_961ad0c6909086c3, []int{4}
 }
 
 func (m *DCResourceInfo) XXX_Unmarshal(b []byte) error {
@@ -438,7 +556,7 @@ func (m *YAMLResourceInfo) Reset()         { *m = YAMLResourceInfo{} }
 func (m *YAMLResourceInfo) String() string { return proto.CompactTextString(m) }
 func (*YAMLResourceInfo) ProtoMessage()    {}
 func (*YAMLResourceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{4}
+	return fileDescriptor_961ad0c6909086c3, []int{5}
 }
 
 func (m *YAMLResourceInfo) XXX_Unmarshal(b []byte) error {
@@ -478,7 +596,7 @@ func (m *LocalResourceInfo) Reset()         { *m = LocalResourceInfo{} }
 func (m *LocalResourceInfo) String() string { return proto.CompactTextString(m) }
 func (*LocalResourceInfo) ProtoMessage()    {}
 func (*LocalResourceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{5}
+	return fileDescriptor_961ad0c6909086c3, []int{6}
 }
 
 func (m *LocalResourceInfo) XXX_Unmarshal(b []byte) error {
@@ -528,7 +646,7 @@ func (m *Facet) Reset()         { *m = Facet{} }
 func (m *Facet) String() string { return proto.CompactTextString(m) }
 func (*Facet) ProtoMessage()    {}
 func (*Facet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{6}
+	return fileDescriptor_961ad0c6909086c3, []int{7}
 }
 
 func (m *Facet) XXX_Unmarshal(b []byte) error {
@@ -582,7 +700,7 @@ func (m *Link) Reset()         { *m = Link{} }
 func (m *Link) String() string { return proto.CompactTextString(m) }
 func (*Link) ProtoMessage()    {}
 func (*Link) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{7}
+	return fileDescriptor_961ad0c6909086c3, []int{8}
 }
 
 func (m *Link) XXX_Unmarshal(b []byte) error {
@@ -618,37 +736,44 @@ func (m *Link) GetName() string {
 }
 
 type Resource struct {
-	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	LastDeployTime       *timestamp.Timestamp `protobuf:"bytes,4,opt,name=last_deploy_time,json=lastDeployTime,proto3" json:"last_deploy_time,omitempty"`
-	TriggerMode          int32                `protobuf:"varint,5,opt,name=trigger_mode,json=triggerMode,proto3" json:"trigger_mode,omitempty"`
-	BuildHistory         []*BuildRecord       `protobuf:"bytes,6,rep,name=build_history,json=buildHistory,proto3" json:"build_history,omitempty"`
-	CurrentBuild         *BuildRecord         `protobuf:"bytes,7,opt,name=current_build,json=currentBuild,proto3" json:"current_build,omitempty"`
-	PendingBuildReason   int32                `protobuf:"varint,8,opt,name=pending_build_reason,json=pendingBuildReason,proto3" json:"pending_build_reason,omitempty"`
-	PendingBuildEdits    []string             `protobuf:"bytes,9,rep,name=pending_build_edits,json=pendingBuildEdits,proto3" json:"pending_build_edits,omitempty"`
-	PendingBuildSince    *timestamp.Timestamp `protobuf:"bytes,10,opt,name=pending_build_since,json=pendingBuildSince,proto3" json:"pending_build_since,omitempty"`
-	HasPendingChanges    bool                 `protobuf:"varint,11,opt,name=has_pending_changes,json=hasPendingChanges,proto3" json:"has_pending_changes,omitempty"`
-	EndpointLinks        []*Link              `protobuf:"bytes,28,rep,name=endpoint_links,json=endpointLinks,proto3" json:"endpoint_links,omitempty"`
-	PodID                string               `protobuf:"bytes,13,opt,name=podID,proto3" json:"podID,omitempty"`
-	K8SResourceInfo      *K8SResourceInfo     `protobuf:"bytes,14,opt,name=k8s_resource_info,json=k8sResourceInfo,proto3" json:"k8s_resource_info,omitempty"`
-	DcResourceInfo       *DCResourceInfo      `protobuf:"bytes,15,opt,name=dc_resource_info,json=dcResourceInfo,proto3" json:"dc_resource_info,omitempty"`
-	YamlResourceInfo     *YAMLResourceInfo    `protobuf:"bytes,16,opt,name=yaml_resource_info,json=yamlResourceInfo,proto3" json:"yaml_resource_info,omitempty"`
-	LocalResourceInfo    *LocalResourceInfo   `protobuf:"bytes,17,opt,name=local_resource_info,json=localResourceInfo,proto3" json:"local_resource_info,omitempty"`
-	RuntimeStatus        string               `protobuf:"bytes,18,opt,name=runtime_status,json=runtimeStatus,proto3" json:"runtime_status,omitempty"`
-	UpdateStatus         string               `protobuf:"bytes,29,opt,name=update_status,json=updateStatus,proto3" json:"update_status,omitempty"`
-	IsTiltfile           bool                 `protobuf:"varint,19,opt,name=is_tiltfile,json=isTiltfile,proto3" json:"is_tiltfile,omitempty"`
-	Specs                []*TargetSpec        `protobuf:"bytes,27,rep,name=specs,proto3" json:"specs,omitempty"`
-	ShowBuildStatus      bool                 `protobuf:"varint,20,opt,name=show_build_status,json=showBuildStatus,proto3" json:"show_build_status,omitempty"`
-	Queued               bool                 `protobuf:"varint,25,opt,name=queued,proto3" json:"queued,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Name               string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LastDeployTime     *timestamp.Timestamp `protobuf:"bytes,4,opt,name=last_deploy_time,json=lastDeployTime,proto3" json:"last_deploy_time,omitempty"`
+	TriggerMode        int32                `protobuf:"varint,5,opt,name=trigger_mode,json=triggerMode,proto3" json:"trigger_mode,omitempty"`
+	BuildHistory       []*BuildRecord       `protobuf:"bytes,6,rep,name=build_history,json=buildHistory,proto3" json:"build_history,omitempty"`
+	CurrentBuild       *BuildRecord         `protobuf:"bytes,7,opt,name=current_build,json=currentBuild,proto3" json:"current_build,omitempty"`
+	PendingBuildReason int32                `protobuf:"varint,8,opt,name=pending_build_reason,json=pendingBuildReason,proto3" json:"pending_build_reason,omitempty"`
+	PendingBuildEdits  []string             `protobuf:"bytes,9,rep,name=pending_build_edits,json=pendingBuildEdits,proto3" json:"pending_build_edits,omitempty"`
+	PendingBuildSince  *timestamp.Timestamp `protobuf:"bytes,10,opt,name=pending_build_since,json=pendingBuildSince,proto3" json:"pending_build_since,omitempty"`
+	HasPendingChanges  bool                 `protobuf:"varint,11,opt,name=has_pending_changes,json=hasPendingChanges,proto3" json:"has_pending_changes,omitempty"`
+	EndpointLinks      []*Link              `protobuf:"bytes,28,rep,name=endpoint_links,json=endpointLinks,proto3" json:"endpoint_links,omitempty"`
+	PodID              string               `protobuf:"bytes,13,opt,name=podID,proto3" json:"podID,omitempty"`
+	K8SResourceInfo    *K8SResourceInfo     `protobuf:"bytes,14,opt,name=k8s_resource_info,json=k8sResourceInfo,proto3" json:"k8s_resource_info,omitempty"`
+	DcResourceInfo     *DCResourceInfo      `protobuf:"bytes,15,opt,name=dc_resource_info,json=dcResourceInfo,proto3" json:"dc_resource_info,omitempty"`
+	YamlResourceInfo   *YAMLResourceInfo    `protobuf:"bytes,16,opt,name=yaml_resource_info,json=yamlResourceInfo,proto3" json:"yaml_resource_info,omitempty"`
+	LocalResourceInfo  *LocalResourceInfo   `protobuf:"bytes,17,opt,name=local_resource_info,json=localResourceInfo,proto3" json:"local_resource_info,omitempty"`
+	RuntimeStatus      string               `protobuf:"bytes,18,opt,name=runtime_status,json=runtimeStatus,proto3" json:"runtime_status,omitempty"`
+	UpdateStatus       string               `protobuf:"bytes,29,opt,name=update_status,json=updateStatus,proto3" json:"update_status,omitempty"`
+	IsTiltfile         bool                 `protobuf:"varint,19,opt,name=is_tiltfile,json=isTiltfile,proto3" json:"is_tiltfile,omitempty"`
+	Specs              []*TargetSpec        `protobuf:"bytes,27,rep,name=specs,proto3" json:"specs,omitempty"`
+	ShowBuildStatus    bool                 `protobuf:"varint,20,opt,name=show_build_status,json=showBuildStatus,proto3" json:"show_build_status,omitempty"`
+	Queued             bool                 `protobuf:"varint,25,opt,name=queued,proto3" json:"queued,omitempty"`
+	// Runtime log lines that matched one of the resource's alert patterns
+	// since its last deploy, and the most recent one.
+	LogAlertCount int32  `protobuf:"varint,30,opt,name=log_alert_count,json=logAlertCount,proto3" json:"log_alert_count,omitempty"`
+	LastLogAlert  string `protobuf:"bytes,31,opt,name=last_log_alert,json=lastLogAlert,proto3" json:"last_log_alert,omitempty"`
+	// The log checkpoint that the resource's logs were last cleared at.
+	// Clients should hide the resource's logs before it.
+	LogClearCheckpoint   int32    `protobuf:"varint,32,opt,name=log_clear_checkpoint,json=logClearCheckpoint,proto3" json:"log_clear_checkpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Resource) Reset()         { *m = Resource{} }
 func (m *Resource) String() string { return proto.CompactTextString(m) }
 func (*Resource) ProtoMessage()    {}
 func (*Resource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{8}
+	return fileDescriptor_961ad0c6909086c3, []int{9}
 }
 
 func (m *Resource) XXX_Unmarshal(b []byte) error {
@@ -816,6 +941,27 @@ func (m *Resource) GetQueued() bool {
 	return false
 }
 
+func (m *Resource) GetLogAlertCount() int32 {
+	if m != nil {
+		return m.LogAlertCount
+	}
+	return 0
+}
+
+func (m *Resource) GetLastLogAlert() string {
+	if m != nil {
+		return m.LastLogAlert
+	}
+	return ""
+}
+
+func (m *Resource) GetLogClearCheckpoint() int32 {
+	if m != nil {
+		return m.LogClearCheckpoint
+	}
+	return 0
+}
+
 type TiltBuild struct {
 	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 	CommitSHA            string   `protobuf:"bytes,2,opt,name=commitSHA,proto3" json:"commitSHA,omitempty"`
@@ -830,7 +976,7 @@ func (m *TiltBuild) Reset()         { *m = TiltBuild{} }
 func (m *TiltBuild) String() string { return proto.CompactTextString(m) }
 func (*TiltBuild) ProtoMessage()    {}
 func (*TiltBuild) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{9}
+	return fileDescriptor_961ad0c6909086c3, []int{10}
 }
 
 func (m *TiltBuild) XXX_Unmarshal(b []byte) error {
@@ -890,7 +1036,7 @@ func (m *VersionSettings) Reset()         { *m = VersionSettings{} }
 func (m *VersionSettings) String() string { return proto.CompactTextString(m) }
 func (*VersionSettings) ProtoMessage()    {}
 func (*VersionSettings) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{10}
+	return fileDescriptor_961ad0c6909086c3, []int{11}
 }
 
 func (m *VersionSettings) XXX_Unmarshal(b []byte) error {
@@ -940,18 +1086,20 @@ type View struct {
 	// so we can tell when Tilt restarted.
 	TiltStartTime *timestamp.Timestamp `protobuf:"bytes,14,opt,name=tilt_start_time,json=tiltStartTime,proto3" json:"tilt_start_time,omitempty"`
 	// an identifier for the tiltfile that is running, so that the web ui can store data per tiltfile
-	TiltfileKey          string          `protobuf:"bytes,17,opt,name=tiltfile_key,json=tiltfileKey,proto3" json:"tiltfile_key,omitempty"`
-	MetricsServing       *MetricsServing `protobuf:"bytes,18,opt,name=metrics_serving,json=metricsServing,proto3" json:"metrics_serving,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	TiltfileKey    string          `protobuf:"bytes,17,opt,name=tiltfile_key,json=tiltfileKey,proto3" json:"tiltfile_key,omitempty"`
+	MetricsServing *MetricsServing `protobuf:"bytes,18,opt,name=metrics_serving,json=metricsServing,proto3" json:"metrics_serving,omitempty"`
+	// The log checkpoint that logs without a resource were last cleared at.
+	LogClearCheckpoint   int32    `protobuf:"varint,19,opt,name=log_clear_checkpoint,json=logClearCheckpoint,proto3" json:"log_clear_checkpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *View) Reset()         { *m = View{} }
 func (m *View) String() string { return proto.CompactTextString(m) }
 func (*View) ProtoMessage()    {}
 func (*View) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{11}
+	return fileDescriptor_961ad0c6909086c3, []int{12}
 }
 
 func (m *View) XXX_Unmarshal(b []byte) error {
@@ -1098,6 +1246,13 @@ func (m *View) GetMetricsServing() *MetricsServing {
 	return nil
 }
 
+func (m *View) GetLogClearCheckpoint() int32 {
+	if m != nil {
+		return m.LogClearCheckpoint
+	}
+	return 0
+}
+
 type MetricsServing struct {
 	// Whether we're using the local or remote metrics stack.
 	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
@@ -1112,7 +1267,7 @@ func (m *MetricsServing) Reset()         { *m = MetricsServing{} }
 func (m *MetricsServing) String() string { return proto.CompactTextString(m) }
 func (*MetricsServing) ProtoMessage()    {}
 func (*MetricsServing) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{12}
+	return fileDescriptor_961ad0c6909086c3, []int{13}
 }
 
 func (m *MetricsServing) XXX_Unmarshal(b []byte) error {
@@ -1157,7 +1312,7 @@ func (m *GetViewRequest) Reset()         { *m = GetViewRequest{} }
 func (m *GetViewRequest) String() string { return proto.CompactTextString(m) }
 func (*GetViewRequest) ProtoMessage()    {}
 func (*GetViewRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{13}
+	return fileDescriptor_961ad0c6909086c3, []int{14}
 }
 
 func (m *GetViewRequest) XXX_Unmarshal(b []byte) error {
@@ -1191,7 +1346,7 @@ func (m *SnapshotHighlight) Reset()         { *m = SnapshotHighlight{} }
 func (m *SnapshotHighlight) String() string { return proto.CompactTextString(m) }
 func (*SnapshotHighlight) ProtoMessage()    {}
 func (*SnapshotHighlight) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{14}
+	return fileDescriptor_961ad0c6909086c3, []int{15}
 }
 
 func (m *SnapshotHighlight) XXX_Unmarshal(b []byte) error {
@@ -1248,7 +1403,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{15}
+	return fileDescriptor_961ad0c6909086c3, []int{16}
 }
 
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
@@ -1315,7 +1470,7 @@ func (m *UploadSnapshotResponse) Reset()         { *m = UploadSnapshotResponse{}
 func (m *UploadSnapshotResponse) String() string { return proto.CompactTextString(m) }
 func (*UploadSnapshotResponse) ProtoMessage()    {}
 func (*UploadSnapshotResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{16}
+	return fileDescriptor_961ad0c6909086c3, []int{17}
 }
 
 func (m *UploadSnapshotResponse) XXX_Unmarshal(b []byte) error {
@@ -1364,7 +1519,7 @@ func (m *AckWebsocketRequest) Reset()         { *m = AckWebsocketRequest{} }
 func (m *AckWebsocketRequest) String() string { return proto.CompactTextString(m) }
 func (*AckWebsocketRequest) ProtoMessage()    {}
 func (*AckWebsocketRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{17}
+	return fileDescriptor_961ad0c6909086c3, []int{18}
 }
 
 func (m *AckWebsocketRequest) XXX_Unmarshal(b []byte) error {
@@ -1409,7 +1564,7 @@ func (m *AckWebsocketResponse) Reset()         { *m = AckWebsocketResponse{} }
 func (m *AckWebsocketResponse) String() string { return proto.CompactTextString(m) }
 func (*AckWebsocketResponse) ProtoMessage()    {}
 func (*AckWebsocketResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_961ad0c6909086c3, []int{18}
+	return fileDescriptor_961ad0c6909086c3, []int{19}
 }
 
 func (m *AckWebsocketResponse) XXX_Unmarshal(b []byte) error {
@@ -1436,6 +1591,7 @@ func init() {
 	proto.RegisterType((*TargetSpec)(nil), "webview.TargetSpec")
 	proto.RegisterType((*BuildRecord)(nil), "webview.BuildRecord")
 	proto.RegisterType((*K8SResourceInfo)(nil), "webview.K8sResourceInfo")
+	proto.RegisterType((*PortForwardStatus)(nil), "webview.PortForwardStatus")
 	proto.RegisterType((*DCResourceInfo)(nil), "webview.DCResourceInfo")
 	proto.RegisterType((*YAMLResourceInfo)(nil), "webview.YAMLResourceInfo")
 	proto.RegisterType((*LocalResourceInfo)(nil), "webview.LocalResourceInfo")
@@ -1458,150 +1614,164 @@ func init() {
 func init() { proto.RegisterFile("pkg/webview/view.proto", fileDescriptor_961ad0c6909086c3) }
 
 var fileDescriptor_961ad0c6909086c3 = []byte{
-	// 2280 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0x4d, 0x73, 0xdb, 0xc6,
-	0xf9, 0xff, 0x53, 0xa4, 0x24, 0xf0, 0xe1, 0x1b, 0xb8, 0x94, 0x65, 0x58, 0x71, 0xfe, 0x96, 0xe9,
-	0x36, 0x51, 0xdc, 0x54, 0x6c, 0xd5, 0x4c, 0xea, 0xb8, 0x33, 0x6d, 0x14, 0x92, 0xb6, 0x49, 0xcb,
-	0xb1, 0x66, 0x29, 0xa7, 0x93, 0x5e, 0x30, 0x10, 0xb0, 0x04, 0x77, 0x08, 0x62, 0x11, 0xec, 0x52,
-	0xaa, 0x7a, 0xe8, 0xa1, 0xc7, 0x4e, 0x6f, 0xfd, 0x14, 0xfd, 0x04, 0xbd, 0xf4, 0x5b, 0xe4, 0xdc,
-	0xe9, 0xa5, 0x9f, 0xa2, 0xa7, 0xce, 0xbe, 0x00, 0x04, 0x29, 0x7b, 0xdc, 0x5c, 0x38, 0xbb, 0xcf,
-	0xeb, 0xee, 0x6f, 0x7f, 0xfb, 0xec, 0x03, 0xc2, 0x7e, 0x32, 0x0f, 0x7b, 0xd7, 0xe4, 0xf2, 0x8a,
-	0x92, 0xeb, 0x9e, 0xfc, 0x39, 0x4e, 0x52, 0x26, 0x18, 0xda, 0x35, 0xb2, 0x83, 0xfb, 0x21, 0x63,
-	0x61, 0x44, 0x7a, 0x5e, 0x42, 0x7b, 0x5e, 0x1c, 0x33, 0xe1, 0x09, 0xca, 0x62, 0xae, 0xcd, 0x0e,
-	0x1e, 0x18, 0xad, 0x9a, 0x5d, 0x2e, 0xa7, 0x3d, 0x41, 0x17, 0x84, 0x0b, 0x6f, 0x91, 0x18, 0x83,
-	0x3b, 0xc5, 0xf8, 0x11, 0x0b, 0xb5, 0xb8, 0xbb, 0x00, 0xb8, 0xf0, 0xd2, 0x90, 0x88, 0x49, 0x42,
-	0x7c, 0xd4, 0x84, 0x2d, 0x1a, 0x38, 0xa5, 0xc3, 0xd2, 0x51, 0x15, 0x6f, 0xd1, 0x00, 0x7d, 0x0c,
-	0x15, 0x71, 0x93, 0x10, 0x67, 0xeb, 0xb0, 0x74, 0xd4, 0x3c, 0xe9, 0x1c, 0x1b, 0xff, 0x63, 0xed,
-	0x72, 0x71, 0x93, 0x10, 0xac, 0x0c, 0xd0, 0x47, 0xd0, 0x9a, 0x79, 0xdc, 0x8d, 0xe8, 0x15, 0x71,
-	0x97, 0x49, 0xe0, 0x09, 0xe2, 0x94, 0x0f, 0x4b, 0x47, 0x16, 0x6e, 0xcc, 0x3c, 0x7e, 0x46, 0xaf,
-	0xc8, 0x1b, 0x25, 0xec, 0x7e, 0xbf, 0x05, 0xb5, 0xaf, 0x96, 0x34, 0x0a, 0x30, 0xf1, 0x59, 0x1a,
-	0xa0, 0x3d, 0xd8, 0x26, 0x01, 0x15, 0xdc, 0x29, 0x1d, 0x96, 0x8f, 0xaa, 0x58, 0x4f, 0x94, 0x34,
-	0x4d, 0x59, 0xaa, 0xf2, 0x4a, 0xa9, 0x9c, 0xa0, 0x03, 0xb0, 0xae, 0xbd, 0x34, 0xa6, 0x71, 0xc8,
-	0x9d, 0xb2, 0x32, 0xcf, 0xe7, 0xe8, 0x0b, 0x00, 0x2e, 0xbc, 0x54, 0xb8, 0x72, 0xdb, 0x4e, 0xe5,
-	0xb0, 0x74, 0x54, 0x3b, 0x39, 0x38, 0xd6, 0x98, 0x1c, 0x67, 0x98, 0x1c, 0x5f, 0x64, 0x98, 0xe0,
-	0xaa, 0xb2, 0x96, 0x73, 0xf4, 0x2b, 0xa8, 0x4d, 0x69, 0x4c, 0xf9, 0x4c, 0xfb, 0x6e, 0xbf, 0xd7,
-	0x17, 0xb4, 0xb9, 0x72, 0xfe, 0x1c, 0xea, 0x7a, 0xbb, 0xae, 0x84, 0x81, 0x3b, 0xd5, 0xc3, 0xf2,
-	0x1a, 0x50, 0x7a, 0xdb, 0x0a, 0xa8, 0xda, 0x32, 0x1f, 0x73, 0x74, 0x04, 0x36, 0xe5, 0xae, 0x9f,
-	0x7a, 0x7c, 0xe6, 0xa6, 0xe4, 0x52, 0x22, 0xe2, 0xec, 0x2a, 0xc0, 0x9a, 0x94, 0xf7, 0xa5, 0x18,
-	0x6b, 0x29, 0xba, 0x0b, 0xbb, 0x3c, 0xf1, 0x62, 0x97, 0x06, 0x8e, 0xa5, 0xd0, 0xd8, 0x91, 0xd3,
-	0x51, 0x30, 0xae, 0x58, 0x3b, 0xf6, 0x2e, 0x2e, 0x47, 0x2c, 0xec, 0xfe, 0x67, 0x0b, 0x5a, 0x2f,
-	0x9f, 0x70, 0x4c, 0x38, 0x5b, 0xa6, 0x3e, 0x19, 0xc5, 0x53, 0x86, 0xee, 0x81, 0x95, 0xb0, 0xc0,
-	0x8d, 0xbd, 0x05, 0x31, 0x07, 0xba, 0x9b, 0xb0, 0xe0, 0x6b, 0x6f, 0x41, 0xd0, 0x63, 0x68, 0x4b,
-	0x95, 0x9f, 0x12, 0x45, 0x21, 0xbd, 0x6f, 0x0d, 0x75, 0x2b, 0x61, 0x41, 0xdf, 0xc8, 0xd5, 0x06,
-	0x7f, 0x0e, 0x77, 0xa4, 0xad, 0xd9, 0x64, 0x01, 0xe3, 0xb2, 0xb2, 0x47, 0x09, 0x0b, 0xf4, 0x1e,
-	0x27, 0x39, 0xa0, 0x1f, 0x02, 0x48, 0x17, 0x2e, 0x3c, 0xb1, 0xe4, 0xea, 0x2c, 0xaa, 0xb8, 0x9a,
-	0xb0, 0x60, 0xa2, 0x04, 0xe8, 0x53, 0x40, 0x2b, 0xb5, 0xbb, 0x20, 0x9c, 0x7b, 0xa1, 0x86, 0xbd,
-	0x8a, 0xed, 0xdc, 0xec, 0x95, 0x96, 0xa3, 0x9f, 0xc1, 0x9e, 0x17, 0x45, 0xae, 0xcf, 0x62, 0xe1,
-	0xd1, 0x98, 0xa4, 0xdc, 0x4d, 0x89, 0x17, 0xdc, 0x38, 0x3b, 0x0a, 0x2c, 0xe4, 0x45, 0x51, 0x3f,
-	0x57, 0x61, 0xa9, 0x41, 0x0f, 0xa1, 0x2e, 0xe3, 0xa7, 0x44, 0x2d, 0x96, 0x2b, 0x58, 0xb7, 0x71,
-	0x2d, 0x61, 0x01, 0x36, 0xa2, 0x22, 0xa6, 0xd5, 0x22, 0xa6, 0xe8, 0x11, 0x34, 0x02, 0xca, 0x93,
-	0xc8, 0xbb, 0x51, 0xc0, 0x71, 0x07, 0x14, 0xcf, 0xea, 0x46, 0x28, 0xd1, 0xe3, 0xe3, 0x8a, 0x65,
-	0xd9, 0x1a, 0x4d, 0x57, 0x82, 0xff, 0xaf, 0x12, 0x34, 0x07, 0xfd, 0x35, 0xec, 0x1f, 0x42, 0xdd,
-	0x67, 0xf1, 0x94, 0x86, 0x6e, 0xe2, 0x89, 0x59, 0x46, 0xee, 0x9a, 0x96, 0x9d, 0x4b, 0x11, 0xfa,
-	0x04, 0xec, 0x7c, 0x4f, 0x19, 0x54, 0xe6, 0x08, 0x72, 0xb9, 0x01, 0xec, 0x10, 0x6a, 0xb9, 0x68,
-	0x34, 0x30, 0xc0, 0x17, 0x45, 0x1b, 0xec, 0xdf, 0xfe, 0x21, 0xec, 0x2f, 0x40, 0xb1, 0xb3, 0x41,
-	0xaf, 0x8a, 0xbd, 0xad, 0xe9, 0xf5, 0x4b, 0xb0, 0xbf, 0x3d, 0x7d, 0x75, 0xb6, 0xb6, 0xc5, 0x47,
-	0xd0, 0x98, 0x3f, 0x91, 0x87, 0xa1, 0x65, 0xd9, 0x1e, 0xeb, 0xf3, 0x15, 0x0d, 0x79, 0xf7, 0xd7,
-	0xd0, 0x3e, 0x63, 0xbe, 0x17, 0xad, 0x79, 0xda, 0x50, 0x4e, 0x4c, 0x91, 0x29, 0x63, 0x39, 0x94,
-	0x6b, 0xa0, 0xdc, 0x15, 0x84, 0x0b, 0x05, 0x81, 0x85, 0x77, 0x28, 0xbf, 0x20, 0x5c, 0x74, 0xc7,
-	0xb0, 0xfd, 0xcc, 0xf3, 0x89, 0x40, 0x08, 0x2a, 0x05, 0x22, 0xab, 0xb1, 0x2c, 0x12, 0x57, 0x5e,
-	0xb4, 0xcc, 0x98, 0xab, 0x27, 0xc5, 0xfd, 0x94, 0x8b, 0xfb, 0xe9, 0x7e, 0x0a, 0x95, 0x33, 0x1a,
-	0xcf, 0x65, 0xfa, 0x65, 0x1a, 0x99, 0x48, 0x72, 0x98, 0x07, 0xdf, 0x5a, 0x05, 0xef, 0xfe, 0xa3,
-	0x0a, 0x56, 0xb6, 0xea, 0xb7, 0x66, 0x1f, 0x80, 0x1d, 0x79, 0x5c, 0xb8, 0x01, 0x49, 0x22, 0x76,
-	0xf3, 0xbf, 0x96, 0x9d, 0xa6, 0xf4, 0x19, 0x28, 0x17, 0x85, 0xfe, 0x43, 0xa8, 0x8b, 0x94, 0x86,
-	0x21, 0x49, 0xdd, 0x05, 0x0b, 0xf4, 0xd1, 0x6d, 0xe3, 0x9a, 0x91, 0xbd, 0x62, 0x01, 0x41, 0x5f,
-	0x40, 0x43, 0x15, 0x02, 0x77, 0x46, 0xb9, 0x60, 0xa9, 0x64, 0x7e, 0xf9, 0xa8, 0x76, 0xb2, 0x97,
-	0x97, 0x98, 0x42, 0x39, 0xc5, 0x75, 0x65, 0xfa, 0x42, 0x5b, 0x4a, 0x57, 0x7f, 0x99, 0xa6, 0x24,
-	0x16, 0xee, 0xaa, 0xc2, 0xbc, 0xd3, 0xd5, 0x98, 0x2a, 0x99, 0xbc, 0x76, 0x09, 0x89, 0x03, 0x1a,
-	0x87, 0xda, 0x55, 0xde, 0x3a, 0xce, 0x62, 0x55, 0x82, 0xb6, 0x31, 0x32, 0x3a, 0xe3, 0x2f, 0x35,
-	0xe8, 0x18, 0x3a, 0xeb, 0x1e, 0xba, 0xae, 0x57, 0x15, 0x2d, 0xda, 0x45, 0x87, 0xa1, 0xaa, 0xf1,
-	0xe3, 0x4d, 0x7b, 0x4e, 0x63, 0x9f, 0x38, 0xf0, 0x5e, 0x0c, 0xd7, 0x62, 0x4d, 0xa4, 0x93, 0xcc,
-	0x2d, 0x5f, 0x9f, 0x2c, 0x9e, 0x3f, 0xf3, 0xe2, 0x90, 0x70, 0xa7, 0xa6, 0xc8, 0xd4, 0x9e, 0x79,
-	0xfc, 0x5c, 0x6b, 0xfa, 0x5a, 0x81, 0x3e, 0x83, 0x26, 0x89, 0x83, 0x84, 0xd1, 0x58, 0xb8, 0x11,
-	0x8d, 0xe7, 0xdc, 0xb9, 0xaf, 0x40, 0x6d, 0xe4, 0xc8, 0x48, 0xaa, 0xe0, 0x46, 0x66, 0x24, 0x67,
-	0xea, 0x55, 0x4a, 0x58, 0x30, 0x1a, 0x38, 0x0d, 0x4d, 0x38, 0x35, 0x41, 0x03, 0x68, 0x17, 0x2f,
-	0x82, 0x4b, 0xe3, 0x29, 0x73, 0x9a, 0x6a, 0x17, 0x4e, 0x1e, 0x6e, 0xa3, 0x38, 0xe3, 0xd6, 0x7c,
-	0xa3, 0x5a, 0x9f, 0x82, 0x1d, 0xf8, 0x1b, 0x41, 0x5a, 0x2a, 0xc8, 0xdd, 0x3c, 0xc8, 0x7a, 0x91,
-	0xc1, 0xcd, 0xc0, 0x5f, 0x0b, 0xf1, 0x1c, 0xd0, 0x8d, 0xb7, 0x88, 0x36, 0x82, 0xd8, 0x2a, 0xc8,
-	0xbd, 0x3c, 0xc8, 0xe6, 0x45, 0xc6, 0xb6, 0x74, 0x5a, 0x0b, 0x34, 0x86, 0x4e, 0x24, 0x6f, 0xed,
-	0x46, 0xa4, 0xb6, 0x39, 0x99, 0x1c, 0xa2, 0xcd, 0x9b, 0x8d, 0xdb, 0xd1, 0xad, 0xcb, 0xfe, 0x63,
-	0x68, 0xa6, 0xcb, 0x58, 0xde, 0x8e, 0xac, 0xc8, 0x21, 0x05, 0x5e, 0xc3, 0x48, 0x4d, 0x89, 0x7b,
-	0x04, 0x8d, 0xd5, 0x0b, 0x23, 0xad, 0x3e, 0x54, 0x56, 0xe6, 0x6d, 0x35, 0x46, 0x0f, 0xa0, 0x26,
-	0xcb, 0x04, 0x8d, 0xc4, 0x94, 0x46, 0xc4, 0xe9, 0xa8, 0xd3, 0x05, 0xca, 0x2f, 0x8c, 0x04, 0x7d,
-	0x02, 0xdb, 0x3c, 0x21, 0x3e, 0x77, 0x3e, 0x50, 0xa7, 0xb9, 0xd9, 0xae, 0xc8, 0x0e, 0x07, 0x6b,
-	0x0b, 0xf9, 0x04, 0xf2, 0x19, 0xbb, 0xce, 0xa8, 0xa7, 0x93, 0xee, 0xa9, 0x88, 0x2d, 0xa9, 0xd0,
-	0xe4, 0xd2, 0x79, 0xf7, 0x61, 0xe7, 0xbb, 0x25, 0x59, 0x92, 0xc0, 0xb9, 0xa7, 0xab, 0x93, 0x9e,
-	0x8d, 0x2b, 0xd6, 0x96, 0x5d, 0x1e, 0x57, 0xac, 0xb2, 0x5d, 0x19, 0x57, 0xac, 0xba, 0xdd, 0x18,
-	0x57, 0xac, 0x3b, 0xf6, 0xfe, 0xb8, 0x62, 0xed, 0xdb, 0x77, 0xc7, 0x15, 0xeb, 0xc0, 0xfe, 0x60,
-	0x5c, 0xb1, 0xee, 0xda, 0xce, 0xb8, 0x62, 0x39, 0xf6, 0x3d, 0xdc, 0x09, 0x68, 0x4a, 0x7c, 0xc1,
-	0x52, 0x4a, 0xb8, 0x7b, 0xed, 0x09, 0x7f, 0x46, 0x02, 0xdc, 0x50, 0x2f, 0x44, 0x3e, 0xad, 0x66,
-	0x94, 0xe3, 0xb8, 0xee, 0xb3, 0xc5, 0x25, 0x8d, 0x89, 0x7a, 0x65, 0x70, 0x55, 0xf7, 0x0a, 0x72,
-	0xd8, 0xce, 0x87, 0xae, 0x29, 0x75, 0x78, 0xc7, 0x8b, 0x48, 0x2a, 0x38, 0xde, 0x99, 0xca, 0x72,
-	0xc9, 0xbb, 0x14, 0xaa, 0x12, 0x15, 0x7d, 0x97, 0x1d, 0xd8, 0xbd, 0x22, 0x29, 0xa7, 0x2c, 0xce,
-	0x1a, 0x01, 0x33, 0x45, 0xf7, 0xa1, 0xea, 0xb3, 0xc5, 0x82, 0x8a, 0xc9, 0x8b, 0x53, 0x53, 0xfe,
-	0x56, 0x02, 0x59, 0xf6, 0xf2, 0x46, 0xae, 0x8a, 0xd5, 0x58, 0x56, 0xcf, 0x80, 0x5c, 0xa9, 0x4a,
-	0x67, 0x61, 0x39, 0xec, 0x7e, 0x0e, 0xad, 0x6f, 0x74, 0xb8, 0x09, 0x11, 0x42, 0x35, 0x63, 0x8f,
-	0xa0, 0xe1, 0xcf, 0x88, 0x3f, 0x37, 0x5d, 0x03, 0x57, 0x69, 0x2d, 0x5c, 0x57, 0x42, 0xdd, 0x2d,
-	0xf0, 0xee, 0x9f, 0x2d, 0xa8, 0x7c, 0x43, 0xc9, 0xb5, 0x0c, 0x19, 0xb1, 0x30, 0x2b, 0xc8, 0x11,
-	0x0b, 0x51, 0x0f, 0xaa, 0xab, 0x77, 0x65, 0x4b, 0x9d, 0x65, 0x3b, 0x3f, 0xcb, 0x8c, 0x5e, 0x78,
-	0x65, 0x83, 0x9e, 0xc2, 0xbd, 0xc1, 0xf0, 0x1c, 0x0f, 0xfb, 0xa7, 0x17, 0xc3, 0x81, 0x02, 0x26,
-	0xef, 0x7e, 0xb9, 0xe9, 0x43, 0xef, 0xae, 0x0c, 0xce, 0x58, 0x98, 0x57, 0x13, 0x8e, 0x06, 0xd0,
-	0x98, 0x12, 0x4f, 0x2c, 0x53, 0xe2, 0x4e, 0x23, 0x2f, 0x94, 0x0d, 0x8b, 0x4c, 0xf8, 0x20, 0x4f,
-	0x28, 0x17, 0x79, 0xfc, 0x4c, 0x9b, 0x3c, 0x93, 0x16, 0xc3, 0x58, 0xa4, 0x37, 0xb8, 0x3e, 0x2d,
-	0x88, 0xd0, 0x09, 0xdc, 0x89, 0x09, 0x09, 0xb8, 0xeb, 0xc5, 0x5e, 0x74, 0x23, 0xa8, 0xcf, 0xdd,
-	0x78, 0x19, 0x98, 0xbe, 0xc6, 0xc2, 0x1d, 0xa5, 0x3c, 0xcd, 0x74, 0x5f, 0x4b, 0x15, 0xfa, 0x12,
-	0x50, 0xba, 0x8c, 0x65, 0xff, 0xaa, 0x48, 0x6d, 0x6a, 0xf4, 0x8e, 0xba, 0x66, 0x68, 0xc5, 0xdd,
-	0xec, 0x1c, 0xb1, 0x6d, 0xac, 0x57, 0x27, 0x3b, 0x81, 0xfb, 0xc5, 0x7d, 0x4b, 0x5c, 0x45, 0x31,
-	0xd6, 0xee, 0x3b, 0x63, 0x15, 0xf0, 0x3a, 0x53, 0x6e, 0xab, 0xa0, 0x9f, 0xc1, 0x3e, 0x5f, 0x86,
-	0x21, 0xe1, 0x82, 0x04, 0x3a, 0x58, 0xc6, 0x1e, 0x5b, 0x1d, 0xd1, 0x5e, 0xae, 0x95, 0x3e, 0xe6,
-	0xec, 0x51, 0x1f, 0x6c, 0x63, 0xe6, 0x72, 0xc3, 0x03, 0xa7, 0xbe, 0x51, 0x05, 0x37, 0x78, 0x82,
-	0x5b, 0x57, 0x1b, 0xc4, 0x39, 0x86, 0x8e, 0x4a, 0xe8, 0x47, 0x6c, 0x19, 0xb8, 0x4b, 0x4e, 0x52,
-	0xf5, 0xee, 0xea, 0xbe, 0xb7, 0x2d, 0x55, 0x7d, 0xa9, 0x79, 0x63, 0x14, 0xa8, 0x07, 0x7b, 0x05,
-	0x7b, 0x41, 0xbc, 0x85, 0xee, 0x77, 0x5b, 0x1b, 0x0e, 0x17, 0xc4, 0x5b, 0xa8, 0xce, 0xf7, 0x04,
-	0xee, 0x14, 0x1c, 0xb8, 0x3f, 0x23, 0x0b, 0xf2, 0x82, 0x71, 0x61, 0xda, 0xc0, 0x4e, 0xee, 0x31,
-	0xc9, 0x55, 0xb2, 0x54, 0x6c, 0x24, 0x19, 0x0d, 0xd4, 0x33, 0x55, 0xc5, 0xad, 0xb5, 0x0c, 0xa3,
-	0x81, 0x2c, 0x51, 0x53, 0x4f, 0x78, 0x91, 0xab, 0x3f, 0x5f, 0x6a, 0xca, 0x0a, 0x94, 0x68, 0xa8,
-	0xbe, 0x61, 0x7e, 0x02, 0x96, 0xa4, 0x67, 0x44, 0xb9, 0x50, 0xcf, 0x48, 0xed, 0xc4, 0x2e, 0x14,
-	0xd4, 0xf0, 0x8c, 0x72, 0x81, 0x77, 0x23, 0x3d, 0x40, 0x5f, 0x81, 0x4a, 0x50, 0xec, 0xba, 0x9b,
-	0xef, 0x7d, 0x1e, 0x1b, 0xd2, 0x65, 0xd5, 0x8c, 0xcb, 0x0e, 0xc3, 0xd4, 0x47, 0x77, 0x4e, 0x6e,
-	0x54, 0x15, 0xaf, 0xe2, 0x5a, 0x26, 0x7b, 0x49, 0x6e, 0xd0, 0x97, 0xd0, 0x5a, 0x10, 0x91, 0x4a,
-	0xce, 0x72, 0x92, 0x5e, 0xd1, 0x38, 0x54, 0x45, 0xba, 0xf8, 0xf4, 0xbc, 0xd2, 0xfa, 0x89, 0x56,
-	0xe3, 0xe6, 0x62, 0x6d, 0x7e, 0xf0, 0x1b, 0x68, 0xdf, 0xba, 0x20, 0xf2, 0x5e, 0xcb, 0x84, 0xe6,
-	0x5e, 0xcf, 0xc9, 0xcd, 0x7a, 0xc7, 0x66, 0x99, 0x8e, 0xed, 0xe9, 0xd6, 0x93, 0x52, 0xf7, 0x39,
-	0x34, 0xd7, 0x53, 0xc8, 0xe2, 0xa3, 0x3a, 0x22, 0xd3, 0x73, 0xc9, 0xb1, 0xdc, 0x4b, 0x98, 0x7a,
-	0x53, 0x2f, 0xf6, 0xdc, 0x19, 0x33, 0xcd, 0x62, 0x15, 0xd7, 0x8c, 0x4c, 0x1e, 0x56, 0xd7, 0x86,
-	0xe6, 0x73, 0x22, 0xe4, 0x95, 0xc5, 0xe4, 0xbb, 0xa5, 0xec, 0x21, 0x39, 0xb4, 0x27, 0xb1, 0x97,
-	0xf0, 0x19, 0x13, 0x2f, 0x68, 0x38, 0x8b, 0x68, 0x38, 0x13, 0xe8, 0x63, 0x68, 0x5d, 0x92, 0x90,
-	0xea, 0xcb, 0x17, 0xb1, 0x70, 0x34, 0x30, 0x89, 0x9a, 0xb9, 0xf8, 0x4c, 0x4a, 0x65, 0x4a, 0xd3,
-	0x54, 0x68, 0x2b, 0x93, 0x52, 0xcb, 0xb4, 0x09, 0x82, 0x8a, 0x20, 0xbf, 0x17, 0x59, 0x99, 0x94,
-	0xe3, 0xee, 0x3f, 0x4b, 0x60, 0x65, 0x59, 0xd1, 0x43, 0xa8, 0x48, 0x10, 0x55, 0x86, 0x62, 0x8f,
-	0xa1, 0x56, 0xa9, 0x54, 0x92, 0x63, 0x94, 0xbb, 0x9c, 0x06, 0xe4, 0xd2, 0x4b, 0x25, 0xd3, 0x38,
-	0x09, 0x0c, 0x4a, 0x2d, 0xca, 0x27, 0x5a, 0xde, 0x57, 0x62, 0x99, 0x4f, 0xbe, 0x19, 0x59, 0x3e,
-	0x39, 0x46, 0x23, 0x40, 0xdc, 0xa4, 0x73, 0x67, 0xd9, 0x2e, 0xf3, 0x7e, 0x34, 0x4b, 0x78, 0x0b,
-	0x07, 0xdc, 0xe6, 0xb7, 0xa0, 0x79, 0x04, 0x8d, 0x3c, 0x94, 0xec, 0x8d, 0xcc, 0x97, 0x59, 0x3d,
-	0x13, 0xca, 0x5e, 0xa8, 0xfb, 0x18, 0xf6, 0xdf, 0x24, 0x11, 0xf3, 0x82, 0x2c, 0x24, 0x26, 0x3c,
-	0x61, 0x31, 0x27, 0xb7, 0xdb, 0xeb, 0xee, 0x1f, 0xa1, 0x73, 0xea, 0xcf, 0x7f, 0x4b, 0x2e, 0x39,
-	0xf3, 0xe7, 0x44, 0x98, 0x73, 0x91, 0x79, 0x04, 0x73, 0xd5, 0x93, 0xa0, 0x1e, 0x3c, 0xe5, 0xb2,
-	0x8d, 0xeb, 0x82, 0xf5, 0x73, 0xd9, 0xdb, 0x6e, 0xc0, 0xd6, 0x0f, 0xbc, 0x01, 0xdd, 0x7d, 0xd8,
-	0x5b, 0xcf, 0xaf, 0x57, 0xfa, 0xf8, 0x6f, 0x25, 0x80, 0xd5, 0xe7, 0x39, 0xfa, 0x00, 0xee, 0xbe,
-	0x39, 0x1f, 0x9c, 0x5e, 0x0c, 0xdd, 0x8b, 0x6f, 0xcf, 0x87, 0xee, 0x9b, 0xaf, 0x27, 0xe7, 0xc3,
-	0xfe, 0xe8, 0xd9, 0x68, 0x38, 0xb0, 0xff, 0x0f, 0xdd, 0x81, 0x76, 0x51, 0x39, 0x7a, 0x75, 0xfa,
-	0x7c, 0x68, 0x97, 0x36, 0x7d, 0xce, 0x46, 0xdf, 0x0c, 0x5d, 0x2d, 0xb0, 0xb7, 0xd0, 0xff, 0xc3,
-	0x41, 0x51, 0x39, 0x78, 0xdd, 0x7f, 0x39, 0xc4, 0x6e, 0xff, 0xf5, 0xab, 0xf3, 0xd7, 0x93, 0xa1,
-	0x5d, 0x46, 0x1d, 0x68, 0x15, 0xf5, 0x2f, 0x9f, 0x4c, 0xec, 0xca, 0x66, 0xa2, 0xb3, 0xd7, 0xfd,
-	0xd3, 0x33, 0x7b, 0xfb, 0xf1, 0x5f, 0x4a, 0xd9, 0xdf, 0x34, 0xd9, 0x5a, 0x2f, 0x4e, 0xf1, 0xf3,
-	0xe1, 0xc5, 0x3b, 0xd6, 0x5a, 0x54, 0x66, 0x6b, 0xed, 0x40, 0xab, 0x28, 0x96, 0xe9, 0xd4, 0x1a,
-	0x8b, 0xc2, 0x5b, 0x6b, 0xdc, 0x88, 0xa5, 0x97, 0x53, 0x39, 0xf9, 0x7b, 0x09, 0x6a, 0x92, 0xbd,
-	0xea, 0xb2, 0xfa, 0xf2, 0x63, 0x68, 0xd7, 0xdc, 0x3a, 0xb4, 0xaa, 0x19, 0xeb, 0xf7, 0xf0, 0x60,
-	0x9d, 0xf7, 0xdd, 0xf6, 0x9f, 0xbe, 0xff, 0xf7, 0x5f, 0xb7, 0x6a, 0xa8, 0xaa, 0xfe, 0xcf, 0x52,
-	0x97, 0xe0, 0x12, 0x9a, 0xeb, 0xa4, 0x42, 0xed, 0x5b, 0xd4, 0x3d, 0x78, 0x50, 0xf8, 0x6b, 0xe5,
-	0x6d, 0x04, 0xec, 0xde, 0x57, 0x81, 0xf7, 0xbb, 0x6d, 0x15, 0x38, 0x63, 0x6d, 0x2f, 0x26, 0xd7,
-	0x4f, 0x4b, 0x8f, 0x4f, 0xfe, 0x00, 0x76, 0xce, 0x84, 0x6c, 0xf5, 0x53, 0xa8, 0x17, 0x09, 0x82,
-	0xee, 0xe7, 0x29, 0xde, 0xc2, 0xdb, 0x83, 0x0f, 0xdf, 0xa1, 0x35, 0xe9, 0xef, 0xa9, 0xf4, 0x9d,
-	0x6e, 0xb3, 0x77, 0x9d, 0xe9, 0x7a, 0x9e, 0x3f, 0x7f, 0x5a, 0x7a, 0xfc, 0xd5, 0x47, 0xbf, 0xfb,
-	0x51, 0x48, 0xc5, 0x6c, 0x79, 0x79, 0xec, 0xb3, 0x45, 0x4f, 0x92, 0xf4, 0xa7, 0x01, 0xb9, 0x52,
-	0x83, 0x5e, 0xe1, 0xcf, 0xb9, 0xcb, 0x1d, 0xc5, 0xe9, 0x5f, 0xfc, 0x37, 0x00, 0x00, 0xff, 0xff,
-	0xa0, 0x45, 0xac, 0x3f, 0x12, 0x14, 0x00, 0x00,
+	// 2507 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0x4f, 0x73, 0xdb, 0xc6,
+	0x15, 0x2f, 0x45, 0x52, 0x22, 0x1f, 0xff, 0x81, 0x2b, 0x59, 0x86, 0x15, 0x27, 0x96, 0xe9, 0x34,
+	0x71, 0x9c, 0x44, 0x6a, 0xd5, 0x4c, 0xea, 0xa4, 0x33, 0x6d, 0x14, 0x92, 0xb6, 0x45, 0xcb, 0xb6,
+	0x66, 0x29, 0xa7, 0x93, 0x5e, 0x30, 0x10, 0xb0, 0x04, 0x31, 0x04, 0xb1, 0x08, 0x76, 0x29, 0x55,
+	0x3d, 0xf4, 0xd0, 0x73, 0x6f, 0xfd, 0x14, 0xfd, 0x04, 0xbd, 0xe4, 0x5b, 0xf4, 0xdc, 0xe9, 0xa5,
+	0xd7, 0x76, 0xa6, 0xdf, 0xa0, 0xf3, 0x76, 0x17, 0x20, 0x48, 0xd9, 0x93, 0xe6, 0xc2, 0xc1, 0xfe,
+	0xde, 0xbf, 0xdd, 0xf7, 0x6f, 0xdf, 0x12, 0x76, 0x93, 0x59, 0x70, 0x78, 0xc5, 0x2e, 0x2e, 0x43,
+	0x76, 0x75, 0x88, 0x3f, 0x07, 0x49, 0xca, 0x25, 0x27, 0x5b, 0x06, 0xdb, 0xbb, 0x1b, 0x70, 0x1e,
+	0x44, 0xec, 0xd0, 0x4d, 0xc2, 0x43, 0x37, 0x8e, 0xb9, 0x74, 0x65, 0xc8, 0x63, 0xa1, 0xd9, 0xf6,
+	0xee, 0x19, 0xaa, 0x5a, 0x5d, 0x2c, 0x26, 0x87, 0x32, 0x9c, 0x33, 0x21, 0xdd, 0x79, 0x62, 0x18,
+	0x6e, 0x15, 0xf5, 0x47, 0x3c, 0xd0, 0x70, 0x6f, 0x0e, 0x70, 0xee, 0xa6, 0x01, 0x93, 0xe3, 0x84,
+	0x79, 0xa4, 0x0d, 0x1b, 0xa1, 0x6f, 0x97, 0xf6, 0x4b, 0x0f, 0xeb, 0x74, 0x23, 0xf4, 0xc9, 0x87,
+	0x50, 0x91, 0xd7, 0x09, 0xb3, 0x37, 0xf6, 0x4b, 0x0f, 0xdb, 0x47, 0xdb, 0x07, 0x46, 0xfe, 0x40,
+	0x8b, 0x9c, 0x5f, 0x27, 0x8c, 0x2a, 0x06, 0xf2, 0x01, 0x74, 0xa6, 0xae, 0x70, 0xa2, 0xf0, 0x92,
+	0x39, 0x8b, 0xc4, 0x77, 0x25, 0xb3, 0xcb, 0xfb, 0xa5, 0x87, 0x35, 0xda, 0x9a, 0xba, 0xe2, 0x34,
+	0xbc, 0x64, 0xaf, 0x15, 0xd8, 0xfb, 0xcf, 0x06, 0x34, 0xbe, 0x5e, 0x84, 0x91, 0x4f, 0x99, 0xc7,
+	0x53, 0x9f, 0xec, 0x40, 0x95, 0xf9, 0xa1, 0x14, 0x76, 0x69, 0xbf, 0xfc, 0xb0, 0x4e, 0xf5, 0x42,
+	0xa1, 0x69, 0xca, 0x53, 0x65, 0xb7, 0x4e, 0xf5, 0x82, 0xec, 0x41, 0xed, 0xca, 0x4d, 0xe3, 0x30,
+	0x0e, 0x84, 0x5d, 0x56, 0xec, 0xf9, 0x9a, 0x7c, 0x01, 0x20, 0xa4, 0x9b, 0x4a, 0x07, 0x8f, 0x6d,
+	0x57, 0xf6, 0x4b, 0x0f, 0x1b, 0x47, 0x7b, 0x07, 0xda, 0x27, 0x07, 0x99, 0x4f, 0x0e, 0xce, 0x33,
+	0x9f, 0xd0, 0xba, 0xe2, 0xc6, 0x35, 0xf9, 0x15, 0x34, 0x26, 0x61, 0x1c, 0x8a, 0xa9, 0x96, 0xad,
+	0xfe, 0xa0, 0x2c, 0x68, 0x76, 0x25, 0xfc, 0x39, 0x34, 0xf5, 0x71, 0x1d, 0x74, 0x83, 0xb0, 0xeb,
+	0xfb, 0xe5, 0x15, 0x47, 0xe9, 0x63, 0x2b, 0x47, 0x35, 0x16, 0xf9, 0xb7, 0x20, 0x0f, 0xc1, 0x0a,
+	0x85, 0xe3, 0xa5, 0xae, 0x98, 0x3a, 0x29, 0xbb, 0x40, 0x8f, 0xd8, 0x5b, 0xca, 0x61, 0xed, 0x50,
+	0xf4, 0x11, 0xa6, 0x1a, 0x25, 0xb7, 0x61, 0x4b, 0x24, 0x6e, 0xec, 0x84, 0xbe, 0x5d, 0x53, 0xde,
+	0xd8, 0xc4, 0xe5, 0x89, 0x4f, 0xee, 0x40, 0x6d, 0xf6, 0x58, 0x38, 0x7e, 0x38, 0x99, 0xd8, 0xa0,
+	0x28, 0x5b, 0xb3, 0xc7, 0x62, 0x10, 0x4e, 0x26, 0xa3, 0x4a, 0x6d, 0xd3, 0xda, 0xa2, 0xe5, 0x88,
+	0x07, 0xbd, 0x7f, 0x97, 0xa1, 0xf3, 0xfc, 0xb1, 0xa0, 0x4c, 0xf0, 0x45, 0xea, 0xb1, 0x93, 0x78,
+	0xc2, 0x51, 0x32, 0xe1, 0xbe, 0x13, 0xbb, 0x73, 0x66, 0x62, 0xbd, 0x95, 0x70, 0xff, 0xa5, 0x3b,
+	0x67, 0xe4, 0x11, 0x74, 0x91, 0xe4, 0xa5, 0x4c, 0x65, 0x97, 0x76, 0x89, 0x8e, 0x42, 0x27, 0xe1,
+	0x7e, 0xdf, 0xe0, 0xea, 0xec, 0x3f, 0x87, 0x5b, 0xc8, 0x6b, 0xce, 0x5f, 0x70, 0x7f, 0x59, 0xf1,
+	0x93, 0x84, 0xfb, 0xfa, 0xf8, 0xe3, 0xdc, 0xd7, 0xef, 0x02, 0xa0, 0x88, 0x90, 0xae, 0x5c, 0x08,
+	0x15, 0xa6, 0x3a, 0xad, 0x27, 0xdc, 0x1f, 0x2b, 0x80, 0x7c, 0x02, 0x64, 0x49, 0x76, 0xe6, 0x4c,
+	0x08, 0x37, 0xd0, 0x11, 0xa9, 0x53, 0x2b, 0x67, 0x7b, 0xa1, 0x71, 0xf2, 0x33, 0xd8, 0x71, 0xa3,
+	0xc8, 0xf1, 0x78, 0x2c, 0xdd, 0x30, 0x66, 0xa9, 0x70, 0x52, 0xe6, 0xfa, 0xd7, 0xf6, 0xa6, 0xf2,
+	0x23, 0x71, 0xa3, 0xa8, 0x9f, 0x93, 0x28, 0x52, 0xc8, 0x7d, 0x68, 0xa2, 0xfe, 0x94, 0xa9, 0xcd,
+	0x0a, 0xe5, 0xf1, 0x2a, 0x6d, 0x24, 0xdc, 0xa7, 0x06, 0x2a, 0xba, 0xbb, 0xbe, 0xe2, 0xee, 0x07,
+	0xd0, 0xf2, 0x43, 0x91, 0x44, 0xee, 0xb5, 0x72, 0x9c, 0xb0, 0x41, 0xa5, 0x60, 0xd3, 0x80, 0xe8,
+	0x3d, 0x41, 0x5e, 0xa2, 0x4b, 0x52, 0xe9, 0x4c, 0x78, 0x7a, 0xe5, 0xa6, 0xd9, 0x49, 0x98, 0xb0,
+	0x1b, 0xfb, 0x65, 0x95, 0x55, 0x59, 0x5e, 0x9c, 0xf1, 0x54, 0x3e, 0xd1, 0x4c, 0xfa, 0x50, 0x74,
+	0x3b, 0x59, 0x87, 0x98, 0xc0, 0x0d, 0xcf, 0x16, 0x17, 0x4c, 0x9d, 0x91, 0xfd, 0x5e, 0xda, 0x4d,
+	0xb5, 0xa5, 0x06, 0x62, 0x7d, 0x0d, 0x8d, 0x2a, 0xb5, 0x9a, 0xa5, 0x03, 0xe8, 0x60, 0xbc, 0xff,
+	0x5b, 0x82, 0xee, 0x0d, 0xe5, 0xe8, 0xf7, 0x88, 0x7b, 0x6e, 0xe4, 0xa0, 0x11, 0x15, 0xf3, 0x2a,
+	0xad, 0x2b, 0x04, 0x79, 0xc9, 0x2e, 0x6c, 0x4a, 0x55, 0xd1, 0x26, 0xd4, 0x66, 0x45, 0x08, 0x54,
+	0x12, 0xee, 0x0b, 0x15, 0xd0, 0x2a, 0x55, 0xdf, 0xe4, 0x53, 0x20, 0xae, 0x27, 0xb1, 0xce, 0x3d,
+	0x1e, 0xc7, 0xcc, 0x53, 0x4d, 0x48, 0x85, 0xb2, 0x4a, 0xbb, 0x9a, 0xd2, 0x5f, 0x12, 0xc8, 0xc7,
+	0xd0, 0x95, 0x5c, 0xba, 0xd1, 0x0a, 0x77, 0x55, 0x71, 0x5b, 0x8a, 0x50, 0x64, 0xde, 0x85, 0x4d,
+	0x55, 0xea, 0x42, 0xc5, 0xb0, 0x4a, 0xcd, 0x4a, 0x6d, 0xdf, 0x15, 0xd2, 0x51, 0x4b, 0x15, 0xb5,
+	0x3a, 0xad, 0x23, 0x32, 0x44, 0xa0, 0xf7, 0xcf, 0x12, 0xb4, 0x07, 0xfd, 0x95, 0x14, 0xbf, 0x0f,
+	0x4d, 0x8f, 0xc7, 0x93, 0x30, 0x70, 0x12, 0x57, 0x4e, 0xb3, 0xf6, 0xd2, 0xd0, 0xd8, 0x19, 0x42,
+	0xe4, 0x23, 0xb0, 0xf2, 0xd4, 0xc9, 0x32, 0xd2, 0x64, 0x7a, 0x8e, 0x1b, 0xf7, 0xed, 0x43, 0x23,
+	0x87, 0x4e, 0x06, 0x26, 0xbf, 0x8b, 0xd0, 0x5a, 0xff, 0xa9, 0xfe, 0x98, 0xfe, 0x53, 0xc8, 0xb8,
+	0xcd, 0x62, 0xc6, 0x8d, 0x2a, 0xb5, 0x8a, 0x55, 0xd5, 0x55, 0xfc, 0x4b, 0xb0, 0xbe, 0x3d, 0x7e,
+	0x71, 0xba, 0x72, 0xc4, 0x07, 0xd0, 0xc2, 0xfa, 0x4f, 0x0d, 0x96, 0x9d, 0xb1, 0x39, 0x5b, 0x56,
+	0xbb, 0xe8, 0xfd, 0x1a, 0xba, 0xa7, 0x18, 0xe6, 0x15, 0x49, 0x0b, 0xca, 0x89, 0x69, 0xf3, 0x65,
+	0x8a, 0x9f, 0xb8, 0x87, 0x50, 0x38, 0x92, 0x09, 0x9d, 0x01, 0x35, 0xba, 0x19, 0x8a, 0x73, 0x26,
+	0x64, 0x6f, 0x04, 0xd5, 0x27, 0xae, 0xa7, 0x53, 0xa1, 0xd0, 0x2f, 0xd4, 0x37, 0xb6, 0xe9, 0x4b,
+	0x37, 0x5a, 0x64, 0x0d, 0x42, 0x2f, 0x8a, 0xe7, 0x29, 0x17, 0xcf, 0xd3, 0xfb, 0x04, 0x2a, 0xa7,
+	0x61, 0x3c, 0x43, 0xf3, 0x8b, 0x34, 0x32, 0x9a, 0xf0, 0x33, 0x57, 0xbe, 0xb1, 0x54, 0xde, 0xfb,
+	0x1e, 0xa0, 0x96, 0xed, 0xfa, 0x8d, 0xd6, 0x07, 0x60, 0xa9, 0xa4, 0xf0, 0x59, 0x12, 0xf1, 0xeb,
+	0xff, 0xb7, 0xf1, 0xb7, 0x51, 0x66, 0xa0, 0x44, 0x94, 0xf7, 0xef, 0x43, 0x53, 0xa6, 0x61, 0x10,
+	0xb0, 0xd4, 0x99, 0x73, 0x9f, 0x99, 0xd4, 0x6c, 0x18, 0xec, 0x05, 0xf7, 0x19, 0xf9, 0x02, 0x5a,
+	0xaa, 0x15, 0x3b, 0xd3, 0x50, 0x48, 0x9e, 0x62, 0x83, 0xc1, 0x62, 0xde, 0xc9, 0x8b, 0xb9, 0x70,
+	0xa1, 0xd1, 0xa6, 0x62, 0x7d, 0xa6, 0x39, 0x51, 0xd4, 0x5b, 0xa4, 0x29, 0x8b, 0xa5, 0xb3, 0xec,
+	0xf1, 0x6f, 0x15, 0x35, 0xac, 0x0a, 0xc3, 0xee, 0x96, 0xb0, 0xd8, 0x0f, 0xe3, 0x40, 0x8b, 0x62,
+	0x73, 0x13, 0x3c, 0x56, 0x97, 0x40, 0x95, 0x12, 0x43, 0x33, 0xf2, 0x48, 0x21, 0x07, 0xb0, 0xbd,
+	0x2a, 0xa1, 0x6f, 0xd6, 0xba, 0x4a, 0x8b, 0x6e, 0x51, 0x60, 0x88, 0x04, 0x32, 0x5a, 0xe7, 0x17,
+	0x61, 0xec, 0x31, 0x1b, 0x7e, 0xd0, 0x87, 0x2b, 0xba, 0xc6, 0x28, 0x84, 0xb6, 0xf1, 0xfe, 0xcf,
+	0xf4, 0x79, 0x53, 0x37, 0x0e, 0x54, 0xdb, 0xc3, 0x64, 0xea, 0x4e, 0x5d, 0x71, 0xa6, 0x29, 0x7d,
+	0x4d, 0x20, 0x9f, 0x41, 0x9b, 0xc5, 0x7e, 0xc2, 0xc3, 0x58, 0x3a, 0x51, 0x18, 0xcf, 0x84, 0x7d,
+	0x57, 0x39, 0xb5, 0x95, 0x7b, 0x06, 0x53, 0x85, 0xb6, 0x32, 0x26, 0x5c, 0xa9, 0xb9, 0x20, 0xe1,
+	0xfe, 0xc9, 0xc0, 0x6e, 0xe9, 0x84, 0x53, 0x0b, 0x32, 0x80, 0x6e, 0xb1, 0x10, 0x9c, 0x30, 0x9e,
+	0x70, 0xbb, 0xad, 0x4e, 0x61, 0xe7, 0xea, 0xd6, 0xee, 0x40, 0xda, 0x99, 0xad, 0x02, 0xe4, 0x18,
+	0x2c, 0xdf, 0x5b, 0x53, 0xd2, 0x51, 0x4a, 0x6e, 0xe7, 0x4a, 0x56, 0x9b, 0x0c, 0x6d, 0xfb, 0xde,
+	0x8a, 0x8a, 0xa7, 0x40, 0xae, 0xdd, 0x79, 0xb4, 0xa6, 0xc4, 0x52, 0x4a, 0xee, 0xe4, 0x4a, 0xd6,
+	0x0b, 0x99, 0x5a, 0x28, 0xb4, 0xa2, 0x68, 0x04, 0xdb, 0xba, 0x5d, 0xaf, 0x6a, 0xea, 0x9a, 0xc8,
+	0xe4, 0x2e, 0x5a, 0xaf, 0x6c, 0xda, 0x8d, 0xd6, 0x21, 0xf2, 0x53, 0x68, 0xa7, 0x8b, 0x18, 0xab,
+	0x23, 0x6b, 0x72, 0x44, 0x39, 0xaf, 0x65, 0x50, 0xd3, 0xe2, 0x1e, 0x40, 0x6b, 0x79, 0x91, 0x23,
+	0xd7, 0xbb, 0x8a, 0xcb, 0x4c, 0x37, 0x86, 0xe9, 0x1e, 0x34, 0xb0, 0x4d, 0x84, 0x91, 0x9c, 0x84,
+	0x11, 0xb3, 0xb7, 0x55, 0x74, 0x21, 0x14, 0xe7, 0x06, 0x21, 0x1f, 0x41, 0x55, 0x24, 0xcc, 0x13,
+	0xf6, 0x3b, 0x2a, 0x9a, 0xeb, 0x03, 0x23, 0xce, 0x98, 0x54, 0x73, 0xe0, 0xa4, 0x21, 0xa6, 0xfc,
+	0x2a, 0x4b, 0x3d, 0x6d, 0x74, 0x47, 0x69, 0xec, 0x20, 0x41, 0x27, 0x97, 0xb6, 0xbb, 0x0b, 0x9b,
+	0xdf, 0x2d, 0xd8, 0x82, 0xf9, 0xf6, 0x1d, 0xdd, 0x9d, 0xf4, 0x0a, 0xa7, 0xce, 0x88, 0x07, 0x8e,
+	0x1b, 0xb1, 0x54, 0x3a, 0x1e, 0x5f, 0xc4, 0xd2, 0x7e, 0x4f, 0x95, 0x47, 0x2b, 0xe2, 0xc1, 0x31,
+	0xa2, 0x7d, 0x04, 0xc9, 0xfb, 0xa0, 0xca, 0xde, 0xc9, 0x99, 0xed, 0x7b, 0xfa, 0x74, 0x88, 0x9e,
+	0x1a, 0x56, 0xac, 0x38, 0x64, 0xf0, 0x22, 0xe6, 0xa6, 0x8e, 0x37, 0x65, 0xde, 0x4c, 0xa5, 0x9e,
+	0xbd, 0xaf, 0x2b, 0x2e, 0xe2, 0x41, 0x1f, 0x49, 0xfd, 0x9c, 0x32, 0xaa, 0xd4, 0x36, 0xac, 0xf2,
+	0xa8, 0x52, 0x2b, 0x5b, 0x95, 0x51, 0xa5, 0xd6, 0xb4, 0x5a, 0xa3, 0x4a, 0xed, 0x96, 0xb5, 0x3b,
+	0xaa, 0xd4, 0x76, 0xad, 0xdb, 0xa3, 0x4a, 0x6d, 0xcf, 0x7a, 0x67, 0x54, 0xa9, 0xdd, 0xb6, 0xec,
+	0x51, 0xa5, 0x66, 0x5b, 0x77, 0xe8, 0xb6, 0x1f, 0xa6, 0xcc, 0x93, 0x3c, 0x0d, 0x99, 0x70, 0xae,
+	0x5c, 0xe9, 0x4d, 0x99, 0x4f, 0x5b, 0xea, 0x86, 0xca, 0x97, 0xf5, 0x2c, 0xe5, 0x05, 0x6d, 0x7a,
+	0x7c, 0x7e, 0x11, 0xc6, 0x4c, 0xdd, 0xec, 0xb4, 0xae, 0xa7, 0x45, 0xfc, 0xec, 0xe6, 0x9f, 0x8e,
+	0x69, 0xb5, 0x74, 0x53, 0x9d, 0x4b, 0xd0, 0xcd, 0x09, 0xb6, 0x6b, 0xd1, 0x0b, 0xa1, 0x8e, 0x51,
+	0xd1, 0xbd, 0xc4, 0x86, 0xad, 0x4b, 0x96, 0x8a, 0x90, 0xc7, 0xd9, 0xbc, 0x67, 0x96, 0xe4, 0x2e,
+	0xd4, 0x3d, 0x3e, 0x9f, 0x87, 0x72, 0xfc, 0xec, 0xd8, 0xb4, 0xdf, 0x25, 0x80, 0x6d, 0x37, 0x1f,
+	0xe5, 0xeb, 0x54, 0x7d, 0x63, 0xf7, 0xf6, 0xd9, 0xa5, 0xea, 0xb4, 0x35, 0x8a, 0x9f, 0xbd, 0xcf,
+	0xa1, 0xf3, 0x8d, 0x56, 0x37, 0x66, 0x52, 0xaa, 0x71, 0xfc, 0x01, 0xb4, 0x94, 0x03, 0xcd, 0x70,
+	0x28, 0x94, 0xd9, 0x1a, 0x6d, 0x2a, 0x50, 0x0f, 0x85, 0xa2, 0xf7, 0x7d, 0x0d, 0x2a, 0xdf, 0x84,
+	0xec, 0x0a, 0x55, 0x46, 0x3c, 0xc8, 0x2e, 0x84, 0x88, 0x07, 0xe4, 0x10, 0xea, 0xcb, 0x7b, 0x6d,
+	0x43, 0xe5, 0x52, 0x37, 0xcf, 0xa5, 0x2c, 0xbd, 0xe9, 0x92, 0x87, 0x7c, 0x09, 0x77, 0x06, 0xc3,
+	0x33, 0x3a, 0xec, 0x1f, 0x9f, 0x0f, 0x07, 0xca, 0x31, 0xf9, 0xfb, 0x47, 0x98, 0x97, 0xc8, 0xed,
+	0x25, 0xc3, 0x29, 0x0f, 0xf2, 0x6e, 0x26, 0xc8, 0x00, 0x5a, 0x13, 0xe6, 0xca, 0x45, 0xca, 0x9c,
+	0x49, 0xe4, 0x06, 0x38, 0xcc, 0xa0, 0xc1, 0x7b, 0xb9, 0x41, 0xdc, 0xe4, 0xc1, 0x13, 0xcd, 0xf2,
+	0x04, 0x39, 0x86, 0xb1, 0x4c, 0xaf, 0x69, 0x73, 0x52, 0x80, 0xc8, 0x11, 0xdc, 0x8a, 0x19, 0xf3,
+	0x85, 0xe3, 0xc6, 0x6e, 0x74, 0x2d, 0x43, 0x4f, 0x38, 0xf1, 0xc2, 0x37, 0xe3, 0x6b, 0x8d, 0x6e,
+	0x2b, 0xe2, 0x71, 0x46, 0x7b, 0x89, 0x24, 0xf2, 0x15, 0x90, 0x74, 0x11, 0xe3, 0x0b, 0x46, 0x15,
+	0x95, 0xb9, 0x23, 0x36, 0x55, 0x99, 0x93, 0x65, 0xed, 0x64, 0x71, 0xa4, 0x96, 0xe1, 0x5e, 0x46,
+	0x76, 0x0c, 0x77, 0x8b, 0xe7, 0x46, 0xbf, 0xca, 0xa2, 0xae, 0xad, 0xb7, 0xea, 0x2a, 0xf8, 0xeb,
+	0x54, 0x89, 0x2d, 0x95, 0x7e, 0x06, 0xbb, 0x62, 0x11, 0x04, 0x4c, 0x48, 0xe6, 0x6b, 0x65, 0x59,
+	0xf6, 0x58, 0x2a, 0x44, 0x3b, 0x39, 0x15, 0x65, 0x4c, 0xec, 0x49, 0x1f, 0x2c, 0xc3, 0xe6, 0x08,
+	0x93, 0x07, 0x76, 0x73, 0xad, 0x0b, 0xaf, 0xe5, 0x09, 0xed, 0x5c, 0xae, 0x02, 0x78, 0x8f, 0x28,
+	0x83, 0x5e, 0xc4, 0x17, 0xbe, 0xb3, 0x10, 0x2c, 0x55, 0xf7, 0xbe, 0x7e, 0xf9, 0x74, 0x91, 0xd4,
+	0x47, 0xca, 0x6b, 0x43, 0x20, 0x87, 0xb0, 0x53, 0xe0, 0x97, 0xcc, 0x9d, 0xeb, 0x67, 0x4d, 0x67,
+	0x4d, 0xe0, 0x9c, 0xb9, 0x73, 0xf5, 0xc0, 0x39, 0x82, 0x5b, 0x05, 0x01, 0xe1, 0x4d, 0xd9, 0x9c,
+	0x3d, 0xe3, 0x42, 0x9a, 0x69, 0x7f, 0x3b, 0x97, 0x18, 0xe7, 0x24, 0x6c, 0x55, 0x6b, 0x46, 0x4e,
+	0x06, 0xe6, 0xc9, 0xd5, 0x59, 0xb1, 0x70, 0x32, 0xc0, 0x16, 0x39, 0x71, 0x71, 0xde, 0xd5, 0xb3,
+	0x6a, 0x43, 0x71, 0x81, 0x82, 0xd4, 0xb0, 0x4a, 0x3e, 0x86, 0x1a, 0xa6, 0x67, 0x14, 0x0a, 0xa9,
+	0xae, 0xb1, 0xc6, 0x91, 0x55, 0x68, 0xe8, 0xc1, 0x69, 0x28, 0x24, 0xdd, 0x8a, 0xf4, 0x07, 0xf9,
+	0x1a, 0x94, 0x81, 0xe2, 0xe3, 0xaa, 0xfd, 0x83, 0xd7, 0x73, 0x0b, 0x45, 0x96, 0x6f, 0x2e, 0x9c,
+	0x70, 0x4c, 0x7f, 0x76, 0x66, 0xec, 0x5a, 0xdd, 0x22, 0x75, 0xda, 0xc8, 0xb0, 0xe7, 0xec, 0x9a,
+	0x7c, 0x05, 0x9d, 0x39, 0x93, 0x29, 0xe6, 0xac, 0x60, 0xe9, 0x65, 0x18, 0x07, 0x36, 0x59, 0xbb,
+	0xfa, 0x5e, 0x68, 0xfa, 0x58, 0x93, 0x69, 0x7b, 0xbe, 0xb2, 0x7e, 0x6b, 0xef, 0xdc, 0x7e, 0x5b,
+	0xef, 0xdc, 0xfb, 0x0d, 0x74, 0x6f, 0x94, 0x14, 0x76, 0x02, 0xdc, 0xa2, 0xe9, 0x04, 0x33, 0x76,
+	0xbd, 0x3a, 0x63, 0xd6, 0xcc, 0x8c, 0xf9, 0xe5, 0xc6, 0xe3, 0x52, 0xef, 0x29, 0xb4, 0x57, 0x37,
+	0x85, 0xed, 0x4a, 0xcd, 0x70, 0x66, 0x4a, 0xc4, 0x6f, 0x3c, 0x7d, 0x90, 0xba, 0x13, 0x37, 0x76,
+	0x9d, 0x29, 0x17, 0xd9, 0x03, 0xa7, 0x61, 0x30, 0x0c, 0x6f, 0xcf, 0x82, 0xf6, 0x53, 0x26, 0xb1,
+	0xc8, 0x29, 0xfb, 0x6e, 0x81, 0x53, 0xaf, 0x80, 0xee, 0x38, 0x76, 0x13, 0x31, 0xe5, 0xf2, 0x59,
+	0x18, 0x4c, 0xa3, 0x30, 0x98, 0x4a, 0xf2, 0x21, 0x74, 0x2e, 0x58, 0x10, 0xea, 0x72, 0x8d, 0x78,
+	0x70, 0x32, 0x30, 0x86, 0xda, 0x39, 0x7c, 0x8a, 0x28, 0x9a, 0x34, 0x63, 0x90, 0xe6, 0x32, 0x26,
+	0x35, 0xa6, 0x59, 0x08, 0x54, 0xd4, 0x7b, 0xce, 0x34, 0x56, 0xfc, 0xee, 0xfd, 0xa3, 0x04, 0xb5,
+	0xcc, 0x2a, 0xb9, 0x0f, 0x15, 0x74, 0xbb, 0xb2, 0x50, 0x9c, 0x8a, 0xd4, 0x2e, 0x15, 0x09, 0xb3,
+	0x32, 0x14, 0x8e, 0x08, 0x7d, 0x76, 0x81, 0x3e, 0x8f, 0xb8, 0x60, 0xbe, 0xf1, 0x52, 0x27, 0x14,
+	0x63, 0x8d, 0xf7, 0x15, 0xac, 0x1e, 0x72, 0xae, 0x9c, 0x66, 0xf6, 0xf0, 0x9b, 0x9c, 0x00, 0x11,
+	0xc6, 0x9c, 0x33, 0xcd, 0x4e, 0x99, 0x4f, 0xd0, 0x99, 0xc1, 0x1b, 0x7e, 0xa0, 0x5d, 0x71, 0xc3,
+	0x35, 0x0f, 0xa0, 0x95, 0xab, 0xc2, 0x69, 0xce, 0x3c, 0xd9, 0x9b, 0x19, 0x88, 0xd3, 0x5b, 0xef,
+	0x11, 0xec, 0xbe, 0x4e, 0x22, 0xee, 0xfa, 0x99, 0x4a, 0xca, 0x44, 0xc2, 0x63, 0xc1, 0x6e, 0x3e,
+	0x08, 0x7a, 0x7f, 0x84, 0xed, 0x63, 0x6f, 0xf6, 0x5b, 0x76, 0x21, 0xb8, 0x37, 0x63, 0xd2, 0xc4,
+	0x05, 0xed, 0x48, 0x5e, 0x4c, 0x2f, 0xfd, 0x92, 0x6d, 0x4a, 0xbe, 0x4c, 0xac, 0x37, 0xd5, 0xcc,
+	0xc6, 0x8f, 0xac, 0x99, 0xde, 0x2e, 0xec, 0xac, 0xda, 0xd7, 0x3b, 0x7d, 0xf4, 0xd7, 0x12, 0xc0,
+	0xf2, 0x2f, 0x1d, 0xf2, 0x0e, 0xdc, 0x7e, 0x7d, 0x36, 0x38, 0x3e, 0x1f, 0x3a, 0xe7, 0xdf, 0x9e,
+	0x0d, 0x9d, 0xd7, 0x2f, 0xc7, 0x67, 0xc3, 0xfe, 0xc9, 0x93, 0x93, 0xe1, 0xc0, 0xfa, 0x09, 0xb9,
+	0x05, 0xdd, 0x22, 0xf1, 0xe4, 0xc5, 0xf1, 0xd3, 0xa1, 0x55, 0x5a, 0x97, 0x39, 0x3d, 0xf9, 0x66,
+	0xe8, 0x68, 0xc0, 0xda, 0x20, 0xef, 0xc1, 0x5e, 0x91, 0x38, 0x78, 0xd5, 0x7f, 0x3e, 0xa4, 0x4e,
+	0xff, 0xd5, 0x8b, 0xb3, 0x57, 0xe3, 0xa1, 0x55, 0x26, 0xdb, 0xd0, 0x29, 0xd2, 0x9f, 0x3f, 0x1e,
+	0x5b, 0x95, 0x75, 0x43, 0xa7, 0xaf, 0xfa, 0xc7, 0xa7, 0x56, 0xf5, 0xd1, 0x9f, 0x4b, 0xd9, 0x5f,
+	0x7b, 0xd9, 0x5e, 0xcf, 0x8f, 0xe9, 0xd3, 0xe1, 0xf9, 0x5b, 0xf6, 0x5a, 0x24, 0x66, 0x7b, 0xdd,
+	0x86, 0x4e, 0x11, 0x46, 0x73, 0x6a, 0x8f, 0x45, 0xf0, 0xc6, 0x1e, 0xd7, 0x74, 0xe9, 0xed, 0x54,
+	0x8e, 0xfe, 0x56, 0x82, 0x06, 0x66, 0xaf, 0x2a, 0x56, 0x0f, 0x9f, 0x6f, 0x5b, 0xa6, 0xea, 0xc8,
+	0xb2, 0xcb, 0xac, 0xd6, 0xe1, 0xde, 0x6a, 0xde, 0xf7, 0xba, 0x7f, 0xfa, 0xfb, 0xbf, 0xfe, 0xb2,
+	0xd1, 0x20, 0x75, 0xf5, 0x1f, 0x28, 0xe2, 0xe4, 0x02, 0xda, 0xab, 0x49, 0x45, 0xba, 0x37, 0x52,
+	0x77, 0xef, 0x5e, 0xe1, 0xef, 0xb8, 0x37, 0x25, 0x60, 0xef, 0xae, 0x52, 0xbc, 0xfb, 0x65, 0xe9,
+	0x51, 0xaf, 0xab, 0x74, 0x67, 0x89, 0x7b, 0x18, 0xb3, 0xab, 0xa3, 0x3f, 0x80, 0x95, 0x67, 0x42,
+	0xb6, 0xfb, 0x09, 0x34, 0x8b, 0x09, 0x42, 0xee, 0xe6, 0x26, 0xde, 0x90, 0xb7, 0x7b, 0xef, 0xbe,
+	0x85, 0x6a, 0xcc, 0xdf, 0x51, 0xe6, 0xb7, 0xd1, 0x7c, 0xfb, 0xf0, 0x2a, 0x23, 0x1f, 0xba, 0xde,
+	0xec, 0xeb, 0x0f, 0x7e, 0xf7, 0x7e, 0x10, 0xca, 0xe9, 0xe2, 0xe2, 0xc0, 0xe3, 0xf3, 0x43, 0x4c,
+	0xd2, 0x4f, 0x7d, 0x76, 0xa9, 0x3e, 0x0e, 0x0b, 0x7f, 0xe8, 0x5e, 0x6c, 0xaa, 0x9c, 0xfe, 0xc5,
+	0xff, 0x06, 0x00, 0x1b, 0xd5, 0x3c, 0x6a, 0x46, 0x16, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
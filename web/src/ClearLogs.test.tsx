@@ -7,6 +7,14 @@ import { appendLinesForManifestAndSpan } from "./testlogs"
 import { ResourceName } from "./types"
 
 describe("ClearLogs", () => {
+  beforeEach(() => {
+    fetchMock.resetMocks()
+  })
+
+  // Clicking also reports analytics, so only look at the calls that clear logs.
+  const clearCalls = () =>
+    fetchMock.mock.calls.filter((call) => call[0] === "/api/logs/clear")
+
   const createPopulatedLogStore = (): LogStore => {
     const logStore = new LogStore()
     appendLinesForManifestAndSpan(logStore, "", "", [
@@ -42,6 +50,12 @@ describe("ClearLogs", () => {
     root.find(ClearLogs).simulate("click")
     expect(logStore.spans).toEqual({})
     expect(logStore.allLog()).toHaveLength(0)
+    expect(clearCalls()).toEqual([
+      [
+        "/api/logs/clear",
+        { method: "post", body: JSON.stringify({ manifest_names: [] }) },
+      ],
+    ])
   })
 
   it("clears a specific resource", () => {
@@ -60,5 +74,14 @@ describe("ClearLogs", () => {
     expect(logLinesToString(logStore.allLog(), false)).toEqual(
       "global 1\nglobal 2\nm2 build line 1\nm2 runtime line 1"
     )
+    expect(clearCalls()).toEqual([
+      [
+        "/api/logs/clear",
+        {
+          method: "post",
+          body: JSON.stringify({ manifest_names: ["vigoda"] }),
+        },
+      ],
+    ])
   })
 })
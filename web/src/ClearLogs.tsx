@@ -40,6 +40,19 @@ export const clearLogs = (
   }
   incr("ui.web.clearLogs", { action, all: all.toString() })
   logStore.removeSpans(Object.keys(spans))
+
+  // Also clear the logs on the server, so that they stay cleared
+  // when the page reloads, and in the terminal.
+  fetch("/api/logs/clear", {
+    method: "post",
+    body: JSON.stringify({
+      manifest_names: all ? [] : [resourceName],
+    }),
+  }).then((response) => {
+    if (!response.ok) {
+      console.log(response)
+    }
+  })
 }
 
 const ClearLogs: React.FC<ClearLogsProps> = ({ resourceName }) => {
@@ -15,7 +15,7 @@ import "./HUD.scss"
 import HudState from "./HudState"
 import { InterfaceVersion, useInterfaceVersion } from "./InterfaceVersion"
 import { tiltfileKeyContext } from "./LocalStorage"
-import LogStore, { LogStoreProvider } from "./LogStore"
+import LogStore, { clearCheckpoints, LogStoreProvider } from "./LogStore"
 import OverviewPane from "./OverviewPane"
 import OverviewResourcePane from "./OverviewResourcePane"
 import PathBuilder, { PathBuilderProvider } from "./PathBuilder"
@@ -134,6 +134,11 @@ export default class HUD extends Component<HudProps, HudState> {
           newState.logStore.append(newLogList)
         }
       }
+
+      // Snapshot logs don't have the server's checkpoints to clear them by.
+      if (newState.view && !this.pathBuilder.isSnapshot()) {
+        newState.logStore.setClearCheckpoints(clearCheckpoints(newState.view))
+      }
       return newState
     })
   }
@@ -0,0 +1,69 @@
+import { mount } from "enzyme"
+import React from "react"
+import LoadEarlierLogs, { loadEarlierLogs } from "./LoadEarlierLogs"
+import { logLinesToString } from "./logs"
+import LogStore, { LogStoreProvider } from "./LogStore"
+
+describe("LoadEarlierLogs", () => {
+  beforeEach(() => {
+    fetchMock.resetMocks()
+  })
+
+  const createLogStore = (): LogStore => {
+    const logStore = new LogStore()
+    logStore.append({
+      spans: { "build:1": { manifestName: "fe" } },
+      segments: [{ spanId: "build:1", text: "fe 2\n", checkpoint: 2 }],
+      fromCheckpoint: 2,
+      toCheckpoint: 3,
+    })
+    return logStore
+  }
+
+  it("prepends the page before the oldest logs", async () => {
+    const logStore = createLogStore()
+    fetchMock.mockResponseOnce(
+      JSON.stringify({
+        spans: { "build:1": { manifestName: "fe" } },
+        segments: [
+          { spanId: "build:1", text: "fe 0\n", checkpoint: 0 },
+          { spanId: "build:1", text: "fe 1\n", checkpoint: 1 },
+        ],
+        fromCheckpoint: 0,
+        toCheckpoint: 2,
+      })
+    )
+
+    expect(await loadEarlierLogs(logStore)).toEqual(true)
+    expect(fetchMock.mock.calls[0][0]).toEqual("/api/logs?before=2")
+    expect(logLinesToString(logStore.allLog(), false)).toEqual(
+      "fe 0\nfe 1\nfe 2"
+    )
+  })
+
+  it("stops when the server has no earlier logs", async () => {
+    const logStore = createLogStore()
+    fetchMock.mockResponseOnce(
+      JSON.stringify({ fromCheckpoint: -1, toCheckpoint: -1 })
+    )
+
+    expect(await loadEarlierLogs(logStore)).toEqual(false)
+    expect(logLinesToString(logStore.allLog(), false)).toEqual("fe 2")
+  })
+
+  it("is hidden when we have all the logs", () => {
+    const logStore = new LogStore()
+    logStore.append({
+      spans: { "build:1": { manifestName: "fe" } },
+      segments: [{ spanId: "build:1", text: "fe 0\n", checkpoint: 0 }],
+      fromCheckpoint: 0,
+      toCheckpoint: 1,
+    })
+    const root = mount(
+      <LogStoreProvider value={logStore}>
+        <LoadEarlierLogs />
+      </LogStoreProvider>
+    )
+    expect(root.find("button")).toHaveLength(0)
+  })
+})
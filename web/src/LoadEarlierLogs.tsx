@@ -0,0 +1,65 @@
+import React, { useState } from "react"
+import styled from "styled-components"
+import { incr } from "./analytics"
+import LogStore, { useLogStore } from "./LogStore"
+import {
+  AnimDuration,
+  Color,
+  FontSize,
+  mixinResetButtonStyle,
+} from "./style-helpers"
+
+const LoadEarlierLogsButton = styled.button`
+  ${mixinResetButtonStyle}
+  margin-left: 1rem;
+  font-size: ${FontSize.small};
+  color: ${Color.white};
+  transition: color ${AnimDuration.default} ease;
+
+  &:hover {
+    color: ${Color.blue};
+  }
+`
+
+// Fetches the page of logs before the oldest logs we have, and adds them
+// to the log store.
+//
+// Resolves to false if the server doesn't have any earlier logs.
+export const loadEarlierLogs = (logStore: LogStore): Promise<boolean> => {
+  return fetch(`/api/logs?before=${logStore.firstCheckpoint()}`)
+    .then((response) => response.json())
+    .then((logList: Proto.webviewLogList) => {
+      if ((logList.fromCheckpoint ?? -1) < 0) {
+        return false
+      }
+      logStore.prepend(logList)
+      return true
+    })
+}
+
+const LoadEarlierLogs: React.FC = () => {
+  const logStore = useLogStore()
+  const [done, setDone] = useState(false)
+  if (done || logStore.firstCheckpoint() === 0) {
+    return null
+  }
+
+  const onClick = () => {
+    incr("ui.web.loadEarlierLogs", { action: "click" })
+    loadEarlierLogs(logStore)
+      .then((loaded) => {
+        if (!loaded) {
+          setDone(true)
+        }
+      })
+      .catch((err) => console.log(err))
+  }
+
+  return (
+    <LoadEarlierLogsButton onClick={onClick}>
+      Load Earlier Logs
+    </LoadEarlierLogsButton>
+  )
+}
+
+export default LoadEarlierLogs
@@ -3,6 +3,7 @@ import { useStorageState } from "react-storage-hooks"
 import styled from "styled-components"
 import { incr } from "./analytics"
 import ClearLogs from "./ClearLogs"
+import LoadEarlierLogs from "./LoadEarlierLogs"
 import {
   AnimDuration,
   Color,
@@ -117,6 +118,7 @@ const LogActions: React.FC<LogActionsProps> = ({
   return (
     <LogActionsGroup>
       <LogsFontSize />
+      {isSnapshot || <LoadEarlierLogs />}
       {isSnapshot || <ClearLogs resourceName={resourceName} />}
     </LogActionsGroup>
   )
@@ -1,5 +1,5 @@
 import { logLinesToString } from "./logs"
-import LogStore from "./LogStore"
+import LogStore, { clearCheckpoints } from "./LogStore"
 
 describe("LogStore", () => {
   function now() {
@@ -548,4 +548,121 @@ describe("LogStore", () => {
       "fe          ┊ build 1\nbe          ┊ build 7\nglobal line 1"
     )
   })
+
+  it("hides logs before the clear checkpoints", () => {
+    let logs = new LogStore()
+    logs.append({
+      spans: {
+        "build:1": { manifestName: "fe" },
+        "build:2": { manifestName: "be" },
+        "": {},
+      },
+      segments: [
+        newManifestSegment("build:1", "fe 1\n"),
+        newManifestSegment("build:2", "be 1\n"),
+        newGlobalSegment("global 1\n"),
+      ],
+      fromCheckpoint: 3,
+      toCheckpoint: 6,
+    })
+
+    logs.setClearCheckpoints({ fe: 4 })
+    expect(logLinesToString(logs.allLog(), false)).toEqual("be 1\nglobal 1")
+
+    // The server re-sends a log we already have, which we skip.
+    logs.append({
+      spans: { "build:1": { manifestName: "fe" } },
+      segments: [
+        newGlobalSegment("global 1\n"),
+        newManifestSegment("build:1", "fe 2\n"),
+      ],
+      fromCheckpoint: 5,
+      toCheckpoint: 7,
+    })
+    expect(logLinesToString(logs.manifestLog("fe"), false)).toEqual("fe 2")
+
+    logs.setClearCheckpoints({ "": 6, fe: 4 })
+    expect(logLinesToString(logs.allLog(), false)).toEqual("be 1\nfe 2")
+
+    // Clearing doesn't delete anything.
+    logs.setClearCheckpoints({})
+    expect(logLinesToString(logs.allLog(), false)).toEqual(
+      "fe 1\nbe 1\nglobal 1\nfe 2"
+    )
+  })
+
+  it("uses the checkpoints the server sends", () => {
+    let logs = new LogStore()
+    logs.append({
+      spans: {
+        "build:1": { manifestName: "fe" },
+        "build:2": { manifestName: "be" },
+      },
+      segments: [
+        { ...newManifestSegment("build:2", "be 1\n"), checkpoint: 2 },
+        { ...newManifestSegment("build:1", "fe 9\n"), checkpoint: 9 },
+      ],
+      fromCheckpoint: 2,
+      toCheckpoint: 10,
+    })
+    expect(logs.firstCheckpoint()).toEqual(2)
+
+    logs.setClearCheckpoints({ fe: 9 })
+    expect(logLinesToString(logs.allLog(), false)).toEqual("be 1")
+  })
+
+  it("prepends earlier logs", () => {
+    let logs = new LogStore()
+    logs.maxLogLength = 15
+    logs.append({
+      spans: { "build:1": { manifestName: "fe" } },
+      segments: [
+        { ...newManifestSegment("build:1", "fe 2\n"), checkpoint: 2 },
+        { ...newManifestSegment("build:1", "fe 3\n"), checkpoint: 3 },
+      ],
+      fromCheckpoint: 2,
+      toCheckpoint: 4,
+    })
+
+    // Segments we already have are skipped.
+    logs.prepend({
+      spans: {
+        "build:1": { manifestName: "fe" },
+        "build:2": { manifestName: "be" },
+      },
+      segments: [
+        { ...newManifestSegment("build:2", "be 0\n"), checkpoint: 0 },
+        { ...newManifestSegment("build:1", "fe 1\n"), checkpoint: 1 },
+        { ...newManifestSegment("build:1", "fe 2\n"), checkpoint: 2 },
+      ],
+      fromCheckpoint: 0,
+      toCheckpoint: 3,
+    })
+    expect(logs.firstCheckpoint()).toEqual(0)
+    expect(logLinesToString(logs.allLog(), false)).toEqual(
+      "be 0\nfe 1\nfe 2\nfe 3"
+    )
+
+    // The earlier logs aren't dropped on the next append.
+    logs.append({
+      spans: { "build:1": { manifestName: "fe" } },
+      segments: [
+        { ...newManifestSegment("build:1", "fe 4\n"), checkpoint: 4 },
+      ],
+      fromCheckpoint: 4,
+      toCheckpoint: 5,
+    })
+    expect(logLinesToString(logs.allLog(), false)).toEqual(
+      "be 0\nfe 1\nfe 2\nfe 3\nfe 4"
+    )
+  })
+
+  it("gets the clear checkpoints from the view", () => {
+    expect(
+      clearCheckpoints({
+        logClearCheckpoint: 3,
+        resources: [{ name: "fe", logClearCheckpoint: 5 }, { name: "be" }],
+      })
+    ).toEqual({ "": 3, fe: 5 })
+  })
 })
@@ -22,6 +22,9 @@ type LogSpan = {
   lastLineIndex: number
 }
 
+// A segment, and the server checkpoint it was sent at (if any).
+type StoredSegment = Proto.webviewLogSegment & { checkpoint?: number }
+
 type LogWarning = {
   anchorIndex: number
   spanId: string
@@ -36,13 +39,17 @@ class StoredLine {
   anchor: boolean
   fields: { [key: string]: string } | null
 
-  constructor(seg: Proto.webviewLogSegment) {
+  // The server checkpoint of the segment that started this line.
+  checkpoint: number
+
+  constructor(seg: StoredSegment) {
     this.spanId = seg.spanId || defaultSpanId
     this.time = seg.time ?? ""
     this.text = seg.text ?? ""
     this.level = seg.level ?? "INFO"
     this.anchor = seg.anchor ?? false
     this.fields = (seg.fields as { [key: string]: string }) ?? null
+    this.checkpoint = seg.checkpoint ?? 0
   }
 
   field(key: string) {
@@ -80,7 +87,7 @@ class LogStore {
 
   // These are held in-memory so we can send them on snapshot, and are
   // also used to help with incremental log rendering.
-  segments: Proto.webviewLogSegment[]
+  segments: StoredSegment[]
 
   // A map of segment indices to the line indices that they rendered.
   segmentToLine: number[]
@@ -95,6 +102,10 @@ class LogStore {
   // We index all the warnings up-front by span id.
   warningIndex: { [key: string]: LogWarning[] }
 
+  // The server checkpoint that each manifest's logs were last cleared at.
+  // We keep the logs before it, but don't render them.
+  clearCheckpoints: { [key: string]: number }
+
   updateCallbacks: callback[]
 
   // Track log length, for truncation.
@@ -109,6 +120,7 @@ class LogStore {
     this.checkpoint = 0
     this.warningIndex = {}
     this.lineCache = {}
+    this.clearCheckpoints = {}
     this.updateCallbacks = []
     this.maxLogLength = defaultMaxLogLength
   }
@@ -123,6 +135,31 @@ class LogStore {
     this.updateCallbacks = this.updateCallbacks.filter((item) => item !== c)
   }
 
+  // Hide the logs of each manifest before the given server checkpoint.
+  setClearCheckpoints(checkpoints: { [key: string]: number }) {
+    let names = new Set(
+      Object.keys(checkpoints).concat(Object.keys(this.clearCheckpoints))
+    )
+    let changed = false
+    names.forEach((mn) => {
+      if ((checkpoints[mn] ?? 0) !== (this.clearCheckpoints[mn] ?? 0)) {
+        changed = true
+      }
+    })
+    if (!changed) {
+      return
+    }
+
+    this.clearCheckpoints = { ...checkpoints }
+    this.invokeUpdateCallbacks({
+      action: LogUpdateAction.truncate,
+    })
+  }
+
+  private isCleared(line: StoredLine, span: LogSpan): boolean {
+    return line.checkpoint < (this.clearCheckpoints[span.manifestName] ?? 0)
+  }
+
   hasLinesForSpan(spanId: string): boolean {
     const span = this.spans[spanId]
     return span && span.firstLineIndex !== -1
@@ -139,6 +176,12 @@ class LogStore {
     const segments = [] as Proto.webviewLogSegment[]
     for (let i = this.segments.length - 1; i >= 0; i--) {
       let segment = this.segments[i]
+      let span = this.spans[segment.spanId || defaultSpanId]
+      let clearCheckpoint = this.clearCheckpoints[span?.manifestName ?? ""] ?? 0
+      if ((segment.checkpoint ?? 0) < clearCheckpoint) {
+        continue
+      }
+
       size += segment.text?.length || 0
       if (maxSize && size > maxSize) {
         break
@@ -177,16 +220,61 @@ class LogStore {
       return
     }
 
-    if (fromCheckpoint < this.checkpoint) {
-      // The server is re-sending some logs we already have, so slice them off.
-      let deleteCount = this.checkpoint - fromCheckpoint
-      newSegments = newSegments.slice(deleteCount)
-    }
+    // The server is re-sending some logs we already have, so skip them.
+    let segments = withCheckpoints(newSegments, fromCheckpoint).filter(
+      (segment) => segment.checkpoint >= this.checkpoint
+    )
 
     if (toCheckpoint > this.checkpoint) {
       this.checkpoint = toCheckpoint
     }
 
+    this.addSpans(newSpans)
+    segments.forEach((segment) => this.addSegment(segment))
+
+    this.invokeUpdateCallbacks({
+      action: LogUpdateAction.append,
+    })
+
+    this.ensureMaxLength()
+  }
+
+  // The server checkpoint of the oldest segment we have. To load earlier logs,
+  // ask the server for the page of logs before it.
+  firstCheckpoint(): number {
+    if (this.segments.length === 0) {
+      return this.checkpoint
+    }
+    return this.segments[0].checkpoint ?? 0
+  }
+
+  // Adds a page of logs from before the oldest segment we have.
+  //
+  // Raises the max log length by the size of the page, so that we don't
+  // drop the logs the user asked for on the next append.
+  prepend(logList: Proto.webviewLogList) {
+    let fromCheckpoint = logList.fromCheckpoint ?? 0
+    if (fromCheckpoint < 0) {
+      return
+    }
+
+    let firstCheckpoint = this.firstCheckpoint()
+    let segments = withCheckpoints(logList.segments ?? [], fromCheckpoint)
+    let newSegments = segments.filter(
+      (segment) => segment.checkpoint < firstCheckpoint
+    )
+    if (newSegments.length === 0) {
+      return
+    }
+
+    this.addSpans(logList.spans as { [key: string]: Proto.webviewLogSpan })
+    for (let segment of newSegments) {
+      this.maxLogLength += segment.text?.length || 0
+    }
+    this.resetSegments(newSegments.concat(this.segments))
+  }
+
+  private addSpans(newSpans: { [key: string]: Proto.webviewLogSpan }) {
     for (let key in newSpans) {
       let spanId = key || defaultSpanId
       let existingSpan = this.spans[spanId]
@@ -199,14 +287,6 @@ class LogStore {
         }
       }
     }
-
-    newSegments.forEach((segment) => this.addSegment(segment))
-
-    this.invokeUpdateCallbacks({
-      action: LogUpdateAction.append,
-    })
-
-    this.ensureMaxLength()
   }
 
   private invokeUpdateCallbacks(e: LogUpdateEvent) {
@@ -222,7 +302,7 @@ class LogStore {
     })
   }
 
-  private addSegment(newSegment: Proto.webviewLogSegment) {
+  private addSegment(newSegment: StoredSegment) {
     // workaround firestore bug. see comments on defaultSpanId.
     newSegment.spanId = newSegment.spanId || defaultSpanId
     this.segments.push(newSegment)
@@ -567,7 +647,7 @@ class LogStore {
       let storedLine = this.lines[i]
       let spanId = storedLine.spanId
       let span = spansToLog[spanId]
-      if (!span) {
+      if (!span || this.isCleared(storedLine, span)) {
         continue
       }
 
@@ -674,8 +754,11 @@ class LogStore {
     }
 
     newSegments.reverse()
+    this.resetSegments(newSegments)
+  }
 
-    // Reset the state of the logstore.
+  // Rebuilds the lines from the given segments.
+  private resetSegments(newSegments: StoredSegment[]) {
     this.logLength = 0
     this.lines = []
     this.lineCache = []
@@ -699,6 +782,35 @@ class LogStore {
 
 export default LogStore
 
+// Attaches the server checkpoint to each segment. Older servers don't
+// send checkpoints, so we number those segments from the start of the list.
+function withCheckpoints(
+  segments: Proto.webviewLogSegment[],
+  fromCheckpoint: number
+): (StoredSegment & { checkpoint: number })[] {
+  return segments.map((segment, i) => ({
+    ...segment,
+    checkpoint: segment.checkpoint ?? fromCheckpoint + i,
+  }))
+}
+
+// The server checkpoint that each manifest's logs were last cleared at,
+// where "" is the logs that don't belong to any manifest.
+export function clearCheckpoints(view: Proto.webviewView): {
+  [key: string]: number
+} {
+  let result: { [key: string]: number } = {}
+  if (view.logClearCheckpoint) {
+    result[""] = view.logClearCheckpoint
+  }
+  for (let r of view.resources ?? []) {
+    if (r.name && r.logClearCheckpoint) {
+      result[r.name] = r.logClearCheckpoint
+    }
+  }
+  return result
+}
+
 const logStoreContext = React.createContext<LogStore>(new LogStore())
 
 export function useLogStore(): LogStore {
@@ -78,6 +78,16 @@ describe("combinedStatus", () => {
     expect(warnings(res)).toEqual(["Container restarted"])
   })
 
+  it("warning when runtime logs match an alert pattern", () => {
+    let res = emptyResource()
+    res.updateStatus = UpdateStatus.Ok
+    res.runtimeStatus = RuntimeStatus.Ok
+    res.logAlertCount = 2
+    res.lastLogAlert = "panic: oh no"
+    expect(combinedStatus(res)).toBe(ResourceStatus.Warning)
+    expect(warnings(res)).toEqual(["2 log alerts: panic: oh no"])
+  })
+
   it("none when n/a runtime status and no builds", () => {
     let res = emptyResource()
     res.updateStatus = UpdateStatus.None
@@ -72,6 +72,11 @@ function runtimeWarnings(res: any): string[] {
   if (res.k8sResourceInfo && res.k8sResourceInfo.podRestarts > 0) {
     warnings.push("Container restarted")
   }
+  let alertCount = res.logAlertCount || 0
+  if (alertCount > 0) {
+    let noun = alertCount === 1 ? "alert" : "alerts"
+    warnings.push(`${alertCount} log ${noun}: ${res.lastLogAlert}`)
+  }
   return warnings
 }
 
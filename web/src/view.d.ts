@@ -29,6 +29,10 @@ declare namespace Proto {
     tiltStartTime?: string;
     tiltfileKey?: string;
     metricsServing?: webviewMetricsServing;
+    /**
+     * The log checkpoint that logs without a resource were last cleared at.
+     */
+    logClearCheckpoint?: number;
   }
   export interface webviewVersionSettings {
     checkUpdates?: boolean;
@@ -81,6 +85,17 @@ declare namespace Proto {
     specs?: webviewTargetSpec[];
     showBuildStatus?: boolean;
     queued?: boolean;
+    /**
+     * Runtime log lines that matched one of the resource's alert patterns
+     * since its last deploy, and the most recent one.
+     */
+    logAlertCount?: number;
+    lastLogAlert?: string;
+    /**
+     * The log checkpoint that the resource's logs were last cleared at.
+     * Clients should hide the resource's logs before it.
+     */
+    logClearCheckpoint?: number;
   }
   export interface webviewMetricsServing {
     /**
@@ -112,6 +127,12 @@ declare namespace Proto {
      * Used for experimenting with new types of log metadata.
      */
     fields?: object;
+    /**
+     * The checkpoint of this segment in the server's log store.
+     * There may be gaps between the checkpoints of neighboring segments,
+     * if the server truncated some logs.
+     */
+    checkpoint?: number;
   }
   export interface webviewLogList {
     spans?: object;
@@ -146,6 +167,30 @@ declare namespace Proto {
     podRestarts?: number;
     spanId?: string;
     displayNames?: string[];
+    /**
+     * Port-forwards to a Service or label selector, which balance connections
+     * across pods.
+     */
+    portForwardStatuses?: webviewPortForwardStatus[];
+    /**
+     * The kubeconfig context that this resource deploys to.
+     */
+    kubeContext?: string;
+  }
+  export interface webviewPortForwardStatus {
+    localPort?: number;
+    /**
+     * The pods we forward to, e.g., "svc/frontend" or "app=frontend".
+     */
+    target?: string;
+    /**
+     * The number of ready pods behind the target.
+     */
+    pods?: number;
+    activeConnections?: number;
+    totalConnections?: number;
+    errors?: number;
+    lastError?: string;
   }
   export interface webviewDCResourceInfo {
     configPaths?: string[];
@@ -166,6 +211,11 @@ declare namespace Proto {
      * The span id for this build record's logs in the main logstore.
      */
     spanId?: string;
+    /**
+     * The diff of the K8s objects deployed by this build against the
+     * objects that were live in the cluster beforehand.
+     */
+    k8sDiff?: string;
   }
   export interface webviewAckWebsocketResponse {}
   export interface webviewAckWebsocketRequest {